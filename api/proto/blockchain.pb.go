@@ -199,6 +199,7 @@ type TxInput struct {
 	Vout          int32                  `protobuf:"varint,2,opt,name=vout,proto3" json:"vout,omitempty"`
 	Signature     string                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 	PublicKey     string                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Sequence      uint32                 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"` // Not zero if the input opts in to replace-by-fee
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -261,6 +262,13 @@ func (x *TxInput) GetPublicKey() string {
 	return ""
 }
 
+func (x *TxInput) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 // Transaction Output
 type TxOutput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -377,9 +385,11 @@ func (x *UTXO) GetOutput() *TxOutput {
 
 // Wallet message
 type Wallet struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	PublicKey     string                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // Private key should never be transmitted, only stored locally
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Address   string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	PublicKey string                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Private key should never be transmitted, only stored locally
+	WalletName    string `protobuf:"bytes,3,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"` // Named wallet holding the key; empty for the default wallet
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -428,14 +438,41 @@ func (x *Wallet) GetPublicKey() string {
 	return ""
 }
 
+func (x *Wallet) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
 // Peer information
 type PeerInfo struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	PeerId           string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
 	Addresses        []string               `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	ConnectionStatus string                 `protobuf:"bytes,3,opt,name=connection_status,json=connectionStatus,proto3" json:"connection_status,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Metadata from the version handshake
+	ProtocolVersion uint32                 `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	UserAgent       string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Services        uint64                 `protobuf:"varint,6,opt,name=services,proto3" json:"services,omitempty"`
+	StartHeight     int64                  `protobuf:"varint,7,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	Inbound         bool                   `protobuf:"varint,8,opt,name=inbound,proto3" json:"inbound,omitempty"`
+	GenesisHash     string                 `protobuf:"bytes,9,opt,name=genesis_hash,json=genesisHash,proto3" json:"genesis_hash,omitempty"`
+	ConnectedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	// Misbehavior score; the peer is banned when it reaches 100
+	BanScore int32 `protobuf:"varint,11,opt,name=ban_score,json=banScore,proto3" json:"ban_score,omitempty"`
+	// Traffic and latency
+	BytesSent        uint64                 `protobuf:"varint,12,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived    uint64                 `protobuf:"varint,13,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	MessagesSent     uint64                 `protobuf:"varint,14,opt,name=messages_sent,json=messagesSent,proto3" json:"messages_sent,omitempty"`
+	MessagesReceived uint64                 `protobuf:"varint,15,opt,name=messages_received,json=messagesReceived,proto3" json:"messages_received,omitempty"`
+	LastSend         *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=last_send,json=lastSend,proto3" json:"last_send,omitempty"`
+	LastReceive      *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=last_receive,json=lastReceive,proto3" json:"last_receive,omitempty"`
+	// Ping round trips in seconds; 0 until the first ping is answered
+	PingTime      float64 `protobuf:"fixed64,18,opt,name=ping_time,json=pingTime,proto3" json:"ping_time,omitempty"`
+	MinPingTime   float64 `protobuf:"fixed64,19,opt,name=min_ping_time,json=minPingTime,proto3" json:"min_ping_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PeerInfo) Reset() {
@@ -489,6 +526,196 @@ func (x *PeerInfo) GetConnectionStatus() string {
 	return ""
 }
 
+func (x *PeerInfo) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *PeerInfo) GetServices() uint64 {
+	if x != nil {
+		return x.Services
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetStartHeight() int64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetInbound() bool {
+	if x != nil {
+		return x.Inbound
+	}
+	return false
+}
+
+func (x *PeerInfo) GetGenesisHash() string {
+	if x != nil {
+		return x.GenesisHash
+	}
+	return ""
+}
+
+func (x *PeerInfo) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+func (x *PeerInfo) GetBanScore() int32 {
+	if x != nil {
+		return x.BanScore
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetMessagesSent() uint64 {
+	if x != nil {
+		return x.MessagesSent
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetMessagesReceived() uint64 {
+	if x != nil {
+		return x.MessagesReceived
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetLastSend() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSend
+	}
+	return nil
+}
+
+func (x *PeerInfo) GetLastReceive() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReceive
+	}
+	return nil
+}
+
+func (x *PeerInfo) GetPingTime() float64 {
+	if x != nil {
+		return x.PingTime
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetMinPingTime() float64 {
+	if x != nil {
+		return x.MinPingTime
+	}
+	return 0
+}
+
+// Banned peer
+type BannedPeer struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PeerId    string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Reason    string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Unset for permanent bans
+	BannedUntil   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"`
+	Permanent     bool                   `protobuf:"varint,5,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BannedPeer) Reset() {
+	*x = BannedPeer{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BannedPeer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannedPeer) ProtoMessage() {}
+
+func (x *BannedPeer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannedPeer.ProtoReflect.Descriptor instead.
+func (*BannedPeer) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BannedPeer) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *BannedPeer) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BannedPeer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BannedPeer) GetBannedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return nil
+}
+
+func (x *BannedPeer) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
 // Mining information
 type MiningInfo struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -502,7 +729,7 @@ type MiningInfo struct {
 
 func (x *MiningInfo) Reset() {
 	*x = MiningInfo{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[7]
+	mi := &file_api_proto_blockchain_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -514,7 +741,7 @@ func (x *MiningInfo) String() string {
 func (*MiningInfo) ProtoMessage() {}
 
 func (x *MiningInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[7]
+	mi := &file_api_proto_blockchain_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -527,7 +754,7 @@ func (x *MiningInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MiningInfo.ProtoReflect.Descriptor instead.
 func (*MiningInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *MiningInfo) GetIsMining() bool {
@@ -567,13 +794,14 @@ type BlockchainInfo struct {
 	TotalTransactions int64                  `protobuf:"varint,4,opt,name=total_transactions,json=totalTransactions,proto3" json:"total_transactions,omitempty"`
 	PeerCount         int64                  `protobuf:"varint,5,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"`
 	IsSyncing         bool                   `protobuf:"varint,6,opt,name=is_syncing,json=isSyncing,proto3" json:"is_syncing,omitempty"`
+	Network           string                 `protobuf:"bytes,7,opt,name=network,proto3" json:"network,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *BlockchainInfo) Reset() {
 	*x = BlockchainInfo{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[8]
+	mi := &file_api_proto_blockchain_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -585,7 +813,7 @@ func (x *BlockchainInfo) String() string {
 func (*BlockchainInfo) ProtoMessage() {}
 
 func (x *BlockchainInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[8]
+	mi := &file_api_proto_blockchain_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -598,7 +826,7 @@ func (x *BlockchainInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockchainInfo.ProtoReflect.Descriptor instead.
 func (*BlockchainInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *BlockchainInfo) GetHeight() int64 {
@@ -643,6 +871,13 @@ func (x *BlockchainInfo) GetIsSyncing() bool {
 	return false
 }
 
+func (x *BlockchainInfo) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
 type GetBlockByHashRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
@@ -652,7 +887,7 @@ type GetBlockByHashRequest struct {
 
 func (x *GetBlockByHashRequest) Reset() {
 	*x = GetBlockByHashRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[9]
+	mi := &file_api_proto_blockchain_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -664,7 +899,7 @@ func (x *GetBlockByHashRequest) String() string {
 func (*GetBlockByHashRequest) ProtoMessage() {}
 
 func (x *GetBlockByHashRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[9]
+	mi := &file_api_proto_blockchain_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -677,7 +912,7 @@ func (x *GetBlockByHashRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockByHashRequest.ProtoReflect.Descriptor instead.
 func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetBlockByHashRequest) GetHash() string {
@@ -696,7 +931,7 @@ type GetBlockByHeightRequest struct {
 
 func (x *GetBlockByHeightRequest) Reset() {
 	*x = GetBlockByHeightRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[10]
+	mi := &file_api_proto_blockchain_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -708,7 +943,7 @@ func (x *GetBlockByHeightRequest) String() string {
 func (*GetBlockByHeightRequest) ProtoMessage() {}
 
 func (x *GetBlockByHeightRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[10]
+	mi := &file_api_proto_blockchain_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -721,7 +956,7 @@ func (x *GetBlockByHeightRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockByHeightRequest.ProtoReflect.Descriptor instead.
 func (*GetBlockByHeightRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetBlockByHeightRequest) GetHeight() int64 {
@@ -739,7 +974,7 @@ type GetBlockchainInfoRequest struct {
 
 func (x *GetBlockchainInfoRequest) Reset() {
 	*x = GetBlockchainInfoRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[11]
+	mi := &file_api_proto_blockchain_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -751,7 +986,7 @@ func (x *GetBlockchainInfoRequest) String() string {
 func (*GetBlockchainInfoRequest) ProtoMessage() {}
 
 func (x *GetBlockchainInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[11]
+	mi := &file_api_proto_blockchain_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,7 +999,7 @@ func (x *GetBlockchainInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockchainInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetBlockchainInfoRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{12}
 }
 
 type GetBestBlockHashRequest struct {
@@ -775,7 +1010,7 @@ type GetBestBlockHashRequest struct {
 
 func (x *GetBestBlockHashRequest) Reset() {
 	*x = GetBestBlockHashRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[12]
+	mi := &file_api_proto_blockchain_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -787,7 +1022,7 @@ func (x *GetBestBlockHashRequest) String() string {
 func (*GetBestBlockHashRequest) ProtoMessage() {}
 
 func (x *GetBestBlockHashRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[12]
+	mi := &file_api_proto_blockchain_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -800,7 +1035,7 @@ func (x *GetBestBlockHashRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBestBlockHashRequest.ProtoReflect.Descriptor instead.
 func (*GetBestBlockHashRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{13}
 }
 
 type GetBestBlockHashResponse struct {
@@ -812,7 +1047,7 @@ type GetBestBlockHashResponse struct {
 
 func (x *GetBestBlockHashResponse) Reset() {
 	*x = GetBestBlockHashResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[13]
+	mi := &file_api_proto_blockchain_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -824,7 +1059,7 @@ func (x *GetBestBlockHashResponse) String() string {
 func (*GetBestBlockHashResponse) ProtoMessage() {}
 
 func (x *GetBestBlockHashResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[13]
+	mi := &file_api_proto_blockchain_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -837,7 +1072,7 @@ func (x *GetBestBlockHashResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBestBlockHashResponse.ProtoReflect.Descriptor instead.
 func (*GetBestBlockHashResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetBestBlockHashResponse) GetHash() string {
@@ -855,7 +1090,7 @@ type GetBlockHeightRequest struct {
 
 func (x *GetBlockHeightRequest) Reset() {
 	*x = GetBlockHeightRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[14]
+	mi := &file_api_proto_blockchain_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -867,7 +1102,7 @@ func (x *GetBlockHeightRequest) String() string {
 func (*GetBlockHeightRequest) ProtoMessage() {}
 
 func (x *GetBlockHeightRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[14]
+	mi := &file_api_proto_blockchain_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -880,7 +1115,7 @@ func (x *GetBlockHeightRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockHeightRequest.ProtoReflect.Descriptor instead.
 func (*GetBlockHeightRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{15}
 }
 
 type GetBlockHeightResponse struct {
@@ -892,7 +1127,7 @@ type GetBlockHeightResponse struct {
 
 func (x *GetBlockHeightResponse) Reset() {
 	*x = GetBlockHeightResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[15]
+	mi := &file_api_proto_blockchain_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -904,7 +1139,7 @@ func (x *GetBlockHeightResponse) String() string {
 func (*GetBlockHeightResponse) ProtoMessage() {}
 
 func (x *GetBlockHeightResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[15]
+	mi := &file_api_proto_blockchain_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -917,7 +1152,7 @@ func (x *GetBlockHeightResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockHeightResponse.ProtoReflect.Descriptor instead.
 func (*GetBlockHeightResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetBlockHeightResponse) GetHeight() int64 {
@@ -936,7 +1171,7 @@ type GetTransactionRequest struct {
 
 func (x *GetTransactionRequest) Reset() {
 	*x = GetTransactionRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[16]
+	mi := &file_api_proto_blockchain_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -948,7 +1183,7 @@ func (x *GetTransactionRequest) String() string {
 func (*GetTransactionRequest) ProtoMessage() {}
 
 func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[16]
+	mi := &file_api_proto_blockchain_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -961,7 +1196,7 @@ func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
 func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{16}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetTransactionRequest) GetTxId() string {
@@ -980,7 +1215,7 @@ type SubmitTransactionRequest struct {
 
 func (x *SubmitTransactionRequest) Reset() {
 	*x = SubmitTransactionRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[17]
+	mi := &file_api_proto_blockchain_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -992,7 +1227,7 @@ func (x *SubmitTransactionRequest) String() string {
 func (*SubmitTransactionRequest) ProtoMessage() {}
 
 func (x *SubmitTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[17]
+	mi := &file_api_proto_blockchain_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,7 +1240,7 @@ func (x *SubmitTransactionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubmitTransactionRequest.ProtoReflect.Descriptor instead.
 func (*SubmitTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{17}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SubmitTransactionRequest) GetTransaction() *Transaction {
@@ -1026,7 +1261,7 @@ type SubmitTransactionResponse struct {
 
 func (x *SubmitTransactionResponse) Reset() {
 	*x = SubmitTransactionResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[18]
+	mi := &file_api_proto_blockchain_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1038,7 +1273,7 @@ func (x *SubmitTransactionResponse) String() string {
 func (*SubmitTransactionResponse) ProtoMessage() {}
 
 func (x *SubmitTransactionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[18]
+	mi := &file_api_proto_blockchain_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1051,7 +1286,7 @@ func (x *SubmitTransactionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubmitTransactionResponse.ProtoReflect.Descriptor instead.
 func (*SubmitTransactionResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{18}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SubmitTransactionResponse) GetTxId() string {
@@ -1083,7 +1318,7 @@ type GetMempoolRequest struct {
 
 func (x *GetMempoolRequest) Reset() {
 	*x = GetMempoolRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[19]
+	mi := &file_api_proto_blockchain_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1095,7 +1330,7 @@ func (x *GetMempoolRequest) String() string {
 func (*GetMempoolRequest) ProtoMessage() {}
 
 func (x *GetMempoolRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[19]
+	mi := &file_api_proto_blockchain_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1108,7 +1343,7 @@ func (x *GetMempoolRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMempoolRequest.ProtoReflect.Descriptor instead.
 func (*GetMempoolRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{19}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{20}
 }
 
 type GetMempoolResponse struct {
@@ -1121,7 +1356,7 @@ type GetMempoolResponse struct {
 
 func (x *GetMempoolResponse) Reset() {
 	*x = GetMempoolResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[20]
+	mi := &file_api_proto_blockchain_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1133,7 +1368,7 @@ func (x *GetMempoolResponse) String() string {
 func (*GetMempoolResponse) ProtoMessage() {}
 
 func (x *GetMempoolResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[20]
+	mi := &file_api_proto_blockchain_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1146,7 +1381,7 @@ func (x *GetMempoolResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMempoolResponse.ProtoReflect.Descriptor instead.
 func (*GetMempoolResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{20}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetMempoolResponse) GetTransactions() []*Transaction {
@@ -1172,7 +1407,7 @@ type GetUTXORequest struct {
 
 func (x *GetUTXORequest) Reset() {
 	*x = GetUTXORequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[21]
+	mi := &file_api_proto_blockchain_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1184,7 +1419,7 @@ func (x *GetUTXORequest) String() string {
 func (*GetUTXORequest) ProtoMessage() {}
 
 func (x *GetUTXORequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[21]
+	mi := &file_api_proto_blockchain_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1197,7 +1432,7 @@ func (x *GetUTXORequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUTXORequest.ProtoReflect.Descriptor instead.
 func (*GetUTXORequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{21}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetUTXORequest) GetAddress() string {
@@ -1217,7 +1452,7 @@ type GetUTXOResponse struct {
 
 func (x *GetUTXOResponse) Reset() {
 	*x = GetUTXOResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[22]
+	mi := &file_api_proto_blockchain_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1229,7 +1464,7 @@ func (x *GetUTXOResponse) String() string {
 func (*GetUTXOResponse) ProtoMessage() {}
 
 func (x *GetUTXOResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[22]
+	mi := &file_api_proto_blockchain_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1242,7 +1477,7 @@ func (x *GetUTXOResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUTXOResponse.ProtoReflect.Descriptor instead.
 func (*GetUTXOResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{22}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetUTXOResponse) GetUtxos() []*UTXO {
@@ -1268,7 +1503,7 @@ type GetBalanceRequest struct {
 
 func (x *GetBalanceRequest) Reset() {
 	*x = GetBalanceRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[23]
+	mi := &file_api_proto_blockchain_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1280,7 +1515,7 @@ func (x *GetBalanceRequest) String() string {
 func (*GetBalanceRequest) ProtoMessage() {}
 
 func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[23]
+	mi := &file_api_proto_blockchain_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1293,7 +1528,7 @@ func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
 func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{23}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetBalanceRequest) GetAddress() string {
@@ -1305,15 +1540,18 @@ func (x *GetBalanceRequest) GetAddress() string {
 
 type GetBalanceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Balance       int64                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Balance       int64                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"` // Total of the amounts below
 	UtxoCount     int32                  `protobuf:"varint,2,opt,name=utxo_count,json=utxoCount,proto3" json:"utxo_count,omitempty"`
+	Confirmed     int64                  `protobuf:"varint,3,opt,name=confirmed,proto3" json:"confirmed,omitempty"`     // Spendable outputs in the chain
+	Unconfirmed   int64                  `protobuf:"varint,4,opt,name=unconfirmed,proto3" json:"unconfirmed,omitempty"` // Outputs of mempool transactions
+	Immature      int64                  `protobuf:"varint,5,opt,name=immature,proto3" json:"immature,omitempty"`       // Coinbase rewards not yet mature
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetBalanceResponse) Reset() {
 	*x = GetBalanceResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[24]
+	mi := &file_api_proto_blockchain_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1325,7 +1563,7 @@ func (x *GetBalanceResponse) String() string {
 func (*GetBalanceResponse) ProtoMessage() {}
 
 func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[24]
+	mi := &file_api_proto_blockchain_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1338,7 +1576,7 @@ func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
 func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{24}
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetBalanceResponse) GetBalance() int64 {
@@ -1355,64 +1593,48 @@ func (x *GetBalanceResponse) GetUtxoCount() int32 {
 	return 0
 }
 
-type GetPeerInfoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetPeerInfoRequest) Reset() {
-	*x = GetPeerInfoRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[25]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetPeerInfoRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetBalanceResponse) GetConfirmed() int64 {
+	if x != nil {
+		return x.Confirmed
+	}
+	return 0
 }
 
-func (*GetPeerInfoRequest) ProtoMessage() {}
-
-func (x *GetPeerInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[25]
+func (x *GetBalanceResponse) GetUnconfirmed() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Unconfirmed
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GetPeerInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetPeerInfoRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{25}
+func (x *GetBalanceResponse) GetImmature() int64 {
+	if x != nil {
+		return x.Immature
+	}
+	return 0
 }
 
-type GetPeerInfoResponse struct {
+type GetAddressHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Peers         []*PeerInfo            `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
-	PeerCount     int32                  `protobuf:"varint,2,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"`
+	Addresses     []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPeerInfoResponse) Reset() {
-	*x = GetPeerInfoResponse{}
+func (x *GetAddressHistoryRequest) Reset() {
+	*x = GetAddressHistoryRequest{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPeerInfoResponse) String() string {
+func (x *GetAddressHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPeerInfoResponse) ProtoMessage() {}
+func (*GetAddressHistoryRequest) ProtoMessage() {}
 
-func (x *GetPeerInfoResponse) ProtoReflect() protoreflect.Message {
+func (x *GetAddressHistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1424,46 +1646,45 @@ func (x *GetPeerInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPeerInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetPeerInfoResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAddressHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetPeerInfoResponse) GetPeers() []*PeerInfo {
+func (x *GetAddressHistoryRequest) GetAddresses() []string {
 	if x != nil {
-		return x.Peers
+		return x.Addresses
 	}
 	return nil
 }
 
-func (x *GetPeerInfoResponse) GetPeerCount() int32 {
-	if x != nil {
-		return x.PeerCount
-	}
-	return 0
-}
-
-type ConnectPeerRequest struct {
+// AddressTransaction is a transaction paying to or spending from a set of addresses
+type AddressTransaction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Multiaddr     string                 `protobuf:"bytes,1,opt,name=multiaddr,proto3" json:"multiaddr,omitempty"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Height        int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"` // -1 while in the mempool
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Received      int64                  `protobuf:"varint,4,opt,name=received,proto3" json:"received,omitempty"` // Paid to the addresses
+	Sent          int64                  `protobuf:"varint,5,opt,name=sent,proto3" json:"sent,omitempty"`         // Spent from the addresses
+	Confirmations int64                  `protobuf:"varint,6,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConnectPeerRequest) Reset() {
-	*x = ConnectPeerRequest{}
+func (x *AddressTransaction) Reset() {
+	*x = AddressTransaction{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConnectPeerRequest) String() string {
+func (x *AddressTransaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectPeerRequest) ProtoMessage() {}
+func (*AddressTransaction) ProtoMessage() {}
 
-func (x *ConnectPeerRequest) ProtoReflect() protoreflect.Message {
+func (x *AddressTransaction) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1475,40 +1696,74 @@ func (x *ConnectPeerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectPeerRequest.ProtoReflect.Descriptor instead.
-func (*ConnectPeerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddressTransaction.ProtoReflect.Descriptor instead.
+func (*AddressTransaction) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ConnectPeerRequest) GetMultiaddr() string {
+func (x *AddressTransaction) GetTxId() string {
 	if x != nil {
-		return x.Multiaddr
+		return x.TxId
 	}
 	return ""
 }
 
-type ConnectPeerResponse struct {
+func (x *AddressTransaction) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *AddressTransaction) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *AddressTransaction) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *AddressTransaction) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *AddressTransaction) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type GetAddressHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Transactions  []*AddressTransaction  `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConnectPeerResponse) Reset() {
-	*x = ConnectPeerResponse{}
+func (x *GetAddressHistoryResponse) Reset() {
+	*x = GetAddressHistoryResponse{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConnectPeerResponse) String() string {
+func (x *GetAddressHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectPeerResponse) ProtoMessage() {}
+func (*GetAddressHistoryResponse) ProtoMessage() {}
 
-func (x *ConnectPeerResponse) ProtoReflect() protoreflect.Message {
+func (x *GetAddressHistoryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1520,46 +1775,38 @@ func (x *ConnectPeerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectPeerResponse.ProtoReflect.Descriptor instead.
-func (*ConnectPeerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAddressHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetAddressHistoryResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *ConnectPeerResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *ConnectPeerResponse) GetMessage() string {
+func (x *GetAddressHistoryResponse) GetTransactions() []*AddressTransaction {
 	if x != nil {
-		return x.Message
+		return x.Transactions
 	}
-	return ""
+	return nil
 }
 
-type StartMiningRequest struct {
+type GetPeerInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MinerAddress  string                 `protobuf:"bytes,1,opt,name=miner_address,json=minerAddress,proto3" json:"miner_address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartMiningRequest) Reset() {
-	*x = StartMiningRequest{}
+func (x *GetPeerInfoRequest) Reset() {
+	*x = GetPeerInfoRequest{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartMiningRequest) String() string {
+func (x *GetPeerInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartMiningRequest) ProtoMessage() {}
+func (*GetPeerInfoRequest) ProtoMessage() {}
 
-func (x *StartMiningRequest) ProtoReflect() protoreflect.Message {
+func (x *GetPeerInfoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1571,40 +1818,33 @@ func (x *StartMiningRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartMiningRequest.ProtoReflect.Descriptor instead.
-func (*StartMiningRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetPeerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerInfoRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *StartMiningRequest) GetMinerAddress() string {
-	if x != nil {
-		return x.MinerAddress
-	}
-	return ""
-}
-
-type StartMiningResponse struct {
+type GetPeerInfoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Peers         []*PeerInfo            `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	PeerCount     int32                  `protobuf:"varint,2,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartMiningResponse) Reset() {
-	*x = StartMiningResponse{}
+func (x *GetPeerInfoResponse) Reset() {
+	*x = GetPeerInfoResponse{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartMiningResponse) String() string {
+func (x *GetPeerInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartMiningResponse) ProtoMessage() {}
+func (*GetPeerInfoResponse) ProtoMessage() {}
 
-func (x *StartMiningResponse) ProtoReflect() protoreflect.Message {
+func (x *GetPeerInfoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1616,45 +1856,46 @@ func (x *StartMiningResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartMiningResponse.ProtoReflect.Descriptor instead.
-func (*StartMiningResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetPeerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetPeerInfoResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *StartMiningResponse) GetSuccess() bool {
+func (x *GetPeerInfoResponse) GetPeers() []*PeerInfo {
 	if x != nil {
-		return x.Success
+		return x.Peers
 	}
-	return false
+	return nil
 }
 
-func (x *StartMiningResponse) GetMessage() string {
+func (x *GetPeerInfoResponse) GetPeerCount() int32 {
 	if x != nil {
-		return x.Message
+		return x.PeerCount
 	}
-	return ""
+	return 0
 }
 
-type StopMiningRequest struct {
+type ConnectPeerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Multiaddr     string                 `protobuf:"bytes,1,opt,name=multiaddr,proto3" json:"multiaddr,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopMiningRequest) Reset() {
-	*x = StopMiningRequest{}
+func (x *ConnectPeerRequest) Reset() {
+	*x = ConnectPeerRequest{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopMiningRequest) String() string {
+func (x *ConnectPeerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopMiningRequest) ProtoMessage() {}
+func (*ConnectPeerRequest) ProtoMessage() {}
 
-func (x *StopMiningRequest) ProtoReflect() protoreflect.Message {
+func (x *ConnectPeerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1666,12 +1907,19 @@ func (x *StopMiningRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopMiningRequest.ProtoReflect.Descriptor instead.
-func (*StopMiningRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConnectPeerRequest.ProtoReflect.Descriptor instead.
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{31}
 }
 
-type StopMiningResponse struct {
+func (x *ConnectPeerRequest) GetMultiaddr() string {
+	if x != nil {
+		return x.Multiaddr
+	}
+	return ""
+}
+
+type ConnectPeerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
@@ -1679,20 +1927,20 @@ type StopMiningResponse struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopMiningResponse) Reset() {
-	*x = StopMiningResponse{}
+func (x *ConnectPeerResponse) Reset() {
+	*x = ConnectPeerResponse{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopMiningResponse) String() string {
+func (x *ConnectPeerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopMiningResponse) ProtoMessage() {}
+func (*ConnectPeerResponse) ProtoMessage() {}
 
-func (x *StopMiningResponse) ProtoReflect() protoreflect.Message {
+func (x *ConnectPeerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1704,45 +1952,45 @@ func (x *StopMiningResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopMiningResponse.ProtoReflect.Descriptor instead.
-func (*StopMiningResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConnectPeerResponse.ProtoReflect.Descriptor instead.
+func (*ConnectPeerResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *StopMiningResponse) GetSuccess() bool {
+func (x *ConnectPeerResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *StopMiningResponse) GetMessage() string {
+func (x *ConnectPeerResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type GetMiningInfoRequest struct {
+type ListBannedRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMiningInfoRequest) Reset() {
-	*x = GetMiningInfoRequest{}
+func (x *ListBannedRequest) Reset() {
+	*x = ListBannedRequest{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMiningInfoRequest) String() string {
+func (x *ListBannedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMiningInfoRequest) ProtoMessage() {}
+func (*ListBannedRequest) ProtoMessage() {}
 
-func (x *GetMiningInfoRequest) ProtoReflect() protoreflect.Message {
+func (x *ListBannedRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1754,31 +2002,32 @@ func (x *GetMiningInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMiningInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetMiningInfoRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListBannedRequest.ProtoReflect.Descriptor instead.
+func (*ListBannedRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{33}
 }
 
-type SubscribeBlocksRequest struct {
+type ListBannedResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Banned        []*BannedPeer          `protobuf:"bytes,1,rep,name=banned,proto3" json:"banned,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeBlocksRequest) Reset() {
-	*x = SubscribeBlocksRequest{}
+func (x *ListBannedResponse) Reset() {
+	*x = ListBannedResponse{}
 	mi := &file_api_proto_blockchain_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeBlocksRequest) String() string {
+func (x *ListBannedResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeBlocksRequest) ProtoMessage() {}
+func (*ListBannedResponse) ProtoMessage() {}
 
-func (x *SubscribeBlocksRequest) ProtoReflect() protoreflect.Message {
+func (x *ListBannedResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_blockchain_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1790,32 +2039,3124 @@ func (x *SubscribeBlocksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeBlocksRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeBlocksRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListBannedResponse.ProtoReflect.Descriptor instead.
+func (*ListBannedResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{34}
 }
 
-type SubscribeTransactionsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ListBannedResponse) GetBanned() []*BannedPeer {
+	if x != nil {
+		return x.Banned
+	}
+	return nil
+}
+
+type SetBanRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PeerId string                 `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// "add" or "remove"
+	Command string `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	// Ban length for "add"; 0 uses the default of 24 hours
+	BanTimeSeconds int64 `protobuf:"varint,3,opt,name=ban_time_seconds,json=banTimeSeconds,proto3" json:"ban_time_seconds,omitempty"`
+	// Never expire the ban
+	Permanent     bool   `protobuf:"varint,4,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBanRequest) Reset() {
+	*x = SetBanRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBanRequest) ProtoMessage() {}
+
+func (x *SetBanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBanRequest.ProtoReflect.Descriptor instead.
+func (*SetBanRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetBanRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *SetBanRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *SetBanRequest) GetBanTimeSeconds() int64 {
+	if x != nil {
+		return x.BanTimeSeconds
+	}
+	return 0
+}
+
+func (x *SetBanRequest) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
+func (x *SetBanRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SetBanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBanResponse) Reset() {
+	*x = SetBanResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBanResponse) ProtoMessage() {}
+
+func (x *SetBanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBanResponse.ProtoReflect.Descriptor instead.
+func (*SetBanResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetBanResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetBanResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StartMiningRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinerAddress  string                 `protobuf:"bytes,1,opt,name=miner_address,json=minerAddress,proto3" json:"miner_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartMiningRequest) Reset() {
+	*x = StartMiningRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartMiningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartMiningRequest) ProtoMessage() {}
+
+func (x *StartMiningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartMiningRequest.ProtoReflect.Descriptor instead.
+func (*StartMiningRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StartMiningRequest) GetMinerAddress() string {
+	if x != nil {
+		return x.MinerAddress
+	}
+	return ""
+}
+
+type StartMiningResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartMiningResponse) Reset() {
+	*x = StartMiningResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartMiningResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartMiningResponse) ProtoMessage() {}
+
+func (x *StartMiningResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartMiningResponse.ProtoReflect.Descriptor instead.
+func (*StartMiningResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StartMiningResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartMiningResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StopMiningRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopMiningRequest) Reset() {
+	*x = StopMiningRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopMiningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopMiningRequest) ProtoMessage() {}
+
+func (x *StopMiningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopMiningRequest.ProtoReflect.Descriptor instead.
+func (*StopMiningRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{39}
+}
+
+type StopMiningResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopMiningResponse) Reset() {
+	*x = StopMiningResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopMiningResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopMiningResponse) ProtoMessage() {}
+
+func (x *StopMiningResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopMiningResponse.ProtoReflect.Descriptor instead.
+func (*StopMiningResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StopMiningResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StopMiningResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetMiningInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMiningInfoRequest) Reset() {
+	*x = GetMiningInfoRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMiningInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMiningInfoRequest) ProtoMessage() {}
+
+func (x *GetMiningInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMiningInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetMiningInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{41}
+}
+
+type SubscribeBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeBlocksRequest) Reset() {
+	*x = SubscribeBlocksRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeBlocksRequest) ProtoMessage() {}
+
+func (x *SubscribeBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeBlocksRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{42}
+}
+
+type SubscribeTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeTransactionsRequest) Reset() {
+	*x = SubscribeTransactionsRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTransactionsRequest) ProtoMessage() {}
+
+func (x *SubscribeTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{43}
+}
+
+type CreateWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Wallet to add the new key to, created if it does not exist; empty for the default wallet
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWalletRequest) Reset() {
+	*x = CreateWalletRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWalletRequest) ProtoMessage() {}
+
+func (x *CreateWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWalletRequest.ProtoReflect.Descriptor instead.
+func (*CreateWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CreateWalletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletRequest) Reset() {
+	*x = GetWalletRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletRequest) ProtoMessage() {}
+
+func (x *GetWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetWalletRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ListWalletsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletName    string                 `protobuf:"bytes,1,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"` // Only list this wallet's keys; empty lists every loaded wallet
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWalletsRequest) Reset() {
+	*x = ListWalletsRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWalletsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWalletsRequest) ProtoMessage() {}
+
+func (x *ListWalletsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWalletsRequest.ProtoReflect.Descriptor instead.
+func (*ListWalletsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListWalletsRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+type ListWalletsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wallets       []*Wallet              `protobuf:"bytes,1,rep,name=wallets,proto3" json:"wallets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWalletsResponse) Reset() {
+	*x = ListWalletsResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWalletsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWalletsResponse) ProtoMessage() {}
+
+func (x *ListWalletsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWalletsResponse.ProtoReflect.Descriptor instead.
+func (*ListWalletsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListWalletsResponse) GetWallets() []*Wallet {
+	if x != nil {
+		return x.Wallets
+	}
+	return nil
+}
+
+type GetWalletBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	WalletName    string                 `protobuf:"bytes,2,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"` // Sums every address of the wallet when address is empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletBalanceRequest) Reset() {
+	*x = GetWalletBalanceRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletBalanceRequest) ProtoMessage() {}
+
+func (x *GetWalletBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetWalletBalanceRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GetWalletBalanceRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+type GetWalletBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       int64                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Confirmed     int64                  `protobuf:"varint,2,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Unconfirmed   int64                  `protobuf:"varint,3,opt,name=unconfirmed,proto3" json:"unconfirmed,omitempty"`
+	Immature      int64                  `protobuf:"varint,4,opt,name=immature,proto3" json:"immature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletBalanceResponse) Reset() {
+	*x = GetWalletBalanceResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletBalanceResponse) ProtoMessage() {}
+
+func (x *GetWalletBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetWalletBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetWalletBalanceResponse) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *GetWalletBalanceResponse) GetConfirmed() int64 {
+	if x != nil {
+		return x.Confirmed
+	}
+	return 0
+}
+
+func (x *GetWalletBalanceResponse) GetUnconfirmed() int64 {
+	if x != nil {
+		return x.Unconfirmed
+	}
+	return 0
+}
+
+func (x *GetWalletBalanceResponse) GetImmature() int64 {
+	if x != nil {
+		return x.Immature
+	}
+	return 0
+}
+
+type SendTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromAddress   string                 `protobuf:"bytes,1,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	ToAddress     string                 `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Fee           int64                  `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`                                         // Absolute fee in satoshis
+	FeeRate       int64                  `protobuf:"varint,5,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`                  // Satoshis per byte; overrides fee when set
+	CoinSelection string                 `protobuf:"bytes,6,opt,name=coin_selection,json=coinSelection,proto3" json:"coin_selection,omitempty"` // bnb, knapsack or largest-first; empty tries bnb, then knapsack
+	Replaceable   bool                   `protobuf:"varint,7,opt,name=replaceable,proto3" json:"replaceable,omitempty"`                         // Opt in to replace-by-fee
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTransactionRequest) Reset() {
+	*x = SendTransactionRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTransactionRequest) ProtoMessage() {}
+
+func (x *SendTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTransactionRequest.ProtoReflect.Descriptor instead.
+func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SendTransactionRequest) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
+}
+
+func (x *SendTransactionRequest) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *SendTransactionRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SendTransactionRequest) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *SendTransactionRequest) GetFeeRate() int64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+func (x *SendTransactionRequest) GetCoinSelection() string {
+	if x != nil {
+		return x.CoinSelection
+	}
+	return ""
+}
+
+func (x *SendTransactionRequest) GetReplaceable() bool {
+	if x != nil {
+		return x.Replaceable
+	}
+	return false
+}
+
+type SendTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Fee           int64                  `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTransactionResponse) Reset() {
+	*x = SendTransactionResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTransactionResponse) ProtoMessage() {}
+
+func (x *SendTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTransactionResponse.ProtoReflect.Descriptor instead.
+func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SendTransactionResponse) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *SendTransactionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SendTransactionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SendTransactionResponse) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+type BumpFeeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`           // Wallet transaction waiting in the mempool
+	FeeRate       int64                  `protobuf:"varint,2,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"` // Satoshis per byte; 0 pays the least increase accepted
+	Cpfp          bool                   `protobuf:"varint,3,opt,name=cpfp,proto3" json:"cpfp,omitempty"`                      // Pay with a child even if the transaction is replaceable
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BumpFeeRequest) Reset() {
+	*x = BumpFeeRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BumpFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BumpFeeRequest) ProtoMessage() {}
+
+func (x *BumpFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BumpFeeRequest.ProtoReflect.Descriptor instead.
+func (*BumpFeeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *BumpFeeRequest) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *BumpFeeRequest) GetFeeRate() int64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+func (x *BumpFeeRequest) GetCpfp() bool {
+	if x != nil {
+		return x.Cpfp
+	}
+	return false
+}
+
+type BumpFeeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	TxId          string                 `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`           // The replacement or the child
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`                   // "replace" or "cpfp"
+	OldFee        int64                  `protobuf:"varint,5,opt,name=old_fee,json=oldFee,proto3" json:"old_fee,omitempty"`    // Fee of the transaction or of its package
+	NewFee        int64                  `protobuf:"varint,6,opt,name=new_fee,json=newFee,proto3" json:"new_fee,omitempty"`    // Fee of the replacement or the child
+	FeeRate       int64                  `protobuf:"varint,7,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"` // Of the replacement or the package with the child
+	Replaced      []string               `protobuf:"bytes,8,rep,name=replaced,proto3" json:"replaced,omitempty"`               // Transactions evicted from the mempool
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BumpFeeResponse) Reset() {
+	*x = BumpFeeResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BumpFeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BumpFeeResponse) ProtoMessage() {}
+
+func (x *BumpFeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BumpFeeResponse.ProtoReflect.Descriptor instead.
+func (*BumpFeeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *BumpFeeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BumpFeeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BumpFeeResponse) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *BumpFeeResponse) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *BumpFeeResponse) GetOldFee() int64 {
+	if x != nil {
+		return x.OldFee
+	}
+	return 0
+}
+
+func (x *BumpFeeResponse) GetNewFee() int64 {
+	if x != nil {
+		return x.NewFee
+	}
+	return 0
+}
+
+func (x *BumpFeeResponse) GetFeeRate() int64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+func (x *BumpFeeResponse) GetReplaced() []string {
+	if x != nil {
+		return x.Replaced
+	}
+	return nil
+}
+
+type EncryptWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passphrase    string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	WalletName    string                 `protobuf:"bytes,2,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncryptWalletRequest) Reset() {
+	*x = EncryptWalletRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptWalletRequest) ProtoMessage() {}
+
+func (x *EncryptWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptWalletRequest.ProtoReflect.Descriptor instead.
+func (*EncryptWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *EncryptWalletRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *EncryptWalletRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+type EncryptWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncryptWalletResponse) Reset() {
+	*x = EncryptWalletResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptWalletResponse) ProtoMessage() {}
+
+func (x *EncryptWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptWalletResponse.ProtoReflect.Descriptor instead.
+func (*EncryptWalletResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *EncryptWalletResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EncryptWalletResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type WalletPassphraseRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Passphrase     string                 `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	TimeoutSeconds int64                  `protobuf:"varint,2,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"` // The wallet locks again after this long
+	WalletName     string                 `protobuf:"bytes,3,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WalletPassphraseRequest) Reset() {
+	*x = WalletPassphraseRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletPassphraseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletPassphraseRequest) ProtoMessage() {}
+
+func (x *WalletPassphraseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletPassphraseRequest.ProtoReflect.Descriptor instead.
+func (*WalletPassphraseRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *WalletPassphraseRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *WalletPassphraseRequest) GetTimeoutSeconds() int64 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *WalletPassphraseRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+type WalletPassphraseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UnlockedUntil *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=unlocked_until,json=unlockedUntil,proto3" json:"unlocked_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletPassphraseResponse) Reset() {
+	*x = WalletPassphraseResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletPassphraseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletPassphraseResponse) ProtoMessage() {}
+
+func (x *WalletPassphraseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletPassphraseResponse.ProtoReflect.Descriptor instead.
+func (*WalletPassphraseResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *WalletPassphraseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WalletPassphraseResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WalletPassphraseResponse) GetUnlockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnlockedUntil
+	}
+	return nil
+}
+
+type WalletLockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletName    string                 `protobuf:"bytes,1,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletLockRequest) Reset() {
+	*x = WalletLockRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletLockRequest) ProtoMessage() {}
+
+func (x *WalletLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletLockRequest.ProtoReflect.Descriptor instead.
+func (*WalletLockRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *WalletLockRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+type WalletLockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletLockResponse) Reset() {
+	*x = WalletLockResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletLockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletLockResponse) ProtoMessage() {}
+
+func (x *WalletLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletLockResponse.ProtoReflect.Descriptor instead.
+func (*WalletLockResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *WalletLockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WalletLockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type WalletPassphraseChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldPassphrase string                 `protobuf:"bytes,1,opt,name=old_passphrase,json=oldPassphrase,proto3" json:"old_passphrase,omitempty"`
+	NewPassphrase string                 `protobuf:"bytes,2,opt,name=new_passphrase,json=newPassphrase,proto3" json:"new_passphrase,omitempty"`
+	WalletName    string                 `protobuf:"bytes,3,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletPassphraseChangeRequest) Reset() {
+	*x = WalletPassphraseChangeRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletPassphraseChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletPassphraseChangeRequest) ProtoMessage() {}
+
+func (x *WalletPassphraseChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletPassphraseChangeRequest.ProtoReflect.Descriptor instead.
+func (*WalletPassphraseChangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *WalletPassphraseChangeRequest) GetOldPassphrase() string {
+	if x != nil {
+		return x.OldPassphrase
+	}
+	return ""
+}
+
+func (x *WalletPassphraseChangeRequest) GetNewPassphrase() string {
+	if x != nil {
+		return x.NewPassphrase
+	}
+	return ""
+}
+
+func (x *WalletPassphraseChangeRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+type WalletPassphraseChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletPassphraseChangeResponse) Reset() {
+	*x = WalletPassphraseChangeResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletPassphraseChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletPassphraseChangeResponse) ProtoMessage() {}
+
+func (x *WalletPassphraseChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletPassphraseChangeResponse.ProtoReflect.Descriptor instead.
+func (*WalletPassphraseChangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *WalletPassphraseChangeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WalletPassphraseChangeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LoadWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadWalletRequest) Reset() {
+	*x = LoadWalletRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadWalletRequest) ProtoMessage() {}
+
+func (x *LoadWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadWalletRequest.ProtoReflect.Descriptor instead.
+func (*LoadWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *LoadWalletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type LoadWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadWalletResponse) Reset() {
+	*x = LoadWalletResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadWalletResponse) ProtoMessage() {}
+
+func (x *LoadWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadWalletResponse.ProtoReflect.Descriptor instead.
+func (*LoadWalletResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *LoadWalletResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LoadWalletResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type UnloadWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnloadWalletRequest) Reset() {
+	*x = UnloadWalletRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnloadWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnloadWalletRequest) ProtoMessage() {}
+
+func (x *UnloadWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnloadWalletRequest.ProtoReflect.Descriptor instead.
+func (*UnloadWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *UnloadWalletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UnloadWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnloadWalletResponse) Reset() {
+	*x = UnloadWalletResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnloadWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnloadWalletResponse) ProtoMessage() {}
+
+func (x *UnloadWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnloadWalletResponse.ProtoReflect.Descriptor instead.
+func (*UnloadWalletResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UnloadWalletResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UnloadWalletResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListWalletDirRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWalletDirRequest) Reset() {
+	*x = ListWalletDirRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWalletDirRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWalletDirRequest) ProtoMessage() {}
+
+func (x *ListWalletDirRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWalletDirRequest.ProtoReflect.Descriptor instead.
+func (*ListWalletDirRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{66}
+}
+
+type WalletInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Empty for the default wallet
+	Loaded        bool                   `protobuf:"varint,2,opt,name=loaded,proto3" json:"loaded,omitempty"`
+	Encrypted     bool                   `protobuf:"varint,3,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	Locked        bool                   `protobuf:"varint,4,opt,name=locked,proto3" json:"locked,omitempty"`
+	KeyCount      int32                  `protobuf:"varint,5,opt,name=key_count,json=keyCount,proto3" json:"key_count,omitempty"` // Only known for loaded wallets
+	WatchOnly     bool                   `protobuf:"varint,6,opt,name=watch_only,json=watchOnly,proto3" json:"watch_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletInfo) Reset() {
+	*x = WalletInfo{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletInfo) ProtoMessage() {}
+
+func (x *WalletInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletInfo.ProtoReflect.Descriptor instead.
+func (*WalletInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *WalletInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WalletInfo) GetLoaded() bool {
+	if x != nil {
+		return x.Loaded
+	}
+	return false
+}
+
+func (x *WalletInfo) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *WalletInfo) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *WalletInfo) GetKeyCount() int32 {
+	if x != nil {
+		return x.KeyCount
+	}
+	return 0
+}
+
+func (x *WalletInfo) GetWatchOnly() bool {
+	if x != nil {
+		return x.WatchOnly
+	}
+	return false
+}
+
+type ListWalletDirResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wallets       []*WalletInfo          `protobuf:"bytes,1,rep,name=wallets,proto3" json:"wallets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWalletDirResponse) Reset() {
+	*x = ListWalletDirResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWalletDirResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWalletDirResponse) ProtoMessage() {}
+
+func (x *ListWalletDirResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWalletDirResponse.ProtoReflect.Descriptor instead.
+func (*ListWalletDirResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListWalletDirResponse) GetWallets() []*WalletInfo {
+	if x != nil {
+		return x.Wallets
+	}
+	return nil
+}
+
+type ImportPrivateKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletName    string                 `protobuf:"bytes,1,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	PrivateKey    string                 `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"` // Hex-encoded secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportPrivateKeyRequest) Reset() {
+	*x = ImportPrivateKeyRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportPrivateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportPrivateKeyRequest) ProtoMessage() {}
+
+func (x *ImportPrivateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportPrivateKeyRequest.ProtoReflect.Descriptor instead.
+func (*ImportPrivateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ImportPrivateKeyRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+func (x *ImportPrivateKeyRequest) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+type DumpPrivateKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Confirm       bool                   `protobuf:"varint,2,opt,name=confirm,proto3" json:"confirm,omitempty"` // Must be set: the key can spend the address's funds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpPrivateKeyRequest) Reset() {
+	*x = DumpPrivateKeyRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpPrivateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpPrivateKeyRequest) ProtoMessage() {}
+
+func (x *DumpPrivateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpPrivateKeyRequest.ProtoReflect.Descriptor instead.
+func (*DumpPrivateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *DumpPrivateKeyRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DumpPrivateKeyRequest) GetConfirm() bool {
+	if x != nil {
+		return x.Confirm
+	}
+	return false
+}
+
+type DumpPrivateKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrivateKey    string                 `protobuf:"bytes,1,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DumpPrivateKeyResponse) Reset() {
+	*x = DumpPrivateKeyResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DumpPrivateKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpPrivateKeyResponse) ProtoMessage() {}
+
+func (x *DumpPrivateKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpPrivateKeyResponse.ProtoReflect.Descriptor instead.
+func (*DumpPrivateKeyResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DumpPrivateKeyResponse) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+type SignMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignMessageRequest) Reset() {
+	*x = SignMessageRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignMessageRequest) ProtoMessage() {}
+
+func (x *SignMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignMessageRequest.ProtoReflect.Descriptor instead.
+func (*SignMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SignMessageRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SignMessageRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SignMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signature     string                 `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"` // Base64 public key and signature
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignMessageResponse) Reset() {
+	*x = SignMessageResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignMessageResponse) ProtoMessage() {}
+
+func (x *SignMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignMessageResponse.ProtoReflect.Descriptor instead.
+func (*SignMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *SignMessageResponse) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type BackupWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletName    string                 `protobuf:"bytes,1,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	Destination   string                 `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"` // Path on the node's host; must not exist
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupWalletRequest) Reset() {
+	*x = BackupWalletRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupWalletRequest) ProtoMessage() {}
+
+func (x *BackupWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupWalletRequest.ProtoReflect.Descriptor instead.
+func (*BackupWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *BackupWalletRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+func (x *BackupWalletRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type BackupWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupWalletResponse) Reset() {
+	*x = BackupWalletResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupWalletResponse) ProtoMessage() {}
+
+func (x *BackupWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupWalletResponse.ProtoReflect.Descriptor instead.
+func (*BackupWalletResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *BackupWalletResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BackupWalletResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CreateWatchOnlyWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWatchOnlyWalletRequest) Reset() {
+	*x = CreateWatchOnlyWalletRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWatchOnlyWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWatchOnlyWalletRequest) ProtoMessage() {}
+
+func (x *CreateWatchOnlyWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWatchOnlyWalletRequest.ProtoReflect.Descriptor instead.
+func (*CreateWatchOnlyWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *CreateWatchOnlyWalletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateWatchOnlyWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWatchOnlyWalletResponse) Reset() {
+	*x = CreateWatchOnlyWalletResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWatchOnlyWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWatchOnlyWalletResponse) ProtoMessage() {}
+
+func (x *CreateWatchOnlyWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWatchOnlyWalletResponse.ProtoReflect.Descriptor instead.
+func (*CreateWatchOnlyWalletResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CreateWatchOnlyWalletResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateWatchOnlyWalletResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletName    string                 `protobuf:"bytes,1,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`                                // May be left out when public_key is given
+	PublicKey     string                 `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`           // Hex-encoded; optional
+	Rescan        bool                   `protobuf:"varint,4,opt,name=rescan,proto3" json:"rescan,omitempty"`                                 // Find earlier transactions of the address
+	RescanHeight  int64                  `protobuf:"varint,5,opt,name=rescan_height,json=rescanHeight,proto3" json:"rescan_height,omitempty"` // First block searched by the rescan
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAddressRequest) Reset() {
+	*x = ImportAddressRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAddressRequest) ProtoMessage() {}
+
+func (x *ImportAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAddressRequest.ProtoReflect.Descriptor instead.
+func (*ImportAddressRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ImportAddressRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+func (x *ImportAddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ImportAddressRequest) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *ImportAddressRequest) GetRescan() bool {
+	if x != nil {
+		return x.Rescan
+	}
+	return false
+}
+
+func (x *ImportAddressRequest) GetRescanHeight() int64 {
+	if x != nil {
+		return x.RescanHeight
+	}
+	return 0
+}
+
+type ImportXPubRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletName    string                 `protobuf:"bytes,1,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	Xpub          string                 `protobuf:"bytes,2,opt,name=xpub,proto3" json:"xpub,omitempty"`                          // Extended public key of an account
+	GapLimit      int32                  `protobuf:"varint,3,opt,name=gap_limit,json=gapLimit,proto3" json:"gap_limit,omitempty"` // Unused addresses watched past the last used one; 0 for 20
+	Rescan        bool                   `protobuf:"varint,4,opt,name=rescan,proto3" json:"rescan,omitempty"`
+	RescanHeight  int64                  `protobuf:"varint,5,opt,name=rescan_height,json=rescanHeight,proto3" json:"rescan_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportXPubRequest) Reset() {
+	*x = ImportXPubRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportXPubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportXPubRequest) ProtoMessage() {}
+
+func (x *ImportXPubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportXPubRequest.ProtoReflect.Descriptor instead.
+func (*ImportXPubRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ImportXPubRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+func (x *ImportXPubRequest) GetXpub() string {
+	if x != nil {
+		return x.Xpub
+	}
+	return ""
+}
+
+func (x *ImportXPubRequest) GetGapLimit() int32 {
+	if x != nil {
+		return x.GapLimit
+	}
+	return 0
+}
+
+func (x *ImportXPubRequest) GetRescan() bool {
+	if x != nil {
+		return x.Rescan
+	}
+	return false
+}
+
+func (x *ImportXPubRequest) GetRescanHeight() int64 {
+	if x != nil {
+		return x.RescanHeight
+	}
+	return 0
+}
+
+type ImportWatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Addresses     []string               `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"` // Addresses added to the wallet
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportWatchResponse) Reset() {
+	*x = ImportWatchResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportWatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportWatchResponse) ProtoMessage() {}
+
+func (x *ImportWatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportWatchResponse.ProtoReflect.Descriptor instead.
+func (*ImportWatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ImportWatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImportWatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportWatchResponse) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type RescanWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletName    string                 `protobuf:"bytes,1,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	StartHeight   int64                  `protobuf:"varint,2,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RescanWalletRequest) Reset() {
+	*x = RescanWalletRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RescanWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RescanWalletRequest) ProtoMessage() {}
+
+func (x *RescanWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RescanWalletRequest.ProtoReflect.Descriptor instead.
+func (*RescanWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *RescanWalletRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+func (x *RescanWalletRequest) GetStartHeight() int64 {
+	if x != nil {
+		return x.StartHeight
+	}
+	return 0
+}
+
+type RescanWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ScannedHeight int64                  `protobuf:"varint,3,opt,name=scanned_height,json=scannedHeight,proto3" json:"scanned_height,omitempty"`
+	Transactions  int32                  `protobuf:"varint,4,opt,name=transactions,proto3" json:"transactions,omitempty"` // Transactions found by the rescan
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RescanWalletResponse) Reset() {
+	*x = RescanWalletResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RescanWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RescanWalletResponse) ProtoMessage() {}
+
+func (x *RescanWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RescanWalletResponse.ProtoReflect.Descriptor instead.
+func (*RescanWalletResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *RescanWalletResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RescanWalletResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RescanWalletResponse) GetScannedHeight() int64 {
+	if x != nil {
+		return x.ScannedHeight
+	}
+	return 0
+}
+
+func (x *RescanWalletResponse) GetTransactions() int32 {
+	if x != nil {
+		return x.Transactions
+	}
+	return 0
+}
+
+// Pages through the transactions newest first: skip the most recent skip
+// transactions and return up to count (all when 0), oldest first
+type ListTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletName    string                 `protobuf:"bytes,1,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Skip          int32                  `protobuf:"varint,3,opt,name=skip,proto3" json:"skip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ListTransactionsRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetSkip() int32 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+type WalletTransaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Height        int64                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"` // -1 while in the mempool
+	Confirmations int64                  `protobuf:"varint,3,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Received      int64                  `protobuf:"varint,4,opt,name=received,proto3" json:"received,omitempty"`  // Paid to the wallet's addresses
+	Sent          int64                  `protobuf:"varint,5,opt,name=sent,proto3" json:"sent,omitempty"`          // Spent from the wallet's addresses
+	Amount        int64                  `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`      // Net change of the wallet's balance
+	Fee           int64                  `protobuf:"varint,7,opt,name=fee,proto3" json:"fee,omitempty"`            // Set when the wallet paid it
+	Addresses     []string               `protobuf:"bytes,8,rep,name=addresses,proto3" json:"addresses,omitempty"` // Recipients of sends, senders of receipts
+	Category      string                 `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`   // send, receive, self or coinbase
+	BlockHash     string                 `protobuf:"bytes,10,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Time          int64                  `protobuf:"varint,11,opt,name=time,proto3" json:"time,omitempty"` // Block time in Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletTransaction) Reset() {
+	*x = WalletTransaction{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletTransaction) ProtoMessage() {}
+
+func (x *WalletTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletTransaction.ProtoReflect.Descriptor instead.
+func (*WalletTransaction) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *WalletTransaction) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *WalletTransaction) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*WalletTransaction   `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Transactions in the wallet, including the mempool
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*WalletTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateUnsignedTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromAddress   string                 `protobuf:"bytes,1,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	ToAddress     string                 `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Fee           int64                  `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	FeeRate       int64                  `protobuf:"varint,5,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	CoinSelection string                 `protobuf:"bytes,6,opt,name=coin_selection,json=coinSelection,proto3" json:"coin_selection,omitempty"`
+	Replaceable   bool                   `protobuf:"varint,7,opt,name=replaceable,proto3" json:"replaceable,omitempty"` // Opt in to replace-by-fee
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUnsignedTransactionRequest) Reset() {
+	*x = CreateUnsignedTransactionRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUnsignedTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUnsignedTransactionRequest) ProtoMessage() {}
+
+func (x *CreateUnsignedTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUnsignedTransactionRequest.ProtoReflect.Descriptor instead.
+func (*CreateUnsignedTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *CreateUnsignedTransactionRequest) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
+}
+
+func (x *CreateUnsignedTransactionRequest) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *CreateUnsignedTransactionRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateUnsignedTransactionRequest) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *CreateUnsignedTransactionRequest) GetFeeRate() int64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+func (x *CreateUnsignedTransactionRequest) GetCoinSelection() string {
+	if x != nil {
+		return x.CoinSelection
+	}
+	return ""
+}
+
+func (x *CreateUnsignedTransactionRequest) GetReplaceable() bool {
+	if x != nil {
+		return x.Replaceable
+	}
+	return false
+}
+
+type UnsignedInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Vout          int32                  `protobuf:"varint,2,opt,name=vout,proto3" json:"vout,omitempty"`
+	Value         int64                  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"` // Whose key signs the input
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsignedInput) Reset() {
+	*x = UnsignedInput{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsignedInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedInput) ProtoMessage() {}
+
+func (x *UnsignedInput) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedInput.ProtoReflect.Descriptor instead.
+func (*UnsignedInput) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *UnsignedInput) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *UnsignedInput) GetVout() int32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *UnsignedInput) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *UnsignedInput) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type CreateUnsignedTransactionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RawTransaction string                 `protobuf:"bytes,3,opt,name=raw_transaction,json=rawTransaction,proto3" json:"raw_transaction,omitempty"` // Hex-encoded serialized transaction without signatures
+	Fee            int64                  `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	Inputs         []*UnsignedInput       `protobuf:"bytes,5,rep,name=inputs,proto3" json:"inputs,omitempty"` // Outputs spent, in input order
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateUnsignedTransactionResponse) Reset() {
+	*x = CreateUnsignedTransactionResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUnsignedTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUnsignedTransactionResponse) ProtoMessage() {}
+
+func (x *CreateUnsignedTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUnsignedTransactionResponse.ProtoReflect.Descriptor instead.
+func (*CreateUnsignedTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *CreateUnsignedTransactionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateUnsignedTransactionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateUnsignedTransactionResponse) GetRawTransaction() string {
+	if x != nil {
+		return x.RawTransaction
+	}
+	return ""
+}
+
+func (x *CreateUnsignedTransactionResponse) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *CreateUnsignedTransactionResponse) GetInputs() []*UnsignedInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+type CreatePSBTRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FromAddress    string                 `protobuf:"bytes,1,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	ToAddress      string                 `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Amount         int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Fee            int64                  `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+	FeeRate        int64                  `protobuf:"varint,5,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	CoinSelection  string                 `protobuf:"bytes,6,opt,name=coin_selection,json=coinSelection,proto3" json:"coin_selection,omitempty"`
+	RawTransaction string                 `protobuf:"bytes,7,opt,name=raw_transaction,json=rawTransaction,proto3" json:"raw_transaction,omitempty"` // Hex-encoded transaction to wrap instead of funding a payment
+	Replaceable    bool                   `protobuf:"varint,8,opt,name=replaceable,proto3" json:"replaceable,omitempty"`                            // Opt in to replace-by-fee when funding a payment
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *SubscribeTransactionsRequest) Reset() {
-	*x = SubscribeTransactionsRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[35]
+func (x *CreatePSBTRequest) Reset() {
+	*x = CreatePSBTRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeTransactionsRequest) String() string {
+func (x *CreatePSBTRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeTransactionsRequest) ProtoMessage() {}
+func (*CreatePSBTRequest) ProtoMessage() {}
 
-func (x *SubscribeTransactionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[35]
+func (x *CreatePSBTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1826,77 +5167,89 @@ func (x *SubscribeTransactionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeTransactionsRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeTransactionsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use CreatePSBTRequest.ProtoReflect.Descriptor instead.
+func (*CreatePSBTRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{89}
 }
 
-type CreateWalletRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreatePSBTRequest) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
 }
 
-func (x *CreateWalletRequest) Reset() {
-	*x = CreateWalletRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[36]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CreatePSBTRequest) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
 }
 
-func (x *CreateWalletRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CreatePSBTRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
 }
 
-func (*CreateWalletRequest) ProtoMessage() {}
+func (x *CreatePSBTRequest) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
 
-func (x *CreateWalletRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[36]
+func (x *CreatePSBTRequest) GetFeeRate() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.FeeRate
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CreateWalletRequest.ProtoReflect.Descriptor instead.
-func (*CreateWalletRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{36}
+func (x *CreatePSBTRequest) GetCoinSelection() string {
+	if x != nil {
+		return x.CoinSelection
+	}
+	return ""
 }
 
-func (x *CreateWalletRequest) GetName() string {
+func (x *CreatePSBTRequest) GetRawTransaction() string {
 	if x != nil {
-		return x.Name
+		return x.RawTransaction
 	}
 	return ""
 }
 
-type GetWalletRequest struct {
+func (x *CreatePSBTRequest) GetReplaceable() bool {
+	if x != nil {
+		return x.Replaceable
+	}
+	return false
+}
+
+type PSBTRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Psbt          string                 `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"` // Base64-encoded partially signed transaction
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetWalletRequest) Reset() {
-	*x = GetWalletRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[37]
+func (x *PSBTRequest) Reset() {
+	*x = PSBTRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetWalletRequest) String() string {
+func (x *PSBTRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetWalletRequest) ProtoMessage() {}
+func (*PSBTRequest) ProtoMessage() {}
 
-func (x *GetWalletRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[37]
+func (x *PSBTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1907,39 +5260,41 @@ func (x *GetWalletRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetWalletRequest.ProtoReflect.Descriptor instead.
-func (*GetWalletRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use PSBTRequest.ProtoReflect.Descriptor instead.
+func (*PSBTRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *GetWalletRequest) GetAddress() string {
+func (x *PSBTRequest) GetPsbt() string {
 	if x != nil {
-		return x.Address
+		return x.Psbt
 	}
 	return ""
 }
 
-type ListWalletsRequest struct {
+type SignPSBTRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Psbt          string                 `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	WalletName    string                 `protobuf:"bytes,2,opt,name=wallet_name,json=walletName,proto3" json:"wallet_name,omitempty"` // Wallet whose keys sign
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListWalletsRequest) Reset() {
-	*x = ListWalletsRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[38]
+func (x *SignPSBTRequest) Reset() {
+	*x = SignPSBTRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWalletsRequest) String() string {
+func (x *SignPSBTRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWalletsRequest) ProtoMessage() {}
+func (*SignPSBTRequest) ProtoMessage() {}
 
-func (x *ListWalletsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[38]
+func (x *SignPSBTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1950,33 +5305,47 @@ func (x *ListWalletsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWalletsRequest.ProtoReflect.Descriptor instead.
-func (*ListWalletsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use SignPSBTRequest.ProtoReflect.Descriptor instead.
+func (*SignPSBTRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{91}
 }
 
-type ListWalletsResponse struct {
+func (x *SignPSBTRequest) GetPsbt() string {
+	if x != nil {
+		return x.Psbt
+	}
+	return ""
+}
+
+func (x *SignPSBTRequest) GetWalletName() string {
+	if x != nil {
+		return x.WalletName
+	}
+	return ""
+}
+
+type CombinePSBTRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Wallets       []*Wallet              `protobuf:"bytes,1,rep,name=wallets,proto3" json:"wallets,omitempty"`
+	Psbts         []string               `protobuf:"bytes,1,rep,name=psbts,proto3" json:"psbts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListWalletsResponse) Reset() {
-	*x = ListWalletsResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[39]
+func (x *CombinePSBTRequest) Reset() {
+	*x = CombinePSBTRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWalletsResponse) String() string {
+func (x *CombinePSBTRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWalletsResponse) ProtoMessage() {}
+func (*CombinePSBTRequest) ProtoMessage() {}
 
-func (x *ListWalletsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[39]
+func (x *CombinePSBTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1987,40 +5356,43 @@ func (x *ListWalletsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWalletsResponse.ProtoReflect.Descriptor instead.
-func (*ListWalletsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use CombinePSBTRequest.ProtoReflect.Descriptor instead.
+func (*CombinePSBTRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *ListWalletsResponse) GetWallets() []*Wallet {
+func (x *CombinePSBTRequest) GetPsbts() []string {
 	if x != nil {
-		return x.Wallets
+		return x.Psbts
 	}
 	return nil
 }
 
-type GetWalletBalanceRequest struct {
+type PSBTResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Psbt          string                 `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Complete      bool                   `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`                             // Every input has a final signature
+	Fee           int64                  `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`                                       // Known once every spent output is
+	SignedInputs  int32                  `protobuf:"varint,4,opt,name=signed_inputs,json=signedInputs,proto3" json:"signed_inputs,omitempty"` // Inputs signed by SignPSBT
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetWalletBalanceRequest) Reset() {
-	*x = GetWalletBalanceRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[40]
+func (x *PSBTResponse) Reset() {
+	*x = PSBTResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetWalletBalanceRequest) String() string {
+func (x *PSBTResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetWalletBalanceRequest) ProtoMessage() {}
+func (*PSBTResponse) ProtoMessage() {}
 
-func (x *GetWalletBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[40]
+func (x *PSBTResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2031,86 +5403,62 @@ func (x *GetWalletBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetWalletBalanceRequest.ProtoReflect.Descriptor instead.
-func (*GetWalletBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use PSBTResponse.ProtoReflect.Descriptor instead.
+func (*PSBTResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *GetWalletBalanceRequest) GetAddress() string {
+func (x *PSBTResponse) GetPsbt() string {
 	if x != nil {
-		return x.Address
+		return x.Psbt
 	}
 	return ""
 }
 
-type GetWalletBalanceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Balance       int64                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetWalletBalanceResponse) Reset() {
-	*x = GetWalletBalanceResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[41]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetWalletBalanceResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetWalletBalanceResponse) ProtoMessage() {}
-
-func (x *GetWalletBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[41]
+func (x *PSBTResponse) GetComplete() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Complete
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use GetWalletBalanceResponse.ProtoReflect.Descriptor instead.
-func (*GetWalletBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{41}
+func (x *PSBTResponse) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
 }
 
-func (x *GetWalletBalanceResponse) GetBalance() int64 {
+func (x *PSBTResponse) GetSignedInputs() int32 {
 	if x != nil {
-		return x.Balance
+		return x.SignedInputs
 	}
 	return 0
 }
 
-type SendTransactionRequest struct {
+type ExtractPSBTRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromAddress   string                 `protobuf:"bytes,1,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
-	ToAddress     string                 `protobuf:"bytes,2,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
-	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Psbt          string                 `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Broadcast     bool                   `protobuf:"varint,2,opt,name=broadcast,proto3" json:"broadcast,omitempty"` // Add the transaction to the mempool
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendTransactionRequest) Reset() {
-	*x = SendTransactionRequest{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[42]
+func (x *ExtractPSBTRequest) Reset() {
+	*x = ExtractPSBTRequest{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendTransactionRequest) String() string {
+func (x *ExtractPSBTRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendTransactionRequest) ProtoMessage() {}
+func (*ExtractPSBTRequest) ProtoMessage() {}
 
-func (x *SendTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[42]
+func (x *ExtractPSBTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2121,56 +5469,49 @@ func (x *SendTransactionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendTransactionRequest.ProtoReflect.Descriptor instead.
-func (*SendTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{42}
-}
-
-func (x *SendTransactionRequest) GetFromAddress() string {
-	if x != nil {
-		return x.FromAddress
-	}
-	return ""
+// Deprecated: Use ExtractPSBTRequest.ProtoReflect.Descriptor instead.
+func (*ExtractPSBTRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *SendTransactionRequest) GetToAddress() string {
+func (x *ExtractPSBTRequest) GetPsbt() string {
 	if x != nil {
-		return x.ToAddress
+		return x.Psbt
 	}
 	return ""
 }
 
-func (x *SendTransactionRequest) GetAmount() int64 {
+func (x *ExtractPSBTRequest) GetBroadcast() bool {
 	if x != nil {
-		return x.Amount
+		return x.Broadcast
 	}
-	return 0
+	return false
 }
 
-type SendTransactionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TxId          string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ExtractPSBTResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TxId           string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	RawTransaction string                 `protobuf:"bytes,2,opt,name=raw_transaction,json=rawTransaction,proto3" json:"raw_transaction,omitempty"` // Hex-encoded signed transaction
+	Broadcast      bool                   `protobuf:"varint,3,opt,name=broadcast,proto3" json:"broadcast,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *SendTransactionResponse) Reset() {
-	*x = SendTransactionResponse{}
-	mi := &file_api_proto_blockchain_proto_msgTypes[43]
+func (x *ExtractPSBTResponse) Reset() {
+	*x = ExtractPSBTResponse{}
+	mi := &file_api_proto_blockchain_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendTransactionResponse) String() string {
+func (x *ExtractPSBTResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendTransactionResponse) ProtoMessage() {}
+func (*ExtractPSBTResponse) ProtoMessage() {}
 
-func (x *SendTransactionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_blockchain_proto_msgTypes[43]
+func (x *ExtractPSBTResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_blockchain_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2181,30 +5522,30 @@ func (x *SendTransactionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendTransactionResponse.ProtoReflect.Descriptor instead.
-func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use ExtractPSBTResponse.ProtoReflect.Descriptor instead.
+func (*ExtractPSBTResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_blockchain_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *SendTransactionResponse) GetTxId() string {
+func (x *ExtractPSBTResponse) GetTxId() string {
 	if x != nil {
 		return x.TxId
 	}
 	return ""
 }
 
-func (x *SendTransactionResponse) GetSuccess() bool {
+func (x *ExtractPSBTResponse) GetRawTransaction() string {
 	if x != nil {
-		return x.Success
+		return x.RawTransaction
 	}
-	return false
+	return ""
 }
 
-func (x *SendTransactionResponse) GetMessage() string {
+func (x *ExtractPSBTResponse) GetBroadcast() bool {
 	if x != nil {
-		return x.Message
+		return x.Broadcast
 	}
-	return ""
+	return false
 }
 
 var File_api_proto_blockchain_proto protoreflect.FileDescriptor
@@ -2229,34 +5570,64 @@ const file_api_proto_blockchain_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
 	"\x06inputs\x18\x02 \x03(\v2\x13.blockchain.TxInputR\x06inputs\x12.\n" +
 	"\aoutputs\x18\x03 \x03(\v2\x14.blockchain.TxOutputR\aoutputs\x128\n" +
-	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"o\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x8b\x01\n" +
 	"\aTxInput\x12\x13\n" +
 	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x12\n" +
 	"\x04vout\x18\x02 \x01(\x05R\x04vout\x12\x1c\n" +
 	"\tsignature\x18\x03 \x01(\tR\tsignature\x12\x1d\n" +
 	"\n" +
-	"public_key\x18\x04 \x01(\tR\tpublicKey\"H\n" +
+	"public_key\x18\x04 \x01(\tR\tpublicKey\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\rR\bsequence\"H\n" +
 	"\bTxOutput\x12\x14\n" +
 	"\x05value\x18\x01 \x01(\x03R\x05value\x12&\n" +
 	"\x0fpublic_key_hash\x18\x02 \x01(\tR\rpublicKeyHash\"]\n" +
 	"\x04UTXO\x12\x13\n" +
 	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x12\n" +
 	"\x04vout\x18\x02 \x01(\x05R\x04vout\x12,\n" +
-	"\x06output\x18\x03 \x01(\v2\x14.blockchain.TxOutputR\x06output\"A\n" +
+	"\x06output\x18\x03 \x01(\v2\x14.blockchain.TxOutputR\x06output\"b\n" +
 	"\x06Wallet\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1d\n" +
 	"\n" +
-	"public_key\x18\x02 \x01(\tR\tpublicKey\"n\n" +
+	"public_key\x18\x02 \x01(\tR\tpublicKey\x12\x1f\n" +
+	"\vwallet_name\x18\x03 \x01(\tR\n" +
+	"walletName\"\xe1\x05\n" +
 	"\bPeerInfo\x12\x17\n" +
 	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x1c\n" +
 	"\taddresses\x18\x02 \x03(\tR\taddresses\x12+\n" +
-	"\x11connection_status\x18\x03 \x01(\tR\x10connectionStatus\"\x98\x01\n" +
+	"\x11connection_status\x18\x03 \x01(\tR\x10connectionStatus\x12)\n" +
+	"\x10protocol_version\x18\x04 \x01(\rR\x0fprotocolVersion\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x12\x1a\n" +
+	"\bservices\x18\x06 \x01(\x04R\bservices\x12!\n" +
+	"\fstart_height\x18\a \x01(\x03R\vstartHeight\x12\x18\n" +
+	"\ainbound\x18\b \x01(\bR\ainbound\x12!\n" +
+	"\fgenesis_hash\x18\t \x01(\tR\vgenesisHash\x12=\n" +
+	"\fconnected_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vconnectedAt\x12\x1b\n" +
+	"\tban_score\x18\v \x01(\x05R\bbanScore\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\f \x01(\x04R\tbytesSent\x12%\n" +
+	"\x0ebytes_received\x18\r \x01(\x04R\rbytesReceived\x12#\n" +
+	"\rmessages_sent\x18\x0e \x01(\x04R\fmessagesSent\x12+\n" +
+	"\x11messages_received\x18\x0f \x01(\x04R\x10messagesReceived\x127\n" +
+	"\tlast_send\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\blastSend\x12=\n" +
+	"\flast_receive\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\vlastReceive\x12\x1b\n" +
+	"\tping_time\x18\x12 \x01(\x01R\bpingTime\x12\"\n" +
+	"\rmin_ping_time\x18\x13 \x01(\x01R\vminPingTime\"\xd5\x01\n" +
+	"\n" +
+	"BannedPeer\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fbanned_until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vbannedUntil\x12\x1c\n" +
+	"\tpermanent\x18\x05 \x01(\bR\tpermanent\"\x98\x01\n" +
 	"\n" +
 	"MiningInfo\x12\x1b\n" +
 	"\tis_mining\x18\x01 \x01(\bR\bisMining\x12!\n" +
 	"\fblocks_mined\x18\x02 \x01(\x03R\vblocksMined\x12-\n" +
 	"\x12current_difficulty\x18\x03 \x01(\x03R\x11currentDifficulty\x12\x1b\n" +
-	"\thash_rate\x18\x04 \x01(\x03R\bhashRate\"\xdd\x01\n" +
+	"\thash_rate\x18\x04 \x01(\x03R\bhashRate\"\xf7\x01\n" +
 	"\x0eBlockchainInfo\x12\x16\n" +
 	"\x06height\x18\x01 \x01(\x03R\x06height\x12&\n" +
 	"\x0fbest_block_hash\x18\x02 \x01(\tR\rbestBlockHash\x12\x1e\n" +
@@ -2267,7 +5638,8 @@ const file_api_proto_blockchain_proto_rawDesc = "" +
 	"\n" +
 	"peer_count\x18\x05 \x01(\x03R\tpeerCount\x12\x1d\n" +
 	"\n" +
-	"is_syncing\x18\x06 \x01(\bR\tisSyncing\"+\n" +
+	"is_syncing\x18\x06 \x01(\bR\tisSyncing\x12\x18\n" +
+	"\anetwork\x18\a \x01(\tR\anetwork\"+\n" +
 	"\x15GetBlockByHashRequest\x12\x12\n" +
 	"\x04hash\x18\x01 \x01(\tR\x04hash\"1\n" +
 	"\x17GetBlockByHeightRequest\x12\x16\n" +
@@ -2298,11 +5670,25 @@ const file_api_proto_blockchain_proto_rawDesc = "" +
 	"\vtotal_value\x18\x02 \x01(\x03R\n" +
 	"totalValue\"-\n" +
 	"\x11GetBalanceRequest\x12\x18\n" +
-	"\aaddress\x18\x01 \x01(\tR\aaddress\"M\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"\xa9\x01\n" +
 	"\x12GetBalanceResponse\x12\x18\n" +
 	"\abalance\x18\x01 \x01(\x03R\abalance\x12\x1d\n" +
 	"\n" +
-	"utxo_count\x18\x02 \x01(\x05R\tutxoCount\"\x14\n" +
+	"utxo_count\x18\x02 \x01(\x05R\tutxoCount\x12\x1c\n" +
+	"\tconfirmed\x18\x03 \x01(\x03R\tconfirmed\x12 \n" +
+	"\vunconfirmed\x18\x04 \x01(\x03R\vunconfirmed\x12\x1a\n" +
+	"\bimmature\x18\x05 \x01(\x03R\bimmature\"8\n" +
+	"\x18GetAddressHistoryRequest\x12\x1c\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\"\xd1\x01\n" +
+	"\x12AddressTransaction\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x03R\x06height\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1a\n" +
+	"\breceived\x18\x04 \x01(\x03R\breceived\x12\x12\n" +
+	"\x04sent\x18\x05 \x01(\x03R\x04sent\x12$\n" +
+	"\rconfirmations\x18\x06 \x01(\x03R\rconfirmations\"_\n" +
+	"\x19GetAddressHistoryResponse\x12B\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1e.blockchain.AddressTransactionR\ftransactions\"\x14\n" +
 	"\x12GetPeerInfoRequest\"`\n" +
 	"\x13GetPeerInfoResponse\x12*\n" +
 	"\x05peers\x18\x01 \x03(\v2\x14.blockchain.PeerInfoR\x05peers\x12\x1d\n" +
@@ -2312,6 +5698,18 @@ const file_api_proto_blockchain_proto_rawDesc = "" +
 	"\tmultiaddr\x18\x01 \x01(\tR\tmultiaddr\"I\n" +
 	"\x13ConnectPeerResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x13\n" +
+	"\x11ListBannedRequest\"D\n" +
+	"\x12ListBannedResponse\x12.\n" +
+	"\x06banned\x18\x01 \x03(\v2\x16.blockchain.BannedPeerR\x06banned\"\xa2\x01\n" +
+	"\rSetBanRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12(\n" +
+	"\x10ban_time_seconds\x18\x03 \x01(\x03R\x0ebanTimeSeconds\x12\x1c\n" +
+	"\tpermanent\x18\x04 \x01(\bR\tpermanent\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"D\n" +
+	"\x0eSetBanResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"9\n" +
 	"\x12StartMiningRequest\x12#\n" +
 	"\rminer_address\x18\x01 \x01(\tR\fminerAddress\"I\n" +
@@ -2328,24 +5726,232 @@ const file_api_proto_blockchain_proto_rawDesc = "" +
 	"\x13CreateWalletRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\",\n" +
 	"\x10GetWalletRequest\x12\x18\n" +
-	"\aaddress\x18\x01 \x01(\tR\aaddress\"\x14\n" +
-	"\x12ListWalletsRequest\"C\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\"5\n" +
+	"\x12ListWalletsRequest\x12\x1f\n" +
+	"\vwallet_name\x18\x01 \x01(\tR\n" +
+	"walletName\"C\n" +
 	"\x13ListWalletsResponse\x12,\n" +
-	"\awallets\x18\x01 \x03(\v2\x12.blockchain.WalletR\awallets\"3\n" +
+	"\awallets\x18\x01 \x03(\v2\x12.blockchain.WalletR\awallets\"T\n" +
 	"\x17GetWalletBalanceRequest\x12\x18\n" +
-	"\aaddress\x18\x01 \x01(\tR\aaddress\"4\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1f\n" +
+	"\vwallet_name\x18\x02 \x01(\tR\n" +
+	"walletName\"\x90\x01\n" +
 	"\x18GetWalletBalanceResponse\x12\x18\n" +
-	"\abalance\x18\x01 \x01(\x03R\abalance\"r\n" +
+	"\abalance\x18\x01 \x01(\x03R\abalance\x12\x1c\n" +
+	"\tconfirmed\x18\x02 \x01(\x03R\tconfirmed\x12 \n" +
+	"\vunconfirmed\x18\x03 \x01(\x03R\vunconfirmed\x12\x1a\n" +
+	"\bimmature\x18\x04 \x01(\x03R\bimmature\"\xe8\x01\n" +
 	"\x16SendTransactionRequest\x12!\n" +
 	"\ffrom_address\x18\x01 \x01(\tR\vfromAddress\x12\x1d\n" +
 	"\n" +
 	"to_address\x18\x02 \x01(\tR\ttoAddress\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x03R\x06amount\"b\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x10\n" +
+	"\x03fee\x18\x04 \x01(\x03R\x03fee\x12\x19\n" +
+	"\bfee_rate\x18\x05 \x01(\x03R\afeeRate\x12%\n" +
+	"\x0ecoin_selection\x18\x06 \x01(\tR\rcoinSelection\x12 \n" +
+	"\vreplaceable\x18\a \x01(\bR\vreplaceable\"t\n" +
 	"\x17SendTransactionResponse\x12\x13\n" +
 	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage2\xf6\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x10\n" +
+	"\x03fee\x18\x04 \x01(\x03R\x03fee\"T\n" +
+	"\x0eBumpFeeRequest\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x19\n" +
+	"\bfee_rate\x18\x02 \x01(\x03R\afeeRate\x12\x12\n" +
+	"\x04cpfp\x18\x03 \x01(\bR\x04cpfp\"\xdb\x01\n" +
+	"\x0fBumpFeeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x13\n" +
+	"\x05tx_id\x18\x03 \x01(\tR\x04txId\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\x17\n" +
+	"\aold_fee\x18\x05 \x01(\x03R\x06oldFee\x12\x17\n" +
+	"\anew_fee\x18\x06 \x01(\x03R\x06newFee\x12\x19\n" +
+	"\bfee_rate\x18\a \x01(\x03R\afeeRate\x12\x1a\n" +
+	"\breplaced\x18\b \x03(\tR\breplaced\"W\n" +
+	"\x14EncryptWalletRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\x12\x1f\n" +
+	"\vwallet_name\x18\x02 \x01(\tR\n" +
+	"walletName\"K\n" +
+	"\x15EncryptWalletResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x83\x01\n" +
+	"\x17WalletPassphraseRequest\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x01 \x01(\tR\n" +
+	"passphrase\x12'\n" +
+	"\x0ftimeout_seconds\x18\x02 \x01(\x03R\x0etimeoutSeconds\x12\x1f\n" +
+	"\vwallet_name\x18\x03 \x01(\tR\n" +
+	"walletName\"\x91\x01\n" +
+	"\x18WalletPassphraseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12A\n" +
+	"\x0eunlocked_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\runlockedUntil\"4\n" +
+	"\x11WalletLockRequest\x12\x1f\n" +
+	"\vwallet_name\x18\x01 \x01(\tR\n" +
+	"walletName\"H\n" +
+	"\x12WalletLockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8e\x01\n" +
+	"\x1dWalletPassphraseChangeRequest\x12%\n" +
+	"\x0eold_passphrase\x18\x01 \x01(\tR\roldPassphrase\x12%\n" +
+	"\x0enew_passphrase\x18\x02 \x01(\tR\rnewPassphrase\x12\x1f\n" +
+	"\vwallet_name\x18\x03 \x01(\tR\n" +
+	"walletName\"T\n" +
+	"\x1eWalletPassphraseChangeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"'\n" +
+	"\x11LoadWalletRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"H\n" +
+	"\x12LoadWalletResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\")\n" +
+	"\x13UnloadWalletRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"J\n" +
+	"\x14UnloadWalletResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x16\n" +
+	"\x14ListWalletDirRequest\"\xaa\x01\n" +
+	"\n" +
+	"WalletInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06loaded\x18\x02 \x01(\bR\x06loaded\x12\x1c\n" +
+	"\tencrypted\x18\x03 \x01(\bR\tencrypted\x12\x16\n" +
+	"\x06locked\x18\x04 \x01(\bR\x06locked\x12\x1b\n" +
+	"\tkey_count\x18\x05 \x01(\x05R\bkeyCount\x12\x1d\n" +
+	"\n" +
+	"watch_only\x18\x06 \x01(\bR\twatchOnly\"I\n" +
+	"\x15ListWalletDirResponse\x120\n" +
+	"\awallets\x18\x01 \x03(\v2\x16.blockchain.WalletInfoR\awallets\"[\n" +
+	"\x17ImportPrivateKeyRequest\x12\x1f\n" +
+	"\vwallet_name\x18\x01 \x01(\tR\n" +
+	"walletName\x12\x1f\n" +
+	"\vprivate_key\x18\x02 \x01(\tR\n" +
+	"privateKey\"K\n" +
+	"\x15DumpPrivateKeyRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x18\n" +
+	"\aconfirm\x18\x02 \x01(\bR\aconfirm\"9\n" +
+	"\x16DumpPrivateKeyResponse\x12\x1f\n" +
+	"\vprivate_key\x18\x01 \x01(\tR\n" +
+	"privateKey\"H\n" +
+	"\x12SignMessageRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"3\n" +
+	"\x13SignMessageResponse\x12\x1c\n" +
+	"\tsignature\x18\x01 \x01(\tR\tsignature\"X\n" +
+	"\x13BackupWalletRequest\x12\x1f\n" +
+	"\vwallet_name\x18\x01 \x01(\tR\n" +
+	"walletName\x12 \n" +
+	"\vdestination\x18\x02 \x01(\tR\vdestination\"J\n" +
+	"\x14BackupWalletResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"2\n" +
+	"\x1cCreateWatchOnlyWalletRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"S\n" +
+	"\x1dCreateWatchOnlyWalletResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xad\x01\n" +
+	"\x14ImportAddressRequest\x12\x1f\n" +
+	"\vwallet_name\x18\x01 \x01(\tR\n" +
+	"walletName\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\tR\tpublicKey\x12\x16\n" +
+	"\x06rescan\x18\x04 \x01(\bR\x06rescan\x12#\n" +
+	"\rrescan_height\x18\x05 \x01(\x03R\frescanHeight\"\xa2\x01\n" +
+	"\x11ImportXPubRequest\x12\x1f\n" +
+	"\vwallet_name\x18\x01 \x01(\tR\n" +
+	"walletName\x12\x12\n" +
+	"\x04xpub\x18\x02 \x01(\tR\x04xpub\x12\x1b\n" +
+	"\tgap_limit\x18\x03 \x01(\x05R\bgapLimit\x12\x16\n" +
+	"\x06rescan\x18\x04 \x01(\bR\x06rescan\x12#\n" +
+	"\rrescan_height\x18\x05 \x01(\x03R\frescanHeight\"g\n" +
+	"\x13ImportWatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\taddresses\x18\x03 \x03(\tR\taddresses\"Y\n" +
+	"\x13RescanWalletRequest\x12\x1f\n" +
+	"\vwallet_name\x18\x01 \x01(\tR\n" +
+	"walletName\x12!\n" +
+	"\fstart_height\x18\x02 \x01(\x03R\vstartHeight\"\x95\x01\n" +
+	"\x14RescanWalletResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\x0escanned_height\x18\x03 \x01(\x03R\rscannedHeight\x12\"\n" +
+	"\ftransactions\x18\x04 \x01(\x05R\ftransactions\"d\n" +
+	"\x17ListTransactionsRequest\x12\x1f\n" +
+	"\vwallet_name\x18\x01 \x01(\tR\n" +
+	"walletName\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x12\n" +
+	"\x04skip\x18\x03 \x01(\x05R\x04skip\"\xad\x02\n" +
+	"\x11WalletTransaction\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x03R\x06height\x12$\n" +
+	"\rconfirmations\x18\x03 \x01(\x03R\rconfirmations\x12\x1a\n" +
+	"\breceived\x18\x04 \x01(\x03R\breceived\x12\x12\n" +
+	"\x04sent\x18\x05 \x01(\x03R\x04sent\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x03R\x06amount\x12\x10\n" +
+	"\x03fee\x18\a \x01(\x03R\x03fee\x12\x1c\n" +
+	"\taddresses\x18\b \x03(\tR\taddresses\x12\x1a\n" +
+	"\bcategory\x18\t \x01(\tR\bcategory\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\n" +
+	" \x01(\tR\tblockHash\x12\x12\n" +
+	"\x04time\x18\v \x01(\x03R\x04time\"s\n" +
+	"\x18ListTransactionsResponse\x12A\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1d.blockchain.WalletTransactionR\ftransactions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xf2\x01\n" +
+	" CreateUnsignedTransactionRequest\x12!\n" +
+	"\ffrom_address\x18\x01 \x01(\tR\vfromAddress\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\x02 \x01(\tR\ttoAddress\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x10\n" +
+	"\x03fee\x18\x04 \x01(\x03R\x03fee\x12\x19\n" +
+	"\bfee_rate\x18\x05 \x01(\x03R\afeeRate\x12%\n" +
+	"\x0ecoin_selection\x18\x06 \x01(\tR\rcoinSelection\x12 \n" +
+	"\vreplaceable\x18\a \x01(\bR\vreplaceable\"h\n" +
+	"\rUnsignedInput\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x12\n" +
+	"\x04vout\x18\x02 \x01(\x05R\x04vout\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x03R\x05value\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\"\xc5\x01\n" +
+	"!CreateUnsignedTransactionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12'\n" +
+	"\x0fraw_transaction\x18\x03 \x01(\tR\x0erawTransaction\x12\x10\n" +
+	"\x03fee\x18\x04 \x01(\x03R\x03fee\x121\n" +
+	"\x06inputs\x18\x05 \x03(\v2\x19.blockchain.UnsignedInputR\x06inputs\"\x8c\x02\n" +
+	"\x11CreatePSBTRequest\x12!\n" +
+	"\ffrom_address\x18\x01 \x01(\tR\vfromAddress\x12\x1d\n" +
 	"\n" +
+	"to_address\x18\x02 \x01(\tR\ttoAddress\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x10\n" +
+	"\x03fee\x18\x04 \x01(\x03R\x03fee\x12\x19\n" +
+	"\bfee_rate\x18\x05 \x01(\x03R\afeeRate\x12%\n" +
+	"\x0ecoin_selection\x18\x06 \x01(\tR\rcoinSelection\x12'\n" +
+	"\x0fraw_transaction\x18\a \x01(\tR\x0erawTransaction\x12 \n" +
+	"\vreplaceable\x18\b \x01(\bR\vreplaceable\"!\n" +
+	"\vPSBTRequest\x12\x12\n" +
+	"\x04psbt\x18\x01 \x01(\tR\x04psbt\"F\n" +
+	"\x0fSignPSBTRequest\x12\x12\n" +
+	"\x04psbt\x18\x01 \x01(\tR\x04psbt\x12\x1f\n" +
+	"\vwallet_name\x18\x02 \x01(\tR\n" +
+	"walletName\"*\n" +
+	"\x12CombinePSBTRequest\x12\x14\n" +
+	"\x05psbts\x18\x01 \x03(\tR\x05psbts\"u\n" +
+	"\fPSBTResponse\x12\x12\n" +
+	"\x04psbt\x18\x01 \x01(\tR\x04psbt\x12\x1a\n" +
+	"\bcomplete\x18\x02 \x01(\bR\bcomplete\x12\x10\n" +
+	"\x03fee\x18\x03 \x01(\x03R\x03fee\x12#\n" +
+	"\rsigned_inputs\x18\x04 \x01(\x05R\fsignedInputs\"L\n" +
+	"\x12ExtractPSBTRequest\x12\x12\n" +
+	"\x04psbt\x18\x01 \x01(\tR\x04psbt\x12\x1c\n" +
+	"\tbroadcast\x18\x02 \x01(\bR\tbroadcastJ\x04\b\x03\x10\x04\"q\n" +
+	"\x13ExtractPSBTResponse\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12'\n" +
+	"\x0fraw_transaction\x18\x02 \x01(\tR\x0erawTransaction\x12\x1c\n" +
+	"\tbroadcast\x18\x03 \x01(\bR\tbroadcast2\xe6\f\n" +
 	"\x11BlockchainService\x12F\n" +
 	"\x0eGetBlockByHash\x12!.blockchain.GetBlockByHashRequest\x1a\x11.blockchain.Block\x12J\n" +
 	"\x10GetBlockByHeight\x12#.blockchain.GetBlockByHeightRequest\x1a\x11.blockchain.Block\x12U\n" +
@@ -2358,21 +5964,54 @@ const file_api_proto_blockchain_proto_rawDesc = "" +
 	"GetMempool\x12\x1d.blockchain.GetMempoolRequest\x1a\x1e.blockchain.GetMempoolResponse\x12B\n" +
 	"\aGetUTXO\x12\x1a.blockchain.GetUTXORequest\x1a\x1b.blockchain.GetUTXOResponse\x12K\n" +
 	"\n" +
-	"GetBalance\x12\x1d.blockchain.GetBalanceRequest\x1a\x1e.blockchain.GetBalanceResponse\x12N\n" +
+	"GetBalance\x12\x1d.blockchain.GetBalanceRequest\x1a\x1e.blockchain.GetBalanceResponse\x12`\n" +
+	"\x11GetAddressHistory\x12$.blockchain.GetAddressHistoryRequest\x1a%.blockchain.GetAddressHistoryResponse\x12N\n" +
 	"\vGetPeerInfo\x12\x1e.blockchain.GetPeerInfoRequest\x1a\x1f.blockchain.GetPeerInfoResponse\x12N\n" +
-	"\vConnectPeer\x12\x1e.blockchain.ConnectPeerRequest\x1a\x1f.blockchain.ConnectPeerResponse\x12N\n" +
+	"\vConnectPeer\x12\x1e.blockchain.ConnectPeerRequest\x1a\x1f.blockchain.ConnectPeerResponse\x12K\n" +
+	"\n" +
+	"ListBanned\x12\x1d.blockchain.ListBannedRequest\x1a\x1e.blockchain.ListBannedResponse\x12?\n" +
+	"\x06SetBan\x12\x19.blockchain.SetBanRequest\x1a\x1a.blockchain.SetBanResponse\x12N\n" +
 	"\vStartMining\x12\x1e.blockchain.StartMiningRequest\x1a\x1f.blockchain.StartMiningResponse\x12K\n" +
 	"\n" +
 	"StopMining\x12\x1d.blockchain.StopMiningRequest\x1a\x1e.blockchain.StopMiningResponse\x12I\n" +
 	"\rGetMiningInfo\x12 .blockchain.GetMiningInfoRequest\x1a\x16.blockchain.MiningInfo\x12J\n" +
 	"\x0fSubscribeBlocks\x12\".blockchain.SubscribeBlocksRequest\x1a\x11.blockchain.Block0\x01\x12\\\n" +
-	"\x15SubscribeTransactions\x12(.blockchain.SubscribeTransactionsRequest\x1a\x17.blockchain.Transaction0\x012\x9e\x03\n" +
+	"\x15SubscribeTransactions\x12(.blockchain.SubscribeTransactionsRequest\x1a\x17.blockchain.Transaction0\x012\xf7\x12\n" +
 	"\rWalletService\x12C\n" +
 	"\fCreateWallet\x12\x1f.blockchain.CreateWalletRequest\x1a\x12.blockchain.Wallet\x12=\n" +
 	"\tGetWallet\x12\x1c.blockchain.GetWalletRequest\x1a\x12.blockchain.Wallet\x12N\n" +
 	"\vListWallets\x12\x1e.blockchain.ListWalletsRequest\x1a\x1f.blockchain.ListWalletsResponse\x12]\n" +
 	"\x10GetWalletBalance\x12#.blockchain.GetWalletBalanceRequest\x1a$.blockchain.GetWalletBalanceResponse\x12Z\n" +
-	"\x0fSendTransaction\x12\".blockchain.SendTransactionRequest\x1a#.blockchain.SendTransactionResponseB,Z*github.com/yourusername/bt/api/proto;protob\x06proto3"
+	"\x0fSendTransaction\x12\".blockchain.SendTransactionRequest\x1a#.blockchain.SendTransactionResponse\x12B\n" +
+	"\aBumpFee\x12\x1a.blockchain.BumpFeeRequest\x1a\x1b.blockchain.BumpFeeResponse\x12T\n" +
+	"\rEncryptWallet\x12 .blockchain.EncryptWalletRequest\x1a!.blockchain.EncryptWalletResponse\x12]\n" +
+	"\x10WalletPassphrase\x12#.blockchain.WalletPassphraseRequest\x1a$.blockchain.WalletPassphraseResponse\x12K\n" +
+	"\n" +
+	"WalletLock\x12\x1d.blockchain.WalletLockRequest\x1a\x1e.blockchain.WalletLockResponse\x12o\n" +
+	"\x16WalletPassphraseChange\x12).blockchain.WalletPassphraseChangeRequest\x1a*.blockchain.WalletPassphraseChangeResponse\x12K\n" +
+	"\n" +
+	"LoadWallet\x12\x1d.blockchain.LoadWalletRequest\x1a\x1e.blockchain.LoadWalletResponse\x12Q\n" +
+	"\fUnloadWallet\x12\x1f.blockchain.UnloadWalletRequest\x1a .blockchain.UnloadWalletResponse\x12T\n" +
+	"\rListWalletDir\x12 .blockchain.ListWalletDirRequest\x1a!.blockchain.ListWalletDirResponse\x12K\n" +
+	"\x10ImportPrivateKey\x12#.blockchain.ImportPrivateKeyRequest\x1a\x12.blockchain.Wallet\x12W\n" +
+	"\x0eDumpPrivateKey\x12!.blockchain.DumpPrivateKeyRequest\x1a\".blockchain.DumpPrivateKeyResponse\x12N\n" +
+	"\vSignMessage\x12\x1e.blockchain.SignMessageRequest\x1a\x1f.blockchain.SignMessageResponse\x12Q\n" +
+	"\fBackupWallet\x12\x1f.blockchain.BackupWalletRequest\x1a .blockchain.BackupWalletResponse\x12l\n" +
+	"\x15CreateWatchOnlyWallet\x12(.blockchain.CreateWatchOnlyWalletRequest\x1a).blockchain.CreateWatchOnlyWalletResponse\x12R\n" +
+	"\rImportAddress\x12 .blockchain.ImportAddressRequest\x1a\x1f.blockchain.ImportWatchResponse\x12L\n" +
+	"\n" +
+	"ImportXPub\x12\x1d.blockchain.ImportXPubRequest\x1a\x1f.blockchain.ImportWatchResponse\x12Q\n" +
+	"\fRescanWallet\x12\x1f.blockchain.RescanWalletRequest\x1a .blockchain.RescanWalletResponse\x12]\n" +
+	"\x10ListTransactions\x12#.blockchain.ListTransactionsRequest\x1a$.blockchain.ListTransactionsResponse\x12x\n" +
+	"\x19CreateUnsignedTransaction\x12,.blockchain.CreateUnsignedTransactionRequest\x1a-.blockchain.CreateUnsignedTransactionResponse\x12E\n" +
+	"\n" +
+	"CreatePSBT\x12\x1d.blockchain.CreatePSBTRequest\x1a\x18.blockchain.PSBTResponse\x12?\n" +
+	"\n" +
+	"UpdatePSBT\x12\x17.blockchain.PSBTRequest\x1a\x18.blockchain.PSBTResponse\x12A\n" +
+	"\bSignPSBT\x12\x1b.blockchain.SignPSBTRequest\x1a\x18.blockchain.PSBTResponse\x12G\n" +
+	"\vCombinePSBT\x12\x1e.blockchain.CombinePSBTRequest\x1a\x18.blockchain.PSBTResponse\x12A\n" +
+	"\fFinalizePSBT\x12\x17.blockchain.PSBTRequest\x1a\x18.blockchain.PSBTResponse\x12N\n" +
+	"\vExtractPSBT\x12\x1e.blockchain.ExtractPSBTRequest\x1a\x1f.blockchain.ExtractPSBTResponseB,Z*github.com/yourusername/bt/api/proto;protob\x06proto3"
 
 var (
 	file_api_proto_blockchain_proto_rawDescOnce sync.Once
@@ -2386,115 +6025,233 @@ func file_api_proto_blockchain_proto_rawDescGZIP() []byte {
 	return file_api_proto_blockchain_proto_rawDescData
 }
 
-var file_api_proto_blockchain_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
+var file_api_proto_blockchain_proto_msgTypes = make([]protoimpl.MessageInfo, 96)
 var file_api_proto_blockchain_proto_goTypes = []any{
-	(*Block)(nil),                        // 0: blockchain.Block
-	(*Transaction)(nil),                  // 1: blockchain.Transaction
-	(*TxInput)(nil),                      // 2: blockchain.TxInput
-	(*TxOutput)(nil),                     // 3: blockchain.TxOutput
-	(*UTXO)(nil),                         // 4: blockchain.UTXO
-	(*Wallet)(nil),                       // 5: blockchain.Wallet
-	(*PeerInfo)(nil),                     // 6: blockchain.PeerInfo
-	(*MiningInfo)(nil),                   // 7: blockchain.MiningInfo
-	(*BlockchainInfo)(nil),               // 8: blockchain.BlockchainInfo
-	(*GetBlockByHashRequest)(nil),        // 9: blockchain.GetBlockByHashRequest
-	(*GetBlockByHeightRequest)(nil),      // 10: blockchain.GetBlockByHeightRequest
-	(*GetBlockchainInfoRequest)(nil),     // 11: blockchain.GetBlockchainInfoRequest
-	(*GetBestBlockHashRequest)(nil),      // 12: blockchain.GetBestBlockHashRequest
-	(*GetBestBlockHashResponse)(nil),     // 13: blockchain.GetBestBlockHashResponse
-	(*GetBlockHeightRequest)(nil),        // 14: blockchain.GetBlockHeightRequest
-	(*GetBlockHeightResponse)(nil),       // 15: blockchain.GetBlockHeightResponse
-	(*GetTransactionRequest)(nil),        // 16: blockchain.GetTransactionRequest
-	(*SubmitTransactionRequest)(nil),     // 17: blockchain.SubmitTransactionRequest
-	(*SubmitTransactionResponse)(nil),    // 18: blockchain.SubmitTransactionResponse
-	(*GetMempoolRequest)(nil),            // 19: blockchain.GetMempoolRequest
-	(*GetMempoolResponse)(nil),           // 20: blockchain.GetMempoolResponse
-	(*GetUTXORequest)(nil),               // 21: blockchain.GetUTXORequest
-	(*GetUTXOResponse)(nil),              // 22: blockchain.GetUTXOResponse
-	(*GetBalanceRequest)(nil),            // 23: blockchain.GetBalanceRequest
-	(*GetBalanceResponse)(nil),           // 24: blockchain.GetBalanceResponse
-	(*GetPeerInfoRequest)(nil),           // 25: blockchain.GetPeerInfoRequest
-	(*GetPeerInfoResponse)(nil),          // 26: blockchain.GetPeerInfoResponse
-	(*ConnectPeerRequest)(nil),           // 27: blockchain.ConnectPeerRequest
-	(*ConnectPeerResponse)(nil),          // 28: blockchain.ConnectPeerResponse
-	(*StartMiningRequest)(nil),           // 29: blockchain.StartMiningRequest
-	(*StartMiningResponse)(nil),          // 30: blockchain.StartMiningResponse
-	(*StopMiningRequest)(nil),            // 31: blockchain.StopMiningRequest
-	(*StopMiningResponse)(nil),           // 32: blockchain.StopMiningResponse
-	(*GetMiningInfoRequest)(nil),         // 33: blockchain.GetMiningInfoRequest
-	(*SubscribeBlocksRequest)(nil),       // 34: blockchain.SubscribeBlocksRequest
-	(*SubscribeTransactionsRequest)(nil), // 35: blockchain.SubscribeTransactionsRequest
-	(*CreateWalletRequest)(nil),          // 36: blockchain.CreateWalletRequest
-	(*GetWalletRequest)(nil),             // 37: blockchain.GetWalletRequest
-	(*ListWalletsRequest)(nil),           // 38: blockchain.ListWalletsRequest
-	(*ListWalletsResponse)(nil),          // 39: blockchain.ListWalletsResponse
-	(*GetWalletBalanceRequest)(nil),      // 40: blockchain.GetWalletBalanceRequest
-	(*GetWalletBalanceResponse)(nil),     // 41: blockchain.GetWalletBalanceResponse
-	(*SendTransactionRequest)(nil),       // 42: blockchain.SendTransactionRequest
-	(*SendTransactionResponse)(nil),      // 43: blockchain.SendTransactionResponse
-	(*timestamppb.Timestamp)(nil),        // 44: google.protobuf.Timestamp
+	(*Block)(nil),                             // 0: blockchain.Block
+	(*Transaction)(nil),                       // 1: blockchain.Transaction
+	(*TxInput)(nil),                           // 2: blockchain.TxInput
+	(*TxOutput)(nil),                          // 3: blockchain.TxOutput
+	(*UTXO)(nil),                              // 4: blockchain.UTXO
+	(*Wallet)(nil),                            // 5: blockchain.Wallet
+	(*PeerInfo)(nil),                          // 6: blockchain.PeerInfo
+	(*BannedPeer)(nil),                        // 7: blockchain.BannedPeer
+	(*MiningInfo)(nil),                        // 8: blockchain.MiningInfo
+	(*BlockchainInfo)(nil),                    // 9: blockchain.BlockchainInfo
+	(*GetBlockByHashRequest)(nil),             // 10: blockchain.GetBlockByHashRequest
+	(*GetBlockByHeightRequest)(nil),           // 11: blockchain.GetBlockByHeightRequest
+	(*GetBlockchainInfoRequest)(nil),          // 12: blockchain.GetBlockchainInfoRequest
+	(*GetBestBlockHashRequest)(nil),           // 13: blockchain.GetBestBlockHashRequest
+	(*GetBestBlockHashResponse)(nil),          // 14: blockchain.GetBestBlockHashResponse
+	(*GetBlockHeightRequest)(nil),             // 15: blockchain.GetBlockHeightRequest
+	(*GetBlockHeightResponse)(nil),            // 16: blockchain.GetBlockHeightResponse
+	(*GetTransactionRequest)(nil),             // 17: blockchain.GetTransactionRequest
+	(*SubmitTransactionRequest)(nil),          // 18: blockchain.SubmitTransactionRequest
+	(*SubmitTransactionResponse)(nil),         // 19: blockchain.SubmitTransactionResponse
+	(*GetMempoolRequest)(nil),                 // 20: blockchain.GetMempoolRequest
+	(*GetMempoolResponse)(nil),                // 21: blockchain.GetMempoolResponse
+	(*GetUTXORequest)(nil),                    // 22: blockchain.GetUTXORequest
+	(*GetUTXOResponse)(nil),                   // 23: blockchain.GetUTXOResponse
+	(*GetBalanceRequest)(nil),                 // 24: blockchain.GetBalanceRequest
+	(*GetBalanceResponse)(nil),                // 25: blockchain.GetBalanceResponse
+	(*GetAddressHistoryRequest)(nil),          // 26: blockchain.GetAddressHistoryRequest
+	(*AddressTransaction)(nil),                // 27: blockchain.AddressTransaction
+	(*GetAddressHistoryResponse)(nil),         // 28: blockchain.GetAddressHistoryResponse
+	(*GetPeerInfoRequest)(nil),                // 29: blockchain.GetPeerInfoRequest
+	(*GetPeerInfoResponse)(nil),               // 30: blockchain.GetPeerInfoResponse
+	(*ConnectPeerRequest)(nil),                // 31: blockchain.ConnectPeerRequest
+	(*ConnectPeerResponse)(nil),               // 32: blockchain.ConnectPeerResponse
+	(*ListBannedRequest)(nil),                 // 33: blockchain.ListBannedRequest
+	(*ListBannedResponse)(nil),                // 34: blockchain.ListBannedResponse
+	(*SetBanRequest)(nil),                     // 35: blockchain.SetBanRequest
+	(*SetBanResponse)(nil),                    // 36: blockchain.SetBanResponse
+	(*StartMiningRequest)(nil),                // 37: blockchain.StartMiningRequest
+	(*StartMiningResponse)(nil),               // 38: blockchain.StartMiningResponse
+	(*StopMiningRequest)(nil),                 // 39: blockchain.StopMiningRequest
+	(*StopMiningResponse)(nil),                // 40: blockchain.StopMiningResponse
+	(*GetMiningInfoRequest)(nil),              // 41: blockchain.GetMiningInfoRequest
+	(*SubscribeBlocksRequest)(nil),            // 42: blockchain.SubscribeBlocksRequest
+	(*SubscribeTransactionsRequest)(nil),      // 43: blockchain.SubscribeTransactionsRequest
+	(*CreateWalletRequest)(nil),               // 44: blockchain.CreateWalletRequest
+	(*GetWalletRequest)(nil),                  // 45: blockchain.GetWalletRequest
+	(*ListWalletsRequest)(nil),                // 46: blockchain.ListWalletsRequest
+	(*ListWalletsResponse)(nil),               // 47: blockchain.ListWalletsResponse
+	(*GetWalletBalanceRequest)(nil),           // 48: blockchain.GetWalletBalanceRequest
+	(*GetWalletBalanceResponse)(nil),          // 49: blockchain.GetWalletBalanceResponse
+	(*SendTransactionRequest)(nil),            // 50: blockchain.SendTransactionRequest
+	(*SendTransactionResponse)(nil),           // 51: blockchain.SendTransactionResponse
+	(*BumpFeeRequest)(nil),                    // 52: blockchain.BumpFeeRequest
+	(*BumpFeeResponse)(nil),                   // 53: blockchain.BumpFeeResponse
+	(*EncryptWalletRequest)(nil),              // 54: blockchain.EncryptWalletRequest
+	(*EncryptWalletResponse)(nil),             // 55: blockchain.EncryptWalletResponse
+	(*WalletPassphraseRequest)(nil),           // 56: blockchain.WalletPassphraseRequest
+	(*WalletPassphraseResponse)(nil),          // 57: blockchain.WalletPassphraseResponse
+	(*WalletLockRequest)(nil),                 // 58: blockchain.WalletLockRequest
+	(*WalletLockResponse)(nil),                // 59: blockchain.WalletLockResponse
+	(*WalletPassphraseChangeRequest)(nil),     // 60: blockchain.WalletPassphraseChangeRequest
+	(*WalletPassphraseChangeResponse)(nil),    // 61: blockchain.WalletPassphraseChangeResponse
+	(*LoadWalletRequest)(nil),                 // 62: blockchain.LoadWalletRequest
+	(*LoadWalletResponse)(nil),                // 63: blockchain.LoadWalletResponse
+	(*UnloadWalletRequest)(nil),               // 64: blockchain.UnloadWalletRequest
+	(*UnloadWalletResponse)(nil),              // 65: blockchain.UnloadWalletResponse
+	(*ListWalletDirRequest)(nil),              // 66: blockchain.ListWalletDirRequest
+	(*WalletInfo)(nil),                        // 67: blockchain.WalletInfo
+	(*ListWalletDirResponse)(nil),             // 68: blockchain.ListWalletDirResponse
+	(*ImportPrivateKeyRequest)(nil),           // 69: blockchain.ImportPrivateKeyRequest
+	(*DumpPrivateKeyRequest)(nil),             // 70: blockchain.DumpPrivateKeyRequest
+	(*DumpPrivateKeyResponse)(nil),            // 71: blockchain.DumpPrivateKeyResponse
+	(*SignMessageRequest)(nil),                // 72: blockchain.SignMessageRequest
+	(*SignMessageResponse)(nil),               // 73: blockchain.SignMessageResponse
+	(*BackupWalletRequest)(nil),               // 74: blockchain.BackupWalletRequest
+	(*BackupWalletResponse)(nil),              // 75: blockchain.BackupWalletResponse
+	(*CreateWatchOnlyWalletRequest)(nil),      // 76: blockchain.CreateWatchOnlyWalletRequest
+	(*CreateWatchOnlyWalletResponse)(nil),     // 77: blockchain.CreateWatchOnlyWalletResponse
+	(*ImportAddressRequest)(nil),              // 78: blockchain.ImportAddressRequest
+	(*ImportXPubRequest)(nil),                 // 79: blockchain.ImportXPubRequest
+	(*ImportWatchResponse)(nil),               // 80: blockchain.ImportWatchResponse
+	(*RescanWalletRequest)(nil),               // 81: blockchain.RescanWalletRequest
+	(*RescanWalletResponse)(nil),              // 82: blockchain.RescanWalletResponse
+	(*ListTransactionsRequest)(nil),           // 83: blockchain.ListTransactionsRequest
+	(*WalletTransaction)(nil),                 // 84: blockchain.WalletTransaction
+	(*ListTransactionsResponse)(nil),          // 85: blockchain.ListTransactionsResponse
+	(*CreateUnsignedTransactionRequest)(nil),  // 86: blockchain.CreateUnsignedTransactionRequest
+	(*UnsignedInput)(nil),                     // 87: blockchain.UnsignedInput
+	(*CreateUnsignedTransactionResponse)(nil), // 88: blockchain.CreateUnsignedTransactionResponse
+	(*CreatePSBTRequest)(nil),                 // 89: blockchain.CreatePSBTRequest
+	(*PSBTRequest)(nil),                       // 90: blockchain.PSBTRequest
+	(*SignPSBTRequest)(nil),                   // 91: blockchain.SignPSBTRequest
+	(*CombinePSBTRequest)(nil),                // 92: blockchain.CombinePSBTRequest
+	(*PSBTResponse)(nil),                      // 93: blockchain.PSBTResponse
+	(*ExtractPSBTRequest)(nil),                // 94: blockchain.ExtractPSBTRequest
+	(*ExtractPSBTResponse)(nil),               // 95: blockchain.ExtractPSBTResponse
+	(*timestamppb.Timestamp)(nil),             // 96: google.protobuf.Timestamp
 }
 var file_api_proto_blockchain_proto_depIdxs = []int32{
-	44, // 0: blockchain.Block.timestamp:type_name -> google.protobuf.Timestamp
+	96, // 0: blockchain.Block.timestamp:type_name -> google.protobuf.Timestamp
 	1,  // 1: blockchain.Block.transactions:type_name -> blockchain.Transaction
 	2,  // 2: blockchain.Transaction.inputs:type_name -> blockchain.TxInput
 	3,  // 3: blockchain.Transaction.outputs:type_name -> blockchain.TxOutput
-	44, // 4: blockchain.Transaction.timestamp:type_name -> google.protobuf.Timestamp
+	96, // 4: blockchain.Transaction.timestamp:type_name -> google.protobuf.Timestamp
 	3,  // 5: blockchain.UTXO.output:type_name -> blockchain.TxOutput
-	1,  // 6: blockchain.SubmitTransactionRequest.transaction:type_name -> blockchain.Transaction
-	1,  // 7: blockchain.GetMempoolResponse.transactions:type_name -> blockchain.Transaction
-	4,  // 8: blockchain.GetUTXOResponse.utxos:type_name -> blockchain.UTXO
-	6,  // 9: blockchain.GetPeerInfoResponse.peers:type_name -> blockchain.PeerInfo
-	5,  // 10: blockchain.ListWalletsResponse.wallets:type_name -> blockchain.Wallet
-	9,  // 11: blockchain.BlockchainService.GetBlockByHash:input_type -> blockchain.GetBlockByHashRequest
-	10, // 12: blockchain.BlockchainService.GetBlockByHeight:input_type -> blockchain.GetBlockByHeightRequest
-	11, // 13: blockchain.BlockchainService.GetBlockchainInfo:input_type -> blockchain.GetBlockchainInfoRequest
-	12, // 14: blockchain.BlockchainService.GetBestBlockHash:input_type -> blockchain.GetBestBlockHashRequest
-	14, // 15: blockchain.BlockchainService.GetBlockHeight:input_type -> blockchain.GetBlockHeightRequest
-	16, // 16: blockchain.BlockchainService.GetTransaction:input_type -> blockchain.GetTransactionRequest
-	17, // 17: blockchain.BlockchainService.SubmitTransaction:input_type -> blockchain.SubmitTransactionRequest
-	19, // 18: blockchain.BlockchainService.GetMempool:input_type -> blockchain.GetMempoolRequest
-	21, // 19: blockchain.BlockchainService.GetUTXO:input_type -> blockchain.GetUTXORequest
-	23, // 20: blockchain.BlockchainService.GetBalance:input_type -> blockchain.GetBalanceRequest
-	25, // 21: blockchain.BlockchainService.GetPeerInfo:input_type -> blockchain.GetPeerInfoRequest
-	27, // 22: blockchain.BlockchainService.ConnectPeer:input_type -> blockchain.ConnectPeerRequest
-	29, // 23: blockchain.BlockchainService.StartMining:input_type -> blockchain.StartMiningRequest
-	31, // 24: blockchain.BlockchainService.StopMining:input_type -> blockchain.StopMiningRequest
-	33, // 25: blockchain.BlockchainService.GetMiningInfo:input_type -> blockchain.GetMiningInfoRequest
-	34, // 26: blockchain.BlockchainService.SubscribeBlocks:input_type -> blockchain.SubscribeBlocksRequest
-	35, // 27: blockchain.BlockchainService.SubscribeTransactions:input_type -> blockchain.SubscribeTransactionsRequest
-	36, // 28: blockchain.WalletService.CreateWallet:input_type -> blockchain.CreateWalletRequest
-	37, // 29: blockchain.WalletService.GetWallet:input_type -> blockchain.GetWalletRequest
-	38, // 30: blockchain.WalletService.ListWallets:input_type -> blockchain.ListWalletsRequest
-	40, // 31: blockchain.WalletService.GetWalletBalance:input_type -> blockchain.GetWalletBalanceRequest
-	42, // 32: blockchain.WalletService.SendTransaction:input_type -> blockchain.SendTransactionRequest
-	0,  // 33: blockchain.BlockchainService.GetBlockByHash:output_type -> blockchain.Block
-	0,  // 34: blockchain.BlockchainService.GetBlockByHeight:output_type -> blockchain.Block
-	8,  // 35: blockchain.BlockchainService.GetBlockchainInfo:output_type -> blockchain.BlockchainInfo
-	13, // 36: blockchain.BlockchainService.GetBestBlockHash:output_type -> blockchain.GetBestBlockHashResponse
-	15, // 37: blockchain.BlockchainService.GetBlockHeight:output_type -> blockchain.GetBlockHeightResponse
-	1,  // 38: blockchain.BlockchainService.GetTransaction:output_type -> blockchain.Transaction
-	18, // 39: blockchain.BlockchainService.SubmitTransaction:output_type -> blockchain.SubmitTransactionResponse
-	20, // 40: blockchain.BlockchainService.GetMempool:output_type -> blockchain.GetMempoolResponse
-	22, // 41: blockchain.BlockchainService.GetUTXO:output_type -> blockchain.GetUTXOResponse
-	24, // 42: blockchain.BlockchainService.GetBalance:output_type -> blockchain.GetBalanceResponse
-	26, // 43: blockchain.BlockchainService.GetPeerInfo:output_type -> blockchain.GetPeerInfoResponse
-	28, // 44: blockchain.BlockchainService.ConnectPeer:output_type -> blockchain.ConnectPeerResponse
-	30, // 45: blockchain.BlockchainService.StartMining:output_type -> blockchain.StartMiningResponse
-	32, // 46: blockchain.BlockchainService.StopMining:output_type -> blockchain.StopMiningResponse
-	7,  // 47: blockchain.BlockchainService.GetMiningInfo:output_type -> blockchain.MiningInfo
-	0,  // 48: blockchain.BlockchainService.SubscribeBlocks:output_type -> blockchain.Block
-	1,  // 49: blockchain.BlockchainService.SubscribeTransactions:output_type -> blockchain.Transaction
-	5,  // 50: blockchain.WalletService.CreateWallet:output_type -> blockchain.Wallet
-	5,  // 51: blockchain.WalletService.GetWallet:output_type -> blockchain.Wallet
-	39, // 52: blockchain.WalletService.ListWallets:output_type -> blockchain.ListWalletsResponse
-	41, // 53: blockchain.WalletService.GetWalletBalance:output_type -> blockchain.GetWalletBalanceResponse
-	43, // 54: blockchain.WalletService.SendTransaction:output_type -> blockchain.SendTransactionResponse
-	33, // [33:55] is the sub-list for method output_type
-	11, // [11:33] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	96, // 6: blockchain.PeerInfo.connected_at:type_name -> google.protobuf.Timestamp
+	96, // 7: blockchain.PeerInfo.last_send:type_name -> google.protobuf.Timestamp
+	96, // 8: blockchain.PeerInfo.last_receive:type_name -> google.protobuf.Timestamp
+	96, // 9: blockchain.BannedPeer.created_at:type_name -> google.protobuf.Timestamp
+	96, // 10: blockchain.BannedPeer.banned_until:type_name -> google.protobuf.Timestamp
+	1,  // 11: blockchain.SubmitTransactionRequest.transaction:type_name -> blockchain.Transaction
+	1,  // 12: blockchain.GetMempoolResponse.transactions:type_name -> blockchain.Transaction
+	4,  // 13: blockchain.GetUTXOResponse.utxos:type_name -> blockchain.UTXO
+	96, // 14: blockchain.AddressTransaction.timestamp:type_name -> google.protobuf.Timestamp
+	27, // 15: blockchain.GetAddressHistoryResponse.transactions:type_name -> blockchain.AddressTransaction
+	6,  // 16: blockchain.GetPeerInfoResponse.peers:type_name -> blockchain.PeerInfo
+	7,  // 17: blockchain.ListBannedResponse.banned:type_name -> blockchain.BannedPeer
+	5,  // 18: blockchain.ListWalletsResponse.wallets:type_name -> blockchain.Wallet
+	96, // 19: blockchain.WalletPassphraseResponse.unlocked_until:type_name -> google.protobuf.Timestamp
+	67, // 20: blockchain.ListWalletDirResponse.wallets:type_name -> blockchain.WalletInfo
+	84, // 21: blockchain.ListTransactionsResponse.transactions:type_name -> blockchain.WalletTransaction
+	87, // 22: blockchain.CreateUnsignedTransactionResponse.inputs:type_name -> blockchain.UnsignedInput
+	10, // 23: blockchain.BlockchainService.GetBlockByHash:input_type -> blockchain.GetBlockByHashRequest
+	11, // 24: blockchain.BlockchainService.GetBlockByHeight:input_type -> blockchain.GetBlockByHeightRequest
+	12, // 25: blockchain.BlockchainService.GetBlockchainInfo:input_type -> blockchain.GetBlockchainInfoRequest
+	13, // 26: blockchain.BlockchainService.GetBestBlockHash:input_type -> blockchain.GetBestBlockHashRequest
+	15, // 27: blockchain.BlockchainService.GetBlockHeight:input_type -> blockchain.GetBlockHeightRequest
+	17, // 28: blockchain.BlockchainService.GetTransaction:input_type -> blockchain.GetTransactionRequest
+	18, // 29: blockchain.BlockchainService.SubmitTransaction:input_type -> blockchain.SubmitTransactionRequest
+	20, // 30: blockchain.BlockchainService.GetMempool:input_type -> blockchain.GetMempoolRequest
+	22, // 31: blockchain.BlockchainService.GetUTXO:input_type -> blockchain.GetUTXORequest
+	24, // 32: blockchain.BlockchainService.GetBalance:input_type -> blockchain.GetBalanceRequest
+	26, // 33: blockchain.BlockchainService.GetAddressHistory:input_type -> blockchain.GetAddressHistoryRequest
+	29, // 34: blockchain.BlockchainService.GetPeerInfo:input_type -> blockchain.GetPeerInfoRequest
+	31, // 35: blockchain.BlockchainService.ConnectPeer:input_type -> blockchain.ConnectPeerRequest
+	33, // 36: blockchain.BlockchainService.ListBanned:input_type -> blockchain.ListBannedRequest
+	35, // 37: blockchain.BlockchainService.SetBan:input_type -> blockchain.SetBanRequest
+	37, // 38: blockchain.BlockchainService.StartMining:input_type -> blockchain.StartMiningRequest
+	39, // 39: blockchain.BlockchainService.StopMining:input_type -> blockchain.StopMiningRequest
+	41, // 40: blockchain.BlockchainService.GetMiningInfo:input_type -> blockchain.GetMiningInfoRequest
+	42, // 41: blockchain.BlockchainService.SubscribeBlocks:input_type -> blockchain.SubscribeBlocksRequest
+	43, // 42: blockchain.BlockchainService.SubscribeTransactions:input_type -> blockchain.SubscribeTransactionsRequest
+	44, // 43: blockchain.WalletService.CreateWallet:input_type -> blockchain.CreateWalletRequest
+	45, // 44: blockchain.WalletService.GetWallet:input_type -> blockchain.GetWalletRequest
+	46, // 45: blockchain.WalletService.ListWallets:input_type -> blockchain.ListWalletsRequest
+	48, // 46: blockchain.WalletService.GetWalletBalance:input_type -> blockchain.GetWalletBalanceRequest
+	50, // 47: blockchain.WalletService.SendTransaction:input_type -> blockchain.SendTransactionRequest
+	52, // 48: blockchain.WalletService.BumpFee:input_type -> blockchain.BumpFeeRequest
+	54, // 49: blockchain.WalletService.EncryptWallet:input_type -> blockchain.EncryptWalletRequest
+	56, // 50: blockchain.WalletService.WalletPassphrase:input_type -> blockchain.WalletPassphraseRequest
+	58, // 51: blockchain.WalletService.WalletLock:input_type -> blockchain.WalletLockRequest
+	60, // 52: blockchain.WalletService.WalletPassphraseChange:input_type -> blockchain.WalletPassphraseChangeRequest
+	62, // 53: blockchain.WalletService.LoadWallet:input_type -> blockchain.LoadWalletRequest
+	64, // 54: blockchain.WalletService.UnloadWallet:input_type -> blockchain.UnloadWalletRequest
+	66, // 55: blockchain.WalletService.ListWalletDir:input_type -> blockchain.ListWalletDirRequest
+	69, // 56: blockchain.WalletService.ImportPrivateKey:input_type -> blockchain.ImportPrivateKeyRequest
+	70, // 57: blockchain.WalletService.DumpPrivateKey:input_type -> blockchain.DumpPrivateKeyRequest
+	72, // 58: blockchain.WalletService.SignMessage:input_type -> blockchain.SignMessageRequest
+	74, // 59: blockchain.WalletService.BackupWallet:input_type -> blockchain.BackupWalletRequest
+	76, // 60: blockchain.WalletService.CreateWatchOnlyWallet:input_type -> blockchain.CreateWatchOnlyWalletRequest
+	78, // 61: blockchain.WalletService.ImportAddress:input_type -> blockchain.ImportAddressRequest
+	79, // 62: blockchain.WalletService.ImportXPub:input_type -> blockchain.ImportXPubRequest
+	81, // 63: blockchain.WalletService.RescanWallet:input_type -> blockchain.RescanWalletRequest
+	83, // 64: blockchain.WalletService.ListTransactions:input_type -> blockchain.ListTransactionsRequest
+	86, // 65: blockchain.WalletService.CreateUnsignedTransaction:input_type -> blockchain.CreateUnsignedTransactionRequest
+	89, // 66: blockchain.WalletService.CreatePSBT:input_type -> blockchain.CreatePSBTRequest
+	90, // 67: blockchain.WalletService.UpdatePSBT:input_type -> blockchain.PSBTRequest
+	91, // 68: blockchain.WalletService.SignPSBT:input_type -> blockchain.SignPSBTRequest
+	92, // 69: blockchain.WalletService.CombinePSBT:input_type -> blockchain.CombinePSBTRequest
+	90, // 70: blockchain.WalletService.FinalizePSBT:input_type -> blockchain.PSBTRequest
+	94, // 71: blockchain.WalletService.ExtractPSBT:input_type -> blockchain.ExtractPSBTRequest
+	0,  // 72: blockchain.BlockchainService.GetBlockByHash:output_type -> blockchain.Block
+	0,  // 73: blockchain.BlockchainService.GetBlockByHeight:output_type -> blockchain.Block
+	9,  // 74: blockchain.BlockchainService.GetBlockchainInfo:output_type -> blockchain.BlockchainInfo
+	14, // 75: blockchain.BlockchainService.GetBestBlockHash:output_type -> blockchain.GetBestBlockHashResponse
+	16, // 76: blockchain.BlockchainService.GetBlockHeight:output_type -> blockchain.GetBlockHeightResponse
+	1,  // 77: blockchain.BlockchainService.GetTransaction:output_type -> blockchain.Transaction
+	19, // 78: blockchain.BlockchainService.SubmitTransaction:output_type -> blockchain.SubmitTransactionResponse
+	21, // 79: blockchain.BlockchainService.GetMempool:output_type -> blockchain.GetMempoolResponse
+	23, // 80: blockchain.BlockchainService.GetUTXO:output_type -> blockchain.GetUTXOResponse
+	25, // 81: blockchain.BlockchainService.GetBalance:output_type -> blockchain.GetBalanceResponse
+	28, // 82: blockchain.BlockchainService.GetAddressHistory:output_type -> blockchain.GetAddressHistoryResponse
+	30, // 83: blockchain.BlockchainService.GetPeerInfo:output_type -> blockchain.GetPeerInfoResponse
+	32, // 84: blockchain.BlockchainService.ConnectPeer:output_type -> blockchain.ConnectPeerResponse
+	34, // 85: blockchain.BlockchainService.ListBanned:output_type -> blockchain.ListBannedResponse
+	36, // 86: blockchain.BlockchainService.SetBan:output_type -> blockchain.SetBanResponse
+	38, // 87: blockchain.BlockchainService.StartMining:output_type -> blockchain.StartMiningResponse
+	40, // 88: blockchain.BlockchainService.StopMining:output_type -> blockchain.StopMiningResponse
+	8,  // 89: blockchain.BlockchainService.GetMiningInfo:output_type -> blockchain.MiningInfo
+	0,  // 90: blockchain.BlockchainService.SubscribeBlocks:output_type -> blockchain.Block
+	1,  // 91: blockchain.BlockchainService.SubscribeTransactions:output_type -> blockchain.Transaction
+	5,  // 92: blockchain.WalletService.CreateWallet:output_type -> blockchain.Wallet
+	5,  // 93: blockchain.WalletService.GetWallet:output_type -> blockchain.Wallet
+	47, // 94: blockchain.WalletService.ListWallets:output_type -> blockchain.ListWalletsResponse
+	49, // 95: blockchain.WalletService.GetWalletBalance:output_type -> blockchain.GetWalletBalanceResponse
+	51, // 96: blockchain.WalletService.SendTransaction:output_type -> blockchain.SendTransactionResponse
+	53, // 97: blockchain.WalletService.BumpFee:output_type -> blockchain.BumpFeeResponse
+	55, // 98: blockchain.WalletService.EncryptWallet:output_type -> blockchain.EncryptWalletResponse
+	57, // 99: blockchain.WalletService.WalletPassphrase:output_type -> blockchain.WalletPassphraseResponse
+	59, // 100: blockchain.WalletService.WalletLock:output_type -> blockchain.WalletLockResponse
+	61, // 101: blockchain.WalletService.WalletPassphraseChange:output_type -> blockchain.WalletPassphraseChangeResponse
+	63, // 102: blockchain.WalletService.LoadWallet:output_type -> blockchain.LoadWalletResponse
+	65, // 103: blockchain.WalletService.UnloadWallet:output_type -> blockchain.UnloadWalletResponse
+	68, // 104: blockchain.WalletService.ListWalletDir:output_type -> blockchain.ListWalletDirResponse
+	5,  // 105: blockchain.WalletService.ImportPrivateKey:output_type -> blockchain.Wallet
+	71, // 106: blockchain.WalletService.DumpPrivateKey:output_type -> blockchain.DumpPrivateKeyResponse
+	73, // 107: blockchain.WalletService.SignMessage:output_type -> blockchain.SignMessageResponse
+	75, // 108: blockchain.WalletService.BackupWallet:output_type -> blockchain.BackupWalletResponse
+	77, // 109: blockchain.WalletService.CreateWatchOnlyWallet:output_type -> blockchain.CreateWatchOnlyWalletResponse
+	80, // 110: blockchain.WalletService.ImportAddress:output_type -> blockchain.ImportWatchResponse
+	80, // 111: blockchain.WalletService.ImportXPub:output_type -> blockchain.ImportWatchResponse
+	82, // 112: blockchain.WalletService.RescanWallet:output_type -> blockchain.RescanWalletResponse
+	85, // 113: blockchain.WalletService.ListTransactions:output_type -> blockchain.ListTransactionsResponse
+	88, // 114: blockchain.WalletService.CreateUnsignedTransaction:output_type -> blockchain.CreateUnsignedTransactionResponse
+	93, // 115: blockchain.WalletService.CreatePSBT:output_type -> blockchain.PSBTResponse
+	93, // 116: blockchain.WalletService.UpdatePSBT:output_type -> blockchain.PSBTResponse
+	93, // 117: blockchain.WalletService.SignPSBT:output_type -> blockchain.PSBTResponse
+	93, // 118: blockchain.WalletService.CombinePSBT:output_type -> blockchain.PSBTResponse
+	93, // 119: blockchain.WalletService.FinalizePSBT:output_type -> blockchain.PSBTResponse
+	95, // 120: blockchain.WalletService.ExtractPSBT:output_type -> blockchain.ExtractPSBTResponse
+	72, // [72:121] is the sub-list for method output_type
+	23, // [23:72] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_blockchain_proto_init() }
@@ -2508,7 +6265,7 @@ func file_api_proto_blockchain_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_blockchain_proto_rawDesc), len(file_api_proto_blockchain_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   44,
+			NumMessages:   96,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -35,9 +35,12 @@ type BlockchainServiceClient interface {
 	// UTXO operations
 	GetUTXO(ctx context.Context, in *GetUTXORequest, opts ...grpc.CallOption) (*GetUTXOResponse, error)
 	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	GetAddressHistory(ctx context.Context, in *GetAddressHistoryRequest, opts ...grpc.CallOption) (*GetAddressHistoryResponse, error)
 	// P2P operations
 	GetPeerInfo(ctx context.Context, in *GetPeerInfoRequest, opts ...grpc.CallOption) (*GetPeerInfoResponse, error)
 	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
+	ListBanned(ctx context.Context, in *ListBannedRequest, opts ...grpc.CallOption) (*ListBannedResponse, error)
+	SetBan(ctx context.Context, in *SetBanRequest, opts ...grpc.CallOption) (*SetBanResponse, error)
 	// Mining operations
 	StartMining(ctx context.Context, in *StartMiningRequest, opts ...grpc.CallOption) (*StartMiningResponse, error)
 	StopMining(ctx context.Context, in *StopMiningRequest, opts ...grpc.CallOption) (*StopMiningResponse, error)
@@ -145,6 +148,15 @@ func (c *blockchainServiceClient) GetBalance(ctx context.Context, in *GetBalance
 	return out, nil
 }
 
+func (c *blockchainServiceClient) GetAddressHistory(ctx context.Context, in *GetAddressHistoryRequest, opts ...grpc.CallOption) (*GetAddressHistoryResponse, error) {
+	out := new(GetAddressHistoryResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.BlockchainService/GetAddressHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) GetPeerInfo(ctx context.Context, in *GetPeerInfoRequest, opts ...grpc.CallOption) (*GetPeerInfoResponse, error) {
 	out := new(GetPeerInfoResponse)
 	err := c.cc.Invoke(ctx, "/blockchain.BlockchainService/GetPeerInfo", in, out, opts...)
@@ -163,6 +175,24 @@ func (c *blockchainServiceClient) ConnectPeer(ctx context.Context, in *ConnectPe
 	return out, nil
 }
 
+func (c *blockchainServiceClient) ListBanned(ctx context.Context, in *ListBannedRequest, opts ...grpc.CallOption) (*ListBannedResponse, error) {
+	out := new(ListBannedResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.BlockchainService/ListBanned", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockchainServiceClient) SetBan(ctx context.Context, in *SetBanRequest, opts ...grpc.CallOption) (*SetBanResponse, error) {
+	out := new(SetBanResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.BlockchainService/SetBan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockchainServiceClient) StartMining(ctx context.Context, in *StartMiningRequest, opts ...grpc.CallOption) (*StartMiningResponse, error) {
 	out := new(StartMiningResponse)
 	err := c.cc.Invoke(ctx, "/blockchain.BlockchainService/StartMining", in, out, opts...)
@@ -271,9 +301,12 @@ type BlockchainServiceServer interface {
 	// UTXO operations
 	GetUTXO(context.Context, *GetUTXORequest) (*GetUTXOResponse, error)
 	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	GetAddressHistory(context.Context, *GetAddressHistoryRequest) (*GetAddressHistoryResponse, error)
 	// P2P operations
 	GetPeerInfo(context.Context, *GetPeerInfoRequest) (*GetPeerInfoResponse, error)
 	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
+	ListBanned(context.Context, *ListBannedRequest) (*ListBannedResponse, error)
+	SetBan(context.Context, *SetBanRequest) (*SetBanResponse, error)
 	// Mining operations
 	StartMining(context.Context, *StartMiningRequest) (*StartMiningResponse, error)
 	StopMining(context.Context, *StopMiningRequest) (*StopMiningResponse, error)
@@ -318,12 +351,21 @@ func (UnimplementedBlockchainServiceServer) GetUTXO(context.Context, *GetUTXOReq
 func (UnimplementedBlockchainServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
 }
+func (UnimplementedBlockchainServiceServer) GetAddressHistory(context.Context, *GetAddressHistoryRequest) (*GetAddressHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressHistory not implemented")
+}
 func (UnimplementedBlockchainServiceServer) GetPeerInfo(context.Context, *GetPeerInfoRequest) (*GetPeerInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPeerInfo not implemented")
 }
 func (UnimplementedBlockchainServiceServer) ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConnectPeer not implemented")
 }
+func (UnimplementedBlockchainServiceServer) ListBanned(context.Context, *ListBannedRequest) (*ListBannedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBanned not implemented")
+}
+func (UnimplementedBlockchainServiceServer) SetBan(context.Context, *SetBanRequest) (*SetBanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBan not implemented")
+}
 func (UnimplementedBlockchainServiceServer) StartMining(context.Context, *StartMiningRequest) (*StartMiningResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartMining not implemented")
 }
@@ -532,6 +574,24 @@ func _BlockchainService_GetBalance_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_GetAddressHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).GetAddressHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.BlockchainService/GetAddressHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).GetAddressHistory(ctx, req.(*GetAddressHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_GetPeerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPeerInfoRequest)
 	if err := dec(in); err != nil {
@@ -568,6 +628,42 @@ func _BlockchainService_ConnectPeer_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockchainService_ListBanned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBannedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).ListBanned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.BlockchainService/ListBanned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).ListBanned(ctx, req.(*ListBannedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockchainService_SetBan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockchainServiceServer).SetBan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.BlockchainService/SetBan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockchainServiceServer).SetBan(ctx, req.(*SetBanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockchainService_StartMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartMiningRequest)
 	if err := dec(in); err != nil {
@@ -711,6 +807,10 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBalance",
 			Handler:    _BlockchainService_GetBalance_Handler,
 		},
+		{
+			MethodName: "GetAddressHistory",
+			Handler:    _BlockchainService_GetAddressHistory_Handler,
+		},
 		{
 			MethodName: "GetPeerInfo",
 			Handler:    _BlockchainService_GetPeerInfo_Handler,
@@ -719,6 +819,14 @@ var BlockchainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ConnectPeer",
 			Handler:    _BlockchainService_ConnectPeer_Handler,
 		},
+		{
+			MethodName: "ListBanned",
+			Handler:    _BlockchainService_ListBanned_Handler,
+		},
+		{
+			MethodName: "SetBan",
+			Handler:    _BlockchainService_SetBan_Handler,
+		},
 		{
 			MethodName: "StartMining",
 			Handler:    _BlockchainService_StartMining_Handler,
@@ -756,6 +864,35 @@ type WalletServiceClient interface {
 	ListWallets(ctx context.Context, in *ListWalletsRequest, opts ...grpc.CallOption) (*ListWalletsResponse, error)
 	GetWalletBalance(ctx context.Context, in *GetWalletBalanceRequest, opts ...grpc.CallOption) (*GetWalletBalanceResponse, error)
 	SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error)
+	// Passphrase protection of stored private keys
+	EncryptWallet(ctx context.Context, in *EncryptWalletRequest, opts ...grpc.CallOption) (*EncryptWalletResponse, error)
+	WalletPassphrase(ctx context.Context, in *WalletPassphraseRequest, opts ...grpc.CallOption) (*WalletPassphraseResponse, error)
+	WalletLock(ctx context.Context, in *WalletLockRequest, opts ...grpc.CallOption) (*WalletLockResponse, error)
+	WalletPassphraseChange(ctx context.Context, in *WalletPassphraseChangeRequest, opts ...grpc.CallOption) (*WalletPassphraseChangeResponse, error)
+	// Named wallets kept in the node's wallet directory
+	LoadWallet(ctx context.Context, in *LoadWalletRequest, opts ...grpc.CallOption) (*LoadWalletResponse, error)
+	UnloadWallet(ctx context.Context, in *UnloadWalletRequest, opts ...grpc.CallOption) (*UnloadWalletResponse, error)
+	ListWalletDir(ctx context.Context, in *ListWalletDirRequest, opts ...grpc.CallOption) (*ListWalletDirResponse, error)
+	// Key management and message signing
+	ImportPrivateKey(ctx context.Context, in *ImportPrivateKeyRequest, opts ...grpc.CallOption) (*Wallet, error)
+	DumpPrivateKey(ctx context.Context, in *DumpPrivateKeyRequest, opts ...grpc.CallOption) (*DumpPrivateKeyResponse, error)
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+	BackupWallet(ctx context.Context, in *BackupWalletRequest, opts ...grpc.CallOption) (*BackupWalletResponse, error)
+	// Watch-only wallets, transaction tracking and offline signing
+	CreateWatchOnlyWallet(ctx context.Context, in *CreateWatchOnlyWalletRequest, opts ...grpc.CallOption) (*CreateWatchOnlyWalletResponse, error)
+	ImportAddress(ctx context.Context, in *ImportAddressRequest, opts ...grpc.CallOption) (*ImportWatchResponse, error)
+	ImportXPub(ctx context.Context, in *ImportXPubRequest, opts ...grpc.CallOption) (*ImportWatchResponse, error)
+	RescanWallet(ctx context.Context, in *RescanWalletRequest, opts ...grpc.CallOption) (*RescanWalletResponse, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	CreateUnsignedTransaction(ctx context.Context, in *CreateUnsignedTransactionRequest, opts ...grpc.CallOption) (*CreateUnsignedTransactionResponse, error)
+	// Partially signed transactions, for offline and multi-party signing
+	CreatePSBT(ctx context.Context, in *CreatePSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error)
+	UpdatePSBT(ctx context.Context, in *PSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error)
+	SignPSBT(ctx context.Context, in *SignPSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error)
+	CombinePSBT(ctx context.Context, in *CombinePSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error)
+	FinalizePSBT(ctx context.Context, in *PSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error)
+	ExtractPSBT(ctx context.Context, in *ExtractPSBTRequest, opts ...grpc.CallOption) (*ExtractPSBTResponse, error)
 }
 
 type walletServiceClient struct {
@@ -811,6 +948,222 @@ func (c *walletServiceClient) SendTransaction(ctx context.Context, in *SendTrans
 	return out, nil
 }
 
+func (c *walletServiceClient) BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error) {
+	out := new(BumpFeeResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/BumpFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) EncryptWallet(ctx context.Context, in *EncryptWalletRequest, opts ...grpc.CallOption) (*EncryptWalletResponse, error) {
+	out := new(EncryptWalletResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/EncryptWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WalletPassphrase(ctx context.Context, in *WalletPassphraseRequest, opts ...grpc.CallOption) (*WalletPassphraseResponse, error) {
+	out := new(WalletPassphraseResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/WalletPassphrase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WalletLock(ctx context.Context, in *WalletLockRequest, opts ...grpc.CallOption) (*WalletLockResponse, error) {
+	out := new(WalletLockResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/WalletLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WalletPassphraseChange(ctx context.Context, in *WalletPassphraseChangeRequest, opts ...grpc.CallOption) (*WalletPassphraseChangeResponse, error) {
+	out := new(WalletPassphraseChangeResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/WalletPassphraseChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) LoadWallet(ctx context.Context, in *LoadWalletRequest, opts ...grpc.CallOption) (*LoadWalletResponse, error) {
+	out := new(LoadWalletResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/LoadWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) UnloadWallet(ctx context.Context, in *UnloadWalletRequest, opts ...grpc.CallOption) (*UnloadWalletResponse, error) {
+	out := new(UnloadWalletResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/UnloadWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListWalletDir(ctx context.Context, in *ListWalletDirRequest, opts ...grpc.CallOption) (*ListWalletDirResponse, error) {
+	out := new(ListWalletDirResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/ListWalletDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportPrivateKey(ctx context.Context, in *ImportPrivateKeyRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/ImportPrivateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DumpPrivateKey(ctx context.Context, in *DumpPrivateKeyRequest, opts ...grpc.CallOption) (*DumpPrivateKeyResponse, error) {
+	out := new(DumpPrivateKeyResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/DumpPrivateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/SignMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) BackupWallet(ctx context.Context, in *BackupWalletRequest, opts ...grpc.CallOption) (*BackupWalletResponse, error) {
+	out := new(BackupWalletResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/BackupWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateWatchOnlyWallet(ctx context.Context, in *CreateWatchOnlyWalletRequest, opts ...grpc.CallOption) (*CreateWatchOnlyWalletResponse, error) {
+	out := new(CreateWatchOnlyWalletResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/CreateWatchOnlyWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportAddress(ctx context.Context, in *ImportAddressRequest, opts ...grpc.CallOption) (*ImportWatchResponse, error) {
+	out := new(ImportWatchResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/ImportAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ImportXPub(ctx context.Context, in *ImportXPubRequest, opts ...grpc.CallOption) (*ImportWatchResponse, error) {
+	out := new(ImportWatchResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/ImportXPub", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RescanWallet(ctx context.Context, in *RescanWalletRequest, opts ...grpc.CallOption) (*RescanWalletResponse, error) {
+	out := new(RescanWalletResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/RescanWallet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/ListTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreateUnsignedTransaction(ctx context.Context, in *CreateUnsignedTransactionRequest, opts ...grpc.CallOption) (*CreateUnsignedTransactionResponse, error) {
+	out := new(CreateUnsignedTransactionResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/CreateUnsignedTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CreatePSBT(ctx context.Context, in *CreatePSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error) {
+	out := new(PSBTResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/CreatePSBT", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) UpdatePSBT(ctx context.Context, in *PSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error) {
+	out := new(PSBTResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/UpdatePSBT", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SignPSBT(ctx context.Context, in *SignPSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error) {
+	out := new(PSBTResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/SignPSBT", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CombinePSBT(ctx context.Context, in *CombinePSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error) {
+	out := new(PSBTResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/CombinePSBT", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) FinalizePSBT(ctx context.Context, in *PSBTRequest, opts ...grpc.CallOption) (*PSBTResponse, error) {
+	out := new(PSBTResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/FinalizePSBT", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ExtractPSBT(ctx context.Context, in *ExtractPSBTRequest, opts ...grpc.CallOption) (*ExtractPSBTResponse, error) {
+	out := new(ExtractPSBTResponse)
+	err := c.cc.Invoke(ctx, "/blockchain.WalletService/ExtractPSBT", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WalletServiceServer is the server API for WalletService service.
 // All implementations must embed UnimplementedWalletServiceServer
 // for forward compatibility
@@ -820,6 +1173,35 @@ type WalletServiceServer interface {
 	ListWallets(context.Context, *ListWalletsRequest) (*ListWalletsResponse, error)
 	GetWalletBalance(context.Context, *GetWalletBalanceRequest) (*GetWalletBalanceResponse, error)
 	SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error)
+	BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error)
+	// Passphrase protection of stored private keys
+	EncryptWallet(context.Context, *EncryptWalletRequest) (*EncryptWalletResponse, error)
+	WalletPassphrase(context.Context, *WalletPassphraseRequest) (*WalletPassphraseResponse, error)
+	WalletLock(context.Context, *WalletLockRequest) (*WalletLockResponse, error)
+	WalletPassphraseChange(context.Context, *WalletPassphraseChangeRequest) (*WalletPassphraseChangeResponse, error)
+	// Named wallets kept in the node's wallet directory
+	LoadWallet(context.Context, *LoadWalletRequest) (*LoadWalletResponse, error)
+	UnloadWallet(context.Context, *UnloadWalletRequest) (*UnloadWalletResponse, error)
+	ListWalletDir(context.Context, *ListWalletDirRequest) (*ListWalletDirResponse, error)
+	// Key management and message signing
+	ImportPrivateKey(context.Context, *ImportPrivateKeyRequest) (*Wallet, error)
+	DumpPrivateKey(context.Context, *DumpPrivateKeyRequest) (*DumpPrivateKeyResponse, error)
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+	BackupWallet(context.Context, *BackupWalletRequest) (*BackupWalletResponse, error)
+	// Watch-only wallets, transaction tracking and offline signing
+	CreateWatchOnlyWallet(context.Context, *CreateWatchOnlyWalletRequest) (*CreateWatchOnlyWalletResponse, error)
+	ImportAddress(context.Context, *ImportAddressRequest) (*ImportWatchResponse, error)
+	ImportXPub(context.Context, *ImportXPubRequest) (*ImportWatchResponse, error)
+	RescanWallet(context.Context, *RescanWalletRequest) (*RescanWalletResponse, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	CreateUnsignedTransaction(context.Context, *CreateUnsignedTransactionRequest) (*CreateUnsignedTransactionResponse, error)
+	// Partially signed transactions, for offline and multi-party signing
+	CreatePSBT(context.Context, *CreatePSBTRequest) (*PSBTResponse, error)
+	UpdatePSBT(context.Context, *PSBTRequest) (*PSBTResponse, error)
+	SignPSBT(context.Context, *SignPSBTRequest) (*PSBTResponse, error)
+	CombinePSBT(context.Context, *CombinePSBTRequest) (*PSBTResponse, error)
+	FinalizePSBT(context.Context, *PSBTRequest) (*PSBTResponse, error)
+	ExtractPSBT(context.Context, *ExtractPSBTRequest) (*ExtractPSBTResponse, error)
 	mustEmbedUnimplementedWalletServiceServer()
 }
 
@@ -842,6 +1224,78 @@ func (UnimplementedWalletServiceServer) GetWalletBalance(context.Context, *GetWa
 func (UnimplementedWalletServiceServer) SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
 }
+func (UnimplementedWalletServiceServer) BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BumpFee not implemented")
+}
+func (UnimplementedWalletServiceServer) EncryptWallet(context.Context, *EncryptWalletRequest) (*EncryptWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EncryptWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) WalletPassphrase(context.Context, *WalletPassphraseRequest) (*WalletPassphraseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletPassphrase not implemented")
+}
+func (UnimplementedWalletServiceServer) WalletLock(context.Context, *WalletLockRequest) (*WalletLockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletLock not implemented")
+}
+func (UnimplementedWalletServiceServer) WalletPassphraseChange(context.Context, *WalletPassphraseChangeRequest) (*WalletPassphraseChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletPassphraseChange not implemented")
+}
+func (UnimplementedWalletServiceServer) LoadWallet(context.Context, *LoadWalletRequest) (*LoadWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) UnloadWallet(context.Context, *UnloadWalletRequest) (*UnloadWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnloadWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ListWalletDir(context.Context, *ListWalletDirRequest) (*ListWalletDirResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWalletDir not implemented")
+}
+func (UnimplementedWalletServiceServer) ImportPrivateKey(context.Context, *ImportPrivateKeyRequest) (*Wallet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportPrivateKey not implemented")
+}
+func (UnimplementedWalletServiceServer) DumpPrivateKey(context.Context, *DumpPrivateKeyRequest) (*DumpPrivateKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpPrivateKey not implemented")
+}
+func (UnimplementedWalletServiceServer) SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMessage not implemented")
+}
+func (UnimplementedWalletServiceServer) BackupWallet(context.Context, *BackupWalletRequest) (*BackupWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateWatchOnlyWallet(context.Context, *CreateWatchOnlyWalletRequest) (*CreateWatchOnlyWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWatchOnlyWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ImportAddress(context.Context, *ImportAddressRequest) (*ImportWatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAddress not implemented")
+}
+func (UnimplementedWalletServiceServer) ImportXPub(context.Context, *ImportXPubRequest) (*ImportWatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportXPub not implemented")
+}
+func (UnimplementedWalletServiceServer) RescanWallet(context.Context, *RescanWalletRequest) (*RescanWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RescanWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateUnsignedTransaction(context.Context, *CreateUnsignedTransactionRequest) (*CreateUnsignedTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUnsignedTransaction not implemented")
+}
+func (UnimplementedWalletServiceServer) CreatePSBT(context.Context, *CreatePSBTRequest) (*PSBTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePSBT not implemented")
+}
+func (UnimplementedWalletServiceServer) UpdatePSBT(context.Context, *PSBTRequest) (*PSBTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePSBT not implemented")
+}
+func (UnimplementedWalletServiceServer) SignPSBT(context.Context, *SignPSBTRequest) (*PSBTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignPSBT not implemented")
+}
+func (UnimplementedWalletServiceServer) CombinePSBT(context.Context, *CombinePSBTRequest) (*PSBTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CombinePSBT not implemented")
+}
+func (UnimplementedWalletServiceServer) FinalizePSBT(context.Context, *PSBTRequest) (*PSBTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizePSBT not implemented")
+}
+func (UnimplementedWalletServiceServer) ExtractPSBT(context.Context, *ExtractPSBTRequest) (*ExtractPSBTResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtractPSBT not implemented")
+}
 func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
 
 // UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -945,33 +1399,561 @@ func _WalletService_SendTransaction_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
-// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var WalletService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "blockchain.WalletService",
-	HandlerType: (*WalletServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateWallet",
-			Handler:    _WalletService_CreateWallet_Handler,
-		},
-		{
-			MethodName: "GetWallet",
-			Handler:    _WalletService_GetWallet_Handler,
-		},
-		{
-			MethodName: "ListWallets",
-			Handler:    _WalletService_ListWallets_Handler,
-		},
-		{
-			MethodName: "GetWalletBalance",
+func _WalletService_BumpFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).BumpFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/BumpFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).BumpFee(ctx, req.(*BumpFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_EncryptWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).EncryptWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/EncryptWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).EncryptWallet(ctx, req.(*EncryptWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WalletPassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletPassphraseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WalletPassphrase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/WalletPassphrase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WalletPassphrase(ctx, req.(*WalletPassphraseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WalletLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WalletLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/WalletLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WalletLock(ctx, req.(*WalletLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WalletPassphraseChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletPassphraseChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WalletPassphraseChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/WalletPassphraseChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WalletPassphraseChange(ctx, req.(*WalletPassphraseChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_LoadWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).LoadWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/LoadWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).LoadWallet(ctx, req.(*LoadWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_UnloadWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnloadWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).UnloadWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/UnloadWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).UnloadWallet(ctx, req.(*UnloadWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListWalletDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWalletDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListWalletDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/ListWalletDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListWalletDir(ctx, req.(*ListWalletDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportPrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPrivateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportPrivateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/ImportPrivateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportPrivateKey(ctx, req.(*ImportPrivateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DumpPrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpPrivateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DumpPrivateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/DumpPrivateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DumpPrivateKey(ctx, req.(*DumpPrivateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_BackupWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).BackupWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/BackupWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).BackupWallet(ctx, req.(*BackupWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateWatchOnlyWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWatchOnlyWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateWatchOnlyWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/CreateWatchOnlyWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateWatchOnlyWallet(ctx, req.(*CreateWatchOnlyWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/ImportAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportAddress(ctx, req.(*ImportAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ImportXPub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportXPubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportXPub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/ImportXPub",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportXPub(ctx, req.(*ImportXPubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RescanWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescanWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RescanWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/RescanWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RescanWallet(ctx, req.(*RescanWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/ListTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreateUnsignedTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUnsignedTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateUnsignedTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/CreateUnsignedTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateUnsignedTransaction(ctx, req.(*CreateUnsignedTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CreatePSBT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePSBTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreatePSBT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/CreatePSBT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreatePSBT(ctx, req.(*CreatePSBTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_UpdatePSBT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PSBTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).UpdatePSBT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/UpdatePSBT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).UpdatePSBT(ctx, req.(*PSBTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SignPSBT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignPSBTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SignPSBT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/SignPSBT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SignPSBT(ctx, req.(*SignPSBTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CombinePSBT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CombinePSBTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CombinePSBT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/CombinePSBT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CombinePSBT(ctx, req.(*CombinePSBTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_FinalizePSBT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PSBTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).FinalizePSBT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/FinalizePSBT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).FinalizePSBT(ctx, req.(*PSBTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ExtractPSBT_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtractPSBTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ExtractPSBT(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blockchain.WalletService/ExtractPSBT",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ExtractPSBT(ctx, req.(*ExtractPSBTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WalletService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blockchain.WalletService",
+	HandlerType: (*WalletServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWallet",
+			Handler:    _WalletService_CreateWallet_Handler,
+		},
+		{
+			MethodName: "GetWallet",
+			Handler:    _WalletService_GetWallet_Handler,
+		},
+		{
+			MethodName: "ListWallets",
+			Handler:    _WalletService_ListWallets_Handler,
+		},
+		{
+			MethodName: "GetWalletBalance",
 			Handler:    _WalletService_GetWalletBalance_Handler,
 		},
 		{
 			MethodName: "SendTransaction",
 			Handler:    _WalletService_SendTransaction_Handler,
 		},
+		{
+			MethodName: "BumpFee",
+			Handler:    _WalletService_BumpFee_Handler,
+		},
+		{
+			MethodName: "EncryptWallet",
+			Handler:    _WalletService_EncryptWallet_Handler,
+		},
+		{
+			MethodName: "WalletPassphrase",
+			Handler:    _WalletService_WalletPassphrase_Handler,
+		},
+		{
+			MethodName: "WalletLock",
+			Handler:    _WalletService_WalletLock_Handler,
+		},
+		{
+			MethodName: "WalletPassphraseChange",
+			Handler:    _WalletService_WalletPassphraseChange_Handler,
+		},
+		{
+			MethodName: "LoadWallet",
+			Handler:    _WalletService_LoadWallet_Handler,
+		},
+		{
+			MethodName: "UnloadWallet",
+			Handler:    _WalletService_UnloadWallet_Handler,
+		},
+		{
+			MethodName: "ListWalletDir",
+			Handler:    _WalletService_ListWalletDir_Handler,
+		},
+		{
+			MethodName: "ImportPrivateKey",
+			Handler:    _WalletService_ImportPrivateKey_Handler,
+		},
+		{
+			MethodName: "DumpPrivateKey",
+			Handler:    _WalletService_DumpPrivateKey_Handler,
+		},
+		{
+			MethodName: "SignMessage",
+			Handler:    _WalletService_SignMessage_Handler,
+		},
+		{
+			MethodName: "BackupWallet",
+			Handler:    _WalletService_BackupWallet_Handler,
+		},
+		{
+			MethodName: "CreateWatchOnlyWallet",
+			Handler:    _WalletService_CreateWatchOnlyWallet_Handler,
+		},
+		{
+			MethodName: "ImportAddress",
+			Handler:    _WalletService_ImportAddress_Handler,
+		},
+		{
+			MethodName: "ImportXPub",
+			Handler:    _WalletService_ImportXPub_Handler,
+		},
+		{
+			MethodName: "RescanWallet",
+			Handler:    _WalletService_RescanWallet_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _WalletService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "CreateUnsignedTransaction",
+			Handler:    _WalletService_CreateUnsignedTransaction_Handler,
+		},
+		{
+			MethodName: "CreatePSBT",
+			Handler:    _WalletService_CreatePSBT_Handler,
+		},
+		{
+			MethodName: "UpdatePSBT",
+			Handler:    _WalletService_UpdatePSBT_Handler,
+		},
+		{
+			MethodName: "SignPSBT",
+			Handler:    _WalletService_SignPSBT_Handler,
+		},
+		{
+			MethodName: "CombinePSBT",
+			Handler:    _WalletService_CombinePSBT_Handler,
+		},
+		{
+			MethodName: "FinalizePSBT",
+			Handler:    _WalletService_FinalizePSBT_Handler,
+		},
+		{
+			MethodName: "ExtractPSBT",
+			Handler:    _WalletService_ExtractPSBT_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/blockchain.proto",
@@ -2,18 +2,32 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"time"
 
 	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/chaincfg"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
+	networkName := flag.String("network", "main", "Network of the node (main, test or regtest)")
+	grpcAddr := flag.String("grpc", "", "gRPC server address (default localhost:<network RPC port>)")
+	flag.Parse()
+
+	params, err := chaincfg.ParamsForNetwork(*networkName)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if *grpcAddr == "" {
+		*grpcAddr = fmt.Sprintf("localhost:%d", params.RPCPort)
+	}
+
 	// Connect to gRPC server
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(*grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		log.Fatalf("Failed to connect: %v", err)
 	}
@@ -26,7 +40,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	fmt.Println("=== Testing gRPC API ===\n")
+	fmt.Print("=== Testing gRPC API ===\n\n")
 
 	// Test 1: Get blockchain info
 	fmt.Println("1. Getting blockchain info...")
@@ -34,6 +48,7 @@ func main() {
 	if err != nil {
 		log.Printf("Error: %v", err)
 	} else {
+		fmt.Printf("   Network: %s\n", info.Network)
 		fmt.Printf("   Height: %d\n", info.Height)
 		fmt.Printf("   Best Block: %s\n", info.BestBlockHash)
 		fmt.Printf("   Difficulty: %d\n", info.Difficulty)
@@ -1,26 +1,58 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
 	"github.com/yourusername/bt/internal/crypto"
 	"github.com/yourusername/bt/internal/grpc"
+	"github.com/yourusername/bt/internal/p2p"
 )
 
 func main() {
 	// Command-line flags
-	dbPath := flag.String("db", "./data/blockchain", "Path to blockchain database")
+	networkName := flag.String("network", "main", "Network to join (main, test or regtest)")
+	dataDir := flag.String("datadir", chaincfg.DefaultDataDir(), "Base data directory")
+	dbPath := flag.String("db", "", "Path to blockchain database (default <datadir>/<network>/blockchain)")
 	fresh := flag.Bool("fresh", false, "Start with a fresh blockchain")
-	grpcAddr := flag.String("grpc", ":50051", "gRPC server address")
+	grpcAddr := flag.String("grpc", "", "gRPC server address (default localhost:<network RPC port>)")
+	listen := flag.String("listen", "", "P2P listen address; P2P is disabled when empty")
+	connect := flag.String("connect", "", "Connect to peer (e.g., /ip4/127.0.0.1/tcp/9000/p2p/...)")
+	nodeKey := flag.String("nodekey", "", "Path to the node's libp2p private key (default <datadir>/<network>/nodekey)")
+	allow := flag.String("allow", "", "Comma-separated peer IDs; when set, only these peers may connect")
+	security := flag.String("security", "noise,tls", "Comma-separated security transports in order of preference (noise, tls)")
+	walletNames := flag.String("wallet", "", "Comma-separated named wallets to load besides the default wallet")
 	flag.Parse()
 
+	params, err := chaincfg.ParamsForNetwork(*networkName)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	crypto.SetAddressVersion(params.AddressVersion)
+
+	if *dbPath == "" {
+		*dbPath = filepath.Join(params.DataDir(*dataDir), "blockchain")
+	}
+	if *nodeKey == "" {
+		*nodeKey = filepath.Join(params.DataDir(*dataDir), "nodekey")
+	}
+	if *grpcAddr == "" {
+		// The API is unauthenticated and can export keys, so it is not
+		// exposed to other hosts unless asked for
+		*grpcAddr = fmt.Sprintf("localhost:%d", params.RPCPort)
+	}
+	log.Printf("Network: %s", params.Name)
+
 	// Delete old database if fresh start
 	if *fresh {
 		log.Println("Starting with fresh blockchain...")
@@ -32,19 +64,63 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create wallet: %v", err)
 	}
-	minerAddr := wallet.GetAddress()
-	bc, err := blockchain.NewBlockchain(minerAddr, *dbPath)
+	bc, err := blockchain.NewBlockchain(params, *dbPath)
 	if err != nil {
 		log.Fatalf("Failed to create blockchain: %v", err)
 	}
 	defer bc.Close()
 
 	log.Printf("Blockchain initialized with height: %d", bc.Height())
-	log.Printf("Current difficulty: %d", bc.DifficultyTarget)
+	log.Printf("Genesis: %x", bc.GenesisHash())
+	log.Printf("Current difficulty: %d", bc.Difficulty())
+
+	// Optionally join the P2P network so peers show up in GetPeerInfo
+	var network *p2p.Network
+	if *listen != "" {
+		netConfig := p2p.NetworkConfig{
+			ListenAddr: *listen,
+			KeyPath:    *nodeKey,
+			Security:   strings.Split(*security, ","),
+		}
+		if *allow != "" {
+			netConfig.AllowedPeers, err = p2p.ParsePeerIDs(strings.Split(*allow, ","))
+			if err != nil {
+				log.Fatalf("Invalid -allow: %v", err)
+			}
+		}
 
-	// Create and start gRPC server (without P2P for now)
+		network, err = p2p.NewNetworkWithConfig(context.Background(), bc, netConfig)
+		if err != nil {
+			log.Fatalf("Failed to create P2P network: %v", err)
+		}
+		defer network.Stop()
+		if err := network.LoadBanList(filepath.Join(params.DataDir(*dataDir), "banlist.json")); err != nil {
+			log.Fatalf("Failed to load ban list: %v", err)
+		}
+		network.Start()
+
+		if *connect != "" {
+			if err := network.ConnectToPeer(*connect); err != nil {
+				log.Printf("Failed to connect to peer: %v", err)
+			}
+		}
+	}
+
+	// Create and start gRPC server
 	log.Printf("Starting gRPC server on %s", *grpcAddr)
-	server := grpc.NewServer(bc, nil)
+	server := grpc.NewServer(bc, network)
+
+	// Wallets created over gRPC are kept with those of the wallet CLI
+	if err := server.SetWalletDir(params.DataDir(*dataDir)); err != nil {
+		log.Fatalf("Failed to open wallet storage: %v", err)
+	}
+	if *walletNames != "" {
+		for _, name := range strings.Split(*walletNames, ",") {
+			if err := server.LoadWalletByName(name); err != nil {
+				log.Fatalf("Failed to load wallet: %v", err)
+			}
+		}
+	}
 
 	// Start gRPC server in goroutine
 	go func() {
@@ -60,7 +136,7 @@ func main() {
 	log.Println("\n=== Node Information ===")
 	log.Printf("Blockchain Height: %d", bc.Height())
 	log.Printf("gRPC Address: %s", *grpcAddr)
-	log.Println("========================\n")
+	log.Print("========================\n\n")
 
 	// Example: Create a wallet on startup
 	log.Printf("Created wallet with address: %s", wallet.GetAddress())
@@ -88,11 +164,25 @@ func printUsageInstructions(grpcAddr string) {
 	fmt.Printf("  grpcurl -plaintext -d '{\"height\": 0}' %s blockchain.BlockchainService/GetBlockByHeight\n\n", grpcAddr)
 	fmt.Printf("  # Create a wallet\n")
 	fmt.Printf("  grpcurl -plaintext -d '{}' %s blockchain.WalletService/CreateWallet\n\n", grpcAddr)
+	fmt.Printf("  # Encrypt the stored wallets, then unlock them for 5 minutes to send\n")
+	fmt.Printf("  grpcurl -plaintext -d '{\"passphrase\": \"<passphrase>\"}' %s blockchain.WalletService/EncryptWallet\n", grpcAddr)
+	fmt.Printf("  grpcurl -plaintext -d '{\"passphrase\": \"<passphrase>\", \"timeout_seconds\": 300}' %s blockchain.WalletService/WalletPassphrase\n", grpcAddr)
+	fmt.Printf("  grpcurl -plaintext %s blockchain.WalletService/WalletLock\n\n", grpcAddr)
+	fmt.Printf("  # Create, load and unload named wallets\n")
+	fmt.Printf("  grpcurl -plaintext -d '{\"name\": \"savings\"}' %s blockchain.WalletService/CreateWallet\n", grpcAddr)
+	fmt.Printf("  grpcurl -plaintext %s blockchain.WalletService/ListWalletDir\n", grpcAddr)
+	fmt.Printf("  grpcurl -plaintext -d '{\"name\": \"savings\"}' %s blockchain.WalletService/UnloadWallet\n", grpcAddr)
+	fmt.Printf("  grpcurl -plaintext -d '{\"name\": \"savings\"}' %s blockchain.WalletService/LoadWallet\n\n", grpcAddr)
 	fmt.Printf("  # Get balance\n")
 	fmt.Printf("  grpcurl -plaintext -d '{\"address\": \"<address>\"}' %s blockchain.BlockchainService/GetBalance\n\n", grpcAddr)
+	fmt.Printf("  # List connected peers\n")
+	fmt.Printf("  grpcurl -plaintext %s blockchain.BlockchainService/GetPeerInfo\n\n", grpcAddr)
+	fmt.Printf("  # List and manage banned peers\n")
+	fmt.Printf("  grpcurl -plaintext %s blockchain.BlockchainService/ListBanned\n", grpcAddr)
+	fmt.Printf("  grpcurl -plaintext -d '{\"peer_id\": \"<id>\", \"command\": \"add\"}' %s blockchain.BlockchainService/SetBan\n\n", grpcAddr)
 	fmt.Printf("  # Start mining\n")
 	fmt.Printf("  grpcurl -plaintext -d '{\"miner_address\": \"<address>\"}' %s blockchain.BlockchainService/StartMining\n\n", grpcAddr)
 	fmt.Println("\nInstall grpcurl: go install github.com/fullstorydev/grpcurl/cmd/grpcurl@latest")
 	fmt.Println("\nPress Ctrl+C to stop the node")
-	fmt.Println("===========================\n")
+	fmt.Print("===========================\n\n")
 }
@@ -7,9 +7,12 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
 	"github.com/yourusername/bt/internal/crypto"
 	"github.com/yourusername/bt/internal/p2p"
 	"github.com/yourusername/bt/internal/tx"
@@ -18,15 +21,43 @@ import (
 
 func main() {
 	// Command line flags
-	dbPath := flag.String("db", "./blockchain.db", "Path to blockchain database")
+	networkName := flag.String("network", "main", "Network to join (main, test or regtest)")
+	dataDir := flag.String("datadir", chaincfg.DefaultDataDir(), "Base data directory")
+	dbPath := flag.String("db", "", "Path to blockchain database (default <datadir>/<network>/blockchain)")
 	fresh := flag.Bool("fresh", false, "Start with a fresh blockchain")
-	listen := flag.String("listen", "/ip4/0.0.0.0/tcp/9000", "P2P listen address")
+	listen := flag.String("listen", "", "P2P listen address (default /ip4/0.0.0.0/tcp/<network port>)")
 	connect := flag.String("connect", "", "Connect to peer (e.g., /ip4/127.0.0.1/tcp/9000/p2p/...)")
+	seeds := flag.String("seeds", "", "Comma-separated seed peer multiaddrs")
+	mdnsDiscovery := flag.Bool("mdns", true, "Discover peers on the local network with mDNS")
+	dhtDiscovery := flag.Bool("dht", false, "Discover peers through a Kademlia DHT rendezvous (requires -tags dht)")
+	targetPeers := flag.Int("peers", p2p.DefaultTargetOutbound, "Number of outbound peers to maintain")
+	maxInbound := flag.Int("maxinbound", p2p.DefaultMaxInbound, "Maximum number of inbound peers")
+	maxOutbound := flag.Int("maxoutbound", p2p.DefaultMaxOutbound, "Maximum number of outbound peers, including -connect")
+	nodeKey := flag.String("nodekey", "", "Path to the node's libp2p private key (default <datadir>/<network>/nodekey)")
+	allow := flag.String("allow", "", "Comma-separated peer IDs; when set, only these peers may connect")
+	security := flag.String("security", "noise,tls", "Comma-separated security transports in order of preference (noise, tls)")
 	mine := flag.Bool("mine", false, "Enable mining mode")
 	flag.Parse()
 
+	params, err := chaincfg.ParamsForNetwork(*networkName)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	crypto.SetAddressVersion(params.AddressVersion)
+
+	if *dbPath == "" {
+		*dbPath = filepath.Join(params.DataDir(*dataDir), "blockchain")
+	}
+	if *listen == "" {
+		*listen = fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", params.DefaultPort)
+	}
+	if *nodeKey == "" {
+		*nodeKey = filepath.Join(params.DataDir(*dataDir), "nodekey")
+	}
+
 	fmt.Println("🚀 Starting Bitcoin-like Cryptocurrency Node (Phase 4 - P2P)")
 	fmt.Println("================================================================")
+	fmt.Printf("Network: %s\n", params.Name)
 
 	// Create wallets for testing
 	fmt.Println("\n📝 Creating test wallets...")
@@ -49,12 +80,13 @@ func main() {
 		os.RemoveAll(*dbPath)
 	}
 
-	bc, err := blockchain.NewBlockchain(minerAddr, *dbPath)
+	bc, err := blockchain.NewBlockchain(params, *dbPath)
 	if err != nil {
 		log.Fatalf("Failed to create blockchain: %v", err)
 	}
 	defer bc.Close()
 
+	fmt.Printf("  Genesis: %x\n", bc.GenesisHash())
 	fmt.Printf("  Height: %d\n", bc.Height())
 	fmt.Printf("  Difficulty: %d bits\n", bc.DifficultyTarget)
 
@@ -65,11 +97,31 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	network, err := p2p.NewNetwork(ctx, bc, *listen)
+	// The node key keeps our peer ID stable across restarts
+	netConfig := p2p.NetworkConfig{
+		ListenAddr: *listen,
+		KeyPath:    *nodeKey,
+		Security:   strings.Split(*security, ","),
+	}
+	if *allow != "" {
+		netConfig.AllowedPeers, err = p2p.ParsePeerIDs(strings.Split(*allow, ","))
+		if err != nil {
+			log.Fatalf("Invalid -allow: %v", err)
+		}
+		fmt.Printf("  Allowlist: %d peers\n", len(netConfig.AllowedPeers))
+	}
+
+	network, err := p2p.NewNetworkWithConfig(ctx, bc, netConfig)
 	if err != nil {
 		log.Fatalf("Failed to create P2P network: %v", err)
 	}
 	defer network.Stop()
+	network.SetConnLimits(*maxInbound, *maxOutbound)
+
+	// Bans from earlier runs stay in force
+	if err := network.LoadBanList(filepath.Join(params.DataDir(*dataDir), "banlist.json")); err != nil {
+		log.Fatalf("Failed to load ban list: %v", err)
+	}
 
 	if err := network.Start(); err != nil {
 		log.Fatalf("Failed to start P2P network: %v", err)
@@ -84,6 +136,20 @@ func main() {
 		fmt.Printf("💸 New transaction received from network: %x\n", transaction.ID[:8])
 	})
 
+	// Find peers through seeds, mDNS, the DHT and addresses saved by earlier runs
+	discovery := p2p.DiscoveryConfig{
+		AddrBookPath:   filepath.Join(params.DataDir(*dataDir), "peers.json"),
+		MDNS:           *mdnsDiscovery,
+		DHT:            *dhtDiscovery,
+		TargetOutbound: *targetPeers,
+	}
+	if *seeds != "" {
+		discovery.Seeds = strings.Split(*seeds, ",")
+	}
+	if err := network.StartDiscovery(discovery); err != nil {
+		log.Fatalf("Failed to start peer discovery: %v", err)
+	}
+
 	// Connect to peer if specified
 	if *connect != "" {
 		fmt.Printf("\n🔗 Connecting to peer: %s\n", *connect)
@@ -122,6 +188,14 @@ func main() {
 
 	// New blockchain or mining mode - run demo transactions
 	if bc.Height() == 1 {
+		// The genesis reward is unspendable, so fund the miner first
+		fmt.Println("⛏️  Mining reward block...")
+		rewardBlock, err := bc.AddBlock(nil, minerAddr)
+		if err != nil {
+			log.Fatalf("Failed to mine reward block: %v", err)
+		}
+		network.BroadcastBlock(rewardBlock)
+
 		minerBalance, _ := bc.UTXOSet.GetBalance(minerAddr)
 		fmt.Printf("\n💰 Initial Balances:\n")
 		fmt.Printf("  Miner: %d satoshis (50 BTC)\n", minerBalance)
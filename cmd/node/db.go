@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/storage"
+)
+
+// runDBCommand handles the offline "node db <migrate|check>" maintenance commands
+func runDBCommand(args []string) {
+	if len(args) < 1 {
+		printDBUsage()
+		os.Exit(1)
+	}
+
+	cmd := flag.NewFlagSet("db "+args[0], flag.ExitOnError)
+	networkName := cmd.String("network", "main", "Network of the database (main, test or regtest)")
+	dataDir := cmd.String("datadir", chaincfg.DefaultDataDir(), "Base data directory")
+	dbPath := cmd.String("db", "", "Path to blockchain database (default <datadir>/<network>/blockchain)")
+
+	switch args[0] {
+	case "migrate":
+		cmd.Parse(args[1:])
+		selectNetwork(*networkName, *dataDir, dbPath)
+		dbMigrate(*dbPath)
+	case "check":
+		cmd.Parse(args[1:])
+		selectNetwork(*networkName, *dataDir, dbPath)
+		dbCheck(*dbPath)
+	default:
+		printDBUsage()
+		os.Exit(1)
+	}
+}
+
+func printDBUsage() {
+	fmt.Println("Usage:")
+	fmt.Println("  node db migrate [-network NET] [-datadir DIR] [-db PATH]   Upgrade the database to the current schema")
+	fmt.Println("  node db check [-network NET] [-datadir DIR] [-db PATH]     Report the schema version without modifying anything")
+}
+
+// openBackend opens an existing database; it refuses to create a new one
+func openBackend(dbPath string) storage.Backend {
+	if _, err := os.Stat(dbPath); err != nil {
+		fmt.Printf("❌ Database not found: %s\n", dbPath)
+		os.Exit(1)
+	}
+
+	backend, err := storage.NewLevelDBBackend(dbPath)
+	if err != nil {
+		fmt.Printf("❌ Failed to open database: %v\n", err)
+		os.Exit(1)
+	}
+	return backend
+}
+
+func dbMigrate(dbPath string) {
+	backend := openBackend(dbPath)
+	defer backend.Close()
+
+	status, err := storage.Migrate(backend)
+	if err != nil {
+		fmt.Printf("❌ Migration failed: %v\n", err)
+		if errors.Is(err, storage.ErrResyncRequired) {
+			fmt.Printf("💡 Remove %s and restart the node to sync the current chain\n", dbPath)
+		}
+		backend.Close()
+		os.Exit(1)
+	}
+
+	if !status.NeedsMigration() {
+		fmt.Printf("✓ Database is already at schema v%d\n", storage.CurrentSchemaVersion)
+		return
+	}
+
+	for _, m := range status.Pending {
+		fmt.Printf("🔧 v%d: %s\n", m.Version, m.Description)
+	}
+	fmt.Printf("✓ Migrated database from schema v%d to v%d\n", status.Version, storage.CurrentSchemaVersion)
+}
+
+func dbCheck(dbPath string) {
+	backend := openBackend(dbPath)
+	defer backend.Close()
+
+	fmt.Printf("💾 Database: %s\n", dbPath)
+
+	status, err := storage.CheckSchema(backend)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		backend.Close()
+		os.Exit(1)
+	}
+
+	if status.Fresh {
+		fmt.Println("  Database is empty")
+		return
+	}
+
+	fmt.Printf("  Schema version: %d (this build: %d)\n", status.Version, storage.CurrentSchemaVersion)
+
+	s := storage.NewStorageWithBackend(backend)
+	if height, err := s.GetChainHeight(); err == nil {
+		fmt.Printf("  Chain height: %d\n", height)
+	}
+	if tip, err := s.GetChainTip(); err == nil {
+		fmt.Printf("  Chain tip: %x\n", tip)
+	}
+
+	if status.NeedsMigration() {
+		fmt.Printf("⚠️  %d pending migration(s); run 'node db migrate'\n", len(status.Pending))
+		backend.Close()
+		os.Exit(2)
+	}
+	fmt.Println("✓ Schema is up to date")
+}
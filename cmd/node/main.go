@@ -4,19 +4,33 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 
 	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
 	"github.com/yourusername/bt/internal/crypto"
 	"github.com/yourusername/bt/internal/tx"
 )
 
 func main() {
-	dbPath := flag.String("db", "./blockchain.db", "Path to blockchain database")
+	// Offline maintenance commands run without starting the node
+	if len(os.Args) > 1 && os.Args[1] == "db" {
+		runDBCommand(os.Args[2:])
+		return
+	}
+
+	networkName := flag.String("network", "main", "Network to join (main, test or regtest)")
+	dataDir := flag.String("datadir", chaincfg.DefaultDataDir(), "Base data directory")
+	dbPath := flag.String("db", "", "Path to blockchain database (default <datadir>/<network>/blockchain)")
 	fresh := flag.Bool("fresh", false, "Start with a fresh blockchain")
 	flag.Parse()
 
+	params := selectNetwork(*networkName, *dataDir, dbPath)
+
 	fmt.Println("🚀 Starting Bitcoin-like Cryptocurrency Node (Phase 3)")
 	fmt.Println("=" + "=====================================================")
+	fmt.Printf("Network: %s\n", params.Name)
 
 	// Create wallets for testing
 	fmt.Println("\n📝 Creating test wallets...")
@@ -39,12 +53,13 @@ func main() {
 		// Note: In production, you'd want to properly delete the DB
 	}
 
-	bc, err := blockchain.NewBlockchain(minerAddr, *dbPath)
+	bc, err := blockchain.NewBlockchain(params, *dbPath)
 	if err != nil {
 		log.Fatalf("Failed to create blockchain: %v", err)
 	}
 	defer bc.Close()
 
+	fmt.Printf("  Genesis: %x\n", bc.GenesisHash())
 	fmt.Printf("  Height: %d\n", bc.Height())
 	fmt.Printf("  Difficulty: %d bits\n\n", bc.DifficultyTarget)
 
@@ -64,6 +79,12 @@ func main() {
 		return
 	}
 
+	// The genesis reward is unspendable, so fund the miner first
+	fmt.Println("⛏️  Mining reward block...")
+	if _, err := bc.AddBlock(nil, minerAddr); err != nil {
+		log.Fatalf("Failed to mine reward block: %v", err)
+	}
+
 	// New blockchain - run demo transactions
 	minerBalance, _ := bc.UTXOSet.GetBalance(minerAddr)
 	fmt.Printf("💰 Initial Balances:\n")
@@ -143,3 +164,18 @@ func main() {
 	fmt.Println("\n✓ Phase 3 Complete: LevelDB Persistence")
 	fmt.Println("✨ Run again to load blockchain from disk!")
 }
+
+// selectNetwork activates the chosen network and fills in the default
+// database path inside its data directory
+func selectNetwork(name, dataDir string, dbPath *string) *chaincfg.Params {
+	params, err := chaincfg.ParamsForNetwork(name)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	crypto.SetAddressVersion(params.AddressVersion)
+
+	if *dbPath == "" {
+		*dbPath = filepath.Join(params.DataDir(dataDir), "blockchain")
+	}
+	return params
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/hdwallet"
+	"github.com/yourusername/bt/internal/storage"
+)
+
+// openWalletStore opens the wallet storage, unlocking it if it is encrypted;
+// the caller closes it
+func openWalletStore(path string) *storage.WalletStorage {
+	walletStore, err := storage.NewWalletStorage(path)
+	if err != nil {
+		log.Fatalf("Failed to open wallet storage: %v", err)
+	}
+	if walletStore.IsEncrypted() {
+		if err := walletStore.Unlock(readPassphrase("Wallet passphrase: ")); err != nil {
+			walletStore.Close()
+			log.Fatalf("Failed to unlock wallet: %v", err)
+		}
+	}
+	return walletStore
+}
+
+// createMnemonicWallet generates a mnemonic, stores its seed and derives
+// the first receiving address
+func createMnemonicWallet(path string, params *chaincfg.Params, words int, seedPassphrase bool) {
+	entropy, err := hdwallet.NewEntropy(words * 32 / 3)
+	if err != nil {
+		log.Fatalf("Invalid mnemonic length %d words: %v", words, err)
+	}
+	mnemonic, err := hdwallet.NewMnemonic(entropy)
+	if err != nil {
+		log.Fatalf("Failed to create mnemonic: %v", err)
+	}
+
+	walletStore := openWalletStore(path)
+	defer walletStore.Close()
+	defer walletStore.Lock()
+	if walletStore.HasHDSeed() {
+		log.Fatalf("Wallet already has an HD seed")
+	}
+
+	passphrase := ""
+	if seedPassphrase {
+		passphrase = readPassphrase("Mnemonic passphrase: ")
+		if readPassphrase("Repeat mnemonic passphrase: ") != passphrase {
+			log.Fatalf("Passphrases do not match")
+		}
+	}
+	seed := hdwallet.NewSeed(mnemonic, passphrase)
+	if err := walletStore.SaveHDSeed(seed, []storage.HDAccount{{Account: 0}}); err != nil {
+		log.Fatalf("Failed to save HD seed: %v", err)
+	}
+	address, keyPath := deriveNextAddress(walletStore, params)
+
+	fmt.Println("\n✓ New HD Wallet Created & Saved")
+	fmt.Println("==========================================")
+	fmt.Printf("Address:     %s\n", address)
+	fmt.Printf("Path:        %s\n", keyPath)
+	fmt.Println("==========================================")
+	fmt.Println("\n📝 Recovery phrase:")
+	fmt.Printf("\n   %s\n\n", mnemonic)
+	fmt.Println("⚠️  Write these words down and keep them offline. They restore every")
+	fmt.Println("address of this wallet; anyone who has them can spend its funds.")
+	if seedPassphrase {
+		fmt.Println("The mnemonic passphrase is needed too.")
+	}
+	fmt.Println("The phrase is not shown again.")
+	fmt.Printf("\n💾 Wallet saved to: %s\n", path)
+}
+
+// restoreWallet recreates an HD wallet from its mnemonic, finding the used
+// addresses of each account on the local chain with the gap limit
+func restoreWallet(path string, params *chaincfg.Params, dbPath string, gapLimit int, seedPassphrase bool) {
+	walletStore := openWalletStore(path)
+	defer walletStore.Close()
+	defer walletStore.Lock()
+	if walletStore.HasHDSeed() {
+		log.Fatalf("Wallet already has an HD seed")
+	}
+
+	mnemonic := strings.Join(strings.Fields(readPassphrase("Recovery phrase: ")), " ")
+	if err := hdwallet.ValidateMnemonic(mnemonic); err != nil {
+		log.Fatalf("Invalid recovery phrase: %v", err)
+	}
+	passphrase := ""
+	if seedPassphrase {
+		passphrase = readPassphrase("Mnemonic passphrase: ")
+	}
+	seed := hdwallet.NewSeed(mnemonic, passphrase)
+	master, err := hdwallet.NewMaster(seed, params)
+	if err != nil {
+		log.Fatalf("Failed to derive master key: %v", err)
+	}
+
+	used := chainUsage(params, dbPath)
+	fmt.Printf("🔍 Scanning accounts with a gap limit of %d...\n", gapLimit)
+	accounts, err := hdwallet.Discover(master, params.HDCoinType, gapLimit, used)
+	if err != nil {
+		log.Fatalf("Failed to discover accounts: %v", err)
+	}
+
+	hdAccounts := make([]storage.HDAccount, 0, len(accounts))
+	for _, account := range accounts {
+		hdAccounts = append(hdAccounts, storage.HDAccount{Account: account.Account})
+	}
+	if err := walletStore.SaveHDSeed(seed, hdAccounts); err != nil {
+		log.Fatalf("Failed to save HD seed: %v", err)
+	}
+
+	// Save every key up to the last used one so its funds can be spent
+	restored := 0
+	for _, account := range accounts {
+		for _, chain := range []uint32{hdwallet.ExternalChain, hdwallet.InternalChain} {
+			next := account.NextExternal
+			if chain == hdwallet.InternalChain {
+				next = account.NextInternal
+			}
+			for index := uint32(0); index < next; index++ {
+				keyPath := hdwallet.AddressPath(params.HDCoinType, account.Account, chain, index)
+				if saveDerivedKey(walletStore, master, keyPath) {
+					restored++
+				}
+			}
+		}
+		if err := walletStore.SetHDAccount(storage.HDAccount{
+			Account:      account.Account,
+			NextExternal: account.NextExternal,
+			NextInternal: account.NextInternal,
+		}); err != nil {
+			log.Fatalf("Failed to save HD account: %v", err)
+		}
+		fmt.Printf("   Account %d: %d receiving, %d change addresses used\n", account.Account, account.NextExternal, account.NextInternal)
+	}
+
+	// A wallet with no history still gets a receiving address
+	if restored == 0 {
+		deriveNextAddress(walletStore, params)
+	}
+
+	addresses, _ := walletStore.GetAllAddresses()
+	fmt.Printf("\n✓ Wallet restored: %d keys recovered, %d addresses saved\n", restored, len(addresses))
+	fmt.Printf("💾 Wallet saved to: %s\n", path)
+}
+
+// chainUsage reports which addresses have received outputs on the local
+// chain. Without a local chain nothing counts as used.
+func chainUsage(params *chaincfg.Params, dbPath string) hdwallet.UsedFunc {
+	if _, err := os.Stat(dbPath); err != nil {
+		fmt.Printf("⚠️  No local chain at %s; restoring without a scan\n", dbPath)
+		return func(string) bool { return false }
+	}
+
+	bc, err := blockchain.NewBlockchain(params, dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open blockchain (stop the node while restoring): %v", err)
+	}
+	defer bc.Close()
+	fmt.Printf("⛓️  Scanning %d blocks of the local chain\n", bc.Height())
+
+	usedHashes := bc.UsedPubKeyHashes()
+	return func(address string) bool {
+		pubKeyHash, err := crypto.DecodeAddress(address)
+		return err == nil && usedHashes[string(pubKeyHash)]
+	}
+}
+
+// deriveNextAddress saves the next unused receiving key of account 0 and
+// returns its address and path
+func deriveNextAddress(walletStore *storage.WalletStorage, params *chaincfg.Params) (string, hdwallet.Path) {
+	key, keyPath, err := hdwallet.DeriveNext(walletStore, params, 0, hdwallet.ExternalChain)
+	if err != nil {
+		log.Fatalf("Failed to derive address: %v", err)
+	}
+	return key.Address(), keyPath
+}
+
+// saveDerivedKey derives and stores the key at a path; it returns false if
+// the path has no valid key
+func saveDerivedKey(walletStore *storage.WalletStorage, master *hdwallet.ExtendedKey, keyPath hdwallet.Path) bool {
+	_, err := hdwallet.SaveKey(walletStore, master, keyPath)
+	if err == hdwallet.ErrInvalidChild {
+		return false
+	}
+	if err != nil {
+		log.Fatalf("Failed to save %s: %v", keyPath, err)
+	}
+	return true
+}
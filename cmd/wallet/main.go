@@ -1,21 +1,144 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/yourusername/bt/internal/chaincfg"
 	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/hdwallet"
 	"github.com/yourusername/bt/internal/storage"
+	"golang.org/x/term"
 )
 
 func main() {
 	createCmd := flag.NewFlagSet("create", flag.ExitOnError)
 	balanceCmd := flag.NewFlagSet("balance", flag.ExitOnError)
 	listCmd := flag.NewFlagSet("list", flag.ExitOnError)
+	encryptCmd := flag.NewFlagSet("encrypt", flag.ExitOnError)
+	passphraseCmd := flag.NewFlagSet("passphrase", flag.ExitOnError)
+	restoreCmd := flag.NewFlagSet("restore", flag.ExitOnError)
+	sendCmd := flag.NewFlagSet("send", flag.ExitOnError)
+	bumpFeeCmd := flag.NewFlagSet("bumpfee", flag.ExitOnError)
+	historyCmd := flag.NewFlagSet("history", flag.ExitOnError)
+	unspentCmd := flag.NewFlagSet("listunspent", flag.ExitOnError)
+	newAddressCmd := flag.NewFlagSet("newaddress", flag.ExitOnError)
+	importKeyCmd := flag.NewFlagSet("importkey", flag.ExitOnError)
+	exportKeyCmd := flag.NewFlagSet("exportkey", flag.ExitOnError)
+	signMessageCmd := flag.NewFlagSet("signmessage", flag.ExitOnError)
+	verifyMessageCmd := flag.NewFlagSet("verifymessage", flag.ExitOnError)
+	backupCmd := flag.NewFlagSet("backup", flag.ExitOnError)
+	watchOnlyCmd := flag.NewFlagSet("createwatchonly", flag.ExitOnError)
+	importAddressCmd := flag.NewFlagSet("importaddress", flag.ExitOnError)
+	importXPubCmd := flag.NewFlagSet("importxpub", flag.ExitOnError)
+	rescanCmd := flag.NewFlagSet("rescan", flag.ExitOnError)
+	transactionsCmd := flag.NewFlagSet("transactions", flag.ExitOnError)
+	unsignedCmd := flag.NewFlagSet("createunsigned", flag.ExitOnError)
+	createPSBTCmd := flag.NewFlagSet("createpsbt", flag.ExitOnError)
+	updatePSBTCmd := flag.NewFlagSet("updatepsbt", flag.ExitOnError)
+	signPSBTCmd := flag.NewFlagSet("signpsbt", flag.ExitOnError)
+	combinePSBTCmd := flag.NewFlagSet("combinepsbt", flag.ExitOnError)
+	finalizePSBTCmd := flag.NewFlagSet("finalizepsbt", flag.ExitOnError)
+	extractPSBTCmd := flag.NewFlagSet("extractpsbt", flag.ExitOnError)
+	decodePSBTCmd := flag.NewFlagSet("decodepsbt", flag.ExitOnError)
 
-	balanceAddress := balanceCmd.String("address", "", "Address to check balance")
+	createMnemonic := createCmd.Bool("mnemonic", false, "Create an HD wallet with a new recovery phrase")
+	createWords := createCmd.Int("words", 24, "Number of recovery phrase words (12, 15, 18, 21 or 24)")
+	createSeedPassphrase := createCmd.Bool("seed-passphrase", false, "Protect the recovery phrase with an extra mnemonic passphrase")
+	restoreGap := restoreCmd.Int("gap", hdwallet.DefaultGapLimit, "Consecutive unused addresses after which scanning stops")
+	restoreDB := restoreCmd.String("db", "", "Blockchain database to scan (default <datadir>/<network>/blockchain)")
+	restoreSeedPassphrase := restoreCmd.Bool("seed-passphrase", false, "Prompt for the mnemonic passphrase of the recovery phrase")
+
+	balanceAddress := balanceCmd.String("address", "", "Address to check (default every address of the wallet)")
+	sendFrom := sendCmd.String("from", "", "Address to spend from (default the wallet address with the most funds)")
+	sendTo := sendCmd.String("to", "", "Recipient address")
+	sendAmount := sendCmd.Int64("amount", 0, "Amount in satoshis")
+	sendFee := sendCmd.Int64("fee", 0, "Absolute fee in satoshis")
+	sendFeeRate := sendCmd.Int64("feerate", 0, "Fee rate in satoshis per byte (overrides --fee)")
+	sendCoinSelection := sendCmd.String("coinselect", "", "Coin selection: bnb, knapsack or largest-first (default bnb, then knapsack)")
+	sendReplaceable := sendCmd.Bool("replaceable", false, "Let bumpfee replace the transaction while it is unconfirmed")
+	bumpFeeRate := bumpFeeCmd.Int64("feerate", 0, "New fee rate in satoshis per byte (default the least increase accepted)")
+	bumpFeeCPFP := bumpFeeCmd.Bool("cpfp", false, "Pay with a child transaction even if the transaction is replaceable")
+	historyAddress := historyCmd.String("address", "", "Address to list (default every address of the wallet)")
+	unspentAddress := unspentCmd.String("address", "", "Address to list (default every address of the wallet)")
+	exportKeyAddress := exportKeyCmd.String("address", "", "Address whose private key to export")
+	exportKeyConfirm := exportKeyCmd.Bool("confirm", false, "Confirm that the private key may be shown")
+	signAddress := signMessageCmd.String("address", "", "Address whose key signs")
+	signMessage := signMessageCmd.String("message", "", "Message to sign")
+	verifyAddress := verifyMessageCmd.String("address", "", "Address that signed")
+	verifyMessageText := verifyMessageCmd.String("message", "", "Signed message")
+	verifySignature := verifyMessageCmd.String("signature", "", "Signature to check")
+	backupDestination := backupCmd.String("destination", "", "Directory on the node's host to copy the wallet to")
+	importAddress := importAddressCmd.String("address", "", "Address to watch")
+	importPubKey := importAddressCmd.String("pubkey", "", "Hex public key of the address to watch")
+	importAddressNoRescan := importAddressCmd.Bool("no-rescan", false, "Only track transactions in new blocks")
+	importAddressHeight := importAddressCmd.Int64("rescan-height", 0, "Block to start the rescan from")
+	importXPub := importXPubCmd.String("xpub", "", "Extended public key of an account")
+	importXPubGap := importXPubCmd.Int("gap", hdwallet.DefaultGapLimit, "Unused addresses watched past the last used one")
+	importXPubNoRescan := importXPubCmd.Bool("no-rescan", false, "Only track transactions in new blocks")
+	importXPubHeight := importXPubCmd.Int64("rescan-height", 0, "Block to start the rescan from")
+	rescanHeight := rescanCmd.Int64("height", 0, "Block to start the rescan from")
+	transactionsCount := transactionsCmd.Int("count", 10, "Number of transactions to show (0 for all)")
+	transactionsSkip := transactionsCmd.Int("skip", 0, "Number of newest transactions to skip")
+	unsignedFrom := unsignedCmd.String("from", "", "Address to spend from (default the wallet address with the most funds)")
+	unsignedTo := unsignedCmd.String("to", "", "Recipient address")
+	unsignedAmount := unsignedCmd.Int64("amount", 0, "Amount in satoshis")
+	unsignedFee := unsignedCmd.Int64("fee", 0, "Absolute fee in satoshis")
+	unsignedFeeRate := unsignedCmd.Int64("feerate", 0, "Fee rate in satoshis per byte (overrides --fee)")
+	unsignedCoinSelection := unsignedCmd.String("coinselect", "", "Coin selection: bnb, knapsack or largest-first (default bnb, then knapsack)")
+	unsignedReplaceable := unsignedCmd.Bool("replaceable", false, "Let bumpfee replace the transaction while it is unconfirmed")
+	psbtFrom := createPSBTCmd.String("from", "", "Address to spend from (default the wallet address with the most funds)")
+	psbtTo := createPSBTCmd.String("to", "", "Recipient address")
+	psbtAmount := createPSBTCmd.Int64("amount", 0, "Amount in satoshis")
+	psbtFee := createPSBTCmd.Int64("fee", 0, "Absolute fee in satoshis")
+	psbtFeeRate := createPSBTCmd.Int64("feerate", 0, "Fee rate in satoshis per byte (overrides --fee)")
+	psbtCoinSelection := createPSBTCmd.String("coinselect", "", "Coin selection: bnb, knapsack or largest-first (default bnb, then knapsack)")
+	psbtRaw := createPSBTCmd.String("raw", "", "Hex unsigned transaction to wrap instead of funding one")
+	psbtReplaceable := createPSBTCmd.Bool("replaceable", false, "Let bumpfee replace the transaction while it is unconfirmed")
+	updatePSBT := updatePSBTCmd.String("psbt", "", "Partially signed transaction")
+	signPSBT := signPSBTCmd.String("psbt", "", "Partially signed transaction")
+	signOffline := signPSBTCmd.Bool("offline", false, "Sign with the wallet file instead of the node")
+	combinePSBTs := combinePSBTCmd.String("psbt", "", "Comma-separated partially signed transactions")
+	finalizePSBT := finalizePSBTCmd.String("psbt", "", "Partially signed transaction")
+	extractPSBT := extractPSBTCmd.String("psbt", "", "Finalized partially signed transaction")
+	extractBroadcast := extractPSBTCmd.Bool("broadcast", false, "Add the transaction to the node's mempool")
+	decodePSBTText := decodePSBTCmd.String("psbt", "", "Partially signed transaction")
+
+	// Every subcommand selects the network, data directory and wallet
+	networkName := "main"
+	dataDir := chaincfg.DefaultDataDir()
+	walletName := ""
+	for _, cmd := range []*flag.FlagSet{createCmd, balanceCmd, listCmd, encryptCmd, passphraseCmd, restoreCmd,
+		sendCmd, bumpFeeCmd, historyCmd, unspentCmd, newAddressCmd, importKeyCmd, exportKeyCmd, signMessageCmd, verifyMessageCmd,
+		backupCmd, watchOnlyCmd, importAddressCmd, importXPubCmd, rescanCmd, transactionsCmd, unsignedCmd, createPSBTCmd, updatePSBTCmd,
+		signPSBTCmd, combinePSBTCmd, finalizePSBTCmd, extractPSBTCmd, decodePSBTCmd} {
+		cmd.StringVar(&networkName, "network", networkName, "Network to use (main, test or regtest)")
+		cmd.StringVar(&dataDir, "datadir", dataDir, "Base data directory")
+		cmd.StringVar(&walletName, "wallet", walletName, "Named wallet to use (default wallet when empty)")
+	}
+
+	// Online commands talk to a running node and can print JSON
+	rpcAddress := ""
+	jsonOutput := false
+	for _, cmd := range []*flag.FlagSet{balanceCmd, sendCmd, bumpFeeCmd, historyCmd, unspentCmd, newAddressCmd, importKeyCmd,
+		exportKeyCmd, signMessageCmd, verifyMessageCmd, backupCmd, watchOnlyCmd, importAddressCmd, importXPubCmd,
+		rescanCmd, transactionsCmd, unsignedCmd, createPSBTCmd, updatePSBTCmd, signPSBTCmd, combinePSBTCmd, finalizePSBTCmd,
+		extractPSBTCmd, decodePSBTCmd} {
+		cmd.StringVar(&rpcAddress, "rpc", rpcAddress, "Node gRPC address (default localhost and the network's RPC port)")
+		cmd.BoolVar(&jsonOutput, "json", jsonOutput, "Print JSON for scripts")
+	}
+	connect := func() *nodeClient {
+		params := selectNetwork(networkName)
+		if rpcAddress == "" {
+			rpcAddress = fmt.Sprintf("localhost:%d", params.RPCPort)
+		}
+		return dialNode(rpcAddress, walletName, jsonOutput)
+	}
 
 	if len(os.Args) < 2 {
 		printUsage()
@@ -25,20 +148,257 @@ func main() {
 	switch os.Args[1] {
 	case "create":
 		createCmd.Parse(os.Args[2:])
-		createWallet()
+		params := selectNetwork(networkName)
+		path := walletPath(params, dataDir, walletName)
+		if *createMnemonic {
+			createMnemonicWallet(path, params, *createWords, *createSeedPassphrase)
+		} else {
+			createWallet(path, params)
+		}
+
+	case "restore":
+		restoreCmd.Parse(os.Args[2:])
+		params := selectNetwork(networkName)
+		if *restoreDB == "" {
+			*restoreDB = filepath.Join(params.DataDir(dataDir), "blockchain")
+		}
+		restoreWallet(walletPath(params, dataDir, walletName), params, *restoreDB, *restoreGap, *restoreSeedPassphrase)
 
 	case "balance":
 		balanceCmd.Parse(os.Args[2:])
-		if *balanceAddress == "" {
+		node := connect()
+		defer node.Close()
+		node.balance(*balanceAddress)
+
+	case "send":
+		sendCmd.Parse(os.Args[2:])
+		if *sendTo == "" || *sendAmount <= 0 {
+			fmt.Println("Error: --to and a positive --amount are required")
+			sendCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.send(*sendFrom, *sendTo, *sendAmount, *sendFee, *sendFeeRate, *sendCoinSelection, *sendReplaceable)
+
+	case "bumpfee":
+		// The transaction ID may come before or after the flags
+		args := os.Args[2:]
+		txID := ""
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			txID, args = args[0], args[1:]
+		}
+		bumpFeeCmd.Parse(args)
+		if txID == "" {
+			txID = bumpFeeCmd.Arg(0)
+		}
+		if txID == "" {
+			fmt.Println("Error: the ID of the transaction to bump is required")
+			bumpFeeCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.bumpFee(txID, *bumpFeeRate, *bumpFeeCPFP)
+
+	case "history":
+		historyCmd.Parse(os.Args[2:])
+		node := connect()
+		defer node.Close()
+		node.history(*historyAddress)
+
+	case "listunspent":
+		unspentCmd.Parse(os.Args[2:])
+		node := connect()
+		defer node.Close()
+		node.listUnspent(*unspentAddress)
+
+	case "newaddress":
+		newAddressCmd.Parse(os.Args[2:])
+		node := connect()
+		defer node.Close()
+		node.newAddress()
+
+	case "importkey":
+		importKeyCmd.Parse(os.Args[2:])
+		node := connect()
+		defer node.Close()
+		node.importKey()
+
+	case "exportkey":
+		exportKeyCmd.Parse(os.Args[2:])
+		if *exportKeyAddress == "" {
 			fmt.Println("Error: --address is required")
-			balanceCmd.PrintDefaults()
+			exportKeyCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		if !*exportKeyConfirm {
+			fmt.Println("Error: anyone with the private key can spend the address's funds; pass --confirm to show it")
 			os.Exit(1)
 		}
-		checkBalance(*balanceAddress)
+		node := connect()
+		defer node.Close()
+		node.exportKey(*exportKeyAddress)
+
+	case "signmessage":
+		signMessageCmd.Parse(os.Args[2:])
+		if *signAddress == "" {
+			fmt.Println("Error: --address is required")
+			signMessageCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.signMessage(*signAddress, *signMessage)
+
+	case "verifymessage":
+		verifyMessageCmd.Parse(os.Args[2:])
+		if *verifyAddress == "" || *verifySignature == "" {
+			fmt.Println("Error: --address and --signature are required")
+			verifyMessageCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		selectNetwork(networkName)
+		verifyMessage(*verifyAddress, *verifyMessageText, *verifySignature, jsonOutput)
+
+	case "backup":
+		backupCmd.Parse(os.Args[2:])
+		if *backupDestination == "" {
+			fmt.Println("Error: --destination is required")
+			backupCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.backup(*backupDestination)
+
+	case "createwatchonly":
+		watchOnlyCmd.Parse(os.Args[2:])
+		node := connect()
+		defer node.Close()
+		node.createWatchOnly()
+
+	case "importaddress":
+		importAddressCmd.Parse(os.Args[2:])
+		if *importAddress == "" && *importPubKey == "" {
+			fmt.Println("Error: --address or --pubkey is required")
+			importAddressCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.importAddress(*importAddress, *importPubKey, !*importAddressNoRescan, *importAddressHeight)
+
+	case "importxpub":
+		importXPubCmd.Parse(os.Args[2:])
+		if *importXPub == "" {
+			fmt.Println("Error: --xpub is required")
+			importXPubCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.importXPub(*importXPub, *importXPubGap, !*importXPubNoRescan, *importXPubHeight)
+
+	case "rescan":
+		rescanCmd.Parse(os.Args[2:])
+		node := connect()
+		defer node.Close()
+		node.rescan(*rescanHeight)
+
+	case "transactions":
+		transactionsCmd.Parse(os.Args[2:])
+		node := connect()
+		defer node.Close()
+		node.transactions(*transactionsCount, *transactionsSkip)
+
+	case "createunsigned":
+		unsignedCmd.Parse(os.Args[2:])
+		if *unsignedTo == "" || *unsignedAmount <= 0 {
+			fmt.Println("Error: --to and a positive --amount are required")
+			unsignedCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.createUnsigned(*unsignedFrom, *unsignedTo, *unsignedAmount, *unsignedFee, *unsignedFeeRate, *unsignedCoinSelection, *unsignedReplaceable)
+
+	case "createpsbt":
+		createPSBTCmd.Parse(os.Args[2:])
+		if *psbtRaw == "" && (*psbtTo == "" || *psbtAmount <= 0) {
+			fmt.Println("Error: --raw, or --to and a positive --amount, are required")
+			createPSBTCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.createPSBT(*psbtFrom, *psbtTo, *psbtAmount, *psbtFee, *psbtFeeRate, *psbtCoinSelection, *psbtRaw, *psbtReplaceable)
+
+	case "updatepsbt":
+		updatePSBTCmd.Parse(os.Args[2:])
+		requirePSBT(updatePSBTCmd, *updatePSBT)
+		node := connect()
+		defer node.Close()
+		node.updatePSBT(*updatePSBT)
+
+	case "signpsbt":
+		signPSBTCmd.Parse(os.Args[2:])
+		requirePSBT(signPSBTCmd, *signPSBT)
+		if *signOffline {
+			params := selectNetwork(networkName)
+			signPSBTOffline(walletPath(params, dataDir, walletName), *signPSBT, jsonOutput)
+			break
+		}
+		node := connect()
+		defer node.Close()
+		node.signPSBT(*signPSBT)
+
+	case "combinepsbt":
+		combinePSBTCmd.Parse(os.Args[2:])
+		packets := splitPSBTs(*combinePSBTs)
+		if len(packets) < 2 {
+			fmt.Println("Error: --psbt needs at least two comma-separated packets")
+			combinePSBTCmd.PrintDefaults()
+			os.Exit(1)
+		}
+		node := connect()
+		defer node.Close()
+		node.combinePSBT(packets)
+
+	case "finalizepsbt":
+		finalizePSBTCmd.Parse(os.Args[2:])
+		requirePSBT(finalizePSBTCmd, *finalizePSBT)
+		node := connect()
+		defer node.Close()
+		node.finalizePSBT(*finalizePSBT)
+
+	case "extractpsbt":
+		extractPSBTCmd.Parse(os.Args[2:])
+		requirePSBT(extractPSBTCmd, *extractPSBT)
+		node := connect()
+		defer node.Close()
+		node.extractPSBT(*extractPSBT, *extractBroadcast)
+
+	case "decodepsbt":
+		decodePSBTCmd.Parse(os.Args[2:])
+		requirePSBT(decodePSBTCmd, *decodePSBTText)
+		selectNetwork(networkName)
+		decodePSBT(*decodePSBTText, jsonOutput)
 
 	case "list":
 		listCmd.Parse(os.Args[2:])
-		listWallets()
+		params := selectNetwork(networkName)
+		listWallets(walletPath(params, dataDir, walletName))
+
+	case "encrypt":
+		encryptCmd.Parse(os.Args[2:])
+		params := selectNetwork(networkName)
+		encryptWallets(walletPath(params, dataDir, walletName))
+
+	case "passphrase":
+		passphraseCmd.Parse(os.Args[2:])
+		params := selectNetwork(networkName)
+		changePassphrase(walletPath(params, dataDir, walletName))
 
 	default:
 		printUsage()
@@ -48,27 +408,92 @@ func main() {
 
 func printUsage() {
 	fmt.Println("Bitcoin-like Cryptocurrency Wallet")
-	fmt.Println("\nUsage:")
+	fmt.Println("\nNode commands (need a running node, see --rpc):")
+	fmt.Println("  wallet balance [--address <addr>]             Balance of the wallet or an address")
+	fmt.Println("  wallet send --to <addr> --amount <sat>        Send coins (--from, --fee or --feerate, --replaceable)")
+	fmt.Println("  wallet bumpfee <txid> [--feerate <sat>]       Raise the fee of an unconfirmed transaction (--cpfp)")
+	fmt.Println("  wallet history [--address <addr>]             Transactions of the wallet or an address")
+	fmt.Println("  wallet listunspent [--address <addr>]         Unspent outputs")
+	fmt.Println("  wallet newaddress                             New receiving address")
+	fmt.Println("  wallet importkey                              Import a hex private key")
+	fmt.Println("  wallet exportkey --address <addr> --confirm   Show an address's private key")
+	fmt.Println("  wallet signmessage --address <addr> --message <text>")
+	fmt.Println("  wallet verifymessage --address <addr> --message <text> --signature <sig>")
+	fmt.Println("  wallet backup --destination <dir>             Copy the wallet on the node's host")
+	fmt.Println("  wallet createwatchonly                        Create a wallet without private keys")
+	fmt.Println("  wallet importaddress --address <addr>         Watch an address (or --pubkey <hex>)")
+	fmt.Println("  wallet importxpub --xpub <key>                Watch an account's extended public key")
+	fmt.Println("  wallet rescan [--height <n>]                  Find the wallet's transactions again")
+	fmt.Println("  wallet transactions [--count <n> --skip <n>]  Transactions of the wallet, newest last")
+	fmt.Println("  wallet createunsigned --to <addr> --amount <sat>  Build a transaction to sign offline")
+	fmt.Println("  wallet createpsbt --to <addr> --amount <sat>  Start a partially signed transaction (or --raw <hex>)")
+	fmt.Println("  wallet updatepsbt --psbt <psbt>               Add spent outputs and known keys")
+	fmt.Println("  wallet signpsbt --psbt <psbt>                 Sign with the wallet (--offline for the wallet file)")
+	fmt.Println("  wallet combinepsbt --psbt <psbt>,<psbt>       Merge signatures of several signers")
+	fmt.Println("  wallet finalizepsbt --psbt <psbt>             Pick the final signature of each input")
+	fmt.Println("  wallet extractpsbt --psbt <psbt> [--broadcast]  Get (and send) the signed transaction")
+	fmt.Println("  wallet decodepsbt --psbt <psbt>               Show a partially signed transaction")
+	fmt.Println("\nOffline commands (stop the node first):")
 	fmt.Println("  wallet create                    Create a new wallet")
-	fmt.Println("  wallet balance --address <addr>  Check balance of an address")
+	fmt.Println("  wallet create --mnemonic         Create an HD wallet with a recovery phrase")
+	fmt.Println("  wallet restore                   Restore an HD wallet from its recovery phrase")
 	fmt.Println("  wallet list                      List all wallets")
+	fmt.Println("  wallet encrypt                   Protect the private keys with a passphrase")
+	fmt.Println("  wallet passphrase                Change the wallet passphrase")
+	fmt.Println("\nAll commands accept --network (main, test, regtest), --datadir and --wallet;")
+	fmt.Println("node commands also accept --rpc <host:port> and --json")
 }
 
-func createWallet() {
-	wallet, err := crypto.NewWallet()
-	if err != nil {
-		log.Fatalf("Failed to create wallet: %v", err)
+// requirePSBT exits with the command's usage when --psbt is missing
+func requirePSBT(cmd *flag.FlagSet, encoded string) {
+	if encoded == "" {
+		fmt.Println("Error: --psbt is required")
+		cmd.PrintDefaults()
+		os.Exit(1)
 	}
+}
 
-	address := wallet.GetAddress()
-	privateKey := crypto.PrivateKeyToHex(wallet.PrivateKey)
+// walletPath returns where a named wallet of the network is stored
+func walletPath(params *chaincfg.Params, dataDir, name string) string {
+	if err := storage.ValidateWalletName(name); err != nil {
+		log.Fatalf("%v", err)
+	}
+	return storage.GetNamedWalletPath(params.DataDir(dataDir), name)
+}
 
-	// Save wallet to storage
-	walletStore, err := storage.NewWalletStorage(storage.GetWalletPath())
+// selectNetwork activates the address encoding of the chosen network
+func selectNetwork(name string) *chaincfg.Params {
+	params, err := chaincfg.ParamsForNetwork(name)
 	if err != nil {
-		log.Fatalf("Failed to open wallet storage: %v", err)
+		log.Fatalf("%v", err)
 	}
+	crypto.SetAddressVersion(params.AddressVersion)
+	return params
+}
+
+func createWallet(path string, params *chaincfg.Params) {
+	// Encrypted wallets are unlocked just long enough to add the key
+	walletStore := openWalletStore(path)
 	defer walletStore.Close()
+	defer walletStore.Lock()
+
+	// HD wallets hand out the next address of the seed
+	if walletStore.HasHDSeed() {
+		address, keyPath := deriveNextAddress(walletStore, params)
+		fmt.Println("\n✓ New Address Derived & Saved")
+		fmt.Println("==========================================")
+		fmt.Printf("Address:     %s\n", address)
+		fmt.Printf("Path:        %s\n", keyPath)
+		fmt.Println("==========================================")
+		return
+	}
+
+	wallet, err := crypto.NewWallet()
+	if err != nil {
+		log.Fatalf("Failed to create wallet: %v", err)
+	}
+
+	address := wallet.GetAddress()
 
 	if err := walletStore.SaveWallet(address, wallet.PrivateKey.D.Bytes(), wallet.PublicKey); err != nil {
 		log.Fatalf("Failed to save wallet: %v", err)
@@ -77,28 +502,89 @@ func createWallet() {
 	fmt.Println("\n✓ New Wallet Created & Saved")
 	fmt.Println("==========================================")
 	fmt.Printf("Address:     %s\n", address)
-	fmt.Printf("Private Key: %s\n", privateKey)
 	fmt.Println("==========================================")
-	fmt.Println("\n⚠️  IMPORTANT: Save your private key securely!")
-	fmt.Println("Anyone with your private key can access your funds.")
-	fmt.Printf("\n💾 Wallet saved to: %s\n", storage.GetWalletPath())
+	if !walletStore.IsEncrypted() {
+		fmt.Println("\n⚠️  The private key is stored unencrypted.")
+		fmt.Println("Protect it with a passphrase: wallet encrypt")
+	}
+	fmt.Printf("\n💾 Wallet saved to: %s\n", path)
+}
+
+func encryptWallets(path string) {
+	walletStore, err := storage.NewWalletStorage(path)
+	if err != nil {
+		log.Fatalf("Failed to open wallet storage: %v", err)
+	}
+	defer walletStore.Close()
+
+	if walletStore.IsEncrypted() {
+		log.Fatalf("Wallet is already encrypted; use: wallet passphrase")
+	}
+	passphrase := readNewPassphrase()
+	if err := walletStore.EncryptWallets(passphrase); err != nil {
+		log.Fatalf("Failed to encrypt wallet: %v", err)
+	}
+
+	fmt.Println("\n🔒 Wallet encrypted")
+	fmt.Println("⚠️  Without the passphrase the funds cannot be recovered.")
 }
 
-func checkBalance(address string) {
-	// Validate address format
-	_, err := crypto.DecodeAddress(address)
+func changePassphrase(path string) {
+	walletStore, err := storage.NewWalletStorage(path)
 	if err != nil {
-		log.Fatalf("Invalid address: %v", err)
+		log.Fatalf("Failed to open wallet storage: %v", err)
 	}
+	defer walletStore.Close()
 
-	fmt.Printf("\nAddress: %s\n", address)
-	fmt.Println("Balance check requires blockchain connection (use node to check)")
-	fmt.Println("For now, this validates the address format only.")
-	fmt.Println("✓ Address is valid")
+	if !walletStore.IsEncrypted() {
+		log.Fatalf("Wallet is not encrypted; use: wallet encrypt")
+	}
+	oldPassphrase := readPassphrase("Current passphrase: ")
+	newPassphrase := readNewPassphrase()
+	if err := walletStore.ChangePassphrase(oldPassphrase, newPassphrase); err != nil {
+		log.Fatalf("Failed to change passphrase: %v", err)
+	}
+
+	fmt.Println("\n🔑 Passphrase changed")
+}
+
+// stdin is shared by all passphrase prompts
+var stdin = bufio.NewReader(os.Stdin)
+
+// readPassphrase prompts for a passphrase on stdin, without echoing it
+// when stdin is a terminal
+func readPassphrase(prompt string) string {
+	fmt.Print(prompt)
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		passphrase, err := term.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			log.Fatalf("Failed to read passphrase: %v", err)
+		}
+		return string(passphrase)
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		log.Fatalf("Failed to read passphrase: %v", err)
+	}
+	return strings.TrimRight(line, "\r\n")
+}
+
+// readNewPassphrase prompts for a new passphrase twice
+func readNewPassphrase() string {
+	passphrase := readPassphrase("New passphrase: ")
+	if passphrase == "" {
+		log.Fatalf("Passphrase must not be empty")
+	}
+	if readPassphrase("Repeat passphrase: ") != passphrase {
+		log.Fatalf("Passphrases do not match")
+	}
+	return passphrase
 }
 
-func listWallets() {
-	walletStore, err := storage.NewWalletStorage(storage.GetWalletPath())
+func listWallets(path string) {
+	walletStore, err := storage.NewWalletStorage(path)
 	if err != nil {
 		log.Fatalf("Failed to open wallet storage: %v", err)
 	}
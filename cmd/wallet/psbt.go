@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/psbt"
+)
+
+// printPSBT shows a packet's state and the packet itself, which is passed
+// on to the next signer
+func printPSBT(jsonOutput bool, resp *pb.PSBTResponse) {
+	result := struct {
+		PSBT         string `json:"psbt"`
+		Complete     bool   `json:"complete"`
+		Fee          int64  `json:"fee"`
+		SignedInputs int32  `json:"signed_inputs"`
+	}{resp.Psbt, resp.Complete, resp.Fee, resp.SignedInputs}
+	printResult(jsonOutput, result, func() {
+		if resp.SignedInputs > 0 {
+			fmt.Printf("\n✍️  Signed %d inputs\n", resp.SignedInputs)
+		}
+		if resp.Complete {
+			fmt.Println("\n✓ Every input is signed; extract the transaction with extractpsbt")
+		} else {
+			fmt.Println("\n📝 Partially signed transaction")
+		}
+		fmt.Printf("Fee: %s\n\n", formatAmount(resp.Fee))
+		fmt.Println(resp.Psbt)
+	})
+}
+
+func (c *nodeClient) createPSBT(from, to string, amount, fee, feeRate int64, coinSelection, raw string, replaceable bool) {
+	if raw == "" {
+		if _, err := crypto.DecodeAddress(to); err != nil {
+			log.Fatalf("Invalid recipient address: %v", err)
+		}
+		if from == "" {
+			from = c.richestAddress()
+		}
+	}
+	resp, err := c.wallet.CreatePSBT(context.Background(), &pb.CreatePSBTRequest{
+		FromAddress:    from,
+		ToAddress:      to,
+		Amount:         amount,
+		Fee:            fee,
+		FeeRate:        feeRate,
+		CoinSelection:  coinSelection,
+		RawTransaction: raw,
+		Replaceable:    replaceable,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create partially signed transaction: %s", rpcMessage(err))
+	}
+	printPSBT(c.json, resp)
+}
+
+func (c *nodeClient) updatePSBT(encoded string) {
+	resp, err := c.wallet.UpdatePSBT(context.Background(), &pb.PSBTRequest{Psbt: encoded})
+	if err != nil {
+		log.Fatalf("Failed to update partially signed transaction: %s", rpcMessage(err))
+	}
+	printPSBT(c.json, resp)
+}
+
+func (c *nodeClient) signPSBT(encoded string) {
+	var resp *pb.PSBTResponse
+	c.withUnlock(c.name, func() (err error) {
+		resp, err = c.wallet.SignPSBT(context.Background(), &pb.SignPSBTRequest{Psbt: encoded, WalletName: c.name})
+		return err
+	})
+	printPSBT(c.json, resp)
+}
+
+func (c *nodeClient) combinePSBT(encoded []string) {
+	resp, err := c.wallet.CombinePSBT(context.Background(), &pb.CombinePSBTRequest{Psbts: encoded})
+	if err != nil {
+		log.Fatalf("Failed to combine partially signed transactions: %s", rpcMessage(err))
+	}
+	printPSBT(c.json, resp)
+}
+
+func (c *nodeClient) finalizePSBT(encoded string) {
+	resp, err := c.wallet.FinalizePSBT(context.Background(), &pb.PSBTRequest{Psbt: encoded})
+	if err != nil {
+		log.Fatalf("Failed to finalize partially signed transaction: %s", rpcMessage(err))
+	}
+	printPSBT(c.json, resp)
+}
+
+func (c *nodeClient) extractPSBT(encoded string, broadcast bool) {
+	resp, err := c.wallet.ExtractPSBT(context.Background(), &pb.ExtractPSBTRequest{Psbt: encoded, Broadcast: broadcast})
+	if err != nil {
+		log.Fatalf("Failed to extract transaction: %s", rpcMessage(err))
+	}
+
+	result := struct {
+		TxID        string `json:"txid"`
+		Transaction string `json:"transaction"`
+		Broadcast   bool   `json:"broadcast"`
+	}{resp.TxId, resp.RawTransaction, resp.Broadcast}
+	c.print(result, func() {
+		if resp.Broadcast {
+			fmt.Printf("\n📤 Transaction sent: %s\n", resp.TxId)
+			return
+		}
+		fmt.Printf("\nTxID: %s\n\n", resp.TxId)
+		fmt.Println(resp.RawTransaction)
+	})
+}
+
+// signPSBTOffline signs with the keys of a wallet file, so cold wallets
+// can sign without a node
+func signPSBTOffline(path, encoded string, jsonOutput bool) {
+	p, err := psbt.Decode(encoded)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	walletStore := openWalletStore(path)
+	defer walletStore.Close()
+	defer walletStore.Lock()
+
+	signed := 0
+	seen := make(map[string]bool)
+	for i, in := range p.Inputs {
+		if in.PrevTx == nil {
+			continue
+		}
+		utxo, err := p.SpentOutput(i)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		address := crypto.EncodeAddress(utxo.PubKeyHash)
+		if seen[address] || !walletStore.WalletExists(address) {
+			continue
+		}
+		seen[address] = true
+
+		walletData, err := walletStore.GetWallet(address)
+		if err != nil {
+			log.Fatalf("Failed to read key of %s: %v", address, err)
+		}
+		if walletData.WatchOnly {
+			continue
+		}
+		n, err := p.Sign(crypto.WalletFromPrivateKey(walletData.PrivateKey))
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		signed += n
+	}
+
+	resp := &pb.PSBTResponse{Complete: p.IsComplete(), SignedInputs: int32(signed)}
+	if resp.Psbt, err = p.Encode(); err != nil {
+		log.Fatalf("%v", err)
+	}
+	resp.Fee, _ = p.Fee()
+	printPSBT(jsonOutput, resp)
+}
+
+type psbtInput struct {
+	Outpoint string `json:"outpoint"`
+	Address  string `json:"address,omitempty"`
+	Amount   int64  `json:"amount"`
+	Path     string `json:"path,omitempty"`
+	Sigs     int    `json:"signatures"`
+	Final    bool   `json:"final"`
+}
+
+type psbtOutput struct {
+	Address string `json:"address"`
+	Amount  int64  `json:"amount"`
+}
+
+// decodePSBT shows what a packet pays and who has signed it; it needs no
+// node, so signers can review a transaction before signing
+func decodePSBT(encoded string, jsonOutput bool) {
+	p, err := psbt.Decode(encoded)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	result := struct {
+		Inputs   []psbtInput  `json:"inputs"`
+		Outputs  []psbtOutput `json:"outputs"`
+		Fee      int64        `json:"fee"`
+		Complete bool         `json:"complete"`
+	}{Complete: p.IsComplete()}
+	for i, input := range p.Tx.Inputs {
+		in := p.Inputs[i]
+		entry := psbtInput{Outpoint: fmt.Sprintf("%x:%d", input.TxID, input.OutIndex), Path: in.Path, Sigs: len(in.PartialSigs), Final: in.FinalSig != nil}
+		if utxo, err := p.SpentOutput(i); err == nil {
+			entry.Address = crypto.EncodeAddress(utxo.PubKeyHash)
+			entry.Amount = utxo.Value
+		} else if in.PrevTx != nil {
+			log.Fatalf("%v", err)
+		}
+		result.Inputs = append(result.Inputs, entry)
+	}
+	for _, output := range p.Tx.Outputs {
+		result.Outputs = append(result.Outputs, psbtOutput{Address: crypto.EncodeAddress(output.PubKeyHash), Amount: output.Value})
+	}
+	result.Fee, _ = p.Fee()
+
+	printResult(jsonOutput, result, func() {
+		fmt.Println("\n📝 Partially signed transaction")
+		fmt.Println("==========================================")
+		fmt.Println("Inputs:")
+		for _, in := range result.Inputs {
+			state := fmt.Sprintf("%d signatures", in.Sigs)
+			if in.Final {
+				state = "final"
+			}
+			address := in.Address
+			if address == "" {
+				address = "unknown output"
+			}
+			fmt.Printf("  %s  %s  %s  (%s)\n", in.Outpoint, address, formatAmount(in.Amount), state)
+			if in.Path != "" {
+				fmt.Printf("    path %s\n", in.Path)
+			}
+		}
+		fmt.Println("Outputs:")
+		for _, out := range result.Outputs {
+			fmt.Printf("  %s  %s\n", out.Address, formatAmount(out.Amount))
+		}
+		fmt.Println("==========================================")
+		fmt.Printf("Fee:      %s\n", formatAmount(result.Fee))
+		fmt.Printf("Complete: %v\n", result.Complete)
+	})
+}
+
+// splitPSBTs parses a comma-separated list of packets
+func splitPSBTs(list string) []string {
+	var packets []string
+	for _, encoded := range strings.Split(list, ",") {
+		if encoded = strings.TrimSpace(encoded); encoded != "" {
+			packets = append(packets, encoded)
+		}
+	}
+	return packets
+}
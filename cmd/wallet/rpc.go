@@ -0,0 +1,581 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// unlockSeconds is how long a wallet is unlocked for a single command
+const unlockSeconds = 60
+
+// nodeClient runs the online commands against a node's gRPC API
+type nodeClient struct {
+	conn   *grpc.ClientConn
+	chain  pb.BlockchainServiceClient
+	wallet pb.WalletServiceClient
+	name   string // Selected wallet
+	json   bool   // Print JSON instead of text
+}
+
+// dialNode connects to a node; the caller closes the client
+func dialNode(address, walletName string, jsonOutput bool) *nodeClient {
+	if err := storage.ValidateWalletName(walletName); err != nil {
+		log.Fatalf("%v", err)
+	}
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to node at %s: %v", address, err)
+	}
+	return &nodeClient{
+		conn:   conn,
+		chain:  pb.NewBlockchainServiceClient(conn),
+		wallet: pb.NewWalletServiceClient(conn),
+		name:   walletName,
+		json:   jsonOutput,
+	}
+}
+
+// Close closes the node connection
+func (c *nodeClient) Close() {
+	c.conn.Close()
+}
+
+// print writes a command's result in the selected format
+func (c *nodeClient) print(v interface{}, human func()) {
+	printResult(c.json, v, human)
+}
+
+// printResult writes v as JSON for scripts, or calls human otherwise
+func printResult(jsonOutput bool, v interface{}, human func()) {
+	if !jsonOutput {
+		human()
+		return
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+}
+
+// rpcMessage returns the message of a gRPC error
+func rpcMessage(err error) string {
+	return status.Convert(err).Message()
+}
+
+// isLocked reports whether a failure was caused by a locked wallet
+func isLocked(message string) bool {
+	return strings.Contains(message, storage.ErrWalletLocked.Error())
+}
+
+// unlock prompts for a wallet's passphrase and unlocks it on the node
+// briefly; the returned function locks it again
+func (c *nodeClient) unlock(walletName string) func() {
+	passphrase := readPassphrase(fmt.Sprintf("Passphrase for wallet %q: ", displayWallet(walletName)))
+	resp, err := c.wallet.WalletPassphrase(context.Background(), &pb.WalletPassphraseRequest{
+		Passphrase:     passphrase,
+		TimeoutSeconds: unlockSeconds,
+		WalletName:     walletName,
+	})
+	if err != nil {
+		log.Fatalf("Failed to unlock wallet: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to unlock wallet: %s", resp.Message)
+	}
+	return func() {
+		c.wallet.WalletLock(context.Background(), &pb.WalletLockRequest{WalletName: walletName})
+	}
+}
+
+// withUnlock runs a wallet call, unlocking the wallet and retrying once if
+// it is locked
+func (c *nodeClient) withUnlock(walletName string, call func() error) {
+	err := call()
+	if err != nil && isLocked(rpcMessage(err)) {
+		relock := c.unlock(walletName)
+		err = call()
+		relock()
+	}
+	if err != nil {
+		log.Fatalf("%s", rpcMessage(err))
+	}
+}
+
+// walletOf returns the name of the loaded wallet holding an address
+func (c *nodeClient) walletOf(address string) string {
+	w, err := c.wallet.GetWallet(context.Background(), &pb.GetWalletRequest{Address: address})
+	if err != nil {
+		log.Fatalf("Address %s: %s", address, rpcMessage(err))
+	}
+	return w.WalletName
+}
+
+// addresses returns the given address, or else every address of the
+// selected wallet
+func (c *nodeClient) addresses(address string) []string {
+	if address != "" {
+		if _, err := crypto.DecodeAddress(address); err != nil {
+			log.Fatalf("Invalid address: %v", err)
+		}
+		return []string{address}
+	}
+	resp, err := c.wallet.ListWallets(context.Background(), &pb.ListWalletsRequest{WalletName: c.name})
+	if err != nil {
+		log.Fatalf("Failed to list wallet %q: %s", displayWallet(c.name), rpcMessage(err))
+	}
+	addresses := make([]string, 0, len(resp.Wallets))
+	for _, w := range resp.Wallets {
+		addresses = append(addresses, w.Address)
+	}
+	return addresses
+}
+
+// displayWallet names a wallet in messages
+func displayWallet(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+// formatAmount shows satoshis as coins
+func formatAmount(satoshis int64) string {
+	sign := ""
+	if satoshis < 0 {
+		sign, satoshis = "-", -satoshis
+	}
+	return fmt.Sprintf("%s%d.%08d", sign, satoshis/1e8, satoshis%1e8)
+}
+
+type addressBalance struct {
+	Address     string `json:"address"`
+	Balance     int64  `json:"balance"`
+	Confirmed   int64  `json:"confirmed"`
+	Unconfirmed int64  `json:"unconfirmed"`
+	Immature    int64  `json:"immature"`
+	UTXOs       int32  `json:"utxos"`
+}
+
+func (c *nodeClient) balance(address string) {
+	result := struct {
+		Addresses   []addressBalance `json:"addresses"`
+		Total       int64            `json:"total"`
+		Confirmed   int64            `json:"confirmed"`
+		Unconfirmed int64            `json:"unconfirmed"`
+		Immature    int64            `json:"immature"`
+	}{Addresses: []addressBalance{}}
+	for _, addr := range c.addresses(address) {
+		resp, err := c.chain.GetBalance(context.Background(), &pb.GetBalanceRequest{Address: addr})
+		if err != nil {
+			log.Fatalf("Failed to get balance of %s: %s", addr, rpcMessage(err))
+		}
+		result.Addresses = append(result.Addresses, addressBalance{
+			Address:     addr,
+			Balance:     resp.Balance,
+			Confirmed:   resp.Confirmed,
+			Unconfirmed: resp.Unconfirmed,
+			Immature:    resp.Immature,
+			UTXOs:       resp.UtxoCount,
+		})
+		result.Total += resp.Balance
+		result.Confirmed += resp.Confirmed
+		result.Unconfirmed += resp.Unconfirmed
+		result.Immature += resp.Immature
+	}
+
+	c.print(result, func() {
+		fmt.Printf("\n💰 Balance of wallet %q\n", displayWallet(c.name))
+		fmt.Println("==========================================")
+		for _, b := range result.Addresses {
+			fmt.Printf("%s  %s (%d UTXOs)\n", b.Address, formatAmount(b.Balance), b.UTXOs)
+		}
+		fmt.Println("==========================================")
+		fmt.Printf("Confirmed:   %s\n", formatAmount(result.Confirmed))
+		fmt.Printf("Unconfirmed: %s\n", formatAmount(result.Unconfirmed))
+		fmt.Printf("Immature:    %s\n", formatAmount(result.Immature))
+		fmt.Printf("Total:       %s\n", formatAmount(result.Total))
+	})
+}
+
+// richestAddress picks the wallet address with the largest balance to send from
+func (c *nodeClient) richestAddress() string {
+	best, bestBalance := "", int64(-1)
+	for _, addr := range c.addresses("") {
+		resp, err := c.chain.GetBalance(context.Background(), &pb.GetBalanceRequest{Address: addr})
+		if err != nil {
+			log.Fatalf("Failed to get balance of %s: %s", addr, rpcMessage(err))
+		}
+		if resp.Balance > bestBalance {
+			best, bestBalance = addr, resp.Balance
+		}
+	}
+	if best == "" {
+		log.Fatalf("Wallet %q has no addresses", displayWallet(c.name))
+	}
+	return best
+}
+
+func (c *nodeClient) send(from, to string, amount, fee, feeRate int64, coinSelection string, replaceable bool) {
+	if _, err := crypto.DecodeAddress(to); err != nil {
+		log.Fatalf("Invalid recipient address: %v", err)
+	}
+	if from == "" {
+		from = c.richestAddress()
+	}
+	req := &pb.SendTransactionRequest{
+		FromAddress:   from,
+		ToAddress:     to,
+		Amount:        amount,
+		Fee:           fee,
+		FeeRate:       feeRate,
+		CoinSelection: coinSelection,
+		Replaceable:   replaceable,
+	}
+
+	resp, err := c.wallet.SendTransaction(context.Background(), req)
+	if err == nil && !resp.Success && isLocked(resp.Message) {
+		relock := c.unlock(c.walletOf(from))
+		resp, err = c.wallet.SendTransaction(context.Background(), req)
+		relock()
+	}
+	if err != nil {
+		log.Fatalf("Failed to send: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to send: %s", resp.Message)
+	}
+
+	result := struct {
+		TxID string `json:"txid"`
+		From string `json:"from"`
+		Fee  int64  `json:"fee"`
+	}{resp.TxId, from, resp.Fee}
+	c.print(result, func() {
+		fmt.Println("\n📤 Transaction sent")
+		fmt.Println("==========================================")
+		fmt.Printf("TxID:        %s\n", result.TxID)
+		fmt.Printf("From:        %s\n", from)
+		fmt.Printf("To:          %s\n", to)
+		fmt.Printf("Amount:      %s\n", formatAmount(amount))
+		fmt.Printf("Fee:         %s\n", formatAmount(result.Fee))
+		fmt.Println("==========================================")
+	})
+}
+
+func (c *nodeClient) bumpFee(txID string, feeRate int64, cpfp bool) {
+	req := &pb.BumpFeeRequest{TxId: txID, FeeRate: feeRate, Cpfp: cpfp}
+	resp, err := c.wallet.BumpFee(context.Background(), req)
+	if err == nil && !resp.Success && isLocked(resp.Message) {
+		relock := c.unlock(c.name)
+		resp, err = c.wallet.BumpFee(context.Background(), req)
+		relock()
+	}
+	if err != nil {
+		log.Fatalf("Failed to bump fee: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to bump fee: %s", resp.Message)
+	}
+
+	result := struct {
+		TxID     string   `json:"txid"`
+		Method   string   `json:"method"`
+		OldFee   int64    `json:"old_fee"`
+		NewFee   int64    `json:"new_fee"`
+		FeeRate  int64    `json:"fee_rate"`
+		Replaced []string `json:"replaced,omitempty"`
+	}{resp.TxId, resp.Method, resp.OldFee, resp.NewFee, resp.FeeRate, resp.Replaced}
+	c.print(result, func() {
+		if resp.Method == "cpfp" {
+			fmt.Println("\n👶 Child transaction sent")
+		} else {
+			fmt.Println("\n♻️  Transaction replaced")
+		}
+		fmt.Println("==========================================")
+		fmt.Printf("TxID:        %s\n", result.TxID)
+		fmt.Printf("Old fee:     %s\n", formatAmount(result.OldFee))
+		fmt.Printf("New fee:     %s\n", formatAmount(result.NewFee))
+		fmt.Printf("Fee rate:    %d sat/byte\n", result.FeeRate)
+		for _, id := range result.Replaced {
+			fmt.Printf("Replaced:    %s\n", id)
+		}
+		fmt.Println("==========================================")
+	})
+}
+
+type historyEntry struct {
+	TxID          string `json:"txid"`
+	Height        int64  `json:"height"`
+	Time          string `json:"time,omitempty"`
+	Received      int64  `json:"received"`
+	Sent          int64  `json:"sent"`
+	Confirmations int64  `json:"confirmations"`
+}
+
+func (c *nodeClient) history(address string) {
+	resp, err := c.chain.GetAddressHistory(context.Background(), &pb.GetAddressHistoryRequest{Addresses: c.addresses(address)})
+	if err != nil {
+		log.Fatalf("Failed to get history: %s", rpcMessage(err))
+	}
+
+	entries := make([]historyEntry, 0, len(resp.Transactions))
+	for _, t := range resp.Transactions {
+		entry := historyEntry{TxID: t.TxId, Height: t.Height, Received: t.Received, Sent: t.Sent, Confirmations: t.Confirmations}
+		if t.Timestamp != nil {
+			entry.Time = t.Timestamp.AsTime().Format(time.RFC3339)
+		}
+		entries = append(entries, entry)
+	}
+
+	c.print(entries, func() {
+		if len(entries) == 0 {
+			fmt.Println("\n📭 No transactions")
+			return
+		}
+		fmt.Printf("\n📜 Transactions (%d):\n", len(entries))
+		fmt.Println("==========================================")
+		for _, e := range entries {
+			when := "mempool"
+			if e.Height >= 0 {
+				when = fmt.Sprintf("block %d, %d confirmations", e.Height, e.Confirmations)
+			}
+			amount := formatAmount(e.Received - e.Sent)
+			if e.Received > e.Sent {
+				amount = "+" + amount
+			}
+			fmt.Printf("%s  %s  (%s)\n", e.TxID, amount, when)
+		}
+		fmt.Println("==========================================")
+	})
+}
+
+type walletTxEntry struct {
+	TxID          string   `json:"txid"`
+	Category      string   `json:"category"`
+	Amount        int64    `json:"amount"`
+	Fee           int64    `json:"fee,omitempty"`
+	Addresses     []string `json:"addresses,omitempty"`
+	Height        int64    `json:"height"`
+	BlockHash     string   `json:"blockhash,omitempty"`
+	Time          string   `json:"time,omitempty"`
+	Confirmations int64    `json:"confirmations"`
+}
+
+// transactions lists the wallet's transactions a page at a time, counting
+// back from the newest
+func (c *nodeClient) transactions(count, skip int) {
+	resp, err := c.wallet.ListTransactions(context.Background(), &pb.ListTransactionsRequest{
+		WalletName: c.name,
+		Count:      int32(count),
+		Skip:       int32(skip),
+	})
+	if err != nil {
+		log.Fatalf("Failed to list transactions: %s", rpcMessage(err))
+	}
+
+	entries := make([]walletTxEntry, 0, len(resp.Transactions))
+	for _, t := range resp.Transactions {
+		entry := walletTxEntry{
+			TxID:          t.TxId,
+			Category:      t.Category,
+			Amount:        t.Amount,
+			Fee:           t.Fee,
+			Addresses:     t.Addresses,
+			Height:        t.Height,
+			BlockHash:     t.BlockHash,
+			Confirmations: t.Confirmations,
+		}
+		if t.Time > 0 {
+			entry.Time = time.Unix(t.Time, 0).UTC().Format(time.RFC3339)
+		}
+		entries = append(entries, entry)
+	}
+
+	c.print(entries, func() {
+		if len(entries) == 0 {
+			fmt.Printf("\n📭 No transactions in wallet %q\n", displayWallet(c.name))
+			return
+		}
+		fmt.Printf("\n📜 Transactions of wallet %q (%d of %d):\n", displayWallet(c.name), len(entries), resp.Total)
+		fmt.Println("==========================================")
+		for _, e := range entries {
+			when := "mempool"
+			if e.Height >= 0 {
+				when = fmt.Sprintf("block %d, %d confirmations", e.Height, e.Confirmations)
+			}
+			amount := formatAmount(e.Amount)
+			if e.Amount > 0 {
+				amount = "+" + amount
+			}
+			fmt.Printf("%s  %-8s %s  (%s)\n", e.TxID, e.Category, amount, when)
+			if e.Fee > 0 {
+				fmt.Printf("    fee %s\n", formatAmount(e.Fee))
+			}
+			for _, address := range e.Addresses {
+				if e.Category == "send" {
+					fmt.Printf("    to %s\n", address)
+				} else {
+					fmt.Printf("    from %s\n", address)
+				}
+			}
+		}
+		fmt.Println("==========================================")
+	})
+}
+
+type unspentOutput struct {
+	TxID    string `json:"txid"`
+	Vout    int32  `json:"vout"`
+	Address string `json:"address"`
+	Amount  int64  `json:"amount"`
+}
+
+func (c *nodeClient) listUnspent(address string) {
+	outputs := []unspentOutput{}
+	for _, addr := range c.addresses(address) {
+		resp, err := c.chain.GetUTXO(context.Background(), &pb.GetUTXORequest{Address: addr})
+		if err != nil {
+			log.Fatalf("Failed to list outputs of %s: %s", addr, rpcMessage(err))
+		}
+		for _, u := range resp.Utxos {
+			outputs = append(outputs, unspentOutput{TxID: u.TxId, Vout: u.Vout, Address: addr, Amount: u.Output.GetValue()})
+		}
+	}
+
+	c.print(outputs, func() {
+		if len(outputs) == 0 {
+			fmt.Println("\n📭 No unspent outputs")
+			return
+		}
+		fmt.Printf("\n🪙 Unspent outputs (%d):\n", len(outputs))
+		fmt.Println("==========================================")
+		for _, u := range outputs {
+			fmt.Printf("%s:%d  %s  %s\n", u.TxID, u.Vout, u.Address, formatAmount(u.Amount))
+		}
+		fmt.Println("==========================================")
+	})
+}
+
+func (c *nodeClient) newAddress() {
+	var w *pb.Wallet
+	c.withUnlock(c.name, func() (err error) {
+		w, err = c.wallet.CreateWallet(context.Background(), &pb.CreateWalletRequest{Name: c.name})
+		return err
+	})
+
+	result := struct {
+		Address string `json:"address"`
+		Wallet  string `json:"wallet"`
+	}{w.Address, w.WalletName}
+	c.print(result, func() {
+		fmt.Printf("\n✓ New address in wallet %q: %s\n", displayWallet(w.WalletName), w.Address)
+	})
+}
+
+func (c *nodeClient) importKey() {
+	privateKey := strings.TrimSpace(readPassphrase("Private key (hex): "))
+	var w *pb.Wallet
+	c.withUnlock(c.name, func() (err error) {
+		w, err = c.wallet.ImportPrivateKey(context.Background(), &pb.ImportPrivateKeyRequest{WalletName: c.name, PrivateKey: privateKey})
+		return err
+	})
+
+	result := struct {
+		Address string `json:"address"`
+		Wallet  string `json:"wallet"`
+	}{w.Address, w.WalletName}
+	c.print(result, func() {
+		fmt.Printf("\n🔑 Imported %s into wallet %q\n", w.Address, displayWallet(w.WalletName))
+		fmt.Println("Funds it received earlier are spendable once the node has the blocks.")
+	})
+}
+
+func (c *nodeClient) exportKey(address string) {
+	var resp *pb.DumpPrivateKeyResponse
+	c.withUnlock(c.walletOf(address), func() (err error) {
+		resp, err = c.wallet.DumpPrivateKey(context.Background(), &pb.DumpPrivateKeyRequest{Address: address, Confirm: true})
+		return err
+	})
+
+	result := struct {
+		Address    string `json:"address"`
+		PrivateKey string `json:"private_key"`
+	}{address, resp.PrivateKey}
+	c.print(result, func() {
+		fmt.Printf("\nAddress:     %s\n", address)
+		fmt.Printf("Private key: %s\n", resp.PrivateKey)
+		fmt.Println("\n⚠️  Anyone with this key can spend the address's funds.")
+	})
+}
+
+func (c *nodeClient) signMessage(address, message string) {
+	var resp *pb.SignMessageResponse
+	c.withUnlock(c.walletOf(address), func() (err error) {
+		resp, err = c.wallet.SignMessage(context.Background(), &pb.SignMessageRequest{Address: address, Message: message})
+		return err
+	})
+
+	result := struct {
+		Address   string `json:"address"`
+		Signature string `json:"signature"`
+	}{address, resp.Signature}
+	c.print(result, func() {
+		fmt.Printf("\n✍️  Signature: %s\n", resp.Signature)
+	})
+}
+
+// verifyMessage checks a signature locally; it needs no node
+func verifyMessage(address, message, signature string, jsonOutput bool) {
+	valid, err := crypto.VerifyMessage(address, message, signature)
+	if err != nil {
+		log.Fatalf("Invalid signature: %v", err)
+	}
+
+	printResult(jsonOutput, struct {
+		Valid bool `json:"valid"`
+	}{valid}, func() {
+		if valid {
+			fmt.Printf("\n✓ Signature is valid for %s\n", address)
+		} else {
+			fmt.Printf("\n✗ Signature is not valid for %s\n", address)
+		}
+	})
+	if !valid {
+		os.Exit(1)
+	}
+}
+
+func (c *nodeClient) backup(destination string) {
+	destination, err := filepath.Abs(destination)
+	if err != nil {
+		log.Fatalf("Invalid destination: %v", err)
+	}
+	resp, err := c.wallet.BackupWallet(context.Background(), &pb.BackupWalletRequest{WalletName: c.name, Destination: destination})
+	if err != nil {
+		log.Fatalf("Failed to back up wallet: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to back up wallet: %s", resp.Message)
+	}
+
+	result := struct {
+		Destination string `json:"destination"`
+	}{destination}
+	c.print(result, func() {
+		fmt.Printf("\n💾 %s\n", resp.Message)
+	})
+}
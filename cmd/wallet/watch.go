@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/crypto"
+)
+
+func (c *nodeClient) createWatchOnly() {
+	resp, err := c.wallet.CreateWatchOnlyWallet(context.Background(), &pb.CreateWatchOnlyWalletRequest{Name: c.name})
+	if err != nil {
+		log.Fatalf("Failed to create wallet: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to create wallet: %s", resp.Message)
+	}
+
+	result := struct {
+		Wallet string `json:"wallet"`
+	}{c.name}
+	c.print(result, func() {
+		fmt.Printf("\n👀 %s\n", resp.Message)
+		fmt.Println("Import addresses with importaddress or importxpub.")
+	})
+}
+
+// printImport shows the addresses an import added
+func (c *nodeClient) printImport(resp *pb.ImportWatchResponse) {
+	result := struct {
+		Wallet    string   `json:"wallet"`
+		Addresses []string `json:"addresses"`
+	}{c.name, resp.Addresses}
+	c.print(result, func() {
+		fmt.Printf("\n👀 %s\n", resp.Message)
+		for _, address := range resp.Addresses {
+			fmt.Printf("  %s\n", address)
+		}
+	})
+}
+
+func (c *nodeClient) importAddress(address, publicKey string, rescan bool, rescanHeight int64) {
+	resp, err := c.wallet.ImportAddress(context.Background(), &pb.ImportAddressRequest{
+		WalletName:   c.name,
+		Address:      address,
+		PublicKey:    publicKey,
+		Rescan:       rescan,
+		RescanHeight: rescanHeight,
+	})
+	if err != nil {
+		log.Fatalf("Failed to import address: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to import address: %s", resp.Message)
+	}
+	c.printImport(resp)
+}
+
+func (c *nodeClient) importXPub(xpub string, gapLimit int, rescan bool, rescanHeight int64) {
+	resp, err := c.wallet.ImportXPub(context.Background(), &pb.ImportXPubRequest{
+		WalletName:   c.name,
+		Xpub:         xpub,
+		GapLimit:     int32(gapLimit),
+		Rescan:       rescan,
+		RescanHeight: rescanHeight,
+	})
+	if err != nil {
+		log.Fatalf("Failed to import extended key: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to import extended key: %s", resp.Message)
+	}
+	c.printImport(resp)
+}
+
+func (c *nodeClient) rescan(height int64) {
+	resp, err := c.wallet.RescanWallet(context.Background(), &pb.RescanWalletRequest{WalletName: c.name, StartHeight: height})
+	if err != nil {
+		log.Fatalf("Failed to rescan: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to rescan: %s", resp.Message)
+	}
+
+	result := struct {
+		ScannedHeight int64 `json:"scanned_height"`
+		Transactions  int32 `json:"transactions"`
+	}{resp.ScannedHeight, resp.Transactions}
+	c.print(result, func() {
+		fmt.Printf("\n🔍 %s: %d transactions found\n", resp.Message, resp.Transactions)
+	})
+}
+
+func (c *nodeClient) createUnsigned(from, to string, amount, fee, feeRate int64, coinSelection string, replaceable bool) {
+	if _, err := crypto.DecodeAddress(to); err != nil {
+		log.Fatalf("Invalid recipient address: %v", err)
+	}
+	if from == "" {
+		from = c.richestAddress()
+	}
+	resp, err := c.wallet.CreateUnsignedTransaction(context.Background(), &pb.CreateUnsignedTransactionRequest{
+		FromAddress:   from,
+		ToAddress:     to,
+		Amount:        amount,
+		Fee:           fee,
+		FeeRate:       feeRate,
+		CoinSelection: coinSelection,
+		Replaceable:   replaceable,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create transaction: %s", rpcMessage(err))
+	}
+	if !resp.Success {
+		log.Fatalf("Failed to create transaction: %s", resp.Message)
+	}
+
+	result := struct {
+		Transaction string          `json:"transaction"`
+		Fee         int64           `json:"fee"`
+		Inputs      []unspentOutput `json:"inputs"`
+	}{resp.RawTransaction, resp.Fee, []unspentOutput{}}
+	for _, in := range resp.Inputs {
+		result.Inputs = append(result.Inputs, unspentOutput{TxID: in.TxId, Vout: in.Vout, Address: in.Address, Amount: in.Value})
+	}
+	c.print(result, func() {
+		fmt.Println("\n📝 Unsigned transaction")
+		fmt.Println("==========================================")
+		fmt.Printf("From:        %s\n", from)
+		fmt.Printf("To:          %s\n", to)
+		fmt.Printf("Amount:      %s\n", formatAmount(amount))
+		fmt.Printf("Fee:         %s\n", formatAmount(resp.Fee))
+		fmt.Println("Spends:")
+		for _, in := range result.Inputs {
+			fmt.Printf("  %s:%d  %s\n", in.TxID, in.Vout, formatAmount(in.Amount))
+		}
+		fmt.Println("==========================================")
+		fmt.Println(resp.RawTransaction)
+	})
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -11,14 +12,20 @@ import (
 	"time"
 
 	proto "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/chaincfg"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Configuration
 const (
-	grpcAddress = "localhost:50051"
-	httpPort    = ":8080"
+	httpPort = ":8080"
+)
+
+// Selected network and the node serving it
+var (
+	params      *chaincfg.Params
+	grpcAddress string
 )
 
 // Global gRPC clients
@@ -241,8 +248,12 @@ func listWalletsHandler(w http.ResponseWriter, r *http.Request) {
 	for _, wallet := range wallets.Wallets {
 		balance, _ := walletClient.GetWalletBalance(ctx, &proto.GetWalletBalanceRequest{Address: wallet.Address})
 		walletList = append(walletList, map[string]interface{}{
-			"address": wallet.Address,
-			"balance": balance.GetBalance(),
+			"address":     wallet.Address,
+			"balance":     balance.GetBalance(),
+			"confirmed":   balance.GetConfirmed(),
+			"unconfirmed": balance.GetUnconfirmed(),
+			"immature":    balance.GetImmature(),
+			"wallet":      wallet.WalletName,
 		})
 	}
 
@@ -259,7 +270,8 @@ func createWalletHandler(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	wallet, err := walletClient.CreateWallet(ctx, &proto.CreateWalletRequest{})
+	// An optional ?name= adds the key to a named wallet
+	wallet, err := walletClient.CreateWallet(ctx, &proto.CreateWalletRequest{Name: r.URL.Query().Get("name")})
 	if err != nil {
 		sendJSON(w, http.StatusInternalServerError, APIResponse{
 			Success: false,
@@ -272,6 +284,60 @@ func createWalletHandler(w http.ResponseWriter, r *http.Request) {
 		Success: true,
 		Data: map[string]interface{}{
 			"address": wallet.Address,
+			"wallet":  wallet.WalletName,
+		},
+	})
+}
+
+// List a wallet's transactions; ?name= selects a named wallet, and
+// ?count= and ?skip= page back from the newest
+func listWalletTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := r.URL.Query()
+	count := 10
+	if c, err := strconv.Atoi(query.Get("count")); err == nil && c >= 0 {
+		count = c
+	}
+	skip := 0
+	if s, err := strconv.Atoi(query.Get("skip")); err == nil && s > 0 {
+		skip = s
+	}
+
+	resp, err := walletClient.ListTransactions(ctx, &proto.ListTransactionsRequest{
+		WalletName: query.Get("name"),
+		Count:      int32(count),
+		Skip:       int32(skip),
+	})
+	if err != nil {
+		sendJSON(w, http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	transactions := []map[string]interface{}{}
+	for _, t := range resp.Transactions {
+		transactions = append(transactions, map[string]interface{}{
+			"txid":          t.TxId,
+			"category":      t.Category,
+			"amount":        t.Amount,
+			"fee":           t.Fee,
+			"addresses":     t.Addresses,
+			"height":        t.Height,
+			"blockHash":     t.BlockHash,
+			"time":          t.Time,
+			"confirmations": t.Confirmations,
+		})
+	}
+
+	sendJSON(w, http.StatusOK, APIResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"transactions": transactions,
+			"total":        resp.Total,
 		},
 	})
 }
@@ -326,7 +392,7 @@ func getMiningInfoHandler(w http.ResponseWriter, r *http.Request) {
 		Data: map[string]interface{}{
 			"difficulty":  info.CurrentDifficulty,
 			"isMining":    info.IsMining,
-			"blockReward": 50, // Default block reward
+			"blockReward": params.BlockReward / 1e8,
 		},
 	})
 }
@@ -342,10 +408,36 @@ func initGRPCClients() error {
 	walletClient = proto.NewWalletServiceClient(conn)
 
 	log.Printf("Connected to gRPC server at %s", grpcAddress)
+
+	// Refuse to serve a node of another network
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	info, err := blockchainClient.GetBlockchainInfo(ctx, &proto.GetBlockchainInfoRequest{})
+	if err != nil {
+		log.Printf("Warning: could not verify node network: %v", err)
+		return nil
+	}
+	if info.Network != params.Name {
+		return fmt.Errorf("node at %s is on %s, expected %s", grpcAddress, info.Network, params.Name)
+	}
 	return nil
 }
 
 func main() {
+	networkName := flag.String("network", "main", "Network of the node (main, test or regtest)")
+	grpcAddr := flag.String("grpc", "", "gRPC server address (default localhost:<network RPC port>)")
+	flag.Parse()
+
+	var err error
+	params, err = chaincfg.ParamsForNetwork(*networkName)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	grpcAddress = *grpcAddr
+	if grpcAddress == "" {
+		grpcAddress = fmt.Sprintf("localhost:%d", params.RPCPort)
+	}
+
 	// Initialize gRPC clients
 	if err := initGRPCClients(); err != nil {
 		log.Fatalf("Failed to initialize gRPC clients: %v", err)
@@ -366,6 +458,7 @@ func main() {
 	mux.HandleFunc("/api/blockchain/height", corsMiddleware(getBlockHeightHandler))
 	mux.HandleFunc("/api/wallet/list", corsMiddleware(listWalletsHandler))
 	mux.HandleFunc("/api/wallet/create", corsMiddleware(createWalletHandler))
+	mux.HandleFunc("/api/wallet/transactions", corsMiddleware(listWalletTransactionsHandler))
 	mux.HandleFunc("/api/mempool", corsMiddleware(getMempoolHandler))
 	mux.HandleFunc("/api/mining/info", corsMiddleware(getMiningInfoHandler))
 
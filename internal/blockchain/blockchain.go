@@ -3,8 +3,11 @@ package blockchain
 import (
 	"bytes"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/coinselect"
 	"github.com/yourusername/bt/internal/crypto"
 	"github.com/yourusername/bt/internal/merkle"
 	"github.com/yourusername/bt/internal/pow"
@@ -14,33 +17,50 @@ import (
 	"github.com/yourusername/bt/pkg/types"
 )
 
-const (
-	// GenesisData is the data stored in the genesis block
-	GenesisData = "Genesis Block - Bitcoin-like Cryptocurrency"
-
-	// BlockGenerationInterval is the target time between blocks (in seconds)
-	BlockGenerationInterval = 10 // 10 seconds for testing
-
-	// DifficultyAdjustmentInterval is how often difficulty adjusts (in blocks)
-	DifficultyAdjustmentInterval = 10 // Adjust every 10 blocks
-)
-
-// Blockchain represents the entire blockchain
+// Blockchain represents the entire blockchain. Its methods may be called
+// from several goroutines; Blocks, DifficultyTarget and UTXOSet are only
+// safe to read directly while nothing else changes the chain.
 type Blockchain struct {
+	mu sync.RWMutex // Guards Blocks, DifficultyTarget and UTXOSet
+
 	Blocks           []*types.Block
 	DifficultyTarget uint32
 	UTXOSet          *utxo.UTXOSet
 	PendingTxs       []*tx.Transaction
 	Storage          *storage.Storage
+	Params           *chaincfg.Params
 }
-// NewBlockchain creates a new blockchain with a genesis block
-func NewBlockchain(genesisAddress string, dbPath string) (*Blockchain, error) {
+
+// NewBlockchain creates a new blockchain with a genesis block, stored in LevelDB at dbPath
+func NewBlockchain(params *chaincfg.Params, dbPath string) (*Blockchain, error) {
 	// Open storage
-	store, err := storage.NewStorage(dbPath)
+	backend, err := storage.NewLevelDBBackend(dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open storage: %v", err)
 	}
 
+	bc, err := New(params, backend)
+	if err != nil {
+		backend.Close()
+		return nil, err
+	}
+	return bc, nil
+}
+
+// New creates or loads a blockchain on top of the given storage backend.
+// Use storage.NewMemoryBackend() to run without touching disk.
+func New(params *chaincfg.Params, backend storage.Backend) (*Blockchain, error) {
+	// Bring the on-disk layout up to date before reading anything
+	status, err := storage.Migrate(backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %v", err)
+	}
+	for _, m := range status.Pending {
+		fmt.Printf("🔧 Migrated database to schema v%d: %s\n", m.Version, m.Description)
+	}
+
+	store := storage.NewStorageWithBackend(backend)
+
 	utxoSet := utxo.NewUTXOSet()
 
 	// Try to load existing blockchain
@@ -48,19 +68,28 @@ func NewBlockchain(genesisAddress string, dbPath string) (*Blockchain, error) {
 	if err == nil && len(tip) > 0 {
 		// Blockchain exists, load it
 		fmt.Println("📂 Loading existing blockchain from disk...")
-		return loadBlockchain(store)
+		return loadBlockchain(params, store)
 	}
 
 	// Create new blockchain with genesis
 	fmt.Println("🆕 Creating new blockchain...")
-	genesisBlock := createGenesisBlock(genesisAddress, utxoSet)
-	
+	genesisBlock, err := GenesisBlock(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update UTXO set with genesis transaction
+	for _, transaction := range genesisBlock.Transactions.([]*tx.Transaction) {
+		utxoSet.Update(transaction)
+	}
+
 	bc := &Blockchain{
 		Blocks:           []*types.Block{genesisBlock},
-		DifficultyTarget: pow.DefaultTargetBits,
+		DifficultyTarget: params.PowTargetBits,
 		UTXOSet:          utxoSet,
 		PendingTxs:       []*tx.Transaction{},
 		Storage:          store,
+		Params:           params,
 	}
 
 	// Save genesis block
@@ -72,7 +101,7 @@ func NewBlockchain(genesisAddress string, dbPath string) (*Blockchain, error) {
 }
 
 // loadBlockchain loads an existing blockchain from storage
-func loadBlockchain(store *storage.Storage) (*Blockchain, error) {
+func loadBlockchain(params *chaincfg.Params, store *storage.Storage) (*Blockchain, error) {
 	// Get blockchain metadata
 	height, err := store.GetChainHeight()
 	if err != nil {
@@ -111,6 +140,11 @@ func loadBlockchain(store *storage.Storage) (*Blockchain, error) {
 		currentHash = block.Header.PrevBlockHash
 	}
 
+	// Refuse databases that belong to another chain
+	if !bytes.Equal(blocks[0].Hash, params.GenesisHash) {
+		return nil, fmt.Errorf("database genesis %x does not match %s genesis %x", blocks[0].Hash, params.Name, params.GenesisHash)
+	}
+
 	// Rebuild UTXO set from all blocks in order
 	utxoSet := utxo.NewUTXOSet()
 	for _, block := range blocks {
@@ -129,59 +163,42 @@ func loadBlockchain(store *storage.Storage) (*Blockchain, error) {
 		UTXOSet:          utxoSet,
 		PendingTxs:       []*tx.Transaction{},
 		Storage:          store,
+		Params:           params,
 	}, nil
 }
 
-// createGenesisBlock creates the first block in the chain
-func createGenesisBlock(genesisAddress string, utxoSet *utxo.UTXOSet) *types.Block {
-	timestamp := time.Now()
-	prevHash := make([]byte, 32) // All zeros for genesis
-
-	// Create genesis coinbase transaction (mining reward)
-	genesisTx, err := tx.NewCoinbaseTx(genesisAddress, GenesisData, 50*1e8) // 50 coins
-	if err != nil {
-		panic(fmt.Sprintf("Failed to create genesis transaction: %v", err))
-	}
-
-	// Update UTXO set with genesis transaction
-	utxoSet.AddUTXO(genesisTx.ID, genesisTx.Outputs)
+// AddBlock mines and adds a new block with transactions to the blockchain.
+// The chain is not locked while mining, so the block is rejected if another
+// one reaches the tip first.
+func (bc *Blockchain) AddBlock(transactions []*tx.Transaction, minerAddress string) (*types.Block, error) {
+	prevBlock := bc.GetLatestBlock()
 
-	// Serialize transactions
-	transactions := []*tx.Transaction{genesisTx}
-	txHashes := make([][]byte, len(transactions))
-	for i, transaction := range transactions {
-		txHashes[i] = transaction.ID
+	// Validate all non-coinbase transactions and collect their fees. Each
+	// may spend outputs of those before it, but no output twice.
+	var fees int64
+	view := bc.NewUTXOView(nil)
+	for _, transaction := range transactions {
+		fee, err := view.CheckTransaction(transaction)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction %x: %v", transaction.ID, err)
+		}
+		fees += fee
+		view.Add(transaction)
 	}
-	merkleRoot := merkle.BuildMerkleRoot(txHashes)
 
-	block := &types.Block{
-		Header: types.BlockHeader{
-			Version:          1,
-			PrevBlockHash:    prevHash,
-			MerkleRoot:       merkleRoot,
-			Timestamp:        timestamp,
-			DifficultyTarget: pow.DefaultTargetBits,
-			Nonce:            0,
-		},
-		Transactions: transactions,
+	// Adjust difficulty if needed
+	bc.mu.Lock()
+	if bc.Blocks[len(bc.Blocks)-1] != prevBlock {
+		bc.mu.Unlock()
+		return nil, fmt.Errorf("chain tip changed while validating the block")
 	}
+	height := len(bc.Blocks)
+	bc.adjustDifficulty()
+	target := bc.DifficultyTarget
+	bc.mu.Unlock()
 
-	// Mine the genesis block
-	proofOfWork := pow.NewProofOfWork(block)
-	nonce, hash := proofOfWork.Mine()
-
-	block.Header.Nonce = nonce
-	block.Hash = hash
-
-	return block
-}
-
-// AddBlock mines and adds a new block with transactions to the blockchain
-func (bc *Blockchain) AddBlock(transactions []*tx.Transaction, minerAddress string) (*types.Block, error) {
-	prevBlock := bc.Blocks[len(bc.Blocks)-1]
-
-	// Add coinbase transaction (mining reward)
-	coinbaseTx, err := tx.NewCoinbaseTx(minerAddress, fmt.Sprintf("Block %d reward", len(bc.Blocks)), 50*1e8)
+	// Add coinbase transaction (mining reward plus fees)
+	coinbaseTx, err := tx.NewCoinbaseTx(minerAddress, fmt.Sprintf("Block %d reward", height), bc.Params.BlockReward+fees)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create coinbase: %v", err)
 	}
@@ -189,16 +206,6 @@ func (bc *Blockchain) AddBlock(transactions []*tx.Transaction, minerAddress stri
 	// Add coinbase as first transaction
 	allTxs := append([]*tx.Transaction{coinbaseTx}, transactions...)
 
-	// Validate all non-coinbase transactions
-	for _, transaction := range transactions {
-		if !bc.VerifyTransaction(transaction) {
-			return nil, fmt.Errorf("invalid transaction: %x", transaction.ID)
-		}
-	}
-
-	// Adjust difficulty if needed
-	bc.adjustDifficulty()
-
 	// Build merkle root from transaction IDs
 	txHashes := make([][]byte, len(allTxs))
 	for i, transaction := range allTxs {
@@ -213,7 +220,7 @@ func (bc *Blockchain) AddBlock(transactions []*tx.Transaction, minerAddress stri
 			PrevBlockHash:    prevBlock.Hash,
 			MerkleRoot:       merkleRoot,
 			Timestamp:        time.Now(),
-			DifficultyTarget: bc.DifficultyTarget,
+			DifficultyTarget: target,
 			Nonce:            0,
 		},
 		Transactions: allTxs,
@@ -226,8 +233,14 @@ func (bc *Blockchain) AddBlock(transactions []*tx.Transaction, minerAddress stri
 	newBlock.Header.Nonce = nonce
 	newBlock.Hash = hash
 
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if bc.Blocks[len(bc.Blocks)-1] != prevBlock {
+		return nil, fmt.Errorf("chain tip changed while mining the block")
+	}
+
 	// Validate before adding
-	if err := bc.ValidateBlock(newBlock); err != nil {
+	if err := validateBlock(newBlock, prevBlock); err != nil {
 		return nil, fmt.Errorf("block validation failed: %v", err)
 	}
 
@@ -248,7 +261,8 @@ func (bc *Blockchain) AddBlock(transactions []*tx.Transaction, minerAddress stri
 	return newBlock, nil
 }
 
-// saveBlockToDB saves a block and updates chain metadata
+// saveBlockToDB saves a block and updates chain metadata. It must be called
+// with mu held.
 func (bc *Blockchain) saveBlockToDB(block *types.Block) error {
 	if bc.Storage == nil {
 		return nil // Storage not enabled
@@ -296,8 +310,19 @@ func (bc *Blockchain) Close() error {
 	return nil
 }
 
-// ValidateBlock validates a single block
+// ValidateBlock validates a single block as the next block of the chain
 func (bc *Blockchain) ValidateBlock(block *types.Block) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	var prevBlock *types.Block
+	if len(bc.Blocks) > 0 {
+		prevBlock = bc.Blocks[len(bc.Blocks)-1]
+	}
+	return validateBlock(block, prevBlock)
+}
+
+// validateBlock validates a block on top of prevBlock (nil for genesis)
+func validateBlock(block *types.Block, prevBlock *types.Block) error {
 	// 1. Validate proof-of-work
 	proofOfWork := pow.NewProofOfWork(block)
 	if !proofOfWork.Validate() {
@@ -327,8 +352,7 @@ func (bc *Blockchain) ValidateBlock(block *types.Block) error {
 	}
 
 	// 4. Verify previous block hash (if not genesis)
-	if len(bc.Blocks) > 0 {
-		prevBlock := bc.Blocks[len(bc.Blocks)-1]
+	if prevBlock != nil {
 		if !bytes.Equal(block.Header.PrevBlockHash, prevBlock.Hash) {
 			return fmt.Errorf("previous block hash mismatch")
 		}
@@ -359,6 +383,12 @@ func (bc *Blockchain) ValidateBlock(block *types.Block) error {
 
 // ValidateChain validates the entire blockchain
 func (bc *Blockchain) ValidateChain() error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	if len(bc.Blocks) == 0 || !bytes.Equal(bc.Blocks[0].Hash, bc.Params.GenesisHash) {
+		return fmt.Errorf("chain does not start with the %s genesis block", bc.Params.Name)
+	}
+
 	for i := 1; i < len(bc.Blocks); i++ {
 		block := bc.Blocks[i]
 		prevBlock := bc.Blocks[i-1]
@@ -394,50 +424,82 @@ func (bc *Blockchain) ValidateChain() error {
 	return nil
 }
 
-// adjustDifficulty adjusts the mining difficulty based on block generation time
+// adjustDifficulty adjusts the mining difficulty based on block generation
+// time. It must be called with mu held.
 func (bc *Blockchain) adjustDifficulty() {
+	if bc.Params.NoRetargeting {
+		return
+	}
+
 	blockCount := len(bc.Blocks)
+	interval := bc.Params.DifficultyAdjustmentInterval
 
 	// Only adjust at intervals
-	if blockCount%DifficultyAdjustmentInterval != 0 {
+	if blockCount%interval != 0 {
 		return
 	}
 
 	// Need at least the adjustment interval blocks
-	if blockCount < DifficultyAdjustmentInterval {
+	if blockCount < interval {
 		return
 	}
 
 	// Calculate time taken for last interval
-	lastAdjustmentBlock := bc.Blocks[blockCount-DifficultyAdjustmentInterval]
+	lastAdjustmentBlock := bc.Blocks[blockCount-interval]
 	currentBlock := bc.Blocks[blockCount-1]
 
 	timeTaken := currentBlock.Header.Timestamp.Sub(lastAdjustmentBlock.Header.Timestamp).Seconds()
-	expectedTime := float64(DifficultyAdjustmentInterval * BlockGenerationInterval)
+	expectedTime := float64(int64(interval) * bc.Params.BlockGenerationInterval)
 
 	// Adjust difficulty
 	if timeTaken < expectedTime/2 {
 		// Blocks generated too fast, increase difficulty
-		if bc.DifficultyTarget < 32 {
+		if bc.DifficultyTarget < bc.Params.MaxTargetBits {
 			bc.DifficultyTarget++
 			fmt.Printf("⚡ Difficulty increased to %d bits\n", bc.DifficultyTarget)
 		}
 	} else if timeTaken > expectedTime*2 {
 		// Blocks generated too slow, decrease difficulty
-		if bc.DifficultyTarget > 8 {
+		if bc.DifficultyTarget > bc.Params.MinTargetBits {
 			bc.DifficultyTarget--
 			fmt.Printf("⚡ Difficulty decreased to %d bits\n", bc.DifficultyTarget)
 		}
 	}
 }
 
+// GenesisHash returns the hash of the first block, which identifies the chain
+func (bc *Blockchain) GenesisHash() []byte {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.Blocks[0].Hash
+}
+
 // GetLatestBlock returns the most recent block
 func (bc *Blockchain) GetLatestBlock() *types.Block {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
 	return bc.Blocks[len(bc.Blocks)-1]
 }
 
+// Snapshot returns the blocks of the chain as it is now. Later blocks and
+// reorganizations do not change the returned slice.
+func (bc *Blockchain) Snapshot() []*types.Block {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.Blocks[:len(bc.Blocks):len(bc.Blocks)]
+}
+
+// Difficulty returns the difficulty target of the next block
+func (bc *Blockchain) Difficulty() uint32 {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.DifficultyTarget
+}
+
 // GetBlock returns a block by index
 func (bc *Blockchain) GetBlock(index int) (*types.Block, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
 	if index < 0 || index >= len(bc.Blocks) {
 		return nil, fmt.Errorf("block index out of range")
 	}
@@ -446,7 +508,7 @@ func (bc *Blockchain) GetBlock(index int) (*types.Block, error) {
 
 // GetBlockByHash finds a block by its hash
 func (bc *Blockchain) GetBlockByHash(hash []byte) (*types.Block, error) {
-	for _, block := range bc.Blocks {
+	for _, block := range bc.Snapshot() {
 		if bytes.Equal(block.Hash, hash) {
 			return block, nil
 		}
@@ -456,13 +518,15 @@ func (bc *Blockchain) GetBlockByHash(hash []byte) (*types.Block, error) {
 
 // Height returns the current blockchain height
 func (bc *Blockchain) Height() int {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
 	return len(bc.Blocks)
 }
 
 // PrintChain prints the blockchain for debugging
 func (bc *Blockchain) PrintChain() {
 	fmt.Println("\n=== BLOCKCHAIN ===")
-	for i, block := range bc.Blocks {
+	for i, block := range bc.Snapshot() {
 		fmt.Printf("\nBlock %d:\n", i)
 		fmt.Printf("  Hash: %x\n", block.Hash)
 		fmt.Printf("  Prev Hash: %x\n", block.Header.PrevBlockHash)
@@ -507,7 +571,7 @@ func (bc *Blockchain) VerifyTransaction(transaction *tx.Transaction) bool {
 
 // FindTransaction finds a transaction by ID
 func (bc *Blockchain) FindTransaction(ID []byte) (*tx.Transaction, error) {
-	for _, block := range bc.Blocks {
+	for _, block := range bc.Snapshot() {
 		transactions, ok := block.Transactions.([]*tx.Transaction)
 		if !ok {
 			continue
@@ -523,67 +587,115 @@ func (bc *Blockchain) FindTransaction(ID []byte) (*tx.Transaction, error) {
 	return nil, fmt.Errorf("transaction not found")
 }
 
-// CreateTransaction creates a new signed transaction
-func (bc *Blockchain) CreateTransaction(from, to string, amount int64, wallet *crypto.Wallet) (*tx.Transaction, error) {
-	// Find spendable outputs
-	accumulated, validOutputs, err := bc.UTXOSet.FindSpendableOutputs(from, amount)
-	if err != nil {
-		return nil, err
-	}
+// TransactionFee returns what a transaction's inputs hold beyond its
+// outputs, which the miner collects
+func (bc *Blockchain) TransactionFee(transaction *tx.Transaction) (int64, error) {
+	return bc.NewUTXOView(nil).Fee(transaction)
+}
 
-	// Build inputs
-	var inputs []tx.TxInput
-	for txID, outputs := range validOutputs {
-		for _, outIdx := range outputs {
-			input := tx.TxInput{
-				TxID:      []byte(txID),
-				OutIndex:  outIdx,
-				Signature: nil,
-				PubKey:    wallet.PublicKey,
+// UsedPubKeyHashes returns the public key hashes paid by any output in the
+// chain, keyed by their raw bytes, to find which addresses have been used
+func (bc *Blockchain) UsedPubKeyHashes() map[string]bool {
+	used := make(map[string]bool)
+	for _, block := range bc.Snapshot() {
+		transactions, ok := block.Transactions.([]*tx.Transaction)
+		if !ok {
+			continue
+		}
+
+		for _, transaction := range transactions {
+			for _, output := range transaction.Outputs {
+				used[string(output.PubKeyHash)] = true
 			}
-			inputs = append(inputs, input)
 		}
 	}
+	return used
+}
 
-	// Build outputs
-	var outputs []tx.TxOutput
-
-	// Output to recipient
-	recipientOutput := tx.TxOutput{
-		Value: amount,
-	}
-	if err := recipientOutput.Lock(to); err != nil {
-		return nil, fmt.Errorf("failed to lock output: %v", err)
-	}
-	outputs = append(outputs, recipientOutput)
+// UnspentOutput is an output that no transaction spends
+type UnspentOutput struct {
+	TxID     []byte
+	Index    int
+	Output   tx.TxOutput
+	Height   int // Index of the block holding the transaction, -1 if pending
+	Coinbase bool
+	Change   bool // Pending output of a transaction spending only its key's outputs
+}
 
-	// Change output (if any)
-	if accumulated > amount {
-		changeOutput := tx.TxOutput{
-			Value: accumulated - amount,
+// ListUnspent returns the unspent outputs paying a public key hash, in
+// chain order, with their position in the transaction that created them
+func (bc *Blockchain) ListUnspent(pubKeyHash []byte) []UnspentOutput {
+	spent := make(map[string]bool)
+	var candidates []UnspentOutput
+	for height, block := range bc.Snapshot() {
+		transactions, ok := block.Transactions.([]*tx.Transaction)
+		if !ok {
+			continue
 		}
-		if err := changeOutput.Lock(from); err != nil {
-			return nil, fmt.Errorf("failed to lock change output: %v", err)
+
+		for _, transaction := range transactions {
+			if !transaction.IsCoinbase() {
+				for _, input := range transaction.Inputs {
+					spent[outpointKey(input.TxID, input.OutIndex)] = true
+				}
+			}
+			for index, output := range transaction.Outputs {
+				if output.IsLockedWithKey(pubKeyHash) {
+					candidates = append(candidates, UnspentOutput{
+						TxID:     transaction.ID,
+						Index:    index,
+						Output:   output,
+						Height:   height,
+						Coinbase: transaction.IsCoinbase(),
+					})
+				}
+			}
 		}
-		outputs = append(outputs, changeOutput)
 	}
 
-	// Create transaction
-	transaction := tx.NewTransaction(inputs, outputs)
-
-	// Sign transaction
-	prevTxs := make(map[string]*tx.Transaction)
-	for _, input := range inputs {
-		prevTx, err := bc.FindTransaction(input.TxID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to find previous transaction: %v", err)
+	unspent := candidates[:0]
+	for _, candidate := range candidates {
+		if !spent[outpointKey(candidate.TxID, candidate.Index)] {
+			unspent = append(unspent, candidate)
 		}
-		prevTxs[string(input.TxID)] = prevTx
 	}
+	return unspent
+}
 
-	if err := transaction.Sign(wallet, prevTxs); err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %v", err)
-	}
+// outpointKey identifies an output of a transaction
+func outpointKey(txID []byte, index int) string {
+	return fmt.Sprintf("%x:%d", txID, index)
+}
+
+// FeePolicy is what a new transaction pays the miner: FeeRate satoshis per
+// byte of the signed transaction, or Fee when FeeRate is zero. Replaceable
+// transactions may be replaced by ones paying more while unconfirmed.
+type FeePolicy struct {
+	Fee         int64
+	FeeRate     int64
+	Replaceable bool
+}
 
+// CreateTransaction creates a new signed transaction without a fee
+func (bc *Blockchain) CreateTransaction(from, to string, amount int64, wallet *crypto.Wallet) (*tx.Transaction, error) {
+	transaction, _, err := bc.FundTransaction(from, to, amount, wallet.PublicKey, FeePolicy{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := bc.SignTransaction(transaction, wallet); err != nil {
+		return nil, err
+	}
 	return transaction, nil
 }
+
+// FundTransaction builds an unsigned transaction paying amount to an
+// address from the unspent outputs of another, which also receives the
+// change. The strategy chooses the inputs; nil means coinselect.Select.
+func (bc *Blockchain) FundTransaction(from, to string, amount int64, pubKey []byte, fee FeePolicy, strategy coinselect.Strategy) (*tx.Transaction, *coinselect.Result, error) {
+	return bc.NewUTXOView(nil).FundTransaction(from, to, amount, pubKey, fee, strategy)
+}
+
+// SignTransaction signs every input of a transaction with the wallet's key
+func (bc *Blockchain) SignTransaction(transaction *tx.Transaction, wallet *crypto.Wallet) error {
+	return bc.NewUTXOView(nil).SignTransaction(transaction, wallet)
+}
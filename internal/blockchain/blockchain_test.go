@@ -2,39 +2,46 @@ package blockchain
 
 import (
 	"bytes"
-	"fmt"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/yourusername/bt/internal/chaincfg"
 	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
 	"github.com/yourusername/bt/internal/tx"
 )
 
-// Helper function to create a test blockchain with cleanup
+// Helper function to create an in-memory test blockchain with cleanup.
+// The genesis coinbase is unspendable, so one block is mined to fund the wallet.
 func setupTestBlockchain(t *testing.T) (*Blockchain, *crypto.Wallet, func()) {
-	dbPath := fmt.Sprintf("./test_blockchain_%d.db", time.Now().UnixNano())
 	wallet, err := crypto.NewWallet()
 	if err != nil {
 		t.Fatalf("Failed to create wallet: %v", err)
 	}
 	
-	bc, err := NewBlockchain(wallet.GetAddress(), dbPath)
+	bc, err := New(&chaincfg.MainNetParams, storage.NewMemoryBackend())
 	if err != nil {
 		t.Fatalf("Failed to create blockchain: %v", err)
 	}
+
+	if _, err := bc.AddBlock(nil, wallet.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine funding block: %v", err)
+	}
 	
 	cleanup := func() {
 		bc.Close()
-		os.RemoveAll(dbPath)
 	}
 	
 	return bc, wallet, cleanup
 }
 
 func TestNewBlockchain(t *testing.T) {
-	bc, _, cleanup := setupTestBlockchain(t)
-	defer cleanup()
+	bc, err := New(&chaincfg.MainNetParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	defer bc.Close()
 
 	if bc == nil {
 		t.Fatal("NewBlockchain returned nil")
@@ -117,8 +124,8 @@ func TestAddMultipleBlocks(t *testing.T) {
 		}
 	}
 
-	if bc.Height() != 6 { // 1 genesis + 5 new blocks
-		t.Errorf("Height after 5 blocks = %d, want 6", bc.Height())
+	if bc.Height() != 7 { // 1 genesis + 1 funding + 5 new blocks
+		t.Errorf("Height after 5 blocks = %d, want 7", bc.Height())
 	}
 }
 
@@ -356,8 +363,8 @@ func TestHeight(t *testing.T) {
 	bc, wallet, cleanup := setupTestBlockchain(t)
 	defer cleanup()
 
-	if bc.Height() != 1 {
-		t.Errorf("Initial height = %d, want 1", bc.Height())
+	if bc.Height() != 2 { // genesis + funding block
+		t.Errorf("Initial height = %d, want 2", bc.Height())
 	}
 
 	minerAddr := wallet.GetAddress()
@@ -369,8 +376,8 @@ func TestHeight(t *testing.T) {
 		bc.AddBlock([]*tx.Transaction{tx1}, minerAddr)
 	}
 
-	if bc.Height() != 6 {
-		t.Errorf("Height after 5 additions = %d, want 6", bc.Height())
+	if bc.Height() != 7 {
+		t.Errorf("Height after 5 additions = %d, want 7", bc.Height())
 	}
 }
 
@@ -384,7 +391,7 @@ func TestDifficultyAdjustment(t *testing.T) {
 	aliceAddr := aliceWallet.GetAddress()
 
 	// Add blocks to trigger difficulty adjustment
-	for i := 0; i < DifficultyAdjustmentInterval; i++ {
+	for i := 0; i < bc.Params.DifficultyAdjustmentInterval; i++ {
 		tx1, _ := bc.CreateTransaction(minerAddr, aliceAddr, 1*1e7, wallet)
 		bc.AddBlock([]*tx.Transaction{tx1}, minerAddr)
 	}
@@ -431,10 +438,11 @@ func TestPersistence(t *testing.T) {
 
 	// Create blockchain and add blocks
 	{
-		bc, err := NewBlockchain(minerAddr, dbPath)
+		bc, err := NewBlockchain(&chaincfg.MainNetParams, dbPath)
 		if err != nil {
 			t.Fatalf("Failed to create blockchain: %v", err)
 		}
+		bc.AddBlock(nil, minerAddr)
 
 		for i := 0; i < 3; i++ {
 			tx1, _ := bc.CreateTransaction(minerAddr, aliceAddr, 1*1e8, wallet)
@@ -445,7 +453,7 @@ func TestPersistence(t *testing.T) {
 		bc.Close()
 
 		// Reopen blockchain
-		bc2, err := NewBlockchain(minerAddr, dbPath)
+		bc2, err := NewBlockchain(&chaincfg.MainNetParams, dbPath)
 		if err != nil {
 			t.Fatalf("Failed to load blockchain: %v", err)
 		}
@@ -462,6 +470,48 @@ func TestPersistence(t *testing.T) {
 	}
 }
 
+func TestNewWithMemoryBackend(t *testing.T) {
+	wallet, _ := crypto.NewWallet()
+	minerAddr := wallet.GetAddress()
+	aliceWallet, _ := crypto.NewWallet()
+	aliceAddr := aliceWallet.GetAddress()
+
+	backend := storage.NewMemoryBackend()
+	defer backend.Close()
+
+	bc, err := New(&chaincfg.MainNetParams, backend)
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	bc.AddBlock(nil, minerAddr)
+
+	tx1, err := bc.CreateTransaction(minerAddr, aliceAddr, 1*1e8, wallet)
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+	if _, err := bc.AddBlock([]*tx.Transaction{tx1}, minerAddr); err != nil {
+		t.Fatalf("Failed to add block: %v", err)
+	}
+
+	// A second chain on the same backend loads the stored blocks
+	reloaded, err := New(&chaincfg.MainNetParams, backend)
+	if err != nil {
+		t.Fatalf("Failed to reload blockchain: %v", err)
+	}
+
+	if reloaded.Height() != bc.Height() {
+		t.Errorf("Reloaded height = %d, want %d", reloaded.Height(), bc.Height())
+	}
+	if !bytes.Equal(reloaded.GetLatestBlock().Hash, bc.GetLatestBlock().Hash) {
+		t.Error("Reloaded chain has a different tip")
+	}
+
+	balance, _ := reloaded.UTXOSet.GetBalance(aliceAddr)
+	if balance != 1*1e8 {
+		t.Errorf("Reloaded balance = %d, want %d", balance, int64(1*1e8))
+	}
+}
+
 func BenchmarkAddBlock(b *testing.B) {
 	dbPath := "./bench_blockchain.db"
 	defer os.RemoveAll(dbPath)
@@ -471,8 +521,9 @@ func BenchmarkAddBlock(b *testing.B) {
 	aliceWallet, _ := crypto.NewWallet()
 	aliceAddr := aliceWallet.GetAddress()
 
-	bc, _ := NewBlockchain(minerAddr, dbPath)
+	bc, _ := NewBlockchain(&chaincfg.MainNetParams, dbPath)
 	defer bc.Close()
+	bc.AddBlock(nil, minerAddr)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -490,8 +541,9 @@ func BenchmarkValidateChain(b *testing.B) {
 	aliceWallet, _ := crypto.NewWallet()
 	aliceAddr := aliceWallet.GetAddress()
 
-	bc, _ := NewBlockchain(minerAddr, dbPath)
+	bc, _ := NewBlockchain(&chaincfg.MainNetParams, dbPath)
 	defer bc.Close()
+	bc.AddBlock(nil, minerAddr)
 
 	// Create a chain with 10 blocks
 	for i := 0; i < 10; i++ {
@@ -504,3 +556,174 @@ func BenchmarkValidateChain(b *testing.B) {
 		bc.ValidateChain()
 	}
 }
+
+func TestNewRefusesFutureSchema(t *testing.T) {
+	backend := storage.NewMemoryBackend()
+	defer backend.Close()
+
+	if _, err := New(&chaincfg.MainNetParams, backend); err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+
+	// Simulate a database written by a newer build
+	marker := []byte{0, 0, 0, byte(storage.CurrentSchemaVersion + 1)}
+	backend.Put([]byte("schema_version"), marker)
+
+	if _, err := New(&chaincfg.MainNetParams, backend); err == nil {
+		t.Error("Opened a database with a future schema version")
+	}
+}
+
+func TestRegTestNeverRetargets(t *testing.T) {
+	wallet, _ := crypto.NewWallet()
+	minerAddr := wallet.GetAddress()
+
+	bc, err := New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+
+	if bc.GetLatestBlock().Header.DifficultyTarget != chaincfg.RegTestParams.PowTargetBits {
+		t.Errorf("Genesis difficulty = %d, want %d", bc.GetLatestBlock().Header.DifficultyTarget, chaincfg.RegTestParams.PowTargetBits)
+	}
+
+	// Blocks mined back to back would raise the difficulty on other networks
+	for i := 0; i < bc.Params.DifficultyAdjustmentInterval; i++ {
+		if _, err := bc.AddBlock(nil, minerAddr); err != nil {
+			t.Fatalf("Failed to add block: %v", err)
+		}
+	}
+
+	if bc.DifficultyTarget != chaincfg.RegTestParams.PowTargetBits {
+		t.Errorf("Difficulty = %d, want %d", bc.DifficultyTarget, chaincfg.RegTestParams.PowTargetBits)
+	}
+}
+
+func TestUsedPubKeyHashes(t *testing.T) {
+	bc, wallet, cleanup := setupTestBlockchain(t)
+	defer cleanup()
+
+	unused, _ := crypto.NewWallet()
+	used := bc.UsedPubKeyHashes()
+	if !used[string(crypto.PublicKeyHash(wallet.PublicKey))] {
+		t.Error("Miner address not reported as used")
+	}
+	if used[string(crypto.PublicKeyHash(unused.PublicKey))] {
+		t.Error("Unused address reported as used")
+	}
+}
+
+func TestTransactionFees(t *testing.T) {
+	bc, wallet, cleanup := setupTestBlockchain(t)
+	defer cleanup()
+
+	recipient, _ := crypto.NewWallet()
+	miner, _ := crypto.NewWallet()
+	transaction, err := bc.CreateTransaction(wallet.GetAddress(), recipient.GetAddress(), 1000, wallet)
+	if err != nil {
+		t.Fatalf("CreateTransaction failed: %v", err)
+	}
+
+	// Lower the change so the transaction pays a fee of 500
+	transaction.Outputs[1].Value -= 500
+	transaction.ID = transaction.Hash()
+	prevTx, _ := bc.FindTransaction(transaction.Inputs[0].TxID)
+	transaction.Sign(wallet, map[string]*tx.Transaction{string(prevTx.ID): prevTx})
+
+	if fee, err := bc.TransactionFee(transaction); err != nil || fee != 500 {
+		t.Fatalf("TransactionFee = %d, %v; want 500", fee, err)
+	}
+
+	block, err := bc.AddBlock([]*tx.Transaction{transaction}, miner.GetAddress())
+	if err != nil {
+		t.Fatalf("AddBlock failed: %v", err)
+	}
+	coinbase := block.Transactions.([]*tx.Transaction)[0]
+	if coinbase.Outputs[0].Value != bc.Params.BlockReward+500 {
+		t.Errorf("Coinbase pays %d, want reward plus 500 in fees", coinbase.Outputs[0].Value)
+	}
+
+	// Outputs worth more than the inputs are rejected
+	inflated, _ := bc.CreateTransaction(recipient.GetAddress(), miner.GetAddress(), 1000, recipient)
+	inflated.Outputs[0].Value = 2000
+	if _, err := bc.TransactionFee(inflated); err == nil {
+		t.Error("Accepted outputs exceeding inputs")
+	}
+}
+
+func TestListUnspent(t *testing.T) {
+	bc, wallet, cleanup := setupTestBlockchain(t)
+	defer cleanup()
+
+	recipient, _ := crypto.NewWallet()
+	transaction, err := bc.CreateTransaction(wallet.GetAddress(), recipient.GetAddress(), 1000, wallet)
+	if err != nil {
+		t.Fatalf("CreateTransaction failed: %v", err)
+	}
+	if _, err := bc.AddBlock([]*tx.Transaction{transaction}, recipient.GetAddress()); err != nil {
+		t.Fatalf("AddBlock failed: %v", err)
+	}
+
+	// The funding coinbase is spent; the change keeps its output index
+	unspent := bc.ListUnspent(crypto.PublicKeyHash(wallet.PublicKey))
+	if len(unspent) != 1 {
+		t.Fatalf("Expected 1 unspent output, got %d", len(unspent))
+	}
+	change := unspent[0]
+	if !bytes.Equal(change.TxID, transaction.ID) || change.Index != 1 || change.Height != 2 || change.Coinbase {
+		t.Errorf("Unexpected change output %+v", change)
+	}
+	if change.Output.Value != bc.Params.BlockReward-1000 {
+		t.Errorf("Change value = %d", change.Output.Value)
+	}
+
+	received := bc.ListUnspent(crypto.PublicKeyHash(recipient.PublicKey))
+	if len(received) != 2 {
+		t.Errorf("Expected payment and coinbase for recipient, got %d outputs", len(received))
+	}
+}
+
+func TestFundTransaction(t *testing.T) {
+	bc, wallet, cleanup := setupTestBlockchain(t)
+	defer cleanup()
+
+	// Fund the wallet with a second coinbase
+	if _, err := bc.AddBlock(nil, wallet.GetAddress()); err != nil {
+		t.Fatalf("AddBlock failed: %v", err)
+	}
+	recipient, _ := crypto.NewWallet()
+
+	transaction, selection, err := bc.FundTransaction(wallet.GetAddress(), recipient.GetAddress(), 1000, wallet.PublicKey, FeePolicy{FeeRate: 3}, nil)
+	if err != nil {
+		t.Fatalf("FundTransaction failed: %v", err)
+	}
+	if len(transaction.Inputs) != 1 || len(transaction.Outputs) != 2 {
+		t.Fatalf("Expected one input and change, got %d inputs and %d outputs", len(transaction.Inputs), len(transaction.Outputs))
+	}
+	if err := bc.SignTransaction(transaction, wallet); err != nil {
+		t.Fatalf("SignTransaction failed: %v", err)
+	}
+	serialized, _ := transaction.Serialize()
+	if selection.Fee < int64(len(serialized))*3 {
+		t.Errorf("Fee %d does not pay 3 sat/byte for %d bytes", selection.Fee, len(serialized))
+	}
+	if fee, err := bc.TransactionFee(transaction); err != nil || fee != selection.Fee {
+		t.Errorf("TransactionFee = %d, %v; want %d", fee, err, selection.Fee)
+	}
+
+	// Selection is reproducible
+	again, _, _ := bc.FundTransaction(wallet.GetAddress(), recipient.GetAddress(), 1000, wallet.PublicKey, FeePolicy{FeeRate: 3}, nil)
+	if !bytes.Equal(again.ID, transaction.ID) {
+		t.Error("Funding the same payment twice gave different transactions")
+	}
+
+	// Spending both coins leaves no change for an exact amount
+	all := 2*bc.Params.BlockReward - 100
+	transaction, selection, err = bc.FundTransaction(wallet.GetAddress(), recipient.GetAddress(), all, wallet.PublicKey, FeePolicy{Fee: 100}, nil)
+	if err != nil || len(transaction.Inputs) != 2 || len(transaction.Outputs) != 1 || selection.Fee != 100 {
+		t.Errorf("Unexpected exact spend: %v %+v", err, selection)
+	}
+	if _, _, err := bc.FundTransaction(wallet.GetAddress(), recipient.GetAddress(), all+1, wallet.PublicKey, FeePolicy{Fee: 100}, nil); err == nil {
+		t.Error("Funded more than the wallet holds")
+	}
+}
@@ -0,0 +1,38 @@
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/merkle"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+// GenesisBlock builds the hard-coded genesis block of a network.
+// Every node of the network derives the same block, so its hash identifies the chain.
+func GenesisBlock(params *chaincfg.Params) (*types.Block, error) {
+	coinbase := tx.NewCoinbaseTxToPubKeyHash(params.GenesisPubKeyHash, params.GenesisData, params.BlockReward)
+	transactions := []*tx.Transaction{coinbase}
+
+	block := &types.Block{
+		Header: types.BlockHeader{
+			Version:          1,
+			PrevBlockHash:    make([]byte, 32), // All zeros for genesis
+			MerkleRoot:       merkle.BuildMerkleRoot([][]byte{coinbase.ID}),
+			Timestamp:        params.GenesisTimestamp,
+			DifficultyTarget: params.PowTargetBits,
+			Nonce:            params.GenesisNonce,
+		},
+		Transactions: transactions,
+	}
+	block.Hash = crypto.HashBlockHeader(&block.Header)
+
+	if !bytes.Equal(block.Hash, params.GenesisHash) {
+		return nil, fmt.Errorf("%s genesis block hashes to %x, expected %x", params.Name, block.Hash, params.GenesisHash)
+	}
+
+	return block, nil
+}
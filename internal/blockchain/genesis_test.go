@@ -0,0 +1,71 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/pow"
+	"github.com/yourusername/bt/internal/storage"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+func TestGenesisBlockMatchesParams(t *testing.T) {
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams, &chaincfg.TestNetParams, &chaincfg.RegTestParams} {
+		t.Run(params.Name, func(t *testing.T) {
+			block, err := GenesisBlock(params)
+			if err != nil {
+				t.Fatalf("GenesisBlock failed: %v", err)
+			}
+
+			if !pow.NewProofOfWork(block).Validate() {
+				t.Error("Genesis block does not satisfy its proof-of-work")
+			}
+
+			txs := block.Transactions.([]*tx.Transaction)
+			if len(txs) != 1 || !txs[0].IsCoinbase() {
+				t.Fatal("Genesis block must contain exactly one coinbase")
+			}
+			if !bytes.Equal(txs[0].Outputs[0].PubKeyHash, params.GenesisPubKeyHash) {
+				t.Error("Genesis coinbase pays the wrong public key hash")
+			}
+			if txs[0].Outputs[0].Value != params.BlockReward {
+				t.Errorf("Genesis reward = %d, want %d", txs[0].Outputs[0].Value, params.BlockReward)
+			}
+		})
+	}
+}
+
+func TestIndependentNodesShareGenesis(t *testing.T) {
+	bc1, err := New(&chaincfg.MainNetParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	defer bc1.Close()
+
+	bc2, err := New(&chaincfg.MainNetParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	defer bc2.Close()
+
+	if !bytes.Equal(bc1.GenesisHash(), bc2.GenesisHash()) {
+		t.Error("Independently created chains have different genesis blocks")
+	}
+	if !bytes.Equal(bc1.GenesisHash(), chaincfg.MainNetParams.GenesisHash) {
+		t.Errorf("Genesis hash = %x, want %x", bc1.GenesisHash(), chaincfg.MainNetParams.GenesisHash)
+	}
+}
+
+func TestLoadRefusesForeignGenesis(t *testing.T) {
+	backend := storage.NewMemoryBackend()
+	defer backend.Close()
+
+	if _, err := New(&chaincfg.MainNetParams, backend); err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+
+	if _, err := New(&chaincfg.TestNetParams, backend); err == nil {
+		t.Error("Opened a mainnet database with testnet parameters")
+	}
+}
@@ -0,0 +1,208 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/pow"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/internal/utxo"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+// ErrNotBestChain is returned when a header chain or set of blocks does not
+// carry more work than the current chain
+var ErrNotBestChain = errors.New("chain does not have more work than ours")
+
+// BlockLocator returns hashes from the tip back to genesis, dense near the
+// tip and exponentially sparser further back. A peer answers with the
+// headers following the first locator hash it has on its best chain.
+func (bc *Blockchain) BlockLocator() [][]byte {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	locator := make([][]byte, 0, 32)
+	step := 1
+	for i := len(bc.Blocks) - 1; i > 0; i -= step {
+		locator = append(locator, bc.Blocks[i].Hash)
+		if len(locator) >= 10 {
+			step *= 2
+		}
+	}
+	return append(locator, bc.Blocks[0].Hash)
+}
+
+// LocateHeaders returns up to max headers following the first locator hash
+// found on our chain. If none is found the headers start after genesis.
+func (bc *Blockchain) LocateHeaders(locator [][]byte, max int) []types.BlockHeader {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	start := 0
+	for _, hash := range locator {
+		if height := bc.heightOf(hash); height >= 0 {
+			start = height
+			break
+		}
+	}
+
+	headers := make([]types.BlockHeader, 0)
+	for i := start + 1; i < len(bc.Blocks) && len(headers) < max; i++ {
+		headers = append(headers, bc.Blocks[i].Header)
+	}
+	return headers
+}
+
+// CheckHeaders validates a header chain received from a peer before any
+// bodies are fetched: each header must link to the previous one and carry
+// valid proof-of-work, and the first must build on a block of our chain.
+// It returns the height of the last block shared with our chain and the
+// hashes of the headers that are new to us. ErrNotBestChain is returned if
+// the headers would not give a chain with more work than ours.
+func (bc *Blockchain) CheckHeaders(headers []types.BlockHeader) (int, [][]byte, error) {
+	if len(headers) == 0 {
+		return 0, nil, ErrNotBestChain
+	}
+
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	forkHeight := bc.heightOf(headers[0].PrevBlockHash)
+	if forkHeight < 0 {
+		return 0, nil, fmt.Errorf("headers do not connect to our chain")
+	}
+
+	hashes, err := bc.ValidateHeaders(headers[0].PrevBlockHash, headers)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Skip headers we already have on our chain
+	for len(hashes) > 0 && forkHeight+1 < len(bc.Blocks) && bytes.Equal(bc.Blocks[forkHeight+1].Hash, hashes[0]) {
+		forkHeight++
+		headers = headers[1:]
+		hashes = hashes[1:]
+	}
+	if len(hashes) == 0 {
+		return forkHeight, nil, ErrNotBestChain
+	}
+
+	work := bc.work(bc.Blocks[:forkHeight+1])
+	for i := range headers {
+		work.Add(work, pow.Work(headers[i].DifficultyTarget))
+	}
+	if work.Cmp(bc.work(bc.Blocks)) <= 0 {
+		return forkHeight, nil, ErrNotBestChain
+	}
+
+	return forkHeight, hashes, nil
+}
+
+// ValidateHeaders checks headers on their own, so a long header chain can
+// be checked batch by batch as it arrives: each header must link to the
+// previous one, the first to prevHash, and carry valid proof-of-work. It
+// returns the hashes of the headers.
+func (bc *Blockchain) ValidateHeaders(prevHash []byte, headers []types.BlockHeader) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(headers))
+	for i := range headers {
+		header := &headers[i]
+		if !bytes.Equal(header.PrevBlockHash, prevHash) {
+			return nil, fmt.Errorf("header %d does not link to the previous header", i)
+		}
+		if header.DifficultyTarget < bc.Params.MinTargetBits || header.DifficultyTarget > bc.Params.MaxTargetBits {
+			return nil, fmt.Errorf("header %d has out-of-range difficulty %d", i, header.DifficultyTarget)
+		}
+
+		hash := crypto.HashBlockHeader(header)
+		if !pow.IsValidHash(hash, header.DifficultyTarget) {
+			return nil, fmt.Errorf("header %d has invalid proof-of-work", i)
+		}
+		if header.Timestamp.After(time.Now().Add(2 * time.Hour)) {
+			return nil, fmt.Errorf("header %d timestamp too far in future", i)
+		}
+
+		hashes = append(hashes, hash)
+		prevHash = hash
+	}
+	return hashes, nil
+}
+
+// ConnectBlocks attaches blocks on top of the block at forkHeight. If that is
+// not our tip the blocks form a competing branch; it replaces our blocks
+// above forkHeight only if it carries more work, otherwise ErrNotBestChain
+// is returned and nothing changes.
+func (bc *Blockchain) ConnectBlocks(forkHeight int, blocks []*types.Block) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	if forkHeight < 0 || forkHeight >= len(bc.Blocks) {
+		return fmt.Errorf("fork height %d out of range", forkHeight)
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	prev := bc.Blocks[forkHeight]
+	for i, block := range blocks {
+		if err := validateBlock(block, prev); err != nil {
+			return fmt.Errorf("block %d (%x): %v", forkHeight+1+i, block.Hash, err)
+		}
+		prev = block
+	}
+
+	reorg := forkHeight != len(bc.Blocks)-1
+	candidate := append(append([]*types.Block{}, bc.Blocks[:forkHeight+1]...), blocks...)
+
+	if reorg && bc.work(candidate).Cmp(bc.work(bc.Blocks)) <= 0 {
+		return ErrNotBestChain
+	}
+
+	if reorg {
+		// Without undo data the UTXO set is rebuilt from the new chain
+		utxoSet := utxo.NewUTXOSet()
+		for _, block := range candidate {
+			for _, transaction := range block.Transactions.([]*tx.Transaction) {
+				utxoSet.Update(transaction)
+			}
+		}
+		fmt.Printf("🔀 Reorganized chain: replaced %d block(s) above height %d with %d\n",
+			len(bc.Blocks)-forkHeight-1, forkHeight, len(blocks))
+		bc.UTXOSet = utxoSet
+	} else {
+		for _, block := range blocks {
+			for _, transaction := range block.Transactions.([]*tx.Transaction) {
+				bc.UTXOSet.Update(transaction)
+			}
+		}
+	}
+
+	bc.Blocks = candidate
+	bc.DifficultyTarget = candidate[len(candidate)-1].Header.DifficultyTarget
+
+	for _, block := range blocks {
+		if err := bc.saveBlockToDB(block); err != nil {
+			return fmt.Errorf("failed to save block: %v", err)
+		}
+	}
+	return nil
+}
+
+// heightOf returns the height of a block on our chain, or -1. It must be
+// called with mu held.
+func (bc *Blockchain) heightOf(hash []byte) int {
+	for i := len(bc.Blocks) - 1; i >= 0; i-- {
+		if bytes.Equal(bc.Blocks[i].Hash, hash) {
+			return i
+		}
+	}
+	return -1
+}
+
+// work sums the proof-of-work of the given blocks
+func (bc *Blockchain) work(blocks []*types.Block) *big.Int {
+	total := new(big.Int)
+	for _, block := range blocks {
+		total.Add(total, pow.Work(block.Header.DifficultyTarget))
+	}
+	return total
+}
@@ -0,0 +1,184 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+// mineRegTest creates a regtest chain with n blocks mined to a fresh wallet
+func mineRegTest(t *testing.T, n int) (*Blockchain, *crypto.Wallet) {
+	bc, err := New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+
+	miner, _ := crypto.NewWallet()
+	for i := 0; i < n; i++ {
+		if _, err := bc.AddBlock(nil, miner.GetAddress()); err != nil {
+			t.Fatalf("Failed to mine block: %v", err)
+		}
+	}
+	return bc, miner
+}
+
+// bodies returns the blocks of bc matching hashes
+func bodies(t *testing.T, bc *Blockchain, hashes [][]byte) []*types.Block {
+	blocks := make([]*types.Block, len(hashes))
+	for i, hash := range hashes {
+		block, err := bc.GetBlockByHash(hash)
+		if err != nil {
+			t.Fatalf("Missing block %x: %v", hash, err)
+		}
+		blocks[i] = block
+	}
+	return blocks
+}
+
+func TestBlockLocator(t *testing.T) {
+	bc, _ := mineRegTest(t, 40)
+	defer bc.Close()
+
+	locator := bc.BlockLocator()
+
+	if !bytes.Equal(locator[0], bc.GetLatestBlock().Hash) {
+		t.Error("Locator does not start at the tip")
+	}
+	if !bytes.Equal(locator[len(locator)-1], bc.GenesisHash()) {
+		t.Error("Locator does not end at genesis")
+	}
+	if len(locator) >= bc.Height() {
+		t.Errorf("Locator is not sparse: %d entries for %d blocks", len(locator), bc.Height())
+	}
+}
+
+func TestHeadersFirstExtendsChain(t *testing.T) {
+	source, miner := mineRegTest(t, 5)
+	defer source.Close()
+	target, _ := mineRegTest(t, 0)
+	defer target.Close()
+
+	headers := source.LocateHeaders(target.BlockLocator(), 2000)
+	if len(headers) != 5 {
+		t.Fatalf("Expected 5 headers, got %d", len(headers))
+	}
+
+	forkHeight, hashes, err := target.CheckHeaders(headers)
+	if err != nil {
+		t.Fatalf("CheckHeaders failed: %v", err)
+	}
+	if forkHeight != 0 || len(hashes) != 5 {
+		t.Fatalf("Expected fork at 0 with 5 new headers, got %d and %d", forkHeight, len(hashes))
+	}
+
+	if err := target.ConnectBlocks(forkHeight, bodies(t, source, hashes)); err != nil {
+		t.Fatalf("ConnectBlocks failed: %v", err)
+	}
+
+	if !bytes.Equal(target.GetLatestBlock().Hash, source.GetLatestBlock().Hash) {
+		t.Error("Target did not reach the source tip")
+	}
+	balance, _ := target.UTXOSet.GetBalance(miner.GetAddress())
+	if balance != 5*chaincfg.RegTestParams.BlockReward {
+		t.Errorf("Expected miner balance %d, got %d", 5*chaincfg.RegTestParams.BlockReward, balance)
+	}
+
+	// Nothing left to fetch once synced
+	headers = source.LocateHeaders(target.BlockLocator(), 2000)
+	if len(headers) != 0 {
+		t.Errorf("Expected no headers after sync, got %d", len(headers))
+	}
+}
+
+func TestCheckHeadersRejectsInvalidChains(t *testing.T) {
+	source, _ := mineRegTest(t, 3)
+	defer source.Close()
+	target, _ := mineRegTest(t, 0)
+	defer target.Close()
+
+	broken := source.LocateHeaders(target.BlockLocator(), 2000)
+	broken[1].PrevBlockHash = make([]byte, 32)
+	if _, _, err := target.CheckHeaders(broken); err == nil {
+		t.Error("Accepted headers that do not link")
+	}
+
+	weak := source.LocateHeaders(target.BlockLocator(), 2000)
+	weak[2].DifficultyTarget = chaincfg.RegTestParams.MaxTargetBits
+	if _, _, err := target.CheckHeaders(weak); err == nil {
+		t.Error("Accepted a header with invalid proof-of-work")
+	}
+
+	orphan := source.LocateHeaders(target.BlockLocator(), 2000)[1:]
+	if _, _, err := target.CheckHeaders(orphan); err == nil {
+		t.Error("Accepted headers that do not connect to our chain")
+	}
+}
+
+func TestValidateHeadersInBatches(t *testing.T) {
+	source, _ := mineRegTest(t, 4)
+	defer source.Close()
+	target, _ := mineRegTest(t, 0)
+	defer target.Close()
+
+	headers := source.LocateHeaders(target.BlockLocator(), 2000)
+	first, err := target.ValidateHeaders(target.GenesisHash(), headers[:2])
+	if err != nil || len(first) != 2 {
+		t.Fatalf("ValidateHeaders of the first batch = %d hashes, %v", len(first), err)
+	}
+	if _, err := target.ValidateHeaders(first[1], headers[2:]); err != nil {
+		t.Errorf("Rejected the second batch: %v", err)
+	}
+	if _, err := target.ValidateHeaders(first[0], headers[2:]); err == nil {
+		t.Error("Accepted a batch that does not follow the previous one")
+	}
+}
+
+func TestReorgToHeavierFork(t *testing.T) {
+	heavy, heavyMiner := mineRegTest(t, 3)
+	defer heavy.Close()
+	light, lightMiner := mineRegTest(t, 2)
+	defer light.Close()
+
+	// The lighter fork is not worth fetching
+	_, _, err := heavy.CheckHeaders(light.LocateHeaders(heavy.BlockLocator(), 2000))
+	if err != ErrNotBestChain {
+		t.Errorf("Expected ErrNotBestChain, got %v", err)
+	}
+
+	forkHeight, hashes, err := light.CheckHeaders(heavy.LocateHeaders(light.BlockLocator(), 2000))
+	if err != nil {
+		t.Fatalf("CheckHeaders failed: %v", err)
+	}
+	if forkHeight != 0 {
+		t.Fatalf("Expected fork at genesis, got %d", forkHeight)
+	}
+
+	// A partial branch does not outweigh our chain yet
+	blocks := bodies(t, heavy, hashes)
+	if err := light.ConnectBlocks(forkHeight, blocks[:2]); err != ErrNotBestChain {
+		t.Fatalf("Expected ErrNotBestChain for partial branch, got %v", err)
+	}
+
+	if err := light.ConnectBlocks(forkHeight, blocks); err != nil {
+		t.Fatalf("ConnectBlocks failed: %v", err)
+	}
+
+	if !bytes.Equal(light.GetLatestBlock().Hash, heavy.GetLatestBlock().Hash) {
+		t.Error("Chain did not switch to the heavier fork")
+	}
+	if err := light.ValidateChain(); err != nil {
+		t.Errorf("Reorganized chain is invalid: %v", err)
+	}
+
+	// Rewards of the abandoned fork disappear
+	if balance, _ := light.UTXOSet.GetBalance(lightMiner.GetAddress()); balance != 0 {
+		t.Errorf("Expected abandoned miner balance 0, got %d", balance)
+	}
+	if balance, _ := light.UTXOSet.GetBalance(heavyMiner.GetAddress()); balance != 3*chaincfg.RegTestParams.BlockReward {
+		t.Errorf("Expected winning miner balance %d, got %d", 3*chaincfg.RegTestParams.BlockReward, balance)
+	}
+}
@@ -0,0 +1,338 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/yourusername/bt/internal/coinselect"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+// UTXOView layers unconfirmed transactions, such as the mempool or the
+// transactions of a block being built, over the chain. Their outputs become
+// spendable and the outputs they spend are no longer unspent.
+type UTXOView struct {
+	bc         *Blockchain
+	pending    []*tx.Transaction
+	byID       map[string]*tx.Transaction
+	spent      map[string]bool // Outputs spent by pending transactions
+	chainSpent map[string]bool // Outputs spent on the chain, built on demand
+}
+
+// NewUTXOView returns a view of the chain with the pending transactions,
+// each of which may spend outputs of those before it
+func (bc *Blockchain) NewUTXOView(pending []*tx.Transaction) *UTXOView {
+	v := &UTXOView{
+		bc:    bc,
+		byID:  make(map[string]*tx.Transaction),
+		spent: make(map[string]bool),
+	}
+	for _, transaction := range pending {
+		v.Add(transaction)
+	}
+	return v
+}
+
+// Add layers another unconfirmed transaction over the view
+func (v *UTXOView) Add(transaction *tx.Transaction) {
+	v.pending = append(v.pending, transaction)
+	v.byID[string(transaction.ID)] = transaction
+	if transaction.IsCoinbase() {
+		return
+	}
+	for _, input := range transaction.Inputs {
+		v.spent[outpointKey(input.TxID, input.OutIndex)] = true
+	}
+}
+
+// FindTransaction finds a transaction among the pending ones or on the
+// chain
+func (v *UTXOView) FindTransaction(ID []byte) (*tx.Transaction, error) {
+	if transaction, ok := v.byID[string(ID)]; ok {
+		return transaction, nil
+	}
+	return v.bc.FindTransaction(ID)
+}
+
+// ListUnspent returns the unspent outputs paying a public key hash: those
+// of the chain no pending transaction spends, then those of pending
+// transactions with height -1
+func (v *UTXOView) ListUnspent(pubKeyHash []byte) []UnspentOutput {
+	var unspent []UnspentOutput
+	for _, output := range v.bc.ListUnspent(pubKeyHash) {
+		if !v.spent[outpointKey(output.TxID, output.Index)] {
+			unspent = append(unspent, output)
+		}
+	}
+	for _, transaction := range v.pending {
+		change := spendsOnly(transaction, pubKeyHash)
+		for index, output := range transaction.Outputs {
+			if !output.IsLockedWithKey(pubKeyHash) || v.spent[outpointKey(transaction.ID, index)] {
+				continue
+			}
+			unspent = append(unspent, UnspentOutput{
+				TxID:   transaction.ID,
+				Index:  index,
+				Output: output,
+				Height: -1,
+				Change: change,
+			})
+		}
+	}
+	return unspent
+}
+
+// spendsOnly reports whether every input of a transaction spends an output
+// of the public key hash, so its outputs back to it are change
+func spendsOnly(transaction *tx.Transaction, pubKeyHash []byte) bool {
+	if transaction.IsCoinbase() {
+		return false
+	}
+	for _, input := range transaction.Inputs {
+		if !input.UsesKey(pubKeyHash) {
+			return false
+		}
+	}
+	return true
+}
+
+// Balance splits the coins of a public key hash by how settled they are
+type Balance struct {
+	Confirmed   int64 // On the chain and spendable
+	Unconfirmed int64 // Paid by pending transactions, including change
+	Immature    int64 // Coinbase rewards with too few confirmations
+}
+
+// Total is everything the public key hash holds
+func (b Balance) Total() int64 {
+	return b.Confirmed + b.Unconfirmed + b.Immature
+}
+
+// Balance returns what a public key hash holds in the view. Coinbase
+// rewards count as immature until they have Params.CoinbaseMaturity
+// confirmations.
+func (v *UTXOView) Balance(pubKeyHash []byte) Balance {
+	var balance Balance
+	height := v.bc.Height()
+	for _, output := range v.ListUnspent(pubKeyHash) {
+		switch {
+		case output.Height < 0:
+			balance.Unconfirmed += output.Output.Value
+		case output.Coinbase && height-output.Height < v.bc.Params.CoinbaseMaturity:
+			balance.Immature += output.Output.Value
+		default:
+			balance.Confirmed += output.Output.Value
+		}
+	}
+	return balance
+}
+
+// VerifyTransaction verifies a transaction's signatures against the
+// outputs it spends
+func (v *UTXOView) VerifyTransaction(transaction *tx.Transaction) bool {
+	if transaction.IsCoinbase() {
+		return true
+	}
+
+	prevTxs := make(map[string]*tx.Transaction)
+	for _, input := range transaction.Inputs {
+		prevTx, err := v.FindTransaction(input.TxID)
+		if err != nil {
+			return false
+		}
+		prevTxs[string(input.TxID)] = prevTx
+	}
+	return transaction.Verify(prevTxs)
+}
+
+// CheckTransaction checks that a transaction may join the view: it is
+// signed correctly and spends only outputs that exist and are unspent,
+// each once. It returns the transaction's fee.
+func (v *UTXOView) CheckTransaction(transaction *tx.Transaction) (int64, error) {
+	if transaction.IsCoinbase() {
+		return 0, fmt.Errorf("coinbase transactions are only valid in blocks")
+	}
+	if !v.VerifyTransaction(transaction) {
+		return 0, fmt.Errorf("invalid signature")
+	}
+
+	seen := make(map[string]bool)
+	for _, input := range transaction.Inputs {
+		key := outpointKey(input.TxID, input.OutIndex)
+		if seen[key] {
+			return 0, fmt.Errorf("output %x:%d is spent twice", input.TxID, input.OutIndex)
+		}
+		seen[key] = true
+
+		if err := v.checkUnspent(input.TxID, input.OutIndex); err != nil {
+			return 0, err
+		}
+	}
+	return v.Fee(transaction)
+}
+
+// Fee returns what a transaction's inputs hold beyond its outputs, which
+// the miner collects. It does not check that the inputs are unspent.
+func (v *UTXOView) Fee(transaction *tx.Transaction) (int64, error) {
+	if transaction.IsCoinbase() {
+		return 0, nil
+	}
+
+	var in, out int64
+	for _, input := range transaction.Inputs {
+		prevTx, err := v.FindTransaction(input.TxID)
+		if err != nil {
+			return 0, err
+		}
+		if input.OutIndex < 0 || input.OutIndex >= len(prevTx.Outputs) {
+			return 0, fmt.Errorf("input spends missing output %d of %x", input.OutIndex, input.TxID)
+		}
+		in += prevTx.Outputs[input.OutIndex].Value
+	}
+	for _, output := range transaction.Outputs {
+		if output.Value < 0 {
+			return 0, fmt.Errorf("negative output value")
+		}
+		out += output.Value
+	}
+
+	if out > in {
+		return 0, fmt.Errorf("outputs (%d) exceed inputs (%d)", out, in)
+	}
+	return in - out, nil
+}
+
+// checkUnspent checks that an output exists in the view and is unspent
+func (v *UTXOView) checkUnspent(txID []byte, index int) error {
+	prevTx, err := v.FindTransaction(txID)
+	if err != nil {
+		return fmt.Errorf("input spends unknown transaction %x", txID)
+	}
+	if index < 0 || index >= len(prevTx.Outputs) {
+		return fmt.Errorf("input spends missing output %d of %x", index, txID)
+	}
+
+	key := outpointKey(txID, index)
+	if v.spent[key] {
+		return fmt.Errorf("output %x:%d is already spent", txID, index)
+	}
+	if _, pending := v.byID[string(txID)]; !pending {
+		if v.chainSpent == nil {
+			v.chainSpent = v.bc.spentOutputs()
+		}
+		if v.chainSpent[key] {
+			return fmt.Errorf("output %x:%d is already spent", txID, index)
+		}
+	}
+	return nil
+}
+
+// spentOutputs returns every output spent on the chain
+func (bc *Blockchain) spentOutputs() map[string]bool {
+	spent := make(map[string]bool)
+	for _, block := range bc.Snapshot() {
+		transactions, ok := block.Transactions.([]*tx.Transaction)
+		if !ok {
+			continue
+		}
+		for _, transaction := range transactions {
+			if transaction.IsCoinbase() {
+				continue
+			}
+			for _, input := range transaction.Inputs {
+				spent[outpointKey(input.TxID, input.OutIndex)] = true
+			}
+		}
+	}
+	return spent
+}
+
+// FundTransaction builds an unsigned transaction paying amount to an
+// address from the unspent outputs of another, which also receives the
+// change. Besides confirmed outputs it spends the sender's unconfirmed
+// change. The strategy chooses the inputs; nil means coinselect.Select.
+func (v *UTXOView) FundTransaction(from, to string, amount int64, pubKey []byte, fee FeePolicy, strategy coinselect.Strategy) (*tx.Transaction, *coinselect.Result, error) {
+	if amount <= 0 || fee.Fee < 0 || fee.FeeRate < 0 {
+		return nil, nil, fmt.Errorf("amount must be positive and fees not negative")
+	}
+	fromPubKeyHash, err := crypto.DecodeAddress(from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid sender address: %v", err)
+	}
+	if strategy == nil {
+		strategy = coinselect.Select
+	}
+
+	// Output to recipient
+	recipientOutput := tx.TxOutput{
+		Value: amount,
+	}
+	if err := recipientOutput.Lock(to); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock output: %v", err)
+	}
+
+	var coins []coinselect.Coin
+	for _, unspent := range v.ListUnspent(fromPubKeyHash) {
+		if unspent.Height < 0 && !unspent.Change {
+			continue
+		}
+		coins = append(coins, coinselect.Coin{TxID: unspent.TxID, Index: unspent.Index, Value: unspent.Output.Value})
+	}
+	sizes := tx.EstimateSizes()
+	selection, err := strategy(coins, coinselect.Params{
+		Target:        amount,
+		FeeRate:       fee.FeeRate,
+		Fee:           fee.Fee,
+		BaseSize:      sizes.Size(0, 1),
+		InputSize:     sizes.Input,
+		ChangeSize:    sizes.Output,
+		DustThreshold: coinselect.DefaultDustThreshold,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Build inputs
+	sequence := tx.SequenceFinal
+	if fee.Replaceable {
+		sequence = tx.SequenceReplaceable
+	}
+	var inputs []tx.TxInput
+	for _, coin := range selection.Coins {
+		inputs = append(inputs, tx.TxInput{
+			TxID:      coin.TxID,
+			OutIndex:  coin.Index,
+			Sequence:  sequence,
+			Signature: nil,
+			PubKey:    pubKey,
+		})
+	}
+
+	// Change output (if any)
+	outputs := []tx.TxOutput{recipientOutput}
+	if selection.Change > 0 {
+		outputs = append(outputs, tx.TxOutput{
+			Value:      selection.Change,
+			PubKeyHash: fromPubKeyHash,
+		})
+	}
+
+	return tx.NewTransaction(inputs, outputs), selection, nil
+}
+
+// SignTransaction signs every input of a transaction with the wallet's key
+func (v *UTXOView) SignTransaction(transaction *tx.Transaction, wallet *crypto.Wallet) error {
+	prevTxs := make(map[string]*tx.Transaction)
+	for _, input := range transaction.Inputs {
+		prevTx, err := v.FindTransaction(input.TxID)
+		if err != nil {
+			return fmt.Errorf("failed to find previous transaction: %v", err)
+		}
+		prevTxs[string(input.TxID)] = prevTx
+	}
+
+	if err := transaction.Sign(wallet, prevTxs); err != nil {
+		return fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	return nil
+}
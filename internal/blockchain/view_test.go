@@ -0,0 +1,104 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+func TestUTXOView(t *testing.T) {
+	params := chaincfg.MainNetParams
+	params.CoinbaseMaturity = 3
+	bc, err := New(&params, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	defer bc.Close()
+
+	wallet, _ := crypto.NewWallet()
+	alice, _ := crypto.NewWallet()
+	walletPKH := crypto.PublicKeyHash(wallet.PublicKey)
+	alicePKH := crypto.PublicKeyHash(alice.PublicKey)
+	if _, err := bc.AddBlock(nil, wallet.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine funding block: %v", err)
+	}
+	reward := params.BlockReward
+
+	// A fresh reward is immature until it has enough confirmations
+	view := bc.NewUTXOView(nil)
+	if balance := view.Balance(walletPKH); balance.Immature != reward || balance.Confirmed != 0 {
+		t.Errorf("Balance after one block = %+v", balance)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := bc.AddBlock(nil, alice.GetAddress()); err != nil {
+			t.Fatalf("Failed to mine block: %v", err)
+		}
+	}
+	view = bc.NewUTXOView(nil)
+	if balance := view.Balance(walletPKH); balance.Confirmed != reward || balance.Immature != 0 {
+		t.Errorf("Balance after maturity = %+v", balance)
+	}
+
+	// A payment leaves unconfirmed change, which the next payment spends
+	first, err := bc.CreateTransaction(wallet.GetAddress(), alice.GetAddress(), 1000, wallet)
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+	if fee, err := view.CheckTransaction(first); err != nil || fee != 0 {
+		t.Fatalf("CheckTransaction = %d, %v", fee, err)
+	}
+	view.Add(first)
+	if balance := view.Balance(walletPKH); balance.Unconfirmed != reward-1000 || balance.Confirmed != 0 {
+		t.Errorf("Balance with a pending payment = %+v", balance)
+	}
+	if balance := view.Balance(alicePKH); balance.Unconfirmed != 1000 || balance.Immature != 2*reward {
+		t.Errorf("Recipient balance = %+v", balance)
+	}
+
+	second, _, err := view.FundTransaction(wallet.GetAddress(), alice.GetAddress(), 500, wallet.PublicKey, FeePolicy{Fee: 10}, nil)
+	if err != nil {
+		t.Fatalf("Failed to spend unconfirmed change: %v", err)
+	}
+	if err := view.SignTransaction(second, wallet); err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	if fee, err := view.CheckTransaction(second); err != nil || fee != 10 {
+		t.Fatalf("CheckTransaction of the child = %d, %v", fee, err)
+	}
+
+	// Coins others paid are not spent before they confirm
+	if _, _, err := view.FundTransaction(alice.GetAddress(), wallet.GetAddress(), 2*reward+500, alice.PublicKey, FeePolicy{}, nil); err == nil {
+		t.Error("Funded a payment from unconfirmed coins paid by others")
+	}
+
+	// An output spent in the view cannot be spent again
+	conflict, _ := bc.CreateTransaction(wallet.GetAddress(), wallet.GetAddress(), 2000, wallet)
+	if _, err := view.CheckTransaction(conflict); err == nil {
+		t.Error("Accepted a double spend")
+	}
+	twice := tx.NewTransaction(append(conflict.Inputs, conflict.Inputs...), conflict.Outputs)
+	if err := bc.SignTransaction(twice, wallet); err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+	if _, err := bc.NewUTXOView(nil).CheckTransaction(twice); err == nil {
+		t.Error("Accepted a transaction spending an output twice")
+	}
+
+	// A block holds a transaction and its child, but no double spend
+	block, err := bc.AddBlock([]*tx.Transaction{first, second}, alice.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine a parent and child: %v", err)
+	}
+	if coinbase := block.Transactions.([]*tx.Transaction)[0]; coinbase.Outputs[0].Value != reward+10 {
+		t.Errorf("Miner got %d", coinbase.Outputs[0].Value)
+	}
+	if _, err := bc.AddBlock([]*tx.Transaction{conflict}, alice.GetAddress()); err == nil {
+		t.Error("Mined a transaction spending a spent output")
+	}
+	if balance := bc.NewUTXOView(nil).Balance(walletPKH); balance.Confirmed != reward-1500-10 || balance.Unconfirmed != 0 {
+		t.Errorf("Balance after mining = %+v", balance)
+	}
+}
@@ -0,0 +1,187 @@
+package chaincfg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Params defines the consensus and networking rules of a network
+type Params struct {
+	// Name is the canonical network name used for --network and data directories
+	Name string
+
+	// Net is the magic value that tags every P2P protocol ID of the network
+	Net uint32
+
+	// AddressVersion is the version byte prepended to encoded addresses
+	AddressVersion byte
+
+	// HDPrivateKeyID and HDPublicKeyID are the version bytes of serialized
+	// BIP-32 extended keys
+	HDPrivateKeyID [4]byte
+	HDPublicKeyID  [4]byte
+
+	// HDCoinType is the BIP-44 coin type of derivation paths
+	HDCoinType uint32
+
+	// DefaultPort is the default P2P listen port
+	DefaultPort int
+
+	// RPCPort is the default gRPC listen port
+	RPCPort int
+
+	// Genesis block definition. The genesis coinbase pays GenesisPubKeyHash,
+	// the hash160 of GenesisData, which no known key controls.
+	GenesisData       string
+	GenesisTimestamp  time.Time
+	GenesisPubKeyHash []byte
+	GenesisNonce      uint32
+	GenesisHash       []byte
+
+	// BlockGenerationInterval is the target time between blocks (in seconds)
+	BlockGenerationInterval int64
+
+	// DifficultyAdjustmentInterval is how often difficulty adjusts (in blocks)
+	DifficultyAdjustmentInterval int
+
+	// NoRetargeting disables difficulty adjustment entirely
+	NoRetargeting bool
+
+	// PowTargetBits is the initial difficulty; MinTargetBits and MaxTargetBits bound adjustments
+	PowTargetBits uint32
+	MinTargetBits uint32
+	MaxTargetBits uint32
+
+	// BlockReward is the coinbase subsidy in satoshis
+	BlockReward int64
+
+	// CoinbaseMaturity is the number of confirmations after which wallets
+	// count a coinbase reward as mature rather than immature
+	CoinbaseMaturity int
+}
+
+// MainNetParams are the parameters of the main network
+var MainNetParams = Params{
+	Name:                         "mainnet",
+	Net:                          0xd9b4bef9,
+	AddressVersion:               0x00,
+	HDPrivateKeyID:               [4]byte{0x04, 0x88, 0xad, 0xe4}, // xprv
+	HDPublicKeyID:                [4]byte{0x04, 0x88, 0xb2, 0x1e}, // xpub
+	HDCoinType:                   0,
+	DefaultPort:                  9000,
+	RPCPort:                      50051,
+	GenesisData:                  "Genesis Block - Bitcoin-like Cryptocurrency",
+	GenesisTimestamp:             time.Unix(1735689600, 0).UTC(), // 2025-01-01 00:00:00 UTC
+	GenesisPubKeyHash:            mustDecodeHex("8a2a2aef36e79ade02d055690e4697f17f142ee6"),
+	GenesisNonce:                 139330,
+	GenesisHash:                  mustDecodeHex("000093e73c31babf0d8f9ce46f216220c713eceb1ef8d54fba000fa775bc05bd"),
+	BlockGenerationInterval:      10,
+	DifficultyAdjustmentInterval: 10,
+	PowTargetBits:                16,
+	MinTargetBits:                8,
+	MaxTargetBits:                32,
+	BlockReward:                  50 * 1e8,
+	CoinbaseMaturity:             100,
+}
+
+// TestNetParams are the parameters of the public test network
+var TestNetParams = Params{
+	Name:                         "testnet",
+	Net:                          0x0709110b,
+	AddressVersion:               0x6f,
+	HDPrivateKeyID:               [4]byte{0x04, 0x35, 0x83, 0x94}, // tprv
+	HDPublicKeyID:                [4]byte{0x04, 0x35, 0x87, 0xcf}, // tpub
+	HDCoinType:                   1,
+	DefaultPort:                  19000,
+	RPCPort:                      50052,
+	GenesisData:                  "Genesis Block - Bitcoin-like Cryptocurrency Testnet",
+	GenesisTimestamp:             time.Unix(1735776000, 0).UTC(), // 2025-01-02 00:00:00 UTC
+	GenesisPubKeyHash:            mustDecodeHex("5fa6d097156a3c355661b27d1ddb4651a053c998"),
+	GenesisNonce:                 246396,
+	GenesisHash:                  mustDecodeHex("0000dba0aa2d8f55d355d16af36df4f8bbec5884b8965476eabb83becaf26265"),
+	BlockGenerationInterval:      10,
+	DifficultyAdjustmentInterval: 10,
+	PowTargetBits:                16,
+	MinTargetBits:                8,
+	MaxTargetBits:                32,
+	BlockReward:                  50 * 1e8,
+	CoinbaseMaturity:             100,
+}
+
+// RegTestParams are the parameters of the local regression test network.
+// Difficulty is minimal and never adjusts so blocks can be mined on demand.
+var RegTestParams = Params{
+	Name:                         "regtest",
+	Net:                          0xdab5bffa,
+	AddressVersion:               0x3f,
+	HDPrivateKeyID:               [4]byte{0x04, 0x35, 0x83, 0x94}, // tprv
+	HDPublicKeyID:                [4]byte{0x04, 0x35, 0x87, 0xcf}, // tpub
+	HDCoinType:                   1,
+	DefaultPort:                  29000,
+	RPCPort:                      50053,
+	GenesisData:                  "Genesis Block - Bitcoin-like Cryptocurrency Regtest",
+	GenesisTimestamp:             time.Unix(1735862400, 0).UTC(), // 2025-01-03 00:00:00 UTC
+	GenesisPubKeyHash:            mustDecodeHex("5793991a71319f279f1ad4cc117043bb8332ba24"),
+	GenesisNonce:                 721,
+	GenesisHash:                  mustDecodeHex("00e4a82215df60a229438f382c9ac7258c2d4bcd15da31a425c4a70b42084b2c"),
+	BlockGenerationInterval:      10,
+	DifficultyAdjustmentInterval: 10,
+	NoRetargeting:                true,
+	PowTargetBits:                8,
+	MinTargetBits:                8,
+	MaxTargetBits:                32,
+	BlockReward:                  50 * 1e8,
+	CoinbaseMaturity:             100,
+}
+
+// networks maps every accepted --network value to its parameters
+var networks = map[string]*Params{
+	"main":    &MainNetParams,
+	"mainnet": &MainNetParams,
+	"test":    &TestNetParams,
+	"testnet": &TestNetParams,
+	"regtest": &RegTestParams,
+	"simnet":  &RegTestParams,
+}
+
+// ParamsForNetwork looks up a network by name (main, test, regtest or simnet)
+func ParamsForNetwork(name string) (*Params, error) {
+	params, ok := networks[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %q (expected main, test or regtest)", name)
+	}
+	return params, nil
+}
+
+// ProtocolPrefix returns the prefix of every P2P protocol ID on this network.
+// Peers on different networks never agree on a protocol and cannot open streams.
+func (p *Params) ProtocolPrefix() string {
+	return fmt.Sprintf("/btc/%08x", p.Net)
+}
+
+// DataDir returns the network's subdirectory of the base data directory
+func (p *Params) DataDir(base string) string {
+	return filepath.Join(base, p.Name)
+}
+
+// mustDecodeHex decodes a hard-coded hex constant
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(fmt.Sprintf("chaincfg: invalid hex constant %q: %v", s, err))
+	}
+	return b
+}
+
+// DefaultDataDir returns the base data directory (~/.btc)
+func DefaultDataDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".btc"
+	}
+	return filepath.Join(home, ".btc")
+}
@@ -0,0 +1,63 @@
+package chaincfg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParamsForNetwork(t *testing.T) {
+	tests := map[string]*Params{
+		"main":    &MainNetParams,
+		"mainnet": &MainNetParams,
+		"test":    &TestNetParams,
+		"TestNet": &TestNetParams,
+		"regtest": &RegTestParams,
+		"simnet":  &RegTestParams,
+	}
+
+	for name, want := range tests {
+		got, err := ParamsForNetwork(name)
+		if err != nil {
+			t.Errorf("ParamsForNetwork(%q) failed: %v", name, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParamsForNetwork(%q) = %s, want %s", name, got.Name, want.Name)
+		}
+	}
+
+	if _, err := ParamsForNetwork("nonexistent"); err == nil {
+		t.Error("Expected error for unknown network")
+	}
+}
+
+func TestNetworksAreDistinct(t *testing.T) {
+	all := []*Params{&MainNetParams, &TestNetParams, &RegTestParams}
+
+	for i, a := range all {
+		for _, b := range all[i+1:] {
+			if a.Net == b.Net {
+				t.Errorf("%s and %s share magic %08x", a.Name, b.Name, a.Net)
+			}
+			if a.AddressVersion == b.AddressVersion {
+				t.Errorf("%s and %s share address version 0x%02x", a.Name, b.Name, a.AddressVersion)
+			}
+			if a.ProtocolPrefix() == b.ProtocolPrefix() {
+				t.Errorf("%s and %s share protocol prefix %s", a.Name, b.Name, a.ProtocolPrefix())
+			}
+			if a.DataDir("base") == b.DataDir("base") {
+				t.Errorf("%s and %s share data directory", a.Name, b.Name)
+			}
+			if a.DefaultPort == b.DefaultPort || a.RPCPort == b.RPCPort {
+				t.Errorf("%s and %s share default ports", a.Name, b.Name)
+			}
+		}
+	}
+}
+
+func TestProtocolPrefix(t *testing.T) {
+	prefix := MainNetParams.ProtocolPrefix()
+	if !strings.HasPrefix(prefix, "/btc/") || !strings.HasSuffix(prefix, "d9b4bef9") {
+		t.Errorf("ProtocolPrefix = %q", prefix)
+	}
+}
@@ -0,0 +1,74 @@
+package coinselect
+
+// maxTries bounds the branch-and-bound search
+const maxTries = 100000
+
+// BranchAndBound searches depth-first for the coins whose effective value
+// lands closest above the target while leaving too little for a change
+// output, so the transaction has none. It returns ErrNoExactMatch if there
+// is no such set.
+func BranchAndBound(coins []Coin, p Params) (*Result, error) {
+	pool := candidates(coins, p)
+	values := make([]int64, len(pool))
+	var available int64
+	for i, c := range pool {
+		values[i] = p.effectiveValue(c)
+		available += values[i]
+	}
+
+	target := p.needed()
+	if available < target {
+		return nil, ErrInsufficientFunds
+	}
+
+	// Leftovers below the cost of a useful change output go to the miner
+	upper := target
+	if costOfChange := p.changeFee() + p.DustThreshold; costOfChange > 0 {
+		upper += costOfChange - 1
+	}
+
+	var best, selected []int
+	bestExcess := int64(-1)
+	tries := 0
+	var search func(i int, value, remaining int64)
+	search = func(i int, value, remaining int64) {
+		if tries >= maxTries || bestExcess == 0 || value > upper {
+			return
+		}
+		tries++
+		if value >= target {
+			excess := value - target
+			if best == nil || excess < bestExcess || (excess == bestExcess && len(selected) < len(best)) {
+				best = append(best[:0], selected...)
+				bestExcess = excess
+			}
+			return
+		}
+		if i == len(pool) || value+remaining < target {
+			return
+		}
+
+		selected = append(selected, i)
+		search(i+1, value+values[i], remaining-values[i])
+		selected = selected[:len(selected)-1]
+
+		// Leaving out a coin also leaves out the equal ones after it; taking
+		// one of them instead was covered above
+		next := i + 1
+		for next < len(pool) && values[next] == values[i] {
+			remaining -= values[next]
+			next++
+		}
+		search(next, value, remaining-values[i])
+	}
+	search(0, 0, available)
+
+	if best == nil {
+		return nil, ErrNoExactMatch
+	}
+	selection := make([]Coin, len(best))
+	for i, index := range best {
+		selection[i] = pool[index]
+	}
+	return finish(selection, p)
+}
@@ -0,0 +1,174 @@
+// Package coinselect chooses which unspent outputs fund a transaction. The
+// strategies account for the fee each input adds and avoid change outputs
+// too small to be worth spending.
+package coinselect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// DefaultDustThreshold is the smallest change worth creating, in satoshis
+const DefaultDustThreshold = 546
+
+var (
+	// ErrInsufficientFunds is returned when the coins cannot pay the target
+	// and its fee
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrNoExactMatch is returned by BranchAndBound when no set of coins
+	// pays the target without change
+	ErrNoExactMatch = errors.New("no input set avoids change")
+)
+
+// Coin is a spendable output
+type Coin struct {
+	TxID  []byte
+	Index int
+	Value int64
+}
+
+// Params describes the transaction being funded. Sizes are in bytes of
+// the signed transaction.
+type Params struct {
+	Target        int64 // Paid to the recipients
+	FeeRate       int64 // Satoshis per byte; zero pays Fee instead
+	Fee           int64 // Absolute fee when FeeRate is zero
+	BaseSize      int   // Without inputs or change output
+	InputSize     int   // Added by each input
+	ChangeSize    int   // Added by a change output
+	DustThreshold int64 // Change below this is left to the miner
+}
+
+// Result is a funded transaction's inputs, fee and change
+type Result struct {
+	Coins  []Coin
+	Fee    int64
+	Change int64 // Zero without a change output
+}
+
+// Strategy selects coins for a transaction
+type Strategy func(coins []Coin, p Params) (*Result, error)
+
+// Strategies by name, for configuration
+var Strategies = map[string]Strategy{
+	"default":       Select,
+	"bnb":           BranchAndBound,
+	"knapsack":      Knapsack,
+	"largest-first": LargestFirst,
+}
+
+// StrategyByName returns a named strategy; the empty name is Select
+func StrategyByName(name string) (Strategy, error) {
+	if name == "" {
+		return Select, nil
+	}
+	strategy, ok := Strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown coin selection strategy %q", name)
+	}
+	return strategy, nil
+}
+
+// Select avoids change with BranchAndBound when it can and falls back to
+// Knapsack
+func Select(coins []Coin, p Params) (*Result, error) {
+	result, err := BranchAndBound(coins, p)
+	if err == ErrNoExactMatch {
+		return Knapsack(coins, p)
+	}
+	return result, err
+}
+
+// inputFee is the fee an input adds
+func (p Params) inputFee() int64 {
+	return p.FeeRate * int64(p.InputSize)
+}
+
+// changeFee is the fee a change output adds
+func (p Params) changeFee() int64 {
+	return p.FeeRate * int64(p.ChangeSize)
+}
+
+// fee returns the fee of a transaction with n inputs
+func (p Params) fee(n int, change bool) int64 {
+	if p.FeeRate == 0 {
+		return p.Fee
+	}
+	size := p.BaseSize + n*p.InputSize
+	if change {
+		size += p.ChangeSize
+	}
+	return p.FeeRate * int64(size)
+}
+
+// needed is what the inputs' effective values must cover without change
+func (p Params) needed() int64 {
+	return p.Target + p.fee(0, false)
+}
+
+// effectiveValue is a coin's value less the fee of spending it
+func (p Params) effectiveValue(c Coin) int64 {
+	return c.Value - p.inputFee()
+}
+
+// candidates returns the coins worth spending, largest effective value
+// first and in a fixed order for equal values so selections are
+// reproducible
+func candidates(coins []Coin, p Params) []Coin {
+	var worth []Coin
+	for _, c := range coins {
+		if p.effectiveValue(c) > 0 {
+			worth = append(worth, c)
+		}
+	}
+	sort.SliceStable(worth, func(i, j int) bool {
+		if worth[i].Value != worth[j].Value {
+			return worth[i].Value > worth[j].Value
+		}
+		if c := bytes.Compare(worth[i].TxID, worth[j].TxID); c != 0 {
+			return c < 0
+		}
+		return worth[i].Index < worth[j].Index
+	})
+	return worth
+}
+
+// finish prices a selection, adding change if what is left over pays for
+// the change output and is not dust
+func finish(selected []Coin, p Params) (*Result, error) {
+	var total int64
+	for _, c := range selected {
+		total += c.Value
+	}
+	n := len(selected)
+	if n == 0 || total < p.Target+p.fee(n, false) {
+		return nil, ErrInsufficientFunds
+	}
+
+	result := &Result{Coins: selected, Fee: total - p.Target}
+	if change := total - p.Target - p.fee(n, true); change >= p.DustThreshold && change > 0 {
+		result.Fee = p.fee(n, true)
+		result.Change = change
+	}
+	return result, nil
+}
+
+// LargestFirst spends the largest coins until they pay the target
+func LargestFirst(coins []Coin, p Params) (*Result, error) {
+	var selected []Coin
+	var effective int64
+	for _, c := range candidates(coins, p) {
+		if effective >= p.needed() {
+			break
+		}
+		selected = append(selected, c)
+		effective += p.effectiveValue(c)
+	}
+	if effective < p.needed() {
+		return nil, ErrInsufficientFunds
+	}
+	return finish(selected, p)
+}
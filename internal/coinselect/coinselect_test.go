@@ -0,0 +1,156 @@
+package coinselect
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// testParams pays 1 sat/byte with small sizes
+var testParams = Params{
+	FeeRate:       1,
+	BaseSize:      10,
+	InputSize:     100,
+	ChangeSize:    30,
+	DustThreshold: DefaultDustThreshold,
+}
+
+func coins(values ...int64) []Coin {
+	result := make([]Coin, len(values))
+	for i, v := range values {
+		result[i] = Coin{TxID: []byte(fmt.Sprintf("tx%d", i)), Index: i, Value: v}
+	}
+	return result
+}
+
+func total(selected []Coin) int64 {
+	var sum int64
+	for _, c := range selected {
+		sum += c.Value
+	}
+	return sum
+}
+
+// checkBalanced verifies the inputs pay target, fee and change exactly
+func checkBalanced(t *testing.T, result *Result, p Params) {
+	t.Helper()
+	if total(result.Coins) != p.Target+result.Fee+result.Change {
+		t.Errorf("Inputs %d != target %d + fee %d + change %d", total(result.Coins), p.Target, result.Fee, result.Change)
+	}
+	if min := p.fee(len(result.Coins), result.Change > 0); result.Fee < min {
+		t.Errorf("Fee %d below %d", result.Fee, min)
+	}
+	if result.Change > 0 && result.Change < p.DustThreshold {
+		t.Errorf("Dust change %d", result.Change)
+	}
+}
+
+func TestBranchAndBoundAvoidsChange(t *testing.T) {
+	p := testParams
+	// 3000 and 5000 pay 8000 plus the fee of 10 + 2*100 bytes exactly
+	p.Target = 8000 - 210
+	result, err := BranchAndBound(coins(1000, 5000, 9000, 3000, 20000), p)
+	if err != nil {
+		t.Fatalf("BranchAndBound failed: %v", err)
+	}
+	if result.Change != 0 || total(result.Coins) != 8000 || result.Fee != 210 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	checkBalanced(t, result, p)
+
+	// Nothing lands within the cost of change of 7000
+	p.Target = 7000
+	if _, err := BranchAndBound(coins(5000, 5000), p); err != ErrNoExactMatch {
+		t.Errorf("Expected ErrNoExactMatch, got %v", err)
+	}
+	p.Target = 100000
+	if _, err := BranchAndBound(coins(5000, 5000), p); err != ErrInsufficientFunds {
+		t.Errorf("Expected ErrInsufficientFunds, got %v", err)
+	}
+}
+
+func TestKnapsack(t *testing.T) {
+	p := testParams
+	p.Target = 12000
+	pool := coins(1000, 2000, 5000, 7000, 11000, 50000)
+
+	result, err := Knapsack(pool, p)
+	if err != nil {
+		t.Fatalf("Knapsack failed: %v", err)
+	}
+	checkBalanced(t, result, p)
+	if result.Change == 0 {
+		t.Errorf("Expected change, got %+v", result)
+	}
+	if total(result.Coins) >= 50000 {
+		t.Errorf("Spent the large coin when smaller ones suffice: %+v", result.Coins)
+	}
+
+	// The same coins in another order give the same selection
+	reversed := make([]Coin, len(pool))
+	for i, c := range pool {
+		reversed[len(pool)-1-i] = c
+	}
+	again, _ := Knapsack(reversed, p)
+	if !reflect.DeepEqual(result, again) {
+		t.Errorf("Selections differ: %+v and %+v", result, again)
+	}
+
+	// A single coin is preferred over many small ones coming out higher
+	p.Target = 40000
+	result, err = Knapsack(pool, p)
+	if err != nil || len(result.Coins) != 1 || result.Coins[0].Value != 50000 {
+		t.Errorf("Expected the 50000 coin, got %+v, %v", result, err)
+	}
+}
+
+func TestLargestFirst(t *testing.T) {
+	p := testParams
+	p.Target = 9000
+	result, err := LargestFirst(coins(1000, 8000, 6000, 3000), p)
+	if err != nil {
+		t.Fatalf("LargestFirst failed: %v", err)
+	}
+	if len(result.Coins) != 2 || result.Coins[0].Value != 8000 || result.Coins[1].Value != 6000 {
+		t.Errorf("Unexpected coins %+v", result.Coins)
+	}
+	checkBalanced(t, result, p)
+}
+
+func TestSelect(t *testing.T) {
+	p := testParams
+
+	// Coins worth less than the fee of spending them are ignored
+	p.Target = 1000
+	if _, err := Select(coins(90, 100, 1050), p); err != ErrInsufficientFunds {
+		t.Errorf("Expected ErrInsufficientFunds, got %v", err)
+	}
+
+	// Leftovers too small for change go to the fee
+	p.Target = 1000
+	result, err := Select(coins(1500), p)
+	if err != nil || result.Change != 0 || result.Fee != 500 {
+		t.Errorf("Expected no change and a fee of 500, got %+v, %v", result, err)
+	}
+
+	// With an absolute fee inputs cost nothing extra
+	p = Params{Target: 1000, Fee: 100, DustThreshold: DefaultDustThreshold}
+	result, err = Select(coins(400, 700), p)
+	if err != nil || result.Fee != 100 || result.Change != 0 || len(result.Coins) != 2 {
+		t.Errorf("Unexpected fixed-fee result %+v, %v", result, err)
+	}
+	p.Target = 300
+	result, err = Select(coins(400, 5000), p)
+	if err != nil || len(result.Coins) != 1 || result.Coins[0].Value != 400 {
+		t.Errorf("Expected the exact 400 coin, got %+v, %v", result, err)
+	}
+
+	for _, name := range []string{"", "bnb", "knapsack", "largest-first"} {
+		if _, err := StrategyByName(name); err != nil {
+			t.Errorf("StrategyByName(%q): %v", name, err)
+		}
+	}
+	if _, err := StrategyByName("random"); err == nil {
+		t.Error("Accepted an unknown strategy")
+	}
+}
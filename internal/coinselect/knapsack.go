@@ -0,0 +1,113 @@
+package coinselect
+
+import "math/rand"
+
+// knapsackRounds is the number of random passes of the subset search
+const knapsackRounds = 1000
+
+// Knapsack aims for the target plus a change output above the dust
+// threshold, falling back to the bare target. It prefers the smallest
+// coin covering the aim alone, unless a set of smaller coins comes closer.
+// The search is seeded by the aim, so equal inputs give equal selections.
+func Knapsack(coins []Coin, p Params) (*Result, error) {
+	pool := candidates(coins, p)
+	needed := p.needed()
+	withChange := needed + p.changeFee() + p.DustThreshold
+	if withChange == needed {
+		withChange++
+	}
+
+	for _, aim := range []int64{withChange, needed} {
+		if selection := knapsack(pool, p, aim); selection != nil {
+			return finish(selection, p)
+		}
+	}
+	return nil, ErrInsufficientFunds
+}
+
+// knapsack selects coins whose effective value covers aim, or returns nil
+func knapsack(pool []Coin, p Params, aim int64) []Coin {
+	var smaller []Coin
+	var smallerTotal int64
+	var lowestLarger *Coin
+	for i, c := range pool {
+		value := p.effectiveValue(c)
+		switch {
+		case value == aim:
+			return []Coin{c}
+		case value < aim:
+			smaller = append(smaller, c)
+			smallerTotal += value
+		default:
+			// The pool is largest first, so the last larger coin is the smallest
+			lowestLarger = &pool[i]
+		}
+	}
+
+	if smallerTotal == aim {
+		return smaller
+	}
+	if smallerTotal < aim {
+		if lowestLarger == nil {
+			return nil
+		}
+		return []Coin{*lowestLarger}
+	}
+
+	best, bestTotal := approximateBestSubset(smaller, p, aim)
+	if lowestLarger != nil && bestTotal != aim && p.effectiveValue(*lowestLarger) <= bestTotal {
+		return []Coin{*lowestLarger}
+	}
+	return best
+}
+
+// approximateBestSubset randomly includes coins over several rounds and
+// keeps the smallest total that covers aim; the coins together cover it
+func approximateBestSubset(coins []Coin, p Params, aim int64) ([]Coin, int64) {
+	rng := rand.New(rand.NewSource(aim))
+	bestIncluded := make([]bool, len(coins))
+	for i := range bestIncluded {
+		bestIncluded[i] = true
+	}
+	var bestTotal int64
+	for _, c := range coins {
+		bestTotal += p.effectiveValue(c)
+	}
+
+	included := make([]bool, len(coins))
+	for round := 0; round < knapsackRounds && bestTotal != aim; round++ {
+		for i := range included {
+			included[i] = false
+		}
+		var total int64
+		reached := false
+
+		// The first pass picks coins at random, the second fills the gap in order
+		for pass := 0; pass < 2 && !reached; pass++ {
+			for i, c := range coins {
+				if included[i] || (pass == 0 && rng.Intn(2) == 0) {
+					continue
+				}
+				total += p.effectiveValue(c)
+				included[i] = true
+				if total >= aim {
+					reached = true
+					if total < bestTotal {
+						bestTotal = total
+						copy(bestIncluded, included)
+					}
+					total -= p.effectiveValue(c)
+					included[i] = false
+				}
+			}
+		}
+	}
+
+	var best []Coin
+	for i, c := range coins {
+		if bestIncluded[i] {
+			best = append(best, c)
+		}
+	}
+	return best, bestTotal
+}
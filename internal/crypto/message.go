@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// messageMagic prefixes signed messages so a message signature can never
+// be a valid transaction signature
+const messageMagic = "Bitcoin-like Signed Message:\n"
+
+// publicKeyLength is the length of an uncompressed public key
+const publicKeyLength = 65
+
+// SignMessage signs a text message with the wallet's key. The signature
+// carries the public key so anyone can verify it against the address.
+func SignMessage(w *Wallet, message string) (string, error) {
+	signature, err := w.Sign([]byte(messageMagic + message))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(append(append([]byte(nil), w.PublicKey...), signature...)), nil
+}
+
+// VerifyMessage checks that a message was signed by the key of an address
+func VerifyMessage(address, message, signature string) (bool, error) {
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature encoding: %v", err)
+	}
+	if len(decoded) <= publicKeyLength {
+		return false, fmt.Errorf("invalid signature length")
+	}
+	if _, err := DecodeAddress(address); err != nil {
+		return false, err
+	}
+
+	pubKey, sig := decoded[:publicKeyLength], decoded[publicKeyLength:]
+	if GetAddressFromPubKey(pubKey) != address {
+		return false, nil
+	}
+	return VerifySignature(pubKey, []byte(messageMagic+message), sig), nil
+}
@@ -0,0 +1,27 @@
+package crypto
+
+import "testing"
+
+func TestSignMessage(t *testing.T) {
+	wallet, _ := NewWallet()
+	other, _ := NewWallet()
+	address := wallet.GetAddress()
+
+	signature, err := SignMessage(wallet, "hello")
+	if err != nil {
+		t.Fatalf("SignMessage failed: %v", err)
+	}
+
+	if valid, err := VerifyMessage(address, "hello", signature); err != nil || !valid {
+		t.Errorf("Valid signature rejected: %v", err)
+	}
+	if valid, _ := VerifyMessage(address, "goodbye", signature); valid {
+		t.Error("Signature verified for another message")
+	}
+	if valid, _ := VerifyMessage(other.GetAddress(), "hello", signature); valid {
+		t.Error("Signature verified for another address")
+	}
+	if _, err := VerifyMessage(address, "hello", "not base64!"); err == nil {
+		t.Error("Accepted a malformed signature")
+	}
+}
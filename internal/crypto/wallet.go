@@ -15,13 +15,23 @@ import (
 )
 
 const (
-	// Version for address generation
-	AddressVersion = 0x00
-
 	// ChecksumLength is the length of address checksum
 	ChecksumLength = 4
 )
 
+// addressVersion is the version byte of the selected network (mainnet by default)
+var addressVersion byte = 0x00
+
+// SetAddressVersion selects the version byte used to encode and accept addresses
+func SetAddressVersion(version byte) {
+	addressVersion = version
+}
+
+// AddressVersion returns the version byte of the selected network
+func AddressVersion() byte {
+	return addressVersion
+}
+
 // Wallet represents a cryptocurrency wallet with a key pair
 type Wallet struct {
 	PrivateKey *ecdsa.PrivateKey
@@ -85,7 +95,7 @@ func GetAddressFromPubKey(pubKey []byte) string {
 // EncodeAddress encodes a public key hash into a Bitcoin-like address
 func EncodeAddress(pubKeyHash []byte) string {
 	// Add version byte
-	versionedPayload := append([]byte{AddressVersion}, pubKeyHash...)
+	versionedPayload := append([]byte{addressVersion}, pubKeyHash...)
 
 	// Calculate checksum (first 4 bytes of double SHA-256)
 	checksum := Checksum(versionedPayload)
@@ -118,6 +128,11 @@ func DecodeAddress(address string) ([]byte, error) {
 		return nil, fmt.Errorf("invalid address checksum")
 	}
 
+	// Reject addresses of other networks
+	if payload[0] != addressVersion {
+		return nil, fmt.Errorf("address belongs to another network (version 0x%02x, expected 0x%02x)", payload[0], addressVersion)
+	}
+
 	// Remove version byte
 	pubKeyHash := payload[1:]
 
@@ -140,8 +155,11 @@ func (w *Wallet) Sign(message []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to sign: %v", err)
 	}
 
-	// Combine r and s into signature (r || s)
-	signature := append(r.Bytes(), s.Bytes()...)
+	// Combine r and s into signature (r || s), each padded to 32 bytes so
+	// the verifier can split the signature in half
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
 
 	return signature, nil
 }
@@ -182,20 +200,45 @@ func PrivateKeyToHex(privateKey *ecdsa.PrivateKey) string {
 	return hex.EncodeToString(privateKey.D.Bytes())
 }
 
-// HexToPrivateKey converts a hex string to private key
+// HexToPrivateKey converts a hex string to private key; the secret must be
+// a valid secp256k1 scalar
 func HexToPrivateKey(hexKey string) (*ecdsa.PrivateKey, error) {
 	bytes, err := hex.DecodeString(hexKey)
 	if err != nil {
 		return nil, err
 	}
+	d := new(big.Int).SetBytes(bytes)
+	if len(bytes) > 32 || d.Sign() == 0 || d.Cmp(btcec.S256().N) >= 0 {
+		return nil, fmt.Errorf("private key out of range")
+	}
 
+	return PrivateKeyFromBytes(bytes), nil
+}
+
+// PrivateKeyFromBytes rebuilds a private key from its secret scalar
+func PrivateKeyFromBytes(d []byte) *ecdsa.PrivateKey {
 	curve := btcec.S256()
 	privateKey := new(ecdsa.PrivateKey)
 	privateKey.PublicKey.Curve = curve
-	privateKey.D = new(big.Int).SetBytes(bytes)
-	privateKey.PublicKey.X, privateKey.PublicKey.Y = curve.ScalarBaseMult(bytes)
+	privateKey.D = new(big.Int).SetBytes(d)
+	privateKey.PublicKey.X, privateKey.PublicKey.Y = curve.ScalarBaseMult(d)
 
-	return privateKey, nil
+	return privateKey
+}
+
+// WalletFromPrivateKey rebuilds a wallet from a stored secret scalar
+func WalletFromPrivateKey(d []byte) *Wallet {
+	privateKey := PrivateKeyFromBytes(d)
+	return &Wallet{PrivateKey: privateKey, PublicKey: PublicKeyBytes(privateKey)}
+}
+
+// ValidatePublicKey checks that a public key is an uncompressed point on
+// the curve
+func ValidatePublicKey(pubKey []byte) error {
+	if x, _ := elliptic.Unmarshal(btcec.S256(), pubKey); x == nil {
+		return fmt.Errorf("invalid public key")
+	}
+	return nil
 }
 
 // PublicKeyHash returns the RIPEMD160(SHA256(pubKey))
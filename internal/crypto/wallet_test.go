@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -60,6 +61,26 @@ func TestAddressEncodeDecode(t *testing.T) {
 	}
 }
 
+func TestDecodeAddressRejectsOtherNetwork(t *testing.T) {
+	wallet, _ := NewWallet()
+
+	defer SetAddressVersion(AddressVersion())
+	SetAddressVersion(0x6f)
+	testnetAddress := wallet.GetAddress()
+
+	if _, err := DecodeAddress(testnetAddress); err != nil {
+		t.Fatalf("Failed to decode address of the selected network: %v", err)
+	}
+
+	SetAddressVersion(0x00)
+	if _, err := DecodeAddress(testnetAddress); err == nil {
+		t.Error("Decoded an address of another network")
+	}
+	if testnetAddress == wallet.GetAddress() {
+		t.Error("Networks share the same address encoding")
+	}
+}
+
 func TestSignAndVerify(t *testing.T) {
 	wallet, _ := NewWallet()
 	message := []byte("test message")
@@ -80,6 +101,30 @@ func TestSignAndVerify(t *testing.T) {
 	}
 }
 
+func TestSignShortRAndS(t *testing.T) {
+	wallet, _ := NewWallet()
+
+	// r or s is shorter than 32 bytes in about one signature in 128
+	for i := 0; i < 10000; i++ {
+		message := []byte(fmt.Sprintf("message %d", i))
+		signature, err := wallet.Sign(message)
+		if err != nil {
+			t.Fatalf("Failed to sign message: %v", err)
+		}
+		if len(signature) != 64 {
+			t.Fatalf("Expected a 64-byte signature, got %d bytes", len(signature))
+		}
+		if signature[0] != 0 && signature[32] != 0 {
+			continue
+		}
+		if !VerifySignature(wallet.PublicKey, message, signature) {
+			t.Fatalf("Signature with a short r or s failed verification")
+		}
+		return
+	}
+	t.Fatal("No signature with a short r or s in 10000 tries")
+}
+
 func TestVerifyInvalidSignature(t *testing.T) {
 	wallet, _ := NewWallet()
 	message := []byte("test message")
@@ -147,6 +192,31 @@ func TestPrivateKeyHexConversion(t *testing.T) {
 	if string(pubKey1) != string(pubKey2) {
 		t.Error("Private key conversion is not reversible")
 	}
+
+	for _, bad := range []string{"", "00", "zz", "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"} {
+		if _, err := HexToPrivateKey(bad); err == nil {
+			t.Errorf("Accepted private key %q", bad)
+		}
+	}
+}
+
+func TestWalletFromPrivateKey(t *testing.T) {
+	wallet, _ := NewWallet()
+
+	restored := WalletFromPrivateKey(wallet.PrivateKey.D.Bytes())
+	if restored.GetAddress() != wallet.GetAddress() {
+		t.Error("Restored wallet has a different address")
+	}
+}
+
+func TestValidatePublicKey(t *testing.T) {
+	wallet, _ := NewWallet()
+	if err := ValidatePublicKey(wallet.PublicKey); err != nil {
+		t.Errorf("Rejected a valid public key: %v", err)
+	}
+	if err := ValidatePublicKey(wallet.PublicKey[:33]); err == nil {
+		t.Error("Accepted a truncated public key")
+	}
 }
 
 func TestUniqueAddresses(t *testing.T) {
@@ -0,0 +1,205 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/coinselect"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+// BumpFee speeds up a wallet transaction waiting in the mempool. One that
+// opted in to replacement is replaced by a copy paying the higher fee from
+// its change. Otherwise, or if cpfp is set, a child spends one of its
+// outputs back to the wallet and pays for the package of both.
+func (s *Server) BumpFee(ctx context.Context, req *pb.BumpFeeRequest) (*pb.BumpFeeResponse, error) {
+	txID, err := hex.DecodeString(req.TxId)
+	if err != nil {
+		return &pb.BumpFeeResponse{Success: false, Message: fmt.Sprintf("invalid transaction ID: %v", err)}, nil
+	}
+	if req.FeeRate < 0 {
+		return &pb.BumpFeeResponse{Success: false, Message: "fee rate must not be negative"}, nil
+	}
+
+	s.mempoolMu.RLock()
+	entries := s.mempoolEntries()
+	position := -1
+	for i, entry := range entries {
+		if bytes.Equal(entry.tx.ID, txID) {
+			position = i
+		}
+	}
+	replaceable := position >= 0 && entries[position].tx.SignalsReplacement()
+	s.mempoolMu.RUnlock()
+	if position < 0 {
+		return &pb.BumpFeeResponse{Success: false, Message: fmt.Sprintf("transaction %s is not in the mempool", req.TxId)}, nil
+	}
+
+	var resp *pb.BumpFeeResponse
+	if replaceable && !req.Cpfp {
+		resp, err = s.bumpByReplacement(entries, position, req.FeeRate)
+	} else {
+		resp, err = s.bumpByChild(entries, position, req.FeeRate)
+	}
+	if err != nil {
+		return &pb.BumpFeeResponse{Success: false, Message: err.Error()}, nil
+	}
+	return resp, nil
+}
+
+// bumpByReplacement replaces a transaction with one spending the same
+// inputs and paying the same recipients, taking the higher fee from the
+// change. Change left below the dust threshold goes to the fee as well.
+func (s *Server) bumpByReplacement(entries []mempoolEntry, position int, feeRate int64) (*pb.BumpFeeResponse, error) {
+	original := entries[position]
+	pubKey := original.tx.Inputs[0].PubKey
+	pubKeyHash := crypto.PublicKeyHash(pubKey)
+	for _, input := range original.tx.Inputs {
+		if !input.UsesKey(pubKeyHash) {
+			return nil, fmt.Errorf("only transactions spending from a single address can be replaced")
+		}
+	}
+	wallet, err := s.signingWallet(crypto.GetAddressFromPubKey(pubKey))
+	if err != nil {
+		return nil, err
+	}
+
+	change := -1
+	for i, output := range original.tx.Outputs {
+		if output.IsLockedWithKey(pubKeyHash) {
+			change = i
+		}
+	}
+	if change < 0 {
+		return nil, fmt.Errorf("transaction has no change to pay a higher fee from; bump it with a child instead")
+	}
+
+	// The replacement pays more than everything it evicts, at a higher
+	// fee rate than the original
+	var evictedFees int64
+	for i := range descendants(entries, map[int]bool{position: true}) {
+		evictedFees += entries[i].fee
+	}
+	size := int64(tx.EstimateSizes().Size(len(original.tx.Inputs), len(original.tx.Outputs)))
+	minFee := (original.fee/original.size + incrementalFeeRate) * size
+	if evictedFees+incrementalFeeRate*size > minFee {
+		minFee = evictedFees + incrementalFeeRate*size
+	}
+	fee := feeRate * size
+	if feeRate == 0 {
+		fee = minFee
+	} else if fee < minFee {
+		return nil, fmt.Errorf("fee rate must be at least %d sat/byte to replace the transaction", (minFee+size-1)/size)
+	}
+
+	inputs := make([]tx.TxInput, len(original.tx.Inputs))
+	for i, input := range original.tx.Inputs {
+		inputs[i] = tx.TxInput{TxID: input.TxID, OutIndex: input.OutIndex, Sequence: input.Sequence, PubKey: input.PubKey}
+	}
+	outputs := append([]tx.TxOutput(nil), original.tx.Outputs...)
+	outputs[change].Value -= fee - original.fee
+	if outputs[change].Value < 0 {
+		return nil, fmt.Errorf("change of %d cannot pay the extra %d fee", original.tx.Outputs[change].Value, fee-original.fee)
+	}
+	if outputs[change].Value < coinselect.DefaultDustThreshold {
+		outputs = append(outputs[:change], outputs[change+1:]...)
+	}
+
+	replacement := tx.NewTransaction(inputs, outputs)
+	if err := s.utxoView().SignTransaction(replacement, wallet); err != nil {
+		return nil, err
+	}
+	newFee, replaced, err := s.acceptToMempool(replacement)
+	if err != nil {
+		return nil, fmt.Errorf("replacement rejected: %v", err)
+	}
+	log.Printf("♻️  Replaced %x with %x paying %d instead of %d", original.tx.ID, replacement.ID, newFee, original.fee)
+
+	resp := &pb.BumpFeeResponse{
+		Success: true,
+		Message: "Transaction replaced",
+		TxId:    fmt.Sprintf("%x", replacement.ID),
+		Method:  "replace",
+		OldFee:  original.fee,
+		NewFee:  newFee,
+		FeeRate: newFee / txSize(replacement),
+	}
+	for _, old := range replaced {
+		resp.Replaced = append(resp.Replaced, fmt.Sprintf("%x", old.ID))
+	}
+	return resp, nil
+}
+
+// bumpByChild spends the largest output a wallet holds the key to back to
+// the same address, paying enough that the transaction, its unconfirmed
+// ancestors and the child together reach the fee rate (child pays for
+// parent)
+func (s *Server) bumpByChild(entries []mempoolEntry, position int, feeRate int64) (*pb.BumpFeeResponse, error) {
+	parent := entries[position].tx
+	spent := make(map[int]bool)
+	for _, entry := range entries {
+		for _, input := range entry.tx.Inputs {
+			if bytes.Equal(input.TxID, parent.ID) {
+				spent[input.OutIndex] = true
+			}
+		}
+	}
+	index := -1
+	for i, output := range parent.Outputs {
+		address := crypto.EncodeAddress(output.PubKeyHash)
+		if spent[i] || s.walletForAddress(address) == nil {
+			continue
+		}
+		if index < 0 || output.Value > parent.Outputs[index].Value {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("transaction pays no unspent output to a loaded wallet")
+	}
+	output := parent.Outputs[index]
+	wallet, err := s.signingWallet(crypto.EncodeAddress(output.PubKeyHash))
+	if err != nil {
+		return nil, err
+	}
+
+	packageFee, packageSize := packageValue(entries, ancestors(entries, position, nil))
+	size := int64(tx.EstimateSizes().Size(1, 1))
+	if feeRate == 0 {
+		feeRate = packageFee/packageSize + incrementalFeeRate
+	}
+	fee := feeRate*(packageSize+size) - packageFee
+	if fee < incrementalFeeRate*size {
+		return nil, fmt.Errorf("the transaction already pays %d sat/byte with its ancestors", packageFee/packageSize)
+	}
+	if output.Value-fee < coinselect.DefaultDustThreshold {
+		return nil, fmt.Errorf("output of %d is too small to pay a %d fee", output.Value, fee)
+	}
+
+	child := tx.NewTransaction(
+		[]tx.TxInput{{TxID: parent.ID, OutIndex: index, Sequence: tx.SequenceReplaceable, PubKey: wallet.PublicKey}},
+		[]tx.TxOutput{{Value: output.Value - fee, PubKeyHash: output.PubKeyHash}},
+	)
+	if err := s.utxoView().SignTransaction(child, wallet); err != nil {
+		return nil, err
+	}
+	if _, _, err := s.acceptToMempool(child); err != nil {
+		return nil, fmt.Errorf("child rejected: %v", err)
+	}
+	log.Printf("👶 Child %x pays %d for %x", child.ID, fee, parent.ID)
+
+	return &pb.BumpFeeResponse{
+		Success: true,
+		Message: "Child transaction sent",
+		TxId:    fmt.Sprintf("%x", child.ID),
+		Method:  "cpfp",
+		OldFee:  packageFee,
+		NewFee:  fee,
+		FeeRate: (packageFee + fee) / (packageSize + txSize(child)),
+	}, nil
+}
@@ -0,0 +1,292 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+
+	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+// ListTransactions lists the transactions paying to or spending from a
+// wallet's addresses, including those still in the mempool. Pages are
+// counted from the newest transaction; each page is ordered oldest first.
+func (s *Server) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	if req.Count < 0 || req.Skip < 0 {
+		return nil, fmt.Errorf("count and skip must not be negative")
+	}
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.syncWallet(w); err != nil {
+		return nil, err
+	}
+	txs, err := w.store.GetWalletTxs()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.pendingWalletTxs(w)
+	if err != nil {
+		return nil, err
+	}
+	txs = append(txs, pending...)
+
+	end := len(txs) - int(req.Skip)
+	if end < 0 {
+		end = 0
+	}
+	start := 0
+	if req.Count > 0 && end-int(req.Count) > start {
+		start = end - int(req.Count)
+	}
+
+	height := s.bc.Height()
+	resp := &pb.ListTransactionsResponse{
+		Transactions: make([]*pb.WalletTransaction, 0, end-start),
+		Total:        int32(len(txs)),
+	}
+	for _, wtx := range txs[start:end] {
+		entry := &pb.WalletTransaction{
+			TxId:      fmt.Sprintf("%x", wtx.TxID),
+			Height:    int64(wtx.Height),
+			Received:  wtx.Received,
+			Sent:      wtx.Sent,
+			Amount:    wtx.Amount(),
+			Fee:       wtx.Fee,
+			Addresses: wtx.Addresses,
+			Category:  txCategory(wtx),
+			BlockHash: fmt.Sprintf("%x", wtx.BlockHash),
+			Time:      wtx.Time,
+		}
+		if wtx.Height >= 0 {
+			entry.Confirmations = int64(height - wtx.Height)
+		}
+		resp.Transactions = append(resp.Transactions, entry)
+	}
+	return resp, nil
+}
+
+// txCategory names what a transaction did for the wallet
+func txCategory(wtx storage.WalletTx) string {
+	switch {
+	case wtx.Coinbase:
+		return "coinbase"
+	case wtx.Sent == 0:
+		return "receive"
+	case len(wtx.Addresses) == 0:
+		return "self"
+	default:
+		return "send"
+	}
+}
+
+// syncWallet brings the wallet's transaction records in line with the
+// chain: blocks it scanned that were disconnected, by a reorganization or
+// otherwise, are undone, then the blocks it has not scanned are recorded.
+// It returns how many transactions it found.
+func (s *Server) syncWallet(w *loadedWallet) (int, error) {
+	w.scanMu.Lock()
+	defer w.scanMu.Unlock()
+
+	if err := s.extendXPubs(w); err != nil {
+		return 0, err
+	}
+	pubKeyHashes, err := walletPubKeyHashes(w)
+	if err != nil {
+		return 0, err
+	}
+
+	blocks := s.bc.Snapshot()
+	scanned, err := w.store.ScanHeight()
+	if err != nil {
+		return 0, err
+	}
+	fork := scanned
+	for ; fork >= 0; fork-- {
+		if fork >= len(blocks) {
+			continue
+		}
+		hash, err := w.store.ScannedBlockHash(fork)
+		if err != nil {
+			return 0, err
+		}
+		if bytes.Equal(hash, blocks[fork].Hash) {
+			break
+		}
+	}
+	if fork < scanned {
+		if err := w.store.Rescan(fork + 1); err != nil {
+			return 0, err
+		}
+		log.Printf("↩️  Disconnected %d blocks from wallet %q", scanned-fork, displayName(w.name))
+	}
+
+	found := 0
+	for height := fork + 1; height < len(blocks); height++ {
+		txs := s.walletTxsInBlock(blocks[height], height, pubKeyHashes)
+		if err := w.store.SaveScannedBlock(height, blocks[height].Hash, txs); err != nil {
+			return found, err
+		}
+		found += len(txs)
+	}
+	return found, nil
+}
+
+// syncWallets catches every loaded wallet up with the chain
+func (s *Server) syncWallets() {
+	s.walletsMu.RLock()
+	wallets := make([]*loadedWallet, 0, len(s.wallets))
+	for _, w := range s.wallets {
+		wallets = append(wallets, w)
+	}
+	s.walletsMu.RUnlock()
+
+	for _, w := range wallets {
+		if _, err := s.syncWallet(w); err != nil {
+			log.Printf("Failed to update transactions of wallet %q: %v", displayName(w.name), err)
+		}
+	}
+}
+
+// walletPubKeyHashes returns the public key hashes of a wallet's addresses
+func walletPubKeyHashes(w *loadedWallet) (map[string]bool, error) {
+	addresses, err := w.store.GetAllAddresses()
+	if err != nil {
+		return nil, err
+	}
+	pubKeyHashes := make(map[string]bool)
+	for _, address := range addresses {
+		if pubKeyHash, err := crypto.DecodeAddress(address); err == nil {
+			pubKeyHashes[string(pubKeyHash)] = true
+		}
+	}
+	return pubKeyHashes, nil
+}
+
+// walletTxsInBlock returns the transactions of a block paying to or
+// spending from the public key hashes
+func (s *Server) walletTxsInBlock(block *types.Block, height int, pubKeyHashes map[string]bool) []storage.WalletTx {
+	transactions, ok := block.Transactions.([]*tx.Transaction)
+	if !ok {
+		return nil
+	}
+
+	var txs []storage.WalletTx
+	for i, transaction := range transactions {
+		wtx, ok := s.walletTx(transaction, pubKeyHashes, nil)
+		if !ok {
+			continue
+		}
+		wtx.Height = height
+		wtx.BlockHash = block.Hash
+		wtx.Index = i
+		wtx.Time = block.Header.Timestamp.Unix()
+		txs = append(txs, wtx)
+	}
+	return txs
+}
+
+// pendingWalletTxs returns the mempool transactions paying to or spending
+// from a wallet's addresses
+func (s *Server) pendingWalletTxs(w *loadedWallet) ([]storage.WalletTx, error) {
+	pubKeyHashes, err := walletPubKeyHashes(w)
+	if err != nil {
+		return nil, err
+	}
+	s.mempoolMu.RLock()
+	mempool := append([]*tx.Transaction(nil), s.mempool...)
+	s.mempoolMu.RUnlock()
+
+	var txs []storage.WalletTx
+	for i, transaction := range mempool {
+		if wtx, ok := s.walletTx(transaction, pubKeyHashes, mempool); ok {
+			wtx.Height = -1
+			wtx.Index = i
+			txs = append(txs, wtx)
+		}
+	}
+	return txs, nil
+}
+
+// walletTx describes what a transaction did for the wallet owning the
+// public key hashes, and reports false if it did not touch the wallet.
+// Outputs it spends are looked up on the chain, then among pending.
+func (s *Server) walletTx(transaction *tx.Transaction, pubKeyHashes map[string]bool, pending []*tx.Transaction) (storage.WalletTx, bool) {
+	wtx := storage.WalletTx{TxID: transaction.ID, Coinbase: transaction.IsCoinbase()}
+
+	var in, out int64
+	inputsKnown := true
+	var senders, recipients []string
+	if !wtx.Coinbase {
+		for _, input := range transaction.Inputs {
+			pubKeyHash := crypto.PublicKeyHash(input.PubKey)
+			output, ok := s.spentOutput(input, pending)
+			if !ok {
+				inputsKnown = false
+			}
+			in += output.Value
+			if pubKeyHashes[string(pubKeyHash)] {
+				wtx.Sent += output.Value
+			} else {
+				senders = appendAddress(senders, pubKeyHash)
+			}
+		}
+	}
+	for _, output := range transaction.Outputs {
+		out += output.Value
+		if pubKeyHashes[string(output.PubKeyHash)] {
+			wtx.Received += output.Value
+		} else {
+			recipients = appendAddress(recipients, output.PubKeyHash)
+		}
+	}
+	if wtx.Received == 0 && wtx.Sent == 0 {
+		return wtx, false
+	}
+
+	if wtx.Sent > 0 {
+		if inputsKnown && len(senders) == 0 {
+			wtx.Fee = in - out
+		}
+		wtx.Addresses = recipients
+	} else {
+		wtx.Addresses = senders
+	}
+	return wtx, true
+}
+
+// spentOutput finds the output an input spends on the chain or among
+// pending transactions
+func (s *Server) spentOutput(input tx.TxInput, pending []*tx.Transaction) (tx.TxOutput, bool) {
+	prevTx, err := s.bc.FindTransaction(input.TxID)
+	if err != nil {
+		prevTx = nil
+		for _, transaction := range pending {
+			if bytes.Equal(transaction.ID, input.TxID) {
+				prevTx = transaction
+				break
+			}
+		}
+	}
+	if prevTx == nil || input.OutIndex < 0 || input.OutIndex >= len(prevTx.Outputs) {
+		return tx.TxOutput{}, false
+	}
+	return prevTx.Outputs[input.OutIndex], true
+}
+
+// appendAddress adds the address of a public key hash unless listed
+func appendAddress(addresses []string, pubKeyHash []byte) []string {
+	address := crypto.EncodeAddress(pubKeyHash)
+	for _, existing := range addresses {
+		if existing == address {
+			return addresses
+		}
+	}
+	return append(addresses, address)
+}
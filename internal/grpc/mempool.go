@@ -0,0 +1,301 @@
+package grpc
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+// Mempool policy
+const (
+	// incrementalFeeRate is what a replacement pays, in satoshis per byte of
+	// its own size, on top of the fees of the transactions it evicts
+	incrementalFeeRate = 1
+
+	// maxReplacementEvictions bounds the transactions one replacement may
+	// evict, descendants included
+	maxReplacementEvictions = 100
+
+	// maxBlockTxBytes bounds the serialized transactions of a mined block
+	maxBlockTxBytes = 1000000
+)
+
+// mempoolEntry is a mempool transaction with the fee it pays
+type mempoolEntry struct {
+	tx      *tx.Transaction
+	fee     int64
+	size    int64
+	parents []int // Mempool positions of the transactions it spends from
+}
+
+// utxoView returns the chain with the mempool layered over it, so
+// transactions can spend outputs still waiting to be mined
+func (s *Server) utxoView() *blockchain.UTXOView {
+	s.mempoolMu.RLock()
+	defer s.mempoolMu.RUnlock()
+	return s.bc.NewUTXOView(s.mempool)
+}
+
+// mempoolEntries values the mempool transactions in mempool order, which
+// puts parents before their children. Transactions whose fee cannot be
+// computed, because an output they spend went missing, are left out along
+// with their descendants; callers that rebuild the mempool from the entries
+// evict them. It must be called with mempoolMu held.
+func (s *Server) mempoolEntries() []mempoolEntry {
+	view := s.bc.NewUTXOView(s.mempool)
+	positions := make(map[string]int)
+	dropped := make(map[string]bool)
+	entries := make([]mempoolEntry, 0, len(s.mempool))
+	for _, transaction := range s.mempool {
+		fee, err := view.Fee(transaction)
+		for _, input := range transaction.Inputs {
+			if err == nil && dropped[string(input.TxID)] {
+				err = fmt.Errorf("spends dropped transaction %x", input.TxID)
+			}
+		}
+		if err != nil {
+			log.Printf("Dropping transaction %x from the mempool: %v", transaction.ID, err)
+			dropped[string(transaction.ID)] = true
+			continue
+		}
+
+		entry := mempoolEntry{tx: transaction, fee: fee, size: txSize(transaction)}
+		for _, input := range transaction.Inputs {
+			parent, ok := positions[string(input.TxID)]
+			if ok && !containsInt(entry.parents, parent) {
+				entry.parents = append(entry.parents, parent)
+			}
+		}
+		positions[string(transaction.ID)] = len(entries)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// acceptToMempool adds a transaction to the mempool if it is valid on top of
+// the chain and the transactions already there, then notifies subscribers.
+// A transaction spending outputs that mempool transactions already spend
+// replaces them and their descendants, if they opted in to replacement and
+// it pays more (see checkReplacement). It returns the transaction's fee and
+// the transactions it evicted.
+func (s *Server) acceptToMempool(transaction *tx.Transaction) (int64, []*tx.Transaction, error) {
+	s.mempoolMu.Lock()
+	entries := s.mempoolEntries()
+	for _, entry := range entries {
+		if bytes.Equal(entry.tx.ID, transaction.ID) {
+			s.mempoolMu.Unlock()
+			return 0, nil, fmt.Errorf("transaction is already in the mempool")
+		}
+	}
+	spends := make(map[string]bool)
+	for _, input := range transaction.Inputs {
+		spends[fmt.Sprintf("%x:%d", input.TxID, input.OutIndex)] = true
+	}
+	conflicts := make(map[int]bool)
+	for i, entry := range entries {
+		for _, input := range entry.tx.Inputs {
+			if spends[fmt.Sprintf("%x:%d", input.TxID, input.OutIndex)] {
+				conflicts[i] = true
+			}
+		}
+	}
+	evicted := descendants(entries, conflicts)
+
+	var remaining, replaced []*tx.Transaction
+	for i, entry := range entries {
+		if evicted[i] {
+			replaced = append(replaced, entry.tx)
+		} else {
+			remaining = append(remaining, entry.tx)
+		}
+	}
+	fee, err := s.bc.NewUTXOView(remaining).CheckTransaction(transaction)
+	if err == nil && len(conflicts) > 0 {
+		err = s.checkReplacement(entries, conflicts, evicted, fee, txSize(transaction))
+	}
+	if err != nil {
+		s.mempoolMu.Unlock()
+		return 0, nil, err
+	}
+
+	s.mempool = append(remaining, transaction)
+	s.mempoolMu.Unlock()
+
+	s.notifyTxSubscribers(transaction)
+	return fee, replaced, nil
+}
+
+// checkReplacement applies the replace-by-fee rules, modeled on BIP-125:
+// every transaction the new one conflicts with opted in to replacement
+// through its input sequences, it evicts at most maxReplacementEvictions
+// transactions, it pays a higher fee rate than each conflict, and its fee
+// exceeds all evicted fees by incrementalFeeRate for its own size.
+func (s *Server) checkReplacement(entries []mempoolEntry, conflicts, evicted map[int]bool, fee, size int64) error {
+	for i := range conflicts {
+		if !entries[i].tx.SignalsReplacement() {
+			return fmt.Errorf("conflicts with mempool transaction %x, which is not replaceable", entries[i].tx.ID)
+		}
+		if fee*entries[i].size <= entries[i].fee*size {
+			return fmt.Errorf("fee rate must exceed the %d sat/byte of replaced transaction %x",
+				entries[i].fee/entries[i].size, entries[i].tx.ID)
+		}
+	}
+	if len(evicted) > maxReplacementEvictions {
+		return fmt.Errorf("replacement would evict %d transactions, more than %d", len(evicted), maxReplacementEvictions)
+	}
+
+	var evictedFees int64
+	for i := range evicted {
+		evictedFees += entries[i].fee
+	}
+	if minFee := evictedFees + incrementalFeeRate*size; fee < minFee {
+		return fmt.Errorf("replacement fee %d is below %d: the %d it evicts plus %d sat/byte", fee, minFee, evictedFees, incrementalFeeRate)
+	}
+	return nil
+}
+
+// takeBlockTransactions removes the transactions of the next block from the
+// mempool. Each transaction is valued with its unconfirmed ancestors as a
+// package, so a child paying a high fee rate pulls in its parent (child
+// pays for parent). Packages are taken by fee rate until maxBlockTxBytes.
+func (s *Server) takeBlockTransactions() []*tx.Transaction {
+	s.mempoolMu.Lock()
+	defer s.mempoolMu.Unlock()
+
+	entries := s.mempoolEntries()
+	selected := make(map[int]bool)
+	skipped := make(map[int]bool)
+	var blockSize int64
+	for {
+		best, bestFee, bestSize := -1, int64(0), int64(0)
+		var bestPackage []int
+		for i := range entries {
+			if selected[i] || skipped[i] {
+				continue
+			}
+			pkg := ancestors(entries, i, selected)
+			fee, size := packageValue(entries, pkg)
+			if best < 0 || fee*bestSize > bestFee*size {
+				best, bestFee, bestSize, bestPackage = i, fee, size, pkg
+			}
+		}
+		if best < 0 {
+			break
+		}
+		if blockSize+bestSize > maxBlockTxBytes {
+			skipped[best] = true
+			continue
+		}
+		for _, i := range bestPackage {
+			selected[i] = true
+		}
+		blockSize += bestSize
+	}
+
+	var block, remaining []*tx.Transaction
+	for i, entry := range entries {
+		if selected[i] {
+			block = append(block, entry.tx)
+		} else {
+			remaining = append(remaining, entry.tx)
+		}
+	}
+	s.mempool = remaining
+	return block
+}
+
+// returnToMempool puts back the transactions of a block that could not be
+// added, ahead of those that arrived since. Transactions that are no longer
+// valid on top of the chain and the ones kept before them are dropped, so a
+// single bad transaction does not take the others with it.
+func (s *Server) returnToMempool(transactions []*tx.Transaction) {
+	s.mempoolMu.Lock()
+	defer s.mempoolMu.Unlock()
+
+	view := s.bc.NewUTXOView(nil)
+	var kept []*tx.Transaction
+	for _, transaction := range append(transactions, s.mempool...) {
+		if _, err := view.CheckTransaction(transaction); err != nil {
+			log.Printf("Dropping transaction %x from the mempool: %v", transaction.ID, err)
+			continue
+		}
+		view.Add(transaction)
+		kept = append(kept, transaction)
+	}
+	s.mempool = kept
+}
+
+// ancestors returns the mempool position of an entry and of its unconfirmed
+// ancestors, in mempool order, leaving out those in exclude
+func ancestors(entries []mempoolEntry, i int, exclude map[int]bool) []int {
+	found := map[int]bool{i: true}
+	queue := []int{i}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, parent := range entries[next].parents {
+			if !found[parent] && !exclude[parent] {
+				found[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+
+	pkg := make([]int, 0, len(found))
+	for j := range found {
+		pkg = append(pkg, j)
+	}
+	sort.Ints(pkg)
+	return pkg
+}
+
+// descendants returns the given mempool positions with every transaction
+// spending from them, directly or not
+func descendants(entries []mempoolEntry, roots map[int]bool) map[int]bool {
+	found := make(map[int]bool)
+	for i, entry := range entries {
+		if roots[i] {
+			found[i] = true
+			continue
+		}
+		for _, parent := range entry.parents {
+			if found[parent] {
+				found[i] = true
+				break
+			}
+		}
+	}
+	return found
+}
+
+// packageValue sums the fees and sizes of a package of entries
+func packageValue(entries []mempoolEntry, pkg []int) (fee, size int64) {
+	for _, i := range pkg {
+		fee += entries[i].fee
+		size += entries[i].size
+	}
+	return fee, size
+}
+
+// txSize is the serialized size of a transaction, which fee rates divide by
+func txSize(transaction *tx.Transaction) int64 {
+	data, err := transaction.Serialize()
+	if err != nil {
+		return 1
+	}
+	return int64(len(data))
+}
+
+// containsInt reports whether a list holds a value
+func containsInt(list []int, value int) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package grpc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/psbt"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+// CreatePSBT funds a payment from an address of a loaded wallet, or wraps
+// a given unsigned transaction, in a partially signed transaction filled in
+// with what the node knows
+func (s *Server) CreatePSBT(ctx context.Context, req *pb.CreatePSBTRequest) (*pb.PSBTResponse, error) {
+	var transaction *tx.Transaction
+	if req.RawTransaction != "" {
+		raw, err := hex.DecodeString(req.RawTransaction)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction encoding: %v", err)
+		}
+		if transaction, err = tx.DeserializeTransaction(raw); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		transaction, _, err = s.fundUnsigned(req.FromAddress, req.ToAddress, req.Amount,
+			blockchain.FeePolicy{Fee: req.Fee, FeeRate: req.FeeRate, Replaceable: req.Replaceable}, req.CoinSelection)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := psbt.New(transaction)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updatePSBT(p); err != nil {
+		return nil, err
+	}
+	return psbtResponse(p, 0)
+}
+
+// UpdatePSBT adds the outputs spent by the inputs, and the keys and paths
+// of those the loaded wallets hold
+func (s *Server) UpdatePSBT(ctx context.Context, req *pb.PSBTRequest) (*pb.PSBTResponse, error) {
+	p, err := psbt.Decode(req.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updatePSBT(p); err != nil {
+		return nil, err
+	}
+	return psbtResponse(p, 0)
+}
+
+// SignPSBT signs the inputs spending outputs of a wallet's keys, which
+// must be unlocked if the wallet is encrypted
+func (s *Server) SignPSBT(ctx context.Context, req *pb.SignPSBTRequest) (*pb.PSBTResponse, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return nil, err
+	}
+	p, err := psbt.Decode(req.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updatePSBT(p); err != nil {
+		return nil, err
+	}
+
+	signed := 0
+	seen := make(map[string]bool)
+	for i := range p.Inputs {
+		utxo, err := p.SpentOutput(i)
+		if err != nil {
+			return nil, err
+		}
+		address := crypto.EncodeAddress(utxo.PubKeyHash)
+		if seen[address] || !w.store.WalletExists(address) {
+			continue
+		}
+		seen[address] = true
+
+		walletData, err := w.store.GetWallet(address)
+		if err != nil {
+			return nil, err
+		}
+		if walletData.WatchOnly {
+			continue
+		}
+		n, err := p.Sign(crypto.WalletFromPrivateKey(walletData.PrivateKey))
+		if err != nil {
+			return nil, err
+		}
+		signed += n
+	}
+	return psbtResponse(p, signed)
+}
+
+// CombinePSBT merges copies of a partially signed transaction signed by
+// different parties
+func (s *Server) CombinePSBT(ctx context.Context, req *pb.CombinePSBTRequest) (*pb.PSBTResponse, error) {
+	packets := make([]*psbt.Packet, 0, len(req.Psbts))
+	for i, encoded := range req.Psbts {
+		p, err := psbt.Decode(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("packet %d: %v", i+1, err)
+		}
+		packets = append(packets, p)
+	}
+	combined, err := psbt.Combine(packets...)
+	if err != nil {
+		return nil, err
+	}
+	return psbtResponse(combined, 0)
+}
+
+// FinalizePSBT picks the valid signature of every input it can; complete
+// tells whether the transaction can be extracted
+func (s *Server) FinalizePSBT(ctx context.Context, req *pb.PSBTRequest) (*pb.PSBTResponse, error) {
+	p, err := psbt.Decode(req.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Finalize(); err != nil && err != psbt.ErrIncomplete {
+		return nil, err
+	}
+	return psbtResponse(p, 0)
+}
+
+// ExtractPSBT returns the signed transaction of a finalized packet and
+// optionally adds it to the mempool
+func (s *Server) ExtractPSBT(ctx context.Context, req *pb.ExtractPSBTRequest) (*pb.ExtractPSBTResponse, error) {
+	p, err := psbt.Decode(req.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := p.Extract()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := transaction.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Broadcast {
+		if _, _, err := s.acceptToMempool(transaction); err != nil {
+			return nil, fmt.Errorf("invalid transaction: %v", err)
+		}
+		log.Printf("📤 Broadcast partially signed transaction %x", transaction.ID)
+	}
+
+	return &pb.ExtractPSBTResponse{
+		TxId:           fmt.Sprintf("%x", transaction.ID),
+		RawTransaction: hex.EncodeToString(raw),
+		Broadcast:      req.Broadcast,
+	}, nil
+}
+
+// updatePSBT fills in spent outputs from the chain or the mempool and the
+// keys and paths of addresses in the loaded wallets
+func (s *Server) updatePSBT(p *psbt.Packet) error {
+	if err := p.Update(s.utxoView().FindTransaction); err != nil {
+		return err
+	}
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		utxo, err := p.SpentOutput(i)
+		if err != nil {
+			return err
+		}
+		address := crypto.EncodeAddress(utxo.PubKeyHash)
+		w := s.walletForAddress(address)
+		if w == nil {
+			continue
+		}
+		if in.PubKey == nil {
+			if publicKey, err := w.store.GetPublicKey(address); err == nil && len(publicKey) > 0 {
+				in.PubKey = publicKey
+			}
+		}
+		if in.Path == "" && in.FinalSig == nil {
+			in.Path, _ = w.store.GetKeyPath(address)
+		}
+	}
+	return nil
+}
+
+// psbtResponse encodes a packet with its state
+func psbtResponse(p *psbt.Packet, signed int) (*pb.PSBTResponse, error) {
+	encoded, err := p.Encode()
+	if err != nil {
+		return nil, err
+	}
+	fee, _ := p.Fee()
+	return &pb.PSBTResponse{
+		Psbt:         encoded,
+		Complete:     p.IsComplete(),
+		Fee:          fee,
+		SignedInputs: int32(signed),
+	}, nil
+}
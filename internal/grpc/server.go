@@ -9,9 +9,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	pb "github.com/yourusername/bt/api/proto"
 	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/coinselect"
 	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/hdwallet"
+	"github.com/yourusername/bt/internal/p2p"
+	"github.com/yourusername/bt/internal/storage"
 	"github.com/yourusername/bt/internal/tx"
 	"github.com/yourusername/bt/pkg/types"
 	"google.golang.org/grpc"
@@ -24,7 +29,13 @@ type Server struct {
 	pb.UnimplementedWalletServiceServer
 	
 	bc              *blockchain.Blockchain
-	wallets         map[string]*crypto.Wallet
+	network         *p2p.Network
+	
+	// Named wallets by name, "" being the default wallet. They are stored
+	// under walletDir, or kept in memory if it is empty.
+	walletDir       string
+	wallets         map[string]*loadedWallet
+	walletsMu       sync.RWMutex
 	mempool         []*tx.Transaction
 	mempoolMu       sync.RWMutex
 	
@@ -43,17 +54,28 @@ type Server struct {
 	grpcServer      *grpc.Server
 }
 
-// NewServer creates a new gRPC server
-func NewServer(bc *blockchain.Blockchain, network interface{}) *Server {
-	return &Server{
+// NewServer creates a new gRPC server; network may be nil when P2P is
+// disabled. Its default wallet is kept in memory until SetWalletDir.
+// Loaded wallets record their transactions as blocks are connected.
+func NewServer(bc *blockchain.Blockchain, network *p2p.Network) *Server {
+	s := &Server{
 		bc:       bc,
-		wallets:  make(map[string]*crypto.Wallet),
+		network:  network,
+		wallets:  make(map[string]*loadedWallet),
 		mempool:  make([]*tx.Transaction, 0),
 		blockSubs: make([]chan *types.Block, 0),
 		txSubs:   make([]chan *tx.Transaction, 0),
 	}
+	s.openWallet("")
+	if network != nil {
+		network.SetBlockHandler(func(*types.Block) { s.syncWallets() })
+	}
+	return s
 }
 
+// maxUnlockTimeout bounds how long WalletPassphrase unlocks the wallet
+const maxUnlockTimeout = 100000000 * time.Second
+
 // Start starts the gRPC server
 func (s *Server) Start(address string) error {
 	lis, err := net.Listen("tcp", address)
@@ -75,6 +97,7 @@ func (s *Server) Stop() {
 		s.StopMiningInternal()
 		s.grpcServer.GracefulStop()
 	}
+	s.closeWallets()
 }
 
 // GetBlockByHash retrieves a block by its hash
@@ -108,30 +131,32 @@ func (s *Server) GetBlockByHeight(ctx context.Context, req *pb.GetBlockByHeightR
 // GetBlockchainInfo returns blockchain information
 func (s *Server) GetBlockchainInfo(ctx context.Context, req *pb.GetBlockchainInfoRequest) (*pb.BlockchainInfo, error) {
 	height := s.bc.Height()
-	bestBlock, _ := s.bc.GetBlock(height)
+	bestBlock := s.bc.GetLatestBlock()
 	
 	var bestHash string
 	if bestBlock != nil {
 		bestHash = fmt.Sprintf("%x", bestBlock.Hash)
 	}
 	
+	peerCount := 0
+	if s.network != nil {
+		peerCount = s.network.GetPeerCount()
+	}
+	
 	return &pb.BlockchainInfo{
 		Height:           int64(height),
 		BestBlockHash:    bestHash,
-		Difficulty:       int64(s.bc.DifficultyTarget),
+		Difficulty:       int64(s.bc.Difficulty()),
 		TotalTransactions: s.getTotalTransactions(),
-		PeerCount:        0, // P2P not integrated yet
+		PeerCount:        int64(peerCount),
 		IsSyncing:        false,
+		Network:          s.bc.Params.Name,
 	}, nil
 }
 
 // GetBestBlockHash returns the hash of the best (latest) block
 func (s *Server) GetBestBlockHash(ctx context.Context, req *pb.GetBestBlockHashRequest) (*pb.GetBestBlockHashResponse, error) {
-	height := s.bc.Height()
-	block, err := s.bc.GetBlock(height)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get best block: %v", err)
-	}
+	block := s.bc.GetLatestBlock()
 	
 	return &pb.GetBestBlockHashResponse{
 		Hash: fmt.Sprintf("%x", block.Hash),
@@ -190,13 +215,14 @@ func (s *Server) SubmitTransaction(ctx context.Context, req *pb.SubmitTransactio
 	// Convert proto transaction to internal type
 	transaction := s.protoToTx(req.Transaction)
 	
-	// Add to mempool
-	s.mempoolMu.Lock()
-	s.mempool = append(s.mempool, transaction)
-	s.mempoolMu.Unlock()
-	
-	// Notify subscribers
-	s.notifyTxSubscribers(transaction)
+	// Validate and add to mempool, which notifies subscribers
+	if _, _, err := s.acceptToMempool(transaction); err != nil {
+		return &pb.SubmitTransactionResponse{
+			TxId:     fmt.Sprintf("%x", transaction.ID),
+			Accepted: false,
+			Message:  fmt.Sprintf("transaction rejected: %v", err),
+		}, nil
+	}
 	
 	return &pb.SubmitTransactionResponse{
 		TxId:     fmt.Sprintf("%x", transaction.ID),
@@ -223,21 +249,24 @@ func (s *Server) GetMempool(ctx context.Context, req *pb.GetMempoolRequest) (*pb
 
 // GetUTXO returns UTXOs for an address
 func (s *Server) GetUTXO(ctx context.Context, req *pb.GetUTXORequest) (*pb.GetUTXOResponse, error) {
-	utxos, _ := s.bc.UTXOSet.GetAllUTXOs(req.Address)
+	pubKeyHash, err := crypto.DecodeAddress(req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %v", err)
+	}
 	
 	pbUtxos := make([]*pb.UTXO, 0)
 	totalValue := int64(0)
 	
-	for _, utxo := range utxos {
+	for _, utxo := range s.bc.ListUnspent(pubKeyHash) {
 		pbUtxos = append(pbUtxos, &pb.UTXO{
-			TxId: fmt.Sprintf("%x", utxo.PubKeyHash),
-			Vout: 0,
+			TxId: fmt.Sprintf("%x", utxo.TxID),
+			Vout: int32(utxo.Index),
 			Output: &pb.TxOutput{
-				Value:         int64(utxo.Value),
-				PublicKeyHash: fmt.Sprintf("%x", utxo.PubKeyHash),
+				Value:         utxo.Output.Value,
+				PublicKeyHash: fmt.Sprintf("%x", utxo.Output.PubKeyHash),
 			},
 		})
-		totalValue += int64(utxo.Value)
+		totalValue += utxo.Output.Value
 	}
 	
 	return &pb.GetUTXOResponse{
@@ -246,33 +275,193 @@ func (s *Server) GetUTXO(ctx context.Context, req *pb.GetUTXORequest) (*pb.GetUT
 	}, nil
 }
 
-// GetBalance returns the balance for an address
+// GetBalance returns the balance for an address, split into confirmed,
+// unconfirmed and immature coins
 func (s *Server) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.GetBalanceResponse, error) {
-	balance, _ := s.bc.UTXOSet.GetBalance(req.Address)
-	utxos, _ := s.bc.UTXOSet.GetAllUTXOs(req.Address)
+	pubKeyHash, err := crypto.DecodeAddress(req.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %v", err)
+	}
 	
+	view := s.utxoView()
+	balance := view.Balance(pubKeyHash)
 	return &pb.GetBalanceResponse{
-		Balance:   int64(balance),
-		UtxoCount: int32(len(utxos)),
+		Balance:     balance.Total(),
+		UtxoCount:   int32(len(view.ListUnspent(pubKeyHash))),
+		Confirmed:   balance.Confirmed,
+		Unconfirmed: balance.Unconfirmed,
+		Immature:    balance.Immature,
 	}, nil
 }
 
-// GetPeerInfo returns information about connected peers (stub for now)
+// GetAddressHistory lists the transactions paying to or spending from any
+// of the addresses, oldest first, followed by those in the mempool
+func (s *Server) GetAddressHistory(ctx context.Context, req *pb.GetAddressHistoryRequest) (*pb.GetAddressHistoryResponse, error) {
+	pubKeyHashes := make(map[string]bool)
+	for _, address := range req.Addresses {
+		pubKeyHash, err := crypto.DecodeAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s: %v", address, err)
+		}
+		pubKeyHashes[string(pubKeyHash)] = true
+	}
+	
+	// Outputs of earlier transactions, to value the inputs spending them
+	outputs := make(map[string][]tx.TxOutput)
+	entry := func(transaction *tx.Transaction) *pb.AddressTransaction {
+		var received, sent int64
+		if !transaction.IsCoinbase() {
+			for _, input := range transaction.Inputs {
+				prevOutputs := outputs[string(input.TxID)]
+				if input.OutIndex >= 0 && input.OutIndex < len(prevOutputs) &&
+					pubKeyHashes[string(prevOutputs[input.OutIndex].PubKeyHash)] {
+					sent += prevOutputs[input.OutIndex].Value
+				}
+			}
+		}
+		for _, output := range transaction.Outputs {
+			if pubKeyHashes[string(output.PubKeyHash)] {
+				received += output.Value
+			}
+		}
+		outputs[string(transaction.ID)] = transaction.Outputs
+		if received == 0 && sent == 0 {
+			return nil
+		}
+		return &pb.AddressTransaction{
+			TxId:     fmt.Sprintf("%x", transaction.ID),
+			Received: received,
+			Sent:     sent,
+		}
+	}
+	
+	resp := &pb.GetAddressHistoryResponse{Transactions: []*pb.AddressTransaction{}}
+	height := s.bc.Height()
+	for i, block := range s.bc.Snapshot() {
+		transactions, ok := block.Transactions.([]*tx.Transaction)
+		if !ok {
+			continue
+		}
+		for _, transaction := range transactions {
+			if item := entry(transaction); item != nil {
+				item.Height = int64(i)
+				item.Timestamp = timestamppb.New(block.Header.Timestamp)
+				item.Confirmations = int64(height - i)
+				resp.Transactions = append(resp.Transactions, item)
+			}
+		}
+	}
+	
+	s.mempoolMu.RLock()
+	defer s.mempoolMu.RUnlock()
+	for _, transaction := range s.mempool {
+		if item := entry(transaction); item != nil {
+			item.Height = -1
+			resp.Transactions = append(resp.Transactions, item)
+		}
+	}
+	return resp, nil
+}
+
+// GetPeerInfo returns the handshake metadata of connected peers
 func (s *Server) GetPeerInfo(ctx context.Context, req *pb.GetPeerInfoRequest) (*pb.GetPeerInfoResponse, error) {
+	peers := []*pb.PeerInfo{}
+	if s.network != nil {
+		for _, info := range s.network.GetPeerInfo() {
+			peers = append(peers, peerToProto(info))
+		}
+	}
+	
 	return &pb.GetPeerInfoResponse{
-		Peers:     []*pb.PeerInfo{},
-		PeerCount: 0,
+		Peers:     peers,
+		PeerCount: int32(len(peers)),
 	}, nil
 }
 
-// ConnectPeer connects to a new peer (stub for now)
+// ConnectPeer connects to a new peer and performs the version handshake
 func (s *Server) ConnectPeer(ctx context.Context, req *pb.ConnectPeerRequest) (*pb.ConnectPeerResponse, error) {
+	if s.network == nil {
+		return &pb.ConnectPeerResponse{
+			Success: false,
+			Message: "P2P networking is disabled on this node",
+		}, nil
+	}
+	
+	if err := s.network.ConnectToPeer(req.Multiaddr); err != nil {
+		return &pb.ConnectPeerResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+	
 	return &pb.ConnectPeerResponse{
-		Success: false,
-		Message: "P2P not integrated in this version",
+		Success: true,
+		Message: "Connected",
 	}, nil
 }
 
+// ListBanned returns the peers banned for misbehavior or by the operator
+func (s *Server) ListBanned(ctx context.Context, req *pb.ListBannedRequest) (*pb.ListBannedResponse, error) {
+	banned := []*pb.BannedPeer{}
+	if s.network != nil {
+		for _, entry := range s.network.ListBanned() {
+			banned = append(banned, banToProto(entry))
+		}
+	}
+	
+	return &pb.ListBannedResponse{Banned: banned}, nil
+}
+
+// SetBan bans a peer (disconnecting it) or lifts its ban
+func (s *Server) SetBan(ctx context.Context, req *pb.SetBanRequest) (*pb.SetBanResponse, error) {
+	if s.network == nil {
+		return &pb.SetBanResponse{
+			Success: false,
+			Message: "P2P networking is disabled on this node",
+		}, nil
+	}
+	
+	peerID, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return &pb.SetBanResponse{
+			Success: false,
+			Message: fmt.Sprintf("invalid peer ID: %v", err),
+		}, nil
+	}
+	
+	switch req.Command {
+	case "add":
+		duration := p2p.DefaultBanDuration
+		if req.Permanent {
+			duration = 0
+		} else if req.BanTimeSeconds > 0 {
+			duration = time.Duration(req.BanTimeSeconds) * time.Second
+		}
+		reason := req.Reason
+		if reason == "" {
+			reason = "banned by operator"
+		}
+		if err := s.network.Ban(peerID, duration, reason); err != nil {
+			return &pb.SetBanResponse{Success: false, Message: err.Error()}, nil
+		}
+		return &pb.SetBanResponse{Success: true, Message: "Banned"}, nil
+	case "remove":
+		removed, err := s.network.Unban(peerID)
+		if err != nil {
+			return &pb.SetBanResponse{Success: false, Message: err.Error()}, nil
+		}
+		if !removed {
+			return &pb.SetBanResponse{Success: false, Message: "Peer is not banned"}, nil
+		}
+		return &pb.SetBanResponse{Success: true, Message: "Unbanned"}, nil
+	default:
+		return &pb.SetBanResponse{
+			Success: false,
+			Message: fmt.Sprintf("unknown command %q (expected add or remove)", req.Command),
+		}, nil
+	}
+}
+
 // StartMining starts the mining process
 func (s *Server) StartMining(ctx context.Context, req *pb.StartMiningRequest) (*pb.StartMiningResponse, error) {
 	s.miningMu.Lock()
@@ -315,7 +504,7 @@ func (s *Server) GetMiningInfo(ctx context.Context, req *pb.GetMiningInfoRequest
 	return &pb.MiningInfo{
 		IsMining:          s.isMining,
 		BlocksMined:       s.blocksMined,
-		CurrentDifficulty: int64(s.bc.DifficultyTarget),
+		CurrentDifficulty: int64(s.bc.Difficulty()),
 		HashRate:          0, // TODO: calculate hash rate
 	}, nil
 }
@@ -386,44 +575,103 @@ func (s *Server) SubscribeTransactions(req *pb.SubscribeTransactionsRequest, str
 
 // Wallet service methods
 
-// CreateWallet creates a new wallet
+// CreateWallet adds a new key to a named wallet, creating the wallet if it
+// does not exist. HD wallets derive their next receiving address.
 func (s *Server) CreateWallet(ctx context.Context, req *pb.CreateWalletRequest) (*pb.Wallet, error) {
+	if err := storage.ValidateWalletName(req.Name); err != nil {
+		return nil, err
+	}
+	
+	s.walletsMu.Lock()
+	w, loaded := s.wallets[req.Name]
+	if !loaded {
+		if s.walletOnDisk(req.Name) {
+			s.walletsMu.Unlock()
+			return nil, fmt.Errorf("wallet %q exists but is not loaded; load it with LoadWallet", displayName(req.Name))
+		}
+		var err error
+		if w, err = s.openWallet(req.Name); err != nil {
+			s.walletsMu.Unlock()
+			return nil, fmt.Errorf("failed to create wallet: %v", err)
+		}
+	}
+	s.walletsMu.Unlock()
+	
+	if w.store.HasHDSeed() {
+		key, _, err := hdwallet.DeriveNext(w.store, s.bc.Params, 0, hdwallet.ExternalChain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive address: %v", err)
+		}
+		return &pb.Wallet{
+			Address:    key.Address(),
+			PublicKey:  fmt.Sprintf("%x", key.PublicKey()),
+			WalletName: w.name,
+		}, nil
+	}
+	
 	wallet, err := crypto.NewWallet()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create wallet: %v", err)
 	}
 	address := wallet.GetAddress()
-	
-	s.wallets[address] = wallet
+	if err := w.store.SaveWallet(address, wallet.PrivateKey.D.Bytes(), wallet.PublicKey); err != nil {
+		return nil, fmt.Errorf("failed to save wallet: %v", err)
+	}
 	
 	return &pb.Wallet{
-		Address:   address,
-		PublicKey: fmt.Sprintf("%x", wallet.PublicKey),
+		Address:    address,
+		PublicKey:  fmt.Sprintf("%x", wallet.PublicKey),
+		WalletName: w.name,
 	}, nil
 }
 
-// GetWallet retrieves a wallet by address
+// GetWallet retrieves a key of any loaded wallet by address; public keys
+// are readable while the wallet is locked
 func (s *Server) GetWallet(ctx context.Context, req *pb.GetWalletRequest) (*pb.Wallet, error) {
-	wallet, exists := s.wallets[req.Address]
-	if !exists {
+	w := s.walletForAddress(req.Address)
+	if w == nil {
 		return nil, fmt.Errorf("wallet not found")
 	}
 	
+	publicKey, err := w.store.GetPublicKey(req.Address)
+	if err != nil {
+		return nil, err
+	}
 	return &pb.Wallet{
-		Address:   wallet.GetAddress(),
-		PublicKey: fmt.Sprintf("%x", wallet.PublicKey),
+		Address:    req.Address,
+		PublicKey:  fmt.Sprintf("%x", publicKey),
+		WalletName: w.name,
 	}, nil
 }
 
-// ListWallets lists all wallets
+// ListWallets lists the keys of one or every loaded wallet
 func (s *Server) ListWallets(ctx context.Context, req *pb.ListWalletsRequest) (*pb.ListWalletsResponse, error) {
-	wallets := make([]*pb.Wallet, 0, len(s.wallets))
+	loaded := s.loadedWallets()
+	if req.WalletName != "" {
+		w, err := s.loadedWalletByName(req.WalletName)
+		if err != nil {
+			return nil, err
+		}
+		loaded = []*loadedWallet{w}
+	}
 	
-	for address, wallet := range s.wallets {
-		wallets = append(wallets, &pb.Wallet{
-			Address:   address,
-			PublicKey: fmt.Sprintf("%x", wallet.PublicKey),
-		})
+	wallets := make([]*pb.Wallet, 0)
+	for _, w := range loaded {
+		addresses, err := w.store.GetAllAddresses()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list wallets: %v", err)
+		}
+		for _, address := range addresses {
+			publicKey, err := w.store.GetPublicKey(address)
+			if err != nil {
+				continue
+			}
+			wallets = append(wallets, &pb.Wallet{
+				Address:    address,
+				PublicKey:  fmt.Sprintf("%x", publicKey),
+				WalletName: w.name,
+			})
+		}
 	}
 	
 	return &pb.ListWalletsResponse{
@@ -431,101 +679,188 @@ func (s *Server) ListWallets(ctx context.Context, req *pb.ListWalletsRequest) (*
 	}, nil
 }
 
-// GetWalletBalance returns the balance of a wallet
+// GetWalletBalance returns the balance of an address, or of every address
+// of a loaded wallet when no address is given
 func (s *Server) GetWalletBalance(ctx context.Context, req *pb.GetWalletBalanceRequest) (*pb.GetWalletBalanceResponse, error) {
-	balance, _ := s.bc.UTXOSet.GetBalance(req.Address)
+	addresses := []string{req.Address}
+	if req.Address == "" {
+		w, err := s.loadedWalletByName(req.WalletName)
+		if err != nil {
+			return nil, err
+		}
+		if addresses, err = w.store.GetAllAddresses(); err != nil {
+			return nil, err
+		}
+	}
+	
+	view := s.utxoView()
+	var total blockchain.Balance
+	for _, address := range addresses {
+		pubKeyHash, err := crypto.DecodeAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s: %v", address, err)
+		}
+		balance := view.Balance(pubKeyHash)
+		total.Confirmed += balance.Confirmed
+		total.Unconfirmed += balance.Unconfirmed
+		total.Immature += balance.Immature
+	}
 	
 	return &pb.GetWalletBalanceResponse{
-		Balance: int64(balance),
+		Balance:     total.Total(),
+		Confirmed:   total.Confirmed,
+		Unconfirmed: total.Unconfirmed,
+		Immature:    total.Immature,
 	}, nil
 }
 
-// SendTransaction sends coins from one address to another
+// SendTransaction sends coins from one address to another. The fee is
+// either absolute or fee_rate satoshis per byte of the signed transaction,
+// and coin_selection picks the strategy choosing the inputs.
 func (s *Server) SendTransaction(ctx context.Context, req *pb.SendTransactionRequest) (*pb.SendTransactionResponse, error) {
-	wallet, exists := s.wallets[req.FromAddress]
-	if !exists {
+	wallet, err := s.signingWallet(req.FromAddress)
+	if err != nil {
 		return &pb.SendTransactionResponse{
 			Success: false,
-			Message: "Wallet not found",
+			Message: err.Error(),
 		}, nil
 	}
 	
-	// Find spendable outputs
-	accumulated, spendableOutputs, err := s.bc.UTXOSet.FindSpendableOutputs(req.FromAddress, req.Amount)
+	strategy, err := coinselect.StrategyByName(req.CoinSelection)
 	if err != nil {
 		return &pb.SendTransactionResponse{
 			Success: false,
-			Message: fmt.Sprintf("Insufficient funds: %v", err),
+			Message: err.Error(),
 		}, nil
 	}
 	
-	// Build inputs from spendable outputs
-	var inputs []tx.TxInput
-	for txIDStr, outIndices := range spendableOutputs {
-		for _, outIdx := range outIndices {
-			inputs = append(inputs, tx.TxInput{
-				TxID:      []byte(txIDStr),
-				OutIndex:  outIdx,
-				Signature: nil,
-				PubKey:    wallet.PublicKey,
-			})
-		}
-	}
-	
-	// Decode recipient address
-	pubKeyHash, err := crypto.DecodeAddress(req.ToAddress)
+	// Choose the inputs, which may include unconfirmed change, and sign
+	// with the outputs they spend
+	view := s.utxoView()
+	fee := blockchain.FeePolicy{Fee: req.Fee, FeeRate: req.FeeRate, Replaceable: req.Replaceable}
+	transaction, selection, err := view.FundTransaction(req.FromAddress, req.ToAddress, req.Amount, wallet.PublicKey, fee, strategy)
 	if err != nil {
 		return &pb.SendTransactionResponse{
 			Success: false,
-			Message: fmt.Sprintf("Invalid recipient address: %v", err),
+			Message: err.Error(),
 		}, nil
 	}
-	
-	// Build outputs
-	var outputs []tx.TxOutput
-	
-	// Create output for recipient
-	outputs = append(outputs, tx.TxOutput{
-		Value:      req.Amount,
-		PubKeyHash: pubKeyHash,
-	})
-	
-	// Create change output if needed
-	if accumulated > req.Amount {
-		senderPubKeyHash, _ := crypto.DecodeAddress(req.FromAddress)
-		outputs = append(outputs, tx.TxOutput{
-			Value:      accumulated - req.Amount,
-			PubKeyHash: senderPubKeyHash,
-		})
+	if err := view.SignTransaction(transaction, wallet); err != nil {
+		return &pb.SendTransactionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
 	}
 	
-	// Create transaction
-	transaction := tx.NewTransaction(inputs, outputs)
-	
-	// Sign the transaction (we pass empty prevTxs map for now)
-	err = transaction.Sign(wallet, make(map[string]*tx.Transaction))
-	if err != nil {
+	// Add to mempool and notify subscribers
+	if _, _, err := s.acceptToMempool(transaction); err != nil {
 		return &pb.SendTransactionResponse{
 			Success: false,
-			Message: fmt.Sprintf("Failed to sign transaction: %v", err),
+			Message: fmt.Sprintf("transaction rejected: %v", err),
 		}, nil
 	}
 	
-	// Add to mempool
-	s.mempoolMu.Lock()
-	s.mempool = append(s.mempool, transaction)
-	s.mempoolMu.Unlock()
-	
-	// Notify subscribers
-	s.notifyTxSubscribers(transaction)
-	
 	return &pb.SendTransactionResponse{
 		TxId:    fmt.Sprintf("%x", transaction.ID),
 		Success: true,
 		Message: "Transaction submitted successfully",
+		Fee:     selection.Fee,
+	}, nil
+}
+
+// signingWallet returns the key of an address from the loaded wallet
+// holding it, which must be unlocked if it is encrypted
+func (s *Server) signingWallet(address string) (*crypto.Wallet, error) {
+	w := s.walletForAddress(address)
+	if w == nil {
+		return nil, fmt.Errorf("wallet not found")
+	}
+	
+	walletData, err := w.store.GetWallet(address)
+	if err != nil {
+		return nil, err
+	}
+	if walletData.WatchOnly {
+		return nil, fmt.Errorf("address %s is watch-only", address)
+	}
+	return crypto.WalletFromPrivateKey(walletData.PrivateKey), nil
+}
+
+// EncryptWallet protects a wallet with a passphrase and locks it
+func (s *Server) EncryptWallet(ctx context.Context, req *pb.EncryptWalletRequest) (*pb.EncryptWalletResponse, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return &pb.EncryptWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	if err := w.store.EncryptWallets(req.Passphrase); err != nil {
+		return &pb.EncryptWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.EncryptWalletResponse{
+		Success: true,
+		Message: "Wallet encrypted; unlock it with WalletPassphrase to sign transactions",
+	}, nil
+}
+
+// WalletPassphrase unlocks a wallet for timeout_seconds, after which it
+// locks again. Unlocking again replaces the timeout.
+func (s *Server) WalletPassphrase(ctx context.Context, req *pb.WalletPassphraseRequest) (*pb.WalletPassphraseResponse, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return &pb.WalletPassphraseResponse{Success: false, Message: err.Error()}, nil
+	}
+	if req.TimeoutSeconds <= 0 {
+		return &pb.WalletPassphraseResponse{Success: false, Message: "timeout_seconds must be positive"}, nil
+	}
+	timeout := time.Duration(req.TimeoutSeconds) * time.Second
+	if req.TimeoutSeconds > int64(maxUnlockTimeout/time.Second) {
+		timeout = maxUnlockTimeout
+	}
+	
+	if err := w.store.Unlock(req.Passphrase); err != nil {
+		return &pb.WalletPassphraseResponse{Success: false, Message: err.Error()}, nil
+	}
+	
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.lockTimer != nil {
+		w.lockTimer.Stop()
+	}
+	w.lockTimer = time.AfterFunc(timeout, w.lock)
+	w.unlockedUntil = time.Now().Add(timeout)
+	
+	return &pb.WalletPassphraseResponse{
+		Success:       true,
+		Message:       "Wallet unlocked",
+		UnlockedUntil: timestamppb.New(w.unlockedUntil),
 	}, nil
 }
 
+// WalletLock locks a wallet before its unlock timeout
+func (s *Server) WalletLock(ctx context.Context, req *pb.WalletLockRequest) (*pb.WalletLockResponse, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return &pb.WalletLockResponse{Success: false, Message: err.Error()}, nil
+	}
+	if !w.store.IsEncrypted() {
+		return &pb.WalletLockResponse{Success: false, Message: storage.ErrNotEncrypted.Error()}, nil
+	}
+	
+	w.lock()
+	return &pb.WalletLockResponse{Success: true, Message: "Wallet locked"}, nil
+}
+
+// WalletPassphraseChange changes the passphrase of a wallet
+func (s *Server) WalletPassphraseChange(ctx context.Context, req *pb.WalletPassphraseChangeRequest) (*pb.WalletPassphraseChangeResponse, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return &pb.WalletPassphraseChangeResponse{Success: false, Message: err.Error()}, nil
+	}
+	if err := w.store.ChangePassphrase(req.OldPassphrase, req.NewPassphrase); err != nil {
+		return &pb.WalletPassphraseChangeResponse{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.WalletPassphraseChangeResponse{Success: true, Message: "Passphrase changed"}, nil
+}
+
 // Helper methods
 
 func (s *Server) blockToProto(block *types.Block) *pb.Block {
@@ -563,6 +898,7 @@ func (s *Server) txToProto(transaction *tx.Transaction) *pb.Transaction {
 		inputs[i] = &pb.TxInput{
 			TxId:      fmt.Sprintf("%x", in.TxID),
 			Vout:      int32(in.OutIndex),
+			Sequence:  in.Sequence,
 			Signature: fmt.Sprintf("%x", in.Signature),
 			PublicKey: fmt.Sprintf("%x", in.PubKey),
 		}
@@ -584,6 +920,50 @@ func (s *Server) txToProto(transaction *tx.Transaction) *pb.Transaction {
 	}
 }
 
+// peerToProto converts handshake metadata to its protobuf form
+func peerToProto(info p2p.PeerInfo) *pb.PeerInfo {
+	status := "outbound"
+	if info.Inbound {
+		status = "inbound"
+	}
+	
+	return &pb.PeerInfo{
+		PeerId:           info.ID.String(),
+		Addresses:        []string{info.Addr},
+		ConnectionStatus: status,
+		ProtocolVersion:  info.ProtocolVersion,
+		UserAgent:        info.UserAgent,
+		Services:         uint64(info.Services),
+		StartHeight:      int64(info.StartHeight),
+		Inbound:          info.Inbound,
+		GenesisHash:      fmt.Sprintf("%x", info.GenesisHash),
+		ConnectedAt:      timestamppb.New(info.ConnectedAt),
+		BanScore:         int32(info.BanScore),
+		BytesSent:        info.BytesSent,
+		BytesReceived:    info.BytesReceived,
+		MessagesSent:     info.MessagesSent,
+		MessagesReceived: info.MessagesReceived,
+		LastSend:         timestamppb.New(info.LastSend),
+		LastReceive:      timestamppb.New(info.LastReceive),
+		PingTime:         info.PingTime.Seconds(),
+		MinPingTime:      info.MinPingTime.Seconds(),
+	}
+}
+
+// banToProto converts a ban list entry to its protobuf form
+func banToProto(entry p2p.BanEntry) *pb.BannedPeer {
+	banned := &pb.BannedPeer{
+		PeerId:    entry.PeerID.String(),
+		Reason:    entry.Reason,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+		Permanent: entry.Permanent(),
+	}
+	if !entry.Permanent() {
+		banned.BannedUntil = timestamppb.New(entry.Until)
+	}
+	return banned
+}
+
 func (s *Server) protoToTx(pbTx *pb.Transaction) *tx.Transaction {
 	inputs := make([]tx.TxInput, len(pbTx.Inputs))
 	for i, in := range pbTx.Inputs {
@@ -602,6 +982,7 @@ func (s *Server) protoToTx(pbTx *pb.Transaction) *tx.Transaction {
 		inputs[i] = tx.TxInput{
 			TxID:      txID,
 			OutIndex:  int(in.Vout),
+			Sequence:  in.Sequence,
 			Signature: signature,
 			PubKey:    pubKey,
 		}
@@ -657,16 +1038,13 @@ func (s *Server) mineBlocks() {
 			return
 		default:
 			// Get transactions from mempool
-			s.mempoolMu.Lock()
-			txs := make([]*tx.Transaction, len(s.mempool))
-			copy(txs, s.mempool)
-			s.mempool = s.mempool[:0] // Clear mempool
-			s.mempoolMu.Unlock()
+			txs := s.takeBlockTransactions()
 			
 			// Mine new block
 			block, err := s.bc.AddBlock(txs, s.minerAddress)
 			if err != nil {
 				log.Printf("Mining error: %v", err)
+				s.returnToMempool(txs)
 				time.Sleep(5 * time.Second)
 				continue
 			}
@@ -682,6 +1060,7 @@ func (s *Server) mineBlocks() {
 				
 				// Notify subscribers
 				s.notifyBlockSubscribers(block)
+				s.syncWallets()
 			}
 			
 			time.Sleep(5 * time.Second) // Mining interval
@@ -1,130 +1,147 @@
 package grpc
 
 import (
-	"testing"
+	"bytes"
 	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
 	"time"
 
 	pb "github.com/yourusername/bt/api/proto"
 	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
 	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/hdwallet"
+	"github.com/yourusername/bt/internal/p2p"
+	"github.com/yourusername/bt/internal/storage"
 	"github.com/yourusername/bt/internal/tx"
 )
 
+// testPeerID is a valid libp2p peer ID that is never dialed
+const testPeerID = "12D3KooWKHyutAuhviTbp2rqyqjD9LjgAWe8Ny6fWh2bBqa8d3JJ"
+
+// newTestBlockchain creates an in-memory mainnet chain
+func newTestBlockchain(t *testing.T) *blockchain.Blockchain {
+	bc, err := blockchain.New(&chaincfg.MainNetParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	return bc
+}
+
 func TestNewServer(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
 	if server == nil {
 		t.Fatal("Expected server to be created")
 	}
-	
+
 	if server.bc != bc {
 		t.Error("Blockchain not set correctly")
 	}
 }
 
 func TestGetBlockchainInfo(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	req := &pb.GetBlockchainInfoRequest{}
 	info, err := server.GetBlockchainInfo(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetBlockchainInfo failed: %v", err)
 	}
-	
+
 	if info.Height < 0 {
 		t.Error("Invalid blockchain height")
 	}
-	
+
 	if info.Difficulty <= 0 {
 		t.Error("Invalid difficulty")
 	}
+
+	if info.Network != chaincfg.MainNetParams.Name {
+		t.Errorf("Expected network %s, got %s", chaincfg.MainNetParams.Name, info.Network)
+	}
 }
 
 func TestGetBlockHeight(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	req := &pb.GetBlockHeightRequest{}
 	resp, err := server.GetBlockHeight(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetBlockHeight failed: %v", err)
 	}
-	
+
 	if resp.Height < 0 {
 		t.Error("Invalid height")
 	}
 }
 
 func TestGetBlockByHeight(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	// Get genesis block
 	req := &pb.GetBlockByHeightRequest{Height: 0}
 	block, err := server.GetBlockByHeight(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetBlockByHeight failed: %v", err)
 	}
-	
+
 	if block.Height != 0 {
 		t.Errorf("Expected height 0, got %d", block.Height)
 	}
-	
-	if block.Hash == "" {
-		t.Error("Block hash is empty")
+
+	if block.Hash != fmt.Sprintf("%x", bc.GenesisHash()) {
+		t.Errorf("Expected genesis hash %x, got %s", bc.GenesisHash(), block.Hash)
 	}
 }
 
 func TestGetBestBlockHash(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	req := &pb.GetBestBlockHashRequest{}
 	resp, err := server.GetBestBlockHash(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetBestBlockHash failed: %v", err)
 	}
-	
+
 	if resp.Hash == "" {
 		t.Error("Best block hash is empty")
 	}
 }
 
 func TestGetMempool(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	req := &pb.GetMempoolRequest{}
 	resp, err := server.GetMempool(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetMempool failed: %v", err)
 	}
-	
+
 	// Initially should be empty
 	if resp.Count != 0 {
 		t.Errorf("Expected empty mempool, got %d transactions", resp.Count)
@@ -132,119 +149,139 @@ func TestGetMempool(t *testing.T) {
 }
 
 func TestSubmitTransaction(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
-	// Create a test transaction
+	ctx := context.Background()
+
+	// A transaction spending outputs that do not exist is rejected
 	pbTx := &pb.Transaction{
-		Id:        "test-tx-id",
-		Timestamp: nil,
+		Id: "00",
 		Inputs: []*pb.TxInput{
 			{
-				TxId:      "prev-tx-id",
+				TxId:      "01",
 				Vout:      0,
-				Signature: "test-sig",
-				PublicKey: "test-pubkey",
+				Signature: "02",
+				PublicKey: "03",
 			},
 		},
 		Outputs: []*pb.TxOutput{
 			{
 				Value:         100,
-				PublicKeyHash: "test-hash",
+				PublicKeyHash: "04",
 			},
 		},
 	}
-	
-	req := &pb.SubmitTransactionRequest{Transaction: pbTx}
-	resp, err := server.SubmitTransaction(context.Background(), req)
-	
+	resp, err := server.SubmitTransaction(ctx, &pb.SubmitTransactionRequest{Transaction: pbTx})
+	if err != nil {
+		t.Fatalf("SubmitTransaction failed: %v", err)
+	}
+	if resp.Accepted {
+		t.Error("Accepted a transaction spending unknown outputs")
+	}
+
+	// A signed payment is accepted
+	wallet, _ := crypto.NewWallet()
+	receiver, _ := crypto.NewWallet()
+	if _, err := bc.AddBlock(nil, wallet.GetAddress()); err != nil {
+		t.Fatalf("Failed to fund the wallet: %v", err)
+	}
+	coins := bc.ListUnspent(crypto.PublicKeyHash(wallet.PublicKey))
+	spend := func(fee int64) *tx.Transaction {
+		transaction := tx.NewTransaction(
+			[]tx.TxInput{{TxID: coins[0].TxID, OutIndex: coins[0].Index, PubKey: wallet.PublicKey}},
+			[]tx.TxOutput{{Value: coins[0].Output.Value - fee, PubKeyHash: crypto.PublicKeyHash(receiver.PublicKey)}},
+		)
+		bc.SignTransaction(transaction, wallet)
+		return transaction
+	}
+
+	payment := spend(100)
+	resp, err = server.SubmitTransaction(ctx, &pb.SubmitTransactionRequest{Transaction: server.txToProto(payment)})
 	if err != nil {
 		t.Fatalf("SubmitTransaction failed: %v", err)
 	}
-	
 	if !resp.Accepted {
-		t.Error("Transaction not accepted")
+		t.Errorf("Transaction not accepted: %s", resp.Message)
+	}
+	if resp.TxId != fmt.Sprintf("%x", payment.ID) {
+		t.Errorf("TxId = %s, want %x", resp.TxId, payment.ID)
 	}
-	
-	if resp.TxId == "" {
-		t.Error("Transaction ID is empty")
+
+	// A double spend of a payment that did not opt in to replacement is
+	// rejected, even when it pays more
+	resp, _ = server.SubmitTransaction(ctx, &pb.SubmitTransactionRequest{Transaction: server.txToProto(spend(5000))})
+	if resp.Accepted {
+		t.Error("Accepted a double spend of a non-replaceable transaction")
 	}
-	
-	// Check if transaction is in mempool
-	mempoolReq := &pb.GetMempoolRequest{}
-	mempoolResp, _ := server.GetMempool(context.Background(), mempoolReq)
-	
+
+	mempoolResp, _ := server.GetMempool(ctx, &pb.GetMempoolRequest{})
 	if mempoolResp.Count != 1 {
 		t.Errorf("Expected 1 transaction in mempool, got %d", mempoolResp.Count)
 	}
 }
 
 func TestCreateWallet(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	req := &pb.CreateWalletRequest{Name: "test-wallet"}
 	wallet, err := server.CreateWallet(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("CreateWallet failed: %v", err)
 	}
-	
+
 	if wallet.Address == "" {
 		t.Error("Wallet address is empty")
 	}
-	
+
 	if wallet.PublicKey == "" {
 		t.Error("Wallet public key is empty")
 	}
 }
 
 func TestListWallets(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	// Create a few wallets
 	server.CreateWallet(context.Background(), &pb.CreateWalletRequest{Name: "wallet1"})
 	server.CreateWallet(context.Background(), &pb.CreateWalletRequest{Name: "wallet2"})
-	
+
 	req := &pb.ListWalletsRequest{}
 	resp, err := server.ListWallets(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("ListWallets failed: %v", err)
 	}
-	
+
 	if len(resp.Wallets) != 2 {
 		t.Errorf("Expected 2 wallets, got %d", len(resp.Wallets))
 	}
 }
 
 func TestGetBalance(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	// Create a wallet
 	walletResp, _ := server.CreateWallet(context.Background(), &pb.CreateWalletRequest{Name: "test"})
-	
+
 	req := &pb.GetBalanceRequest{Address: walletResp.Address}
 	resp, err := server.GetBalance(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetBalance failed: %v", err)
 	}
-	
+
 	// New wallet should have 0 balance
 	if resp.Balance != 0 {
 		t.Errorf("Expected balance 0, got %d", resp.Balance)
@@ -252,22 +289,21 @@ func TestGetBalance(t *testing.T) {
 }
 
 func TestGetUTXO(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	// Create a wallet
 	walletResp, _ := server.CreateWallet(context.Background(), &pb.CreateWalletRequest{Name: "test"})
-	
+
 	req := &pb.GetUTXORequest{Address: walletResp.Address}
 	resp, err := server.GetUTXO(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetUTXO failed: %v", err)
 	}
-	
+
 	// New wallet should have no UTXOs
 	if len(resp.Utxos) != 0 {
 		t.Errorf("Expected 0 UTXOs, got %d", len(resp.Utxos))
@@ -275,43 +311,181 @@ func TestGetUTXO(t *testing.T) {
 }
 
 func TestGetPeerInfo(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	req := &pb.GetPeerInfoRequest{}
 	resp, err := server.GetPeerInfo(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetPeerInfo failed: %v", err)
 	}
-	
+
 	// No network, should have 0 peers
 	if resp.PeerCount != 0 {
 		t.Errorf("Expected 0 peers, got %d", resp.PeerCount)
 	}
 }
 
+func TestGetPeerInfoReportsHandshake(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping P2P test in short mode")
+	}
+
+	ctx := context.Background()
+
+	bc1 := newTestBlockchain(t)
+	defer bc1.Close()
+	network1, err := p2p.NewNetwork(ctx, bc1, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create network1: %v", err)
+	}
+	defer network1.Stop()
+
+	bc2 := newTestBlockchain(t)
+	defer bc2.Close()
+	network2, err := p2p.NewNetwork(ctx, bc2, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create network2: %v", err)
+	}
+	defer network2.Stop()
+
+	server := NewServer(bc2, network2)
+
+	connectResp, err := server.ConnectPeer(ctx, &pb.ConnectPeerRequest{Multiaddr: network1.Addrs()[0]})
+	if err != nil {
+		t.Fatalf("ConnectPeer failed: %v", err)
+	}
+	if !connectResp.Success {
+		t.Fatalf("ConnectPeer refused: %s", connectResp.Message)
+	}
+
+	resp, err := server.GetPeerInfo(ctx, &pb.GetPeerInfoRequest{})
+	if err != nil {
+		t.Fatalf("GetPeerInfo failed: %v", err)
+	}
+
+	if resp.PeerCount != 1 || len(resp.Peers) != 1 {
+		t.Fatalf("Expected 1 peer, got %d", resp.PeerCount)
+	}
+
+	peer := resp.Peers[0]
+	if peer.ProtocolVersion != p2p.ProtocolVersion {
+		t.Errorf("Expected protocol version %d, got %d", p2p.ProtocolVersion, peer.ProtocolVersion)
+	}
+	if peer.UserAgent != p2p.UserAgent {
+		t.Errorf("Expected user agent %s, got %s", p2p.UserAgent, peer.UserAgent)
+	}
+	if peer.Inbound {
+		t.Error("Dialed peer reported as inbound")
+	}
+	if peer.GenesisHash != fmt.Sprintf("%x", bc1.GenesisHash()) {
+		t.Errorf("Unexpected genesis hash %s", peer.GenesisHash)
+	}
+	if p2p.ServiceFlag(peer.Services)&p2p.ServiceNodeNetwork == 0 {
+		t.Error("Peer does not advertise ServiceNodeNetwork")
+	}
+}
+
+func TestConnectPeerWithoutNetwork(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+
+	resp, err := server.ConnectPeer(context.Background(), &pb.ConnectPeerRequest{Multiaddr: "/ip4/127.0.0.1/tcp/1"})
+	if err != nil {
+		t.Fatalf("ConnectPeer failed: %v", err)
+	}
+
+	if resp.Success {
+		t.Error("ConnectPeer succeeded without a P2P network")
+	}
+}
+
+func TestSetBanWithoutNetwork(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+
+	resp, err := server.SetBan(context.Background(), &pb.SetBanRequest{PeerId: testPeerID, Command: "add"})
+	if err != nil {
+		t.Fatalf("SetBan failed: %v", err)
+	}
+	if resp.Success {
+		t.Error("SetBan succeeded without a P2P network")
+	}
+}
+
+func TestListBannedAndSetBan(t *testing.T) {
+	ctx := context.Background()
+
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+	network, err := p2p.NewNetwork(ctx, bc, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create network: %v", err)
+	}
+	defer network.Stop()
+
+	server := NewServer(bc, network)
+
+	resp, err := server.SetBan(ctx, &pb.SetBanRequest{PeerId: testPeerID, Command: "add", BanTimeSeconds: 3600, Reason: "spam"})
+	if err != nil || !resp.Success {
+		t.Fatalf("SetBan add failed: %v %v", err, resp)
+	}
+	if resp, _ := server.SetBan(ctx, &pb.SetBanRequest{PeerId: "not-a-peer", Command: "add"}); resp.Success {
+		t.Error("Banned an invalid peer ID")
+	}
+	if resp, _ := server.SetBan(ctx, &pb.SetBanRequest{PeerId: testPeerID, Command: "clear"}); resp.Success {
+		t.Error("Accepted an unknown command")
+	}
+
+	list, err := server.ListBanned(ctx, &pb.ListBannedRequest{})
+	if err != nil {
+		t.Fatalf("ListBanned failed: %v", err)
+	}
+	if len(list.Banned) != 1 {
+		t.Fatalf("Expected 1 ban, got %d", len(list.Banned))
+	}
+	banned := list.Banned[0]
+	if banned.PeerId != testPeerID || banned.Reason != "spam" || banned.Permanent {
+		t.Errorf("Unexpected ban %+v", banned)
+	}
+	if until := banned.BannedUntil.AsTime(); until.Sub(banned.CreatedAt.AsTime()) != time.Hour {
+		t.Errorf("Expected a one hour ban, got until %v", until)
+	}
+
+	resp, err = server.SetBan(ctx, &pb.SetBanRequest{PeerId: testPeerID, Command: "remove"})
+	if err != nil || !resp.Success {
+		t.Fatalf("SetBan remove failed: %v %v", err, resp)
+	}
+	list, _ = server.ListBanned(ctx, &pb.ListBannedRequest{})
+	if len(list.Banned) != 0 {
+		t.Errorf("Expected no bans after removal, got %d", len(list.Banned))
+	}
+}
+
 func TestGetMiningInfo(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	req := &pb.GetMiningInfoRequest{}
 	info, err := server.GetMiningInfo(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("GetMiningInfo failed: %v", err)
 	}
-	
+
 	if info.IsMining {
 		t.Error("Mining should not be active initially")
 	}
-	
+
 	if info.CurrentDifficulty <= 0 {
 		t.Error("Invalid difficulty")
 	}
@@ -321,84 +495,83 @@ func TestStartStopMining(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping mining test in short mode")
 	}
-	
-	bc := blockchain.NewBlockchain("")
+
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	// Create a miner wallet
 	wallet, _ := crypto.NewWallet()
 	minerAddress := wallet.GetAddress()
-	
+
 	// Start mining
 	startReq := &pb.StartMiningRequest{MinerAddress: minerAddress}
 	startResp, err := server.StartMining(context.Background(), startReq)
-	
+
 	if err != nil {
 		t.Fatalf("StartMining failed: %v", err)
 	}
-	
+
 	if !startResp.Success {
 		t.Error("Mining did not start successfully")
 	}
-	
+
 	// Wait a bit for mining to start
 	time.Sleep(100 * time.Millisecond)
-	
+
 	// Check mining info
 	infoReq := &pb.GetMiningInfoRequest{}
 	info, _ := server.GetMiningInfo(context.Background(), infoReq)
-	
+
 	if !info.IsMining {
 		t.Error("Mining should be active")
 	}
-	
+
 	// Stop mining
 	stopReq := &pb.StopMiningRequest{}
 	stopResp, err := server.StopMining(context.Background(), stopReq)
-	
+
 	if err != nil {
 		t.Fatalf("StopMining failed: %v", err)
 	}
-	
+
 	if !stopResp.Success {
 		t.Error("Mining did not stop successfully")
 	}
-	
+
 	// Verify mining stopped
 	time.Sleep(100 * time.Millisecond)
 	info, _ = server.GetMiningInfo(context.Background(), infoReq)
-	
+
 	if info.IsMining {
 		t.Error("Mining should be stopped")
 	}
 }
 
 func TestSendTransaction(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	// Create sender and receiver wallets
 	senderResp, _ := server.CreateWallet(context.Background(), &pb.CreateWalletRequest{Name: "sender"})
 	receiverResp, _ := server.CreateWallet(context.Background(), &pb.CreateWalletRequest{Name: "receiver"})
-	
+
 	// Try to send transaction (will fail due to insufficient funds)
 	req := &pb.SendTransactionRequest{
 		FromAddress: senderResp.Address,
 		ToAddress:   receiverResp.Address,
 		Amount:      100,
 	}
-	
+
 	resp, err := server.SendTransaction(context.Background(), req)
-	
+
 	if err != nil {
 		t.Fatalf("SendTransaction failed: %v", err)
 	}
-	
+
 	// Should fail due to insufficient funds
 	if resp.Success {
 		t.Error("Transaction should fail due to insufficient funds")
@@ -406,68 +579,862 @@ func TestSendTransaction(t *testing.T) {
 }
 
 func TestBlockToProto(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	// Get genesis block
-	block, _ := bc.GetBlockByHeight(0)
-	
+	block, _ := bc.GetBlock(0)
+
 	pbBlock := server.blockToProto(block)
-	
+
 	if pbBlock.Hash == "" {
 		t.Error("Block hash is empty")
 	}
-	
-	if pbBlock.Height != int64(block.Height) {
-		t.Error("Block height mismatch")
+
+	if pbBlock.Height != 0 {
+		t.Errorf("Expected height 0, got %d", pbBlock.Height)
 	}
-	
-	if pbBlock.PreviousHash != block.PrevHash {
+
+	if pbBlock.PreviousHash != fmt.Sprintf("%x", block.Header.PrevBlockHash) {
 		t.Error("Previous hash mismatch")
 	}
 }
 
 func TestTxToProto(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc := newTestBlockchain(t)
 	defer bc.Close()
-	
+
 	server := NewServer(bc, nil)
-	
+
 	// Create a test transaction
-	transaction := &tx.Transaction{
-		ID:        "test-tx",
-		Timestamp: time.Now().Unix(),
-		Inputs: []tx.TxInput{
+	transaction := tx.NewTransaction(
+		[]tx.TxInput{
 			{
-				TxID:      "prev-tx",
-				Vout:      0,
-				Signature: "sig",
-				PublicKey: "pubkey",
+				TxID:      []byte("prev-tx"),
+				OutIndex:  0,
+				Signature: []byte("sig"),
+				PubKey:    []byte("pubkey"),
 			},
 		},
-		Outputs: []tx.TxOutput{
+		[]tx.TxOutput{
 			{
-				Value:         100,
-				PublicKeyHash: "hash",
+				Value:      100,
+				PubKeyHash: []byte("hash"),
 			},
 		},
-	}
-	
+	)
+
 	pbTx := server.txToProto(transaction)
-	
-	if pbTx.Id != transaction.ID {
+
+	if pbTx.Id != fmt.Sprintf("%x", transaction.ID) {
 		t.Error("Transaction ID mismatch")
 	}
-	
+
 	if len(pbTx.Inputs) != len(transaction.Inputs) {
 		t.Error("Input count mismatch")
 	}
-	
+
 	if len(pbTx.Outputs) != len(transaction.Outputs) {
 		t.Error("Output count mismatch")
 	}
 }
+
+func TestWalletPassphrase(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	if err := server.SetWalletDir(t.TempDir()); err != nil {
+		t.Fatalf("SetWalletDir failed: %v", err)
+	}
+	defer server.Stop()
+	ctx := context.Background()
+
+	sender, err := server.CreateWallet(ctx, &pb.CreateWalletRequest{})
+	if err != nil {
+		t.Fatalf("CreateWallet failed: %v", err)
+	}
+	ws := server.wallets[""].store
+	if !ws.WalletExists(sender.Address) {
+		t.Fatal("Wallet was not persisted")
+	}
+
+	if resp, _ := server.WalletLock(ctx, &pb.WalletLockRequest{}); resp.Success {
+		t.Error("Locked a wallet that is not encrypted")
+	}
+	if resp, _ := server.EncryptWallet(ctx, &pb.EncryptWalletRequest{Passphrase: "secret"}); !resp.Success {
+		t.Fatalf("EncryptWallet failed: %s", resp.Message)
+	}
+
+	// Signing and new keys need the passphrase
+	send := &pb.SendTransactionRequest{FromAddress: sender.Address, ToAddress: sender.Address, Amount: 1}
+	if resp, _ := server.SendTransaction(ctx, send); resp.Success || resp.Message != storage.ErrWalletLocked.Error() {
+		t.Errorf("SendTransaction while locked: %q", resp.Message)
+	}
+	if _, err := server.CreateWallet(ctx, &pb.CreateWalletRequest{}); err == nil {
+		t.Error("Created a wallet while locked")
+	}
+	if wallet, err := server.GetWallet(ctx, &pb.GetWalletRequest{Address: sender.Address}); err != nil || wallet.PublicKey != sender.PublicKey {
+		t.Errorf("GetWallet while locked: %v", err)
+	}
+
+	if resp, _ := server.WalletPassphrase(ctx, &pb.WalletPassphraseRequest{Passphrase: "wrong", TimeoutSeconds: 60}); resp.Success {
+		t.Error("Unlocked with a wrong passphrase")
+	}
+	resp, _ := server.WalletPassphrase(ctx, &pb.WalletPassphraseRequest{Passphrase: "secret", TimeoutSeconds: 1})
+	if !resp.Success || resp.UnlockedUntil == nil {
+		t.Fatalf("WalletPassphrase failed: %s", resp.Message)
+	}
+
+	// Unlocked, the transaction gets as far as coin selection
+	if resp, _ := server.SendTransaction(ctx, send); resp.Message == storage.ErrWalletLocked.Error() {
+		t.Error("SendTransaction still locked after WalletPassphrase")
+	}
+
+	// The wallet locks itself after the timeout
+	deadline := time.Now().Add(5 * time.Second)
+	for !ws.IsLocked() {
+		if time.Now().After(deadline) {
+			t.Fatal("Wallet did not lock after the timeout")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	change := &pb.WalletPassphraseChangeRequest{OldPassphrase: "secret", NewPassphrase: "better"}
+	if resp, _ := server.WalletPassphraseChange(ctx, change); !resp.Success {
+		t.Fatalf("WalletPassphraseChange failed: %s", resp.Message)
+	}
+	server.WalletPassphrase(ctx, &pb.WalletPassphraseRequest{Passphrase: "better", TimeoutSeconds: 60})
+	if ws.IsLocked() {
+		t.Error("New passphrase did not unlock")
+	}
+	if resp, _ := server.WalletLock(ctx, &pb.WalletLockRequest{}); !resp.Success || !ws.IsLocked() {
+		t.Errorf("WalletLock failed: %s", resp.Message)
+	}
+}
+
+func TestNamedWallets(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	walletDir := t.TempDir()
+	server := NewServer(bc, nil)
+	if err := server.SetWalletDir(walletDir); err != nil {
+		t.Fatalf("SetWalletDir failed: %v", err)
+	}
+	ctx := context.Background()
+
+	savings, err := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "savings"})
+	if err != nil {
+		t.Fatalf("CreateWallet failed: %v", err)
+	}
+	if savings.WalletName != "savings" {
+		t.Errorf("Key created in wallet %q, want savings", savings.WalletName)
+	}
+	server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "savings"})
+	server.CreateWallet(ctx, &pb.CreateWalletRequest{})
+	if _, err := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "../escape"}); err == nil {
+		t.Error("Created a wallet with an invalid name")
+	}
+
+	resp, err := server.ListWallets(ctx, &pb.ListWalletsRequest{WalletName: "savings"})
+	if err != nil || len(resp.Wallets) != 2 {
+		t.Fatalf("ListWallets of savings = %v, %v", resp, err)
+	}
+	if resp, _ := server.ListWallets(ctx, &pb.ListWalletsRequest{}); len(resp.Wallets) != 3 {
+		t.Errorf("ListWallets of all wallets returned %d keys, want 3", len(resp.Wallets))
+	}
+
+	// Passphrases apply to one wallet
+	server.EncryptWallet(ctx, &pb.EncryptWalletRequest{Passphrase: "secret", WalletName: "savings"})
+	if !server.wallets["savings"].store.IsLocked() || server.wallets[""].store.IsEncrypted() {
+		t.Error("EncryptWallet did not apply to the named wallet only")
+	}
+
+	// Unloaded wallets keep their keys on disk but cannot be used
+	if resp, _ := server.UnloadWallet(ctx, &pb.UnloadWalletRequest{Name: "savings"}); !resp.Success {
+		t.Fatalf("UnloadWallet failed: %s", resp.Message)
+	}
+	if _, err := server.GetWallet(ctx, &pb.GetWalletRequest{Address: savings.Address}); err == nil {
+		t.Error("Found a key of an unloaded wallet")
+	}
+	if _, err := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "savings"}); err == nil {
+		t.Error("Created a key in an unloaded wallet")
+	}
+	dir, _ := server.ListWalletDir(ctx, &pb.ListWalletDirRequest{})
+	if len(dir.Wallets) != 2 || !dir.Wallets[0].Loaded || dir.Wallets[1].Name != "savings" || dir.Wallets[1].Loaded {
+		t.Errorf("ListWalletDir = %v", dir.Wallets)
+	}
+
+	// Wallets survive a restart
+	server.Stop()
+	restarted := NewServer(bc, nil)
+	defer restarted.Stop()
+	restarted.SetWalletDir(walletDir)
+	if resp, _ := restarted.LoadWallet(ctx, &pb.LoadWalletRequest{Name: "savings"}); !resp.Success {
+		t.Fatalf("LoadWallet failed: %s", resp.Message)
+	}
+	if resp, _ := restarted.LoadWallet(ctx, &pb.LoadWalletRequest{Name: "missing"}); resp.Success {
+		t.Error("Loaded a wallet that does not exist")
+	}
+	wallet, err := restarted.GetWallet(ctx, &pb.GetWalletRequest{Address: savings.Address})
+	if err != nil || wallet.WalletName != "savings" || wallet.PublicKey != savings.PublicKey {
+		t.Errorf("GetWallet after restart = %v, %v", wallet, err)
+	}
+	dir, _ = restarted.ListWalletDir(ctx, &pb.ListWalletDirRequest{})
+	if !dir.Wallets[1].Encrypted || !dir.Wallets[1].Locked || dir.Wallets[1].KeyCount != 2 {
+		t.Errorf("Reloaded wallet info = %v", dir.Wallets[1])
+	}
+}
+
+func TestSendTransactionWithFee(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	ctx := context.Background()
+	sender, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{})
+	receiver, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{})
+	miner, _ := crypto.NewWallet()
+	if _, err := bc.AddBlock(nil, sender.Address); err != nil {
+		t.Fatalf("Failed to fund sender: %v", err)
+	}
+
+	resp, err := server.SendTransaction(ctx, &pb.SendTransactionRequest{
+		FromAddress: sender.Address,
+		ToAddress:   receiver.Address,
+		Amount:      1000,
+		FeeRate:     2,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("SendTransaction failed: %v %s", err, resp.Message)
+	}
+	transaction := server.mempool[0]
+	if serialized, _ := transaction.Serialize(); resp.Fee < int64(len(serialized))*2 {
+		t.Errorf("Fee %d does not cover %d bytes at 2 sat/byte", resp.Fee, len(serialized))
+	}
+	if fee, err := bc.TransactionFee(transaction); err != nil || fee != resp.Fee {
+		t.Errorf("TransactionFee = %d, %v; want %d", fee, err, resp.Fee)
+	}
+
+	// The transaction is signed correctly and its fee goes to the miner
+	block, err := bc.AddBlock(server.mempool, miner.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine the transaction: %v", err)
+	}
+	coinbase := block.Transactions.([]*tx.Transaction)[0]
+	if coinbase.Outputs[0].Value != bc.Params.BlockReward+resp.Fee {
+		t.Errorf("Miner got %d", coinbase.Outputs[0].Value)
+	}
+	server.mempool = nil
+
+	history, err := server.GetAddressHistory(ctx, &pb.GetAddressHistoryRequest{Addresses: []string{sender.Address}})
+	if err != nil || len(history.Transactions) != 2 {
+		t.Fatalf("GetAddressHistory = %v, %v", history, err)
+	}
+	funding, payment := history.Transactions[0], history.Transactions[1]
+	if funding.Received != bc.Params.BlockReward || funding.Height != 1 || funding.Confirmations != 2 {
+		t.Errorf("Unexpected funding entry %v", funding)
+	}
+	if payment.Sent != bc.Params.BlockReward || payment.Received != bc.Params.BlockReward-1000-resp.Fee || payment.Confirmations != 1 {
+		t.Errorf("Unexpected payment entry %v", payment)
+	}
+
+	strategy := &pb.SendTransactionRequest{FromAddress: sender.Address, ToAddress: receiver.Address, Amount: 1, CoinSelection: "random"}
+	if resp, _ := server.SendTransaction(ctx, strategy); resp.Success {
+		t.Error("Accepted an unknown coin selection strategy")
+	}
+
+	utxos, err := server.GetUTXO(ctx, &pb.GetUTXORequest{Address: sender.Address})
+	if err != nil || len(utxos.Utxos) != 1 || utxos.Utxos[0].Vout != 1 || utxos.Utxos[0].TxId != payment.TxId {
+		t.Errorf("GetUTXO = %v, %v", utxos, err)
+	}
+}
+
+func TestWalletKeyRPCs(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	ctx := context.Background()
+
+	key, _ := crypto.NewWallet()
+	imported, err := server.ImportPrivateKey(ctx, &pb.ImportPrivateKeyRequest{PrivateKey: crypto.PrivateKeyToHex(key.PrivateKey)})
+	if err != nil || imported.Address != key.GetAddress() {
+		t.Fatalf("ImportPrivateKey = %v, %v", imported, err)
+	}
+	if _, err := server.ImportPrivateKey(ctx, &pb.ImportPrivateKeyRequest{PrivateKey: crypto.PrivateKeyToHex(key.PrivateKey)}); err == nil {
+		t.Error("Imported the same key twice")
+	}
+	if _, err := server.ImportPrivateKey(ctx, &pb.ImportPrivateKeyRequest{PrivateKey: "00"}); err == nil {
+		t.Error("Imported an invalid key")
+	}
+
+	if _, err := server.DumpPrivateKey(ctx, &pb.DumpPrivateKeyRequest{Address: key.GetAddress()}); err == nil {
+		t.Error("Dumped a private key without confirmation")
+	}
+	dumped, err := server.DumpPrivateKey(ctx, &pb.DumpPrivateKeyRequest{Address: key.GetAddress(), Confirm: true})
+	if err != nil || dumped.PrivateKey != crypto.PrivateKeyToHex(key.PrivateKey) {
+		t.Errorf("DumpPrivateKey = %v, %v", dumped, err)
+	}
+
+	signed, err := server.SignMessage(ctx, &pb.SignMessageRequest{Address: key.GetAddress(), Message: "hello"})
+	if err != nil {
+		t.Fatalf("SignMessage failed: %v", err)
+	}
+	if ok, err := crypto.VerifyMessage(key.GetAddress(), "hello", signed.Signature); !ok || err != nil {
+		t.Errorf("Signature does not verify: %v", err)
+	}
+
+	destination := t.TempDir() + "/backup"
+	if resp, _ := server.BackupWallet(ctx, &pb.BackupWalletRequest{Destination: destination}); !resp.Success {
+		t.Fatalf("BackupWallet failed: %s", resp.Message)
+	}
+	backup, err := storage.NewWalletStorage(destination)
+	if err != nil {
+		t.Fatalf("Failed to open backup: %v", err)
+	}
+	defer backup.Close()
+	if !backup.WalletExists(key.GetAddress()) {
+		t.Error("Backup is missing the imported key")
+	}
+	if resp, _ := server.BackupWallet(ctx, &pb.BackupWalletRequest{Destination: "relative"}); resp.Success {
+		t.Error("Backed up to a relative path")
+	}
+}
+
+func TestWatchOnlyWallet(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	ctx := context.Background()
+	cold, _ := crypto.NewWallet()
+	receiver, _ := crypto.NewWallet()
+	if _, err := bc.AddBlock(nil, cold.GetAddress()); err != nil {
+		t.Fatalf("Failed to fund cold address: %v", err)
+	}
+
+	if resp, _ := server.CreateWatchOnlyWallet(ctx, &pb.CreateWatchOnlyWalletRequest{Name: "cold"}); !resp.Success {
+		t.Fatalf("CreateWatchOnlyWallet failed: %s", resp.Message)
+	}
+	if resp, _ := server.CreateWatchOnlyWallet(ctx, &pb.CreateWatchOnlyWalletRequest{Name: "cold"}); resp.Success {
+		t.Error("Created the same wallet twice")
+	}
+	if _, err := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "cold"}); err == nil {
+		t.Error("Created a key in a watch-only wallet")
+	}
+
+	imported, _ := server.ImportAddress(ctx, &pb.ImportAddressRequest{
+		WalletName: "cold",
+		PublicKey:  fmt.Sprintf("%x", cold.PublicKey),
+		Rescan:     true,
+	})
+	if !imported.Success || len(imported.Addresses) != 1 || imported.Addresses[0] != cold.GetAddress() {
+		t.Fatalf("ImportAddress = %v", imported)
+	}
+	if resp, _ := server.ImportAddress(ctx, &pb.ImportAddressRequest{WalletName: "cold", Address: cold.GetAddress()}); resp.Success {
+		t.Error("Imported the same address twice")
+	}
+	if resp, _ := server.ImportAddress(ctx, &pb.ImportAddressRequest{WalletName: "cold", Address: receiver.GetAddress(), PublicKey: fmt.Sprintf("%x", cold.PublicKey)}); resp.Success {
+		t.Error("Imported a public key of another address")
+	}
+
+	listed, err := server.ListTransactions(ctx, &pb.ListTransactionsRequest{WalletName: "cold"})
+	if err != nil || len(listed.Transactions) != 1 || listed.Transactions[0].Received != bc.Params.BlockReward {
+		t.Fatalf("ListTransactions = %v, %v", listed, err)
+	}
+
+	// The node cannot sign for the address but builds the transaction
+	send := &pb.SendTransactionRequest{FromAddress: cold.GetAddress(), ToAddress: receiver.GetAddress(), Amount: 1000, Fee: 100}
+	if resp, _ := server.SendTransaction(ctx, send); resp.Success {
+		t.Error("Sent from a watch-only address")
+	}
+	unsigned, _ := server.CreateUnsignedTransaction(ctx, &pb.CreateUnsignedTransactionRequest{
+		FromAddress: cold.GetAddress(),
+		ToAddress:   receiver.GetAddress(),
+		Amount:      1000,
+		Fee:         100,
+	})
+	if !unsigned.Success || unsigned.Fee != 100 || len(unsigned.Inputs) != 1 || unsigned.Inputs[0].Value != bc.Params.BlockReward {
+		t.Fatalf("CreateUnsignedTransaction = %v", unsigned)
+	}
+
+	// Signed offline, it is valid
+	raw, _ := hex.DecodeString(unsigned.RawTransaction)
+	transaction, err := tx.DeserializeTransaction(raw)
+	if err != nil {
+		t.Fatalf("Failed to decode unsigned transaction: %v", err)
+	}
+	if err := bc.SignTransaction(transaction, cold); err != nil || !bc.VerifyTransaction(transaction) {
+		t.Fatalf("Offline signature invalid: %v", err)
+	}
+	if _, err := bc.AddBlock([]*tx.Transaction{transaction}, receiver.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine the transaction: %v", err)
+	}
+
+	listed, _ = server.ListTransactions(ctx, &pb.ListTransactionsRequest{WalletName: "cold"})
+	if len(listed.Transactions) != 2 || listed.Transactions[1].Sent != bc.Params.BlockReward || listed.Transactions[1].Confirmations != 1 {
+		t.Errorf("Unexpected transactions after spending %v", listed.Transactions)
+	}
+
+	rescanned, _ := server.RescanWallet(ctx, &pb.RescanWalletRequest{WalletName: "cold", StartHeight: 2})
+	if !rescanned.Success || rescanned.Transactions != 1 || rescanned.ScannedHeight != 2 {
+		t.Errorf("RescanWallet = %v", rescanned)
+	}
+
+	dir, _ := server.ListWalletDir(ctx, &pb.ListWalletDirRequest{})
+	for _, info := range dir.Wallets {
+		if info.Name == "cold" && (!info.WatchOnly || info.KeyCount != 1) {
+			t.Errorf("Unexpected wallet info %v", info)
+		}
+	}
+}
+
+func TestImportXPub(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	ctx := context.Background()
+	master, _ := hdwallet.NewMaster(hdwallet.NewSeed("watch only test", ""), bc.Params)
+	account, _ := master.Derive(hdwallet.AccountPath(bc.Params.HDCoinType, 0))
+	receive := func(index uint32) string {
+		key, _ := account.Derive(hdwallet.Path{hdwallet.ExternalChain, index})
+		return key.Address()
+	}
+
+	bc.AddBlock(nil, receive(2))
+	server.CreateWatchOnlyWallet(ctx, &pb.CreateWatchOnlyWalletRequest{Name: "hd"})
+	if resp, _ := server.ImportXPub(ctx, &pb.ImportXPubRequest{WalletName: "hd", Xpub: master.String()}); resp.Success {
+		t.Error("Imported a private extended key")
+	}
+	resp, _ := server.ImportXPub(ctx, &pb.ImportXPubRequest{WalletName: "hd", Xpub: account.Neuter().String(), GapLimit: 3, Rescan: true})
+	// Receive addresses 0-5 and change addresses 0-2
+	if !resp.Success || len(resp.Addresses) != 9 {
+		t.Fatalf("ImportXPub = %v", resp)
+	}
+
+	// Using the last watched address extends the lookahead
+	bc.AddBlock(nil, receive(5))
+	listed, _ := server.ListTransactions(ctx, &pb.ListTransactionsRequest{WalletName: "hd"})
+	if len(listed.Transactions) != 2 {
+		t.Errorf("Expected 2 transactions, got %v", listed.Transactions)
+	}
+	watched, _ := server.ListWallets(ctx, &pb.ListWalletsRequest{WalletName: "hd"})
+	if len(watched.Wallets) != 12 {
+		t.Errorf("Expected 12 watched addresses, got %d", len(watched.Wallets))
+	}
+}
+
+func TestPSBTRPCs(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	ctx := context.Background()
+	alice, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "alice"})
+	bob, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "bob"})
+	receiver, _ := crypto.NewWallet()
+	bc.AddBlock(nil, alice.Address)
+	bc.AddBlock(nil, bob.Address)
+
+	// Alice and Bob each pay half of one transaction
+	var inputs []tx.TxInput
+	for _, address := range []string{alice.Address, bob.Address} {
+		pubKeyHash, _ := crypto.DecodeAddress(address)
+		unspent := bc.ListUnspent(pubKeyHash)[0]
+		inputs = append(inputs, tx.TxInput{TxID: unspent.TxID, OutIndex: unspent.Index})
+	}
+	output := tx.TxOutput{Value: 2*bc.Params.BlockReward - 1000}
+	output.Lock(receiver.GetAddress())
+	raw, _ := tx.NewTransaction(inputs, []tx.TxOutput{output}).Serialize()
+
+	created, err := server.CreatePSBT(ctx, &pb.CreatePSBTRequest{RawTransaction: hex.EncodeToString(raw)})
+	if err != nil || created.Fee != 1000 || created.Complete {
+		t.Fatalf("CreatePSBT = %v, %v", created, err)
+	}
+
+	aliceSigned, err := server.SignPSBT(ctx, &pb.SignPSBTRequest{Psbt: created.Psbt, WalletName: "alice"})
+	if err != nil || aliceSigned.SignedInputs != 1 {
+		t.Fatalf("SignPSBT by alice = %v, %v", aliceSigned, err)
+	}
+	bobSigned, _ := server.SignPSBT(ctx, &pb.SignPSBTRequest{Psbt: created.Psbt, WalletName: "bob"})
+	if bobSigned.SignedInputs != 1 {
+		t.Fatalf("Bob signed %d inputs", bobSigned.SignedInputs)
+	}
+
+	if finalized, _ := server.FinalizePSBT(ctx, &pb.PSBTRequest{Psbt: aliceSigned.Psbt}); finalized.Complete {
+		t.Error("Finalized a half-signed transaction")
+	}
+	if _, err := server.ExtractPSBT(ctx, &pb.ExtractPSBTRequest{Psbt: aliceSigned.Psbt}); err == nil {
+		t.Error("Extracted a half-signed transaction")
+	}
+
+	combined, err := server.CombinePSBT(ctx, &pb.CombinePSBTRequest{Psbts: []string{aliceSigned.Psbt, bobSigned.Psbt}})
+	if err != nil {
+		t.Fatalf("CombinePSBT failed: %v", err)
+	}
+	finalized, _ := server.FinalizePSBT(ctx, &pb.PSBTRequest{Psbt: combined.Psbt})
+	if !finalized.Complete {
+		t.Fatal("Combined transaction is not complete")
+	}
+	extracted, err := server.ExtractPSBT(ctx, &pb.ExtractPSBTRequest{Psbt: finalized.Psbt, Broadcast: true})
+	if err != nil || len(server.mempool) != 1 || extracted.TxId != fmt.Sprintf("%x", server.mempool[0].ID) {
+		t.Fatalf("ExtractPSBT = %v, %v", extracted, err)
+	}
+	if _, err := bc.AddBlock(server.mempool, receiver.GetAddress()); err != nil {
+		t.Errorf("Failed to mine the transaction: %v", err)
+	}
+
+	// Only addresses of loaded wallets fund new packets
+	if funded, err := server.CreatePSBT(ctx, &pb.CreatePSBTRequest{FromAddress: receiver.GetAddress(), ToAddress: alice.Address, Amount: 1000}); err == nil {
+		t.Errorf("Funded from an address no wallet holds: %v", funded)
+	}
+}
+
+func TestTransactionHistory(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	ctx := context.Background()
+	owner, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{})
+	outsider, _ := crypto.NewWallet()
+	if _, err := bc.AddBlock(nil, owner.Address); err != nil {
+		t.Fatalf("Failed to fund wallet: %v", err)
+	}
+
+	resp, err := server.SendTransaction(ctx, &pb.SendTransactionRequest{
+		FromAddress: owner.Address,
+		ToAddress:   outsider.GetAddress(),
+		Amount:      1000,
+		Fee:         100,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("SendTransaction failed: %v %s", err, resp.Message)
+	}
+
+	// The payment is listed while it waits in the mempool
+	listed, err := server.ListTransactions(ctx, &pb.ListTransactionsRequest{})
+	if err != nil || listed.Total != 2 || len(listed.Transactions) != 2 {
+		t.Fatalf("ListTransactions = %v, %v", listed, err)
+	}
+	reward, payment := listed.Transactions[0], listed.Transactions[1]
+	if reward.Category != "coinbase" || reward.Amount != bc.Params.BlockReward || reward.Confirmations != 1 {
+		t.Errorf("Unexpected reward entry %v", reward)
+	}
+	if payment.Category != "send" || payment.Height != -1 || payment.Confirmations != 0 {
+		t.Errorf("Unexpected pending payment %v", payment)
+	}
+	if payment.Amount != -1100 || payment.Fee != 100 || len(payment.Addresses) != 1 || payment.Addresses[0] != outsider.GetAddress() {
+		t.Errorf("Unexpected payment details %v", payment)
+	}
+
+	// Mined, the outsider pays back from its reward
+	if _, err := bc.AddBlock(server.mempool, outsider.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine the payment: %v", err)
+	}
+	server.mempool = nil
+	refund, err := bc.CreateTransaction(outsider.GetAddress(), owner.Address, 500, outsider)
+	if err != nil {
+		t.Fatalf("Failed to create refund: %v", err)
+	}
+	if _, err := bc.AddBlock([]*tx.Transaction{refund}, outsider.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine the refund: %v", err)
+	}
+
+	// Pages count back from the newest transaction
+	page, _ := server.ListTransactions(ctx, &pb.ListTransactionsRequest{Count: 2})
+	if page.Total != 3 || len(page.Transactions) != 2 || page.Transactions[0].TxId != payment.TxId {
+		t.Fatalf("Unexpected newest page %v", page)
+	}
+	mined, received := page.Transactions[0], page.Transactions[1]
+	if mined.Height != 2 || mined.Confirmations != 2 || mined.BlockHash != fmt.Sprintf("%x", bc.Blocks[2].Hash) || mined.Time == 0 {
+		t.Errorf("Unexpected mined payment %v", mined)
+	}
+	if received.Category != "receive" || received.Amount != 500 || received.Fee != 0 || len(received.Addresses) != 1 || received.Addresses[0] != outsider.GetAddress() {
+		t.Errorf("Unexpected refund entry %v", received)
+	}
+	if page, _ := server.ListTransactions(ctx, &pb.ListTransactionsRequest{Count: 2, Skip: 2}); len(page.Transactions) != 1 || page.Transactions[0].TxId != reward.TxId {
+		t.Errorf("Unexpected oldest page %v", page)
+	}
+	if page, _ := server.ListTransactions(ctx, &pb.ListTransactionsRequest{Skip: 5}); len(page.Transactions) != 0 {
+		t.Errorf("Expected an empty page, got %v", page)
+	}
+	if _, err := server.ListTransactions(ctx, &pb.ListTransactionsRequest{Count: -1}); err == nil {
+		t.Error("Accepted a negative count")
+	}
+
+	// A heavier branch from genesis disconnects every block of the wallet
+	fork := newTestBlockchain(t)
+	defer fork.Close()
+	for i := 0; i < 4; i++ {
+		if _, err := fork.AddBlock(nil, outsider.GetAddress()); err != nil {
+			t.Fatalf("Failed to mine fork: %v", err)
+		}
+	}
+	if _, err := fork.AddBlock(nil, owner.Address); err != nil {
+		t.Fatalf("Failed to mine fork: %v", err)
+	}
+	if err := bc.ConnectBlocks(0, fork.Blocks[1:]); err != nil {
+		t.Fatalf("Reorganization failed: %v", err)
+	}
+
+	listed, _ = server.ListTransactions(ctx, &pb.ListTransactionsRequest{})
+	if listed.Total != 1 || listed.Transactions[0].Height != 5 || listed.Transactions[0].BlockHash != fmt.Sprintf("%x", fork.Blocks[5].Hash) {
+		t.Errorf("Unexpected transactions after the reorganization %v", listed.Transactions)
+	}
+}
+
+func TestSpendUnconfirmedChange(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	ctx := context.Background()
+	sender, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "sender"})
+	receiver, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{})
+	miner, _ := crypto.NewWallet()
+	if _, err := bc.AddBlock(nil, sender.Address); err != nil {
+		t.Fatalf("Failed to fund sender: %v", err)
+	}
+	reward := bc.Params.BlockReward
+
+	balance, err := server.GetBalance(ctx, &pb.GetBalanceRequest{Address: sender.Address})
+	if err != nil || balance.Immature != reward || balance.Balance != reward || balance.Confirmed != 0 {
+		t.Fatalf("GetBalance of a fresh reward = %v, %v", balance, err)
+	}
+
+	// The second payment spends the change of the first before it is mined
+	for _, amount := range []int64{1000, 2000} {
+		resp, err := server.SendTransaction(ctx, &pb.SendTransactionRequest{
+			FromAddress: sender.Address,
+			ToAddress:   receiver.Address,
+			Amount:      amount,
+			Fee:         100,
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("SendTransaction of %d failed: %v %s", amount, err, resp.Message)
+		}
+	}
+	if !bytes.Equal(server.mempool[1].Inputs[0].TxID, server.mempool[0].ID) {
+		t.Error("Second payment does not spend the first one's change")
+	}
+
+	balance, _ = server.GetBalance(ctx, &pb.GetBalanceRequest{Address: sender.Address})
+	if want := reward - 3000 - 200; balance.Unconfirmed != want || balance.Immature != 0 || balance.UtxoCount != 1 {
+		t.Errorf("GetBalance with pending payments = %v, want %d unconfirmed", balance, want)
+	}
+	received, _ := server.GetBalance(ctx, &pb.GetBalanceRequest{Address: receiver.Address})
+	if received.Unconfirmed != 3000 || received.Balance != 3000 {
+		t.Errorf("Receiver balance = %v", received)
+	}
+	wallet, err := server.GetWalletBalance(ctx, &pb.GetWalletBalanceRequest{WalletName: "sender"})
+	if err != nil || wallet.Balance != balance.Balance || wallet.Unconfirmed != balance.Unconfirmed {
+		t.Errorf("GetWalletBalance = %v, %v", wallet, err)
+	}
+	if _, err := server.GetBalance(ctx, &pb.GetBalanceRequest{Address: "invalid"}); err == nil {
+		t.Error("GetBalance accepted an invalid address")
+	}
+
+	// The receiver cannot spend coins still in the mempool
+	if resp, _ := server.SendTransaction(ctx, &pb.SendTransactionRequest{FromAddress: receiver.Address, ToAddress: sender.Address, Amount: 500}); resp.Success {
+		t.Error("Spent unconfirmed coins paid by another wallet")
+	}
+
+	// Both payments are mined in one block
+	if _, err := bc.AddBlock(server.mempool, miner.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine a payment and its child: %v", err)
+	}
+	server.mempool = nil
+	received, _ = server.GetBalance(ctx, &pb.GetBalanceRequest{Address: receiver.Address})
+	if received.Confirmed != 3000 || received.Unconfirmed != 0 {
+		t.Errorf("Receiver balance after mining = %v", received)
+	}
+}
+
+func TestBumpFee(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	ctx := context.Background()
+	sender, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "sender"})
+	other, _ := server.CreateWallet(ctx, &pb.CreateWalletRequest{Name: "other"})
+	receiver, _ := crypto.NewWallet()
+	for _, address := range []string{sender.Address, other.Address} {
+		if _, err := bc.AddBlock(nil, address); err != nil {
+			t.Fatalf("Failed to fund %s: %v", address, err)
+		}
+	}
+
+	// A replaceable payment and a child spending its change
+	send := func(from string, amount, fee int64, replaceable bool) *pb.SendTransactionResponse {
+		resp, err := server.SendTransaction(ctx, &pb.SendTransactionRequest{
+			FromAddress: from,
+			ToAddress:   receiver.GetAddress(),
+			Amount:      amount,
+			Fee:         fee,
+			Replaceable: replaceable,
+		})
+		if err != nil || !resp.Success {
+			t.Fatalf("SendTransaction failed: %v %s", err, resp.Message)
+		}
+		return resp
+	}
+	original := send(sender.Address, 1000, 100, true)
+	child := send(sender.Address, 500, 100, false)
+
+	bumped, err := server.BumpFee(ctx, &pb.BumpFeeRequest{TxId: original.TxId})
+	if err != nil || !bumped.Success || bumped.Method != "replace" {
+		t.Fatalf("BumpFee = %v, %v", bumped, err)
+	}
+	if len(bumped.Replaced) != 2 || bumped.Replaced[0] != original.TxId || bumped.Replaced[1] != child.TxId {
+		t.Errorf("Replaced %v, want the payment and its child", bumped.Replaced)
+	}
+	if len(server.mempool) != 1 || fmt.Sprintf("%x", server.mempool[0].ID) != bumped.TxId {
+		t.Fatalf("Mempool holds %d transactions after the replacement", len(server.mempool))
+	}
+	replacement := server.mempool[0]
+	if !replacement.SignalsReplacement() {
+		t.Error("Replacement does not keep signaling replace-by-fee")
+	}
+	if fee, _ := bc.TransactionFee(replacement); fee != bumped.NewFee || fee < 200+txSize(replacement) {
+		t.Errorf("Replacement pays %d, reported %d", fee, bumped.NewFee)
+	}
+	if replacement.Outputs[0].Value != 1000 {
+		t.Errorf("Replacement pays the recipient %d", replacement.Outputs[0].Value)
+	}
+	if resp, _ := server.BumpFee(ctx, &pb.BumpFeeRequest{TxId: bumped.TxId, FeeRate: 1}); resp.Success {
+		t.Error("Replaced a transaction without raising its fee rate")
+	}
+
+	// A payment that did not opt in cannot be replaced, but a child can
+	// pay for it
+	payment := send(other.Address, 1000, 0, false)
+	wallet, _ := server.signingWallet(other.Address)
+	coins := bc.ListUnspent(crypto.PublicKeyHash(wallet.PublicKey))
+	conflict := tx.NewTransaction(
+		[]tx.TxInput{{TxID: coins[0].TxID, OutIndex: coins[0].Index, PubKey: wallet.PublicKey}},
+		[]tx.TxOutput{{Value: coins[0].Output.Value - 5000, PubKeyHash: crypto.PublicKeyHash(receiver.PublicKey)}},
+	)
+	bc.SignTransaction(conflict, wallet)
+	if _, _, err := server.acceptToMempool(conflict); err == nil {
+		t.Error("Replaced a transaction that did not opt in")
+	}
+
+	cpfp, err := server.BumpFee(ctx, &pb.BumpFeeRequest{TxId: payment.TxId, FeeRate: 5})
+	if err != nil || !cpfp.Success || cpfp.Method != "cpfp" {
+		t.Fatalf("BumpFee with a child = %v, %v", cpfp, err)
+	}
+	if cpfp.FeeRate < 5 || cpfp.OldFee != 0 {
+		t.Errorf("Package pays %d sat/byte after a fee of %d", cpfp.FeeRate, cpfp.OldFee)
+	}
+	childTx := server.mempool[len(server.mempool)-1]
+	if fmt.Sprintf("%x", childTx.Inputs[0].TxID) != payment.TxId {
+		t.Error("Child does not spend the payment")
+	}
+
+	// Mining takes every package, parents first
+	block := server.takeBlockTransactions()
+	if len(block) != 3 || len(server.mempool) != 0 {
+		t.Fatalf("Took %d transactions, left %d", len(block), len(server.mempool))
+	}
+	if _, err := bc.AddBlock(block, receiver.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine the bumped transactions: %v", err)
+	}
+	if resp, _ := server.BumpFee(ctx, &pb.BumpFeeRequest{TxId: bumped.TxId}); resp.Success {
+		t.Error("Bumped a confirmed transaction")
+	}
+}
+
+func TestReturnToMempool(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	wallet, _ := crypto.NewWallet()
+	if _, err := bc.AddBlock(nil, wallet.GetAddress()); err != nil {
+		t.Fatalf("Failed to fund the wallet: %v", err)
+	}
+	coins := bc.ListUnspent(crypto.PublicKeyHash(wallet.PublicKey))
+	spend := func(fee int64) *tx.Transaction {
+		transaction := tx.NewTransaction(
+			[]tx.TxInput{{TxID: coins[0].TxID, OutIndex: coins[0].Index, PubKey: wallet.PublicKey}},
+			[]tx.TxOutput{{Value: coins[0].Output.Value - fee, PubKeyHash: crypto.PublicKeyHash(wallet.PublicKey)}},
+		)
+		bc.SignTransaction(transaction, wallet)
+		return transaction
+	}
+
+	// A block holding a double spend fails, and only the conflicting
+	// transaction is dropped when the rest go back
+	payment, conflict := spend(100), spend(200)
+	block := []*tx.Transaction{payment, conflict}
+	if _, err := bc.AddBlock(block, wallet.GetAddress()); err == nil {
+		t.Fatal("Mined a block with a double spend")
+	}
+	server.returnToMempool(block)
+
+	if len(server.mempool) != 1 || !bytes.Equal(server.mempool[0].ID, payment.ID) {
+		t.Fatalf("Mempool holds %d transactions, want the payment only", len(server.mempool))
+	}
+}
+
+func TestMempoolDropsEntriesWithoutFee(t *testing.T) {
+	bc := newTestBlockchain(t)
+	defer bc.Close()
+
+	server := NewServer(bc, nil)
+	wallet, _ := crypto.NewWallet()
+	if _, err := bc.AddBlock(nil, wallet.GetAddress()); err != nil {
+		t.Fatalf("Failed to fund the wallet: %v", err)
+	}
+	coins := bc.ListUnspent(crypto.PublicKeyHash(wallet.PublicKey))
+	payment := tx.NewTransaction(
+		[]tx.TxInput{{TxID: coins[0].TxID, OutIndex: coins[0].Index, PubKey: wallet.PublicKey}},
+		[]tx.TxOutput{{Value: coins[0].Output.Value - 100, PubKeyHash: crypto.PublicKeyHash(wallet.PublicKey)}},
+	)
+	bc.SignTransaction(payment, wallet)
+
+	// A transaction whose input went missing, and a child spending it
+	missing := tx.NewTransaction(
+		[]tx.TxInput{{TxID: []byte("missing"), OutIndex: 0, PubKey: wallet.PublicKey}},
+		[]tx.TxOutput{{Value: 1000, PubKeyHash: crypto.PublicKeyHash(wallet.PublicKey)}},
+	)
+	child := tx.NewTransaction(
+		[]tx.TxInput{{TxID: missing.ID, OutIndex: 0, PubKey: wallet.PublicKey}},
+		[]tx.TxOutput{{Value: 500, PubKeyHash: crypto.PublicKeyHash(wallet.PublicKey)}},
+	)
+	server.mempool = []*tx.Transaction{missing, child, payment}
+
+	entries := server.mempoolEntries()
+	if len(entries) != 1 || !bytes.Equal(entries[0].tx.ID, payment.ID) || entries[0].fee != 100 {
+		t.Fatalf("Entries = %+v, want the payment with a fee of 100", entries)
+	}
+
+	block := server.takeBlockTransactions()
+	if len(block) != 1 || len(server.mempool) != 0 {
+		t.Errorf("Took %d transactions and kept %d, want the payment taken and the rest evicted", len(block), len(server.mempool))
+	}
+}
+
+func TestBlockPackageSelection(t *testing.T) {
+	entries := []mempoolEntry{
+		{fee: 100, size: 100},                     // 1 sat/byte
+		{fee: 0, size: 100},                       // Parent paying nothing
+		{fee: 1000, size: 100, parents: []int{1}}, // Child paying 10 sat/byte
+	}
+	if pkg := ancestors(entries, 2, nil); len(pkg) != 2 || pkg[0] != 1 || pkg[1] != 2 {
+		t.Errorf("ancestors = %v", pkg)
+	}
+	if fee, size := packageValue(entries, ancestors(entries, 2, nil)); fee*100 <= entries[0].fee*size {
+		t.Error("The child does not lift its parent above the other transaction")
+	}
+	if found := descendants(entries, map[int]bool{1: true}); len(found) != 2 || !found[2] {
+		t.Errorf("descendants = %v", found)
+	}
+}
@@ -0,0 +1,319 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+)
+
+// loadedWallet is a named wallet open on the node
+type loadedWallet struct {
+	name  string
+	store *storage.WalletStorage
+
+	// Serializes scans for the wallet's transactions
+	scanMu sync.Mutex
+
+	// Automatic relock after WalletPassphrase
+	mu            sync.Mutex
+	lockTimer     *time.Timer
+	unlockedUntil time.Time
+}
+
+// displayName names a wallet in messages
+func displayName(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+// lock forgets the wallet's decrypted master key
+func (w *loadedWallet) lock() {
+	w.mu.Lock()
+	if w.lockTimer != nil {
+		w.lockTimer.Stop()
+		w.lockTimer = nil
+	}
+	w.unlockedUntil = time.Time{}
+	w.mu.Unlock()
+
+	w.store.Lock()
+	log.Printf("🔒 Wallet %q locked", displayName(w.name))
+}
+
+// close locks the wallet and closes its store
+func (w *loadedWallet) close() {
+	w.mu.Lock()
+	if w.lockTimer != nil {
+		w.lockTimer.Stop()
+		w.lockTimer = nil
+	}
+	w.mu.Unlock()
+
+	w.store.Lock()
+	w.store.Close()
+}
+
+// SetWalletDir keeps wallets in a network's data directory, shared with the
+// wallet CLI, and loads the default wallet. Without a wallet directory the
+// wallets are kept in memory.
+func (s *Server) SetWalletDir(networkDir string) error {
+	s.walletsMu.Lock()
+	defer s.walletsMu.Unlock()
+
+	for _, w := range s.wallets {
+		w.close()
+	}
+	s.wallets = make(map[string]*loadedWallet)
+	s.walletDir = networkDir
+
+	_, err := s.openWallet("")
+	return err
+}
+
+// LoadWalletByName loads a wallet of the wallet directory, such as those
+// named on the command line
+func (s *Server) LoadWalletByName(name string) error {
+	s.walletsMu.Lock()
+	defer s.walletsMu.Unlock()
+
+	if err := storage.ValidateWalletName(name); err != nil {
+		return err
+	}
+	if _, ok := s.wallets[name]; ok {
+		return fmt.Errorf("wallet %q is already loaded", displayName(name))
+	}
+	if !s.walletOnDisk(name) {
+		return fmt.Errorf("wallet %q not found", displayName(name))
+	}
+	_, err := s.openWallet(name)
+	return err
+}
+
+// walletOnDisk reports whether a wallet exists in the wallet directory
+func (s *Server) walletOnDisk(name string) bool {
+	return s.walletDir != "" && storage.WalletStoreExists(storage.GetNamedWalletPath(s.walletDir, name))
+}
+
+// openWallet opens or creates a wallet and adds it to the loaded wallets;
+// walletsMu must be held
+func (s *Server) openWallet(name string) (*loadedWallet, error) {
+	var store *storage.WalletStorage
+	if s.walletDir == "" {
+		store = storage.NewWalletStorageWithBackend(storage.NewMemoryBackend())
+	} else {
+		var err error
+		store, err = storage.NewWalletStorage(storage.GetNamedWalletPath(s.walletDir, name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w := &loadedWallet{name: name, store: store}
+	s.wallets[name] = w
+	if store.IsEncrypted() {
+		log.Printf("💼 Loaded wallet %q (encrypted; unlock it with WalletPassphrase)", displayName(name))
+	} else {
+		log.Printf("💼 Loaded wallet %q", displayName(name))
+	}
+	return w, nil
+}
+
+// loadedWalletByName returns a loaded wallet
+func (s *Server) loadedWalletByName(name string) (*loadedWallet, error) {
+	s.walletsMu.RLock()
+	defer s.walletsMu.RUnlock()
+
+	w, ok := s.wallets[name]
+	if !ok {
+		return nil, fmt.Errorf("wallet %q is not loaded", displayName(name))
+	}
+	return w, nil
+}
+
+// walletForAddress returns the loaded wallet holding an address's key
+func (s *Server) walletForAddress(address string) *loadedWallet {
+	s.walletsMu.RLock()
+	defer s.walletsMu.RUnlock()
+
+	for _, w := range s.wallets {
+		if w.store.WalletExists(address) {
+			return w
+		}
+	}
+	return nil
+}
+
+// loadedWallets returns the loaded wallets ordered by name
+func (s *Server) loadedWallets() []*loadedWallet {
+	s.walletsMu.RLock()
+	defer s.walletsMu.RUnlock()
+
+	wallets := make([]*loadedWallet, 0, len(s.wallets))
+	for _, w := range s.wallets {
+		wallets = append(wallets, w)
+	}
+	sort.Slice(wallets, func(i, j int) bool { return wallets[i].name < wallets[j].name })
+	return wallets
+}
+
+// closeWallets closes every loaded wallet
+func (s *Server) closeWallets() {
+	s.walletsMu.Lock()
+	defer s.walletsMu.Unlock()
+
+	for name, w := range s.wallets {
+		w.close()
+		delete(s.wallets, name)
+	}
+}
+
+// LoadWallet opens a wallet of the wallet directory
+func (s *Server) LoadWallet(ctx context.Context, req *pb.LoadWalletRequest) (*pb.LoadWalletResponse, error) {
+	if err := s.LoadWalletByName(req.Name); err != nil {
+		return &pb.LoadWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.LoadWalletResponse{Success: true, Message: fmt.Sprintf("Wallet %q loaded", displayName(req.Name))}, nil
+}
+
+// UnloadWallet locks and closes a loaded wallet; its keys can no longer
+// sign until it is loaded again
+func (s *Server) UnloadWallet(ctx context.Context, req *pb.UnloadWalletRequest) (*pb.UnloadWalletResponse, error) {
+	s.walletsMu.Lock()
+	w, ok := s.wallets[req.Name]
+	if ok {
+		delete(s.wallets, req.Name)
+	}
+	s.walletsMu.Unlock()
+
+	if !ok {
+		return &pb.UnloadWalletResponse{Success: false, Message: fmt.Sprintf("wallet %q is not loaded", displayName(req.Name))}, nil
+	}
+	w.close()
+	log.Printf("💼 Unloaded wallet %q", displayName(req.Name))
+	return &pb.UnloadWalletResponse{Success: true, Message: fmt.Sprintf("Wallet %q unloaded", displayName(req.Name))}, nil
+}
+
+// ListWalletDir lists the wallets in the wallet directory and those loaded
+func (s *Server) ListWalletDir(ctx context.Context, req *pb.ListWalletDirRequest) (*pb.ListWalletDirResponse, error) {
+	var names []string
+	if s.walletDir != "" {
+		var err error
+		names, err = storage.ListWalletNames(s.walletDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	infos := make(map[string]*pb.WalletInfo)
+	for _, name := range names {
+		infos[name] = &pb.WalletInfo{Name: name}
+	}
+	for _, w := range s.loadedWallets() {
+		addresses, err := w.store.GetAllAddresses()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list wallet %q: %v", displayName(w.name), err)
+		}
+		infos[w.name] = &pb.WalletInfo{
+			Name:      w.name,
+			Loaded:    true,
+			Encrypted: w.store.IsEncrypted(),
+			Locked:    w.store.IsLocked(),
+			KeyCount:  int32(len(addresses)),
+			WatchOnly: w.store.IsWatchOnly(),
+		}
+	}
+
+	resp := &pb.ListWalletDirResponse{Wallets: make([]*pb.WalletInfo, 0, len(infos))}
+	for _, info := range infos {
+		resp.Wallets = append(resp.Wallets, info)
+	}
+	sort.Slice(resp.Wallets, func(i, j int) bool { return resp.Wallets[i].Name < resp.Wallets[j].Name })
+	return resp, nil
+}
+
+// ImportPrivateKey adds a hex-encoded private key to a loaded wallet, which
+// must be unlocked if it is encrypted
+func (s *Server) ImportPrivateKey(ctx context.Context, req *pb.ImportPrivateKeyRequest) (*pb.Wallet, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := crypto.HexToPrivateKey(req.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+
+	wallet := &crypto.Wallet{PrivateKey: privateKey, PublicKey: crypto.PublicKeyBytes(privateKey)}
+	address := wallet.GetAddress()
+	if owner := s.walletForAddress(address); owner != nil {
+		return nil, fmt.Errorf("key is already in wallet %q", displayName(owner.name))
+	}
+	if err := w.store.SaveWallet(address, privateKey.D.Bytes(), wallet.PublicKey); err != nil {
+		return nil, fmt.Errorf("failed to save key: %v", err)
+	}
+	log.Printf("🔑 Imported %s into wallet %q", address, displayName(w.name))
+
+	return &pb.Wallet{
+		Address:    address,
+		PublicKey:  fmt.Sprintf("%x", wallet.PublicKey),
+		WalletName: w.name,
+	}, nil
+}
+
+// DumpPrivateKey returns the hex-encoded private key of an address of a
+// loaded wallet, which must be unlocked if it is encrypted. The caller must
+// set confirm, so the key is never handed out by accident.
+func (s *Server) DumpPrivateKey(ctx context.Context, req *pb.DumpPrivateKeyRequest) (*pb.DumpPrivateKeyResponse, error) {
+	if !req.Confirm {
+		return nil, fmt.Errorf("the private key can spend the address's funds; set confirm to export it")
+	}
+	wallet, err := s.signingWallet(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DumpPrivateKeyResponse{PrivateKey: crypto.PrivateKeyToHex(wallet.PrivateKey)}, nil
+}
+
+// SignMessage signs a message with the key of an address, proving its
+// ownership to anyone holding the address
+func (s *Server) SignMessage(ctx context.Context, req *pb.SignMessageRequest) (*pb.SignMessageResponse, error) {
+	wallet, err := s.signingWallet(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.SignMessage(wallet, req.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %v", err)
+	}
+	return &pb.SignMessageResponse{Signature: signature}, nil
+}
+
+// BackupWallet copies a loaded wallet to a new wallet directory on the
+// node's host. Encrypted wallets stay encrypted in the copy.
+func (s *Server) BackupWallet(ctx context.Context, req *pb.BackupWalletRequest) (*pb.BackupWalletResponse, error) {
+	if !filepath.IsAbs(req.Destination) {
+		return &pb.BackupWalletResponse{Success: false, Message: "destination must be an absolute path"}, nil
+	}
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return &pb.BackupWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	if err := w.store.Backup(req.Destination); err != nil {
+		return &pb.BackupWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	log.Printf("💾 Backed up wallet %q to %s", displayName(w.name), req.Destination)
+	return &pb.BackupWalletResponse{
+		Success: true,
+		Message: fmt.Sprintf("Wallet %q backed up to %s", displayName(w.name), req.Destination),
+	}, nil
+}
@@ -0,0 +1,267 @@
+package grpc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	pb "github.com/yourusername/bt/api/proto"
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/coinselect"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/hdwallet"
+	"github.com/yourusername/bt/internal/storage"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+// CreateWatchOnlyWallet creates a wallet that watches addresses without
+// holding their private keys. It tracks transactions from the current
+// block on; imports can rescan earlier blocks.
+func (s *Server) CreateWatchOnlyWallet(ctx context.Context, req *pb.CreateWatchOnlyWalletRequest) (*pb.CreateWatchOnlyWalletResponse, error) {
+	if err := storage.ValidateWalletName(req.Name); err != nil {
+		return &pb.CreateWatchOnlyWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	s.walletsMu.Lock()
+	if _, loaded := s.wallets[req.Name]; loaded || s.walletOnDisk(req.Name) {
+		s.walletsMu.Unlock()
+		return &pb.CreateWatchOnlyWalletResponse{
+			Success: false,
+			Message: fmt.Sprintf("wallet %q already exists", displayName(req.Name)),
+		}, nil
+	}
+	w, err := s.openWallet(req.Name)
+	s.walletsMu.Unlock()
+	if err != nil {
+		return &pb.CreateWatchOnlyWalletResponse{Success: false, Message: fmt.Sprintf("failed to create wallet: %v", err)}, nil
+	}
+
+	if err := w.store.SetWatchOnly(); err != nil {
+		return &pb.CreateWatchOnlyWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	tip := s.bc.GetLatestBlock()
+	if err := w.store.SaveScannedBlock(s.bc.Height()-1, tip.Hash, nil); err != nil {
+		return &pb.CreateWatchOnlyWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	log.Printf("👀 Created watch-only wallet %q", displayName(w.name))
+	return &pb.CreateWatchOnlyWalletResponse{
+		Success: true,
+		Message: fmt.Sprintf("Watch-only wallet %q created", displayName(w.name)),
+	}, nil
+}
+
+// ImportAddress watches an address, given directly or by its public key
+func (s *Server) ImportAddress(ctx context.Context, req *pb.ImportAddressRequest) (*pb.ImportWatchResponse, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return &pb.ImportWatchResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	address := req.Address
+	var publicKey []byte
+	if req.PublicKey != "" {
+		publicKey, err = hex.DecodeString(req.PublicKey)
+		if err == nil {
+			err = crypto.ValidatePublicKey(publicKey)
+		}
+		if err != nil {
+			return &pb.ImportWatchResponse{Success: false, Message: fmt.Sprintf("invalid public key: %v", err)}, nil
+		}
+		derived := crypto.GetAddressFromPubKey(publicKey)
+		if address != "" && address != derived {
+			return &pb.ImportWatchResponse{Success: false, Message: "public key does not match the address"}, nil
+		}
+		address = derived
+	}
+	if _, err := crypto.DecodeAddress(address); err != nil {
+		return &pb.ImportWatchResponse{Success: false, Message: fmt.Sprintf("invalid address: %v", err)}, nil
+	}
+	if owner := s.walletForAddress(address); owner != nil {
+		return &pb.ImportWatchResponse{
+			Success: false,
+			Message: fmt.Sprintf("address is already in wallet %q", displayName(owner.name)),
+		}, nil
+	}
+
+	if err := w.store.SaveWatchAddress(address, publicKey, ""); err != nil {
+		return &pb.ImportWatchResponse{Success: false, Message: err.Error()}, nil
+	}
+	log.Printf("👀 Watching %s in wallet %q", address, displayName(w.name))
+
+	return s.finishImport(w, []string{address}, req.Rescan, req.RescanHeight)
+}
+
+// ImportXPub watches the receive and change addresses of an account's
+// extended public key, deriving more as they are used
+func (s *Server) ImportXPub(ctx context.Context, req *pb.ImportXPubRequest) (*pb.ImportWatchResponse, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return &pb.ImportWatchResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	key, err := hdwallet.ParseExtendedKey(req.Xpub, s.bc.Params)
+	if err != nil {
+		return &pb.ImportWatchResponse{Success: false, Message: err.Error()}, nil
+	}
+	if key.IsPrivate() {
+		return &pb.ImportWatchResponse{Success: false, Message: "expected an extended public key, not a private one"}, nil
+	}
+	gapLimit := int(req.GapLimit)
+	if gapLimit == 0 {
+		gapLimit = hdwallet.DefaultGapLimit
+	}
+	if gapLimit < 0 {
+		return &pb.ImportWatchResponse{Success: false, Message: "gap limit must be positive"}, nil
+	}
+
+	w.scanMu.Lock()
+	if err := w.store.SaveWatchXPub(storage.WatchXPub{Key: key.String(), GapLimit: gapLimit}); err != nil {
+		w.scanMu.Unlock()
+		return &pb.ImportWatchResponse{Success: false, Message: err.Error()}, nil
+	}
+	added, err := hdwallet.WatchAccount(w.store, key, s.bc.Params, gapLimit, s.usedFunc())
+	w.scanMu.Unlock()
+	if err != nil {
+		return &pb.ImportWatchResponse{Success: false, Message: err.Error()}, nil
+	}
+	log.Printf("👀 Watching %d addresses of an extended key in wallet %q", len(added), displayName(w.name))
+
+	return s.finishImport(w, added, req.Rescan, req.RescanHeight)
+}
+
+// finishImport rescans for the imported addresses if asked to and catches
+// the wallet up with the chain
+func (s *Server) finishImport(w *loadedWallet, addresses []string, rescan bool, rescanHeight int64) (*pb.ImportWatchResponse, error) {
+	if rescan {
+		if err := w.store.Rescan(int(rescanHeight)); err != nil {
+			return &pb.ImportWatchResponse{Success: false, Message: err.Error()}, nil
+		}
+	}
+	if _, err := s.syncWallet(w); err != nil {
+		return &pb.ImportWatchResponse{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.ImportWatchResponse{
+		Success:   true,
+		Message:   fmt.Sprintf("Imported %d addresses into wallet %q", len(addresses), displayName(w.name)),
+		Addresses: addresses,
+	}, nil
+}
+
+// RescanWallet finds the wallet's transactions again from a block height
+func (s *Server) RescanWallet(ctx context.Context, req *pb.RescanWalletRequest) (*pb.RescanWalletResponse, error) {
+	w, err := s.loadedWalletByName(req.WalletName)
+	if err != nil {
+		return &pb.RescanWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	if req.StartHeight < 0 || req.StartHeight >= int64(s.bc.Height()) {
+		return &pb.RescanWalletResponse{Success: false, Message: fmt.Sprintf("start height must be between 0 and %d", s.bc.Height()-1)}, nil
+	}
+
+	if err := w.store.Rescan(int(req.StartHeight)); err != nil {
+		return &pb.RescanWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	found, err := s.syncWallet(w)
+	if err != nil {
+		return &pb.RescanWalletResponse{Success: false, Message: err.Error()}, nil
+	}
+	scanned, _ := w.store.ScanHeight()
+	log.Printf("🔍 Rescanned wallet %q from block %d: %d transactions", displayName(w.name), req.StartHeight, found)
+	return &pb.RescanWalletResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Rescanned blocks %d to %d", req.StartHeight, scanned),
+		ScannedHeight: int64(scanned),
+		Transactions:  int32(found),
+	}, nil
+}
+
+// CreateUnsignedTransaction funds a payment from an address of a loaded
+// wallet, which may be watch-only, and returns it unsigned with the outputs
+// it spends, for the key holder to sign. Inputs carry the sender's public
+// key if the wallet knows it; otherwise the signer adds it, which changes
+// the transaction ID.
+func (s *Server) CreateUnsignedTransaction(ctx context.Context, req *pb.CreateUnsignedTransactionRequest) (*pb.CreateUnsignedTransactionResponse, error) {
+	transaction, selection, err := s.fundUnsigned(req.FromAddress, req.ToAddress, req.Amount,
+		blockchain.FeePolicy{Fee: req.Fee, FeeRate: req.FeeRate, Replaceable: req.Replaceable}, req.CoinSelection)
+	if err != nil {
+		return &pb.CreateUnsignedTransactionResponse{Success: false, Message: err.Error()}, nil
+	}
+	raw, err := transaction.Serialize()
+	if err != nil {
+		return &pb.CreateUnsignedTransactionResponse{Success: false, Message: err.Error()}, nil
+	}
+
+	resp := &pb.CreateUnsignedTransactionResponse{
+		Success:        true,
+		Message:        "Unsigned transaction created",
+		RawTransaction: hex.EncodeToString(raw),
+		Fee:            selection.Fee,
+	}
+	for _, coin := range selection.Coins {
+		resp.Inputs = append(resp.Inputs, &pb.UnsignedInput{
+			TxId:    fmt.Sprintf("%x", coin.TxID),
+			Vout:    int32(coin.Index),
+			Value:   coin.Value,
+			Address: req.FromAddress,
+		})
+	}
+	return resp, nil
+}
+
+// fundUnsigned funds a payment from an address of a loaded wallet without
+// signing it
+func (s *Server) fundUnsigned(from, to string, amount int64, fee blockchain.FeePolicy, coinSelection string) (*tx.Transaction, *coinselect.Result, error) {
+	w := s.walletForAddress(from)
+	if w == nil {
+		return nil, nil, fmt.Errorf("address is not in a loaded wallet")
+	}
+	publicKey, err := w.store.GetPublicKey(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	strategy, err := coinselect.StrategyByName(coinSelection)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.utxoView().FundTransaction(from, to, amount, publicKey, fee, strategy)
+}
+
+// extendXPubs keeps the gap limit of unused addresses past the last used
+// address of each watched extended key. Addresses that turn out to be used
+// already were missed by earlier scans, so the wallet is rescanned.
+func (s *Server) extendXPubs(w *loadedWallet) error {
+	xpubs, err := w.store.GetWatchXPubs()
+	if err != nil || len(xpubs) == 0 {
+		return err
+	}
+
+	used := s.usedFunc()
+	missed := false
+	for _, xpub := range xpubs {
+		key, err := hdwallet.ParseExtendedKey(xpub.Key, s.bc.Params)
+		if err != nil {
+			return fmt.Errorf("invalid watched key: %v", err)
+		}
+		added, err := hdwallet.WatchAccount(w.store, key, s.bc.Params, xpub.GapLimit, used)
+		if err != nil {
+			return err
+		}
+		for _, address := range added {
+			missed = missed || used(address)
+		}
+	}
+	if missed {
+		log.Printf("🔍 New addresses of wallet %q were used before; rescanning", displayName(w.name))
+		return w.store.Rescan(0)
+	}
+	return nil
+}
+
+// usedFunc reports whether the chain pays an address
+func (s *Server) usedFunc() hdwallet.UsedFunc {
+	used := s.bc.UsedPubKeyHashes()
+	return func(address string) bool {
+		pubKeyHash, err := crypto.DecodeAddress(address)
+		return err == nil && used[string(pubKeyHash)]
+	}
+}
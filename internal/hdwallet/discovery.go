@@ -0,0 +1,89 @@
+package hdwallet
+
+import "fmt"
+
+// DefaultGapLimit is the number of consecutive unused addresses after which
+// discovery assumes a chain has no more used addresses
+const DefaultGapLimit = 20
+
+// UsedFunc reports whether an address has appeared on the chain
+type UsedFunc func(address string) bool
+
+// AccountState is the result of discovering an account: the next unused
+// index of each chain
+type AccountState struct {
+	Account      uint32
+	NextExternal uint32
+	NextInternal uint32
+}
+
+// Used reports whether any address of the account was found
+func (s AccountState) Used() bool {
+	return s.NextExternal > 0 || s.NextInternal > 0
+}
+
+// ScanChain derives addresses of one chain of an account key until
+// gapLimit consecutive addresses are unused, and returns the index after
+// the last used address
+func ScanChain(account *ExtendedKey, chain uint32, gapLimit int, used UsedFunc) (uint32, error) {
+	if gapLimit <= 0 {
+		return 0, fmt.Errorf("gap limit must be positive")
+	}
+	chainKey, err := account.Child(chain)
+	if err != nil {
+		return 0, err
+	}
+
+	var next uint32
+	gap := 0
+	for index := uint32(0); gap < gapLimit; index++ {
+		if index >= HardenedKeyStart {
+			return 0, fmt.Errorf("chain %d exhausted", chain)
+		}
+		key, err := chainKey.Child(index)
+		if err == ErrInvalidChild {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if used(key.Address()) {
+			next = index + 1
+			gap = 0
+		} else {
+			gap++
+		}
+	}
+	return next, nil
+}
+
+// Discover restores BIP-44 accounts from a master key: accounts are scanned
+// in order with the gap limit until one has no used addresses. Account 0
+// is always returned.
+func Discover(master *ExtendedKey, coinType uint32, gapLimit int, used UsedFunc) ([]AccountState, error) {
+	var accounts []AccountState
+	for account := uint32(0); account < HardenedKeyStart; account++ {
+		key, err := master.Derive(AccountPath(coinType, account))
+		if err != nil {
+			return nil, err
+		}
+
+		state := AccountState{Account: account}
+		if state.NextExternal, err = ScanChain(key, ExternalChain, gapLimit, used); err != nil {
+			return nil, err
+		}
+		if state.NextInternal, err = ScanChain(key, InternalChain, gapLimit, used); err != nil {
+			return nil, err
+		}
+
+		if account > 0 && !state.Used() {
+			break
+		}
+		accounts = append(accounts, state)
+		if !state.Used() {
+			break
+		}
+	}
+	return accounts, nil
+}
@@ -0,0 +1,128 @@
+package hdwallet
+
+import (
+	"testing"
+
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/storage"
+)
+
+func TestDiscover(t *testing.T) {
+	params := &chaincfg.RegTestParams
+	seed := NewSeed(mnemonicVectors[1].mnemonic, "")
+	master, err := NewMaster(seed, params)
+	if err != nil {
+		t.Fatalf("NewMaster failed: %v", err)
+	}
+
+	address := func(account, chain, index uint32) string {
+		key, err := master.Derive(AddressPath(params.HDCoinType, account, chain, index))
+		if err != nil {
+			t.Fatalf("Derive failed: %v", err)
+		}
+		return key.Address()
+	}
+
+	// Account 0 used receive addresses 0, 3 and 22 (within the gap of 20
+	// after 3) and change address 1; account 1 used receive address 4;
+	// account 2 is unused; account 3 has an address nobody can find
+	used := map[string]bool{
+		address(0, ExternalChain, 0):  true,
+		address(0, ExternalChain, 3):  true,
+		address(0, ExternalChain, 22): true,
+		address(0, InternalChain, 1):  true,
+		address(1, ExternalChain, 4):  true,
+		address(3, ExternalChain, 0):  true,
+	}
+	isUsed := func(addr string) bool { return used[addr] }
+
+	accounts, err := Discover(master, params.HDCoinType, DefaultGapLimit, isUsed)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	want := []AccountState{
+		{Account: 0, NextExternal: 23, NextInternal: 2},
+		{Account: 1, NextExternal: 5},
+	}
+	if len(accounts) != len(want) {
+		t.Fatalf("Discovered %+v, want %+v", accounts, want)
+	}
+	for i := range want {
+		if accounts[i] != want[i] {
+			t.Errorf("Account %d: got %+v, want %+v", i, accounts[i], want[i])
+		}
+	}
+
+	// A smaller gap limit stops before index 22
+	accounts, _ = Discover(master, params.HDCoinType, 5, isUsed)
+	if accounts[0].NextExternal != 4 {
+		t.Errorf("Gap limit 5: next receive index %d, want 4", accounts[0].NextExternal)
+	}
+
+	// A fresh wallet still has account 0
+	accounts, _ = Discover(master, params.HDCoinType, DefaultGapLimit, func(string) bool { return false })
+	if len(accounts) != 1 || accounts[0].Used() {
+		t.Errorf("Unused wallet discovered %+v", accounts)
+	}
+}
+
+func TestDeriveNext(t *testing.T) {
+	params := &chaincfg.RegTestParams
+	ws := storage.NewWalletStorageWithBackend(storage.NewMemoryBackend())
+	defer ws.Close()
+
+	if _, _, err := DeriveNext(ws, params, 0, ExternalChain); err != storage.ErrNoHDSeed {
+		t.Errorf("DeriveNext without a seed: got %v, want ErrNoHDSeed", err)
+	}
+
+	seed := NewSeed(mnemonicVectors[0].mnemonic, "")
+	ws.SaveHDSeed(seed, []storage.HDAccount{{Account: 0, NextExternal: 2}})
+	key, path, err := DeriveNext(ws, params, 0, ExternalChain)
+	if err != nil {
+		t.Fatalf("DeriveNext failed: %v", err)
+	}
+	if path.String() != "m/44'/1'/0'/0/2" {
+		t.Errorf("Derived %s, want m/44'/1'/0'/0/2", path)
+	}
+	walletData, err := ws.GetWallet(key.Address())
+	if err != nil || walletData.Path != path.String() {
+		t.Fatalf("Derived key not saved: %+v, %v", walletData, err)
+	}
+
+	_, path, _ = DeriveNext(ws, params, 0, InternalChain)
+	accounts, _ := ws.GetHDAccounts()
+	if path.String() != "m/44'/1'/0'/1/0" || accounts[0].NextExternal != 3 || accounts[0].NextInternal != 1 {
+		t.Errorf("Change key %s, accounts %+v", path, accounts)
+	}
+}
+
+func TestWatchAccount(t *testing.T) {
+	params := &chaincfg.RegTestParams
+	ws := storage.NewWalletStorageWithBackend(storage.NewMemoryBackend())
+	defer ws.Close()
+	ws.SetWatchOnly()
+
+	master, _ := NewMaster(NewSeed(mnemonicVectors[0].mnemonic, ""), params)
+	account, _ := master.Derive(AccountPath(params.HDCoinType, 0))
+	xpub := account.Neuter()
+	used, _ := account.Derive(Path{ExternalChain, 2})
+
+	added, err := WatchAccount(ws, xpub, params, 3, func(address string) bool { return address == used.Address() })
+	if err != nil {
+		t.Fatalf("WatchAccount failed: %v", err)
+	}
+	// Receive addresses 0-5 and change addresses 0-2
+	if len(added) != 9 {
+		t.Fatalf("Added %d addresses, want 9", len(added))
+	}
+	walletData, err := ws.GetWallet(used.Address())
+	if err != nil || !walletData.WatchOnly || walletData.Path != "m/44'/1'/0'/0/2" {
+		t.Errorf("Unexpected watched address %+v, %v", walletData, err)
+	}
+
+	// Watching again only adds addresses past the lookahead
+	added, _ = WatchAccount(ws, xpub, params, 3, func(string) bool { return false })
+	if len(added) != 0 {
+		t.Errorf("Added %d addresses again", len(added))
+	}
+}
@@ -0,0 +1,311 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/mr-tron/base58"
+
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+)
+
+const (
+	// HardenedKeyStart is the first hardened child index (2^31)
+	HardenedKeyStart uint32 = 0x80000000
+
+	// serializedKeyLen is the length of an encoded extended key without
+	// its checksum
+	serializedKeyLen = 78
+
+	// MinSeedBytes and MaxSeedBytes bound the length of a master seed
+	MinSeedBytes = 16
+	MaxSeedBytes = 64
+)
+
+// masterKeyHMACKey is the HMAC key that turns a seed into the master key
+var masterKeyHMACKey = []byte("Bitcoin seed")
+
+var (
+	// ErrInvalidChild is returned for the rare child indexes whose key is
+	// invalid; BIP-32 says to continue with the next index
+	ErrInvalidChild = errors.New("derived key is invalid; use the next index")
+
+	// ErrHardenedFromPublic is returned when deriving a hardened child of
+	// a public extended key
+	ErrHardenedFromPublic = errors.New("cannot derive a hardened child from a public key")
+)
+
+// ExtendedKey is a BIP-32 extended private or public key: a key plus the
+// chain code and position needed to derive its children
+type ExtendedKey struct {
+	params      *chaincfg.Params
+	key         []byte // 32-byte private scalar or 33-byte compressed public key
+	chainCode   []byte
+	depth       uint8
+	parentFP    [4]byte
+	childNumber uint32
+	private     bool
+}
+
+// NewMaster derives the master extended private key of a seed
+func NewMaster(seed []byte, params *chaincfg.Params) (*ExtendedKey, error) {
+	if len(seed) < MinSeedBytes || len(seed) > MaxSeedBytes {
+		return nil, fmt.Errorf("invalid seed length %d bytes (want %d to %d)", len(seed), MinSeedBytes, MaxSeedBytes)
+	}
+
+	mac := hmac.New(sha512.New, masterKeyHMACKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := sum[:32]
+	k := new(big.Int).SetBytes(key)
+	if k.Sign() == 0 || k.Cmp(btcec.S256().N) >= 0 {
+		return nil, fmt.Errorf("seed gives an invalid master key")
+	}
+	return &ExtendedKey{params: params, key: key, chainCode: sum[32:], private: true}, nil
+}
+
+// IsPrivate reports whether the key can derive private children
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.private
+}
+
+// Depth returns the number of derivations from the master key
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// ChildNumber returns the index the key was derived at
+func (k *ExtendedKey) ChildNumber() uint32 {
+	return k.childNumber
+}
+
+// Child derives the child key at index i; indexes from HardenedKeyStart
+// give hardened children, which need a private key
+func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	if k.depth == 255 {
+		return nil, fmt.Errorf("cannot derive beyond depth 255")
+	}
+
+	hardened := i >= HardenedKeyStart
+	if hardened && !k.private {
+		return nil, ErrHardenedFromPublic
+	}
+
+	// Hardened children hash the private key, others the public key
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0x00)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, k.pubKeyBytes()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	curve := btcec.S256()
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+
+	child := &ExtendedKey{
+		params:      k.params,
+		chainCode:   sum[32:],
+		depth:       k.depth + 1,
+		parentFP:    k.fingerprint(),
+		childNumber: i,
+		private:     k.private,
+	}
+
+	if k.private {
+		// k_i = IL + k_par (mod n)
+		ki := new(big.Int).Add(il, new(big.Int).SetBytes(k.key))
+		ki.Mod(ki, curve.N)
+		if ki.Sign() == 0 {
+			return nil, ErrInvalidChild
+		}
+		child.key = ki.FillBytes(make([]byte, 32))
+		return child, nil
+	}
+
+	// K_i = IL*G + K_par
+	parent, err := btcec.ParsePubKey(k.key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	x, y := curve.ScalarBaseMult(sum[:32])
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	x, y = curve.Add(x, y, parent.X(), parent.Y())
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	child.key = compressPoint(x, y)
+	return child, nil
+}
+
+// Derive follows a derivation path from this key
+func (k *ExtendedKey) Derive(path Path) (*ExtendedKey, error) {
+	key := k
+	for _, i := range path {
+		child, err := key.Child(i)
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+	return key, nil
+}
+
+// Neuter returns the extended public key of a key, which derives the same
+// non-hardened public children but no private keys
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	if !k.private {
+		return k
+	}
+	return &ExtendedKey{
+		params:      k.params,
+		key:         k.pubKeyBytes(),
+		chainCode:   k.chainCode,
+		depth:       k.depth,
+		parentFP:    k.parentFP,
+		childNumber: k.childNumber,
+	}
+}
+
+// pubKeyBytes returns the compressed public key
+func (k *ExtendedKey) pubKeyBytes() []byte {
+	if !k.private {
+		return k.key
+	}
+	x, y := btcec.S256().ScalarBaseMult(k.key)
+	return compressPoint(x, y)
+}
+
+// fingerprint identifies a key as the first 4 bytes of its public key hash
+func (k *ExtendedKey) fingerprint() [4]byte {
+	var fp [4]byte
+	copy(fp[:], crypto.PublicKeyHash(k.pubKeyBytes()))
+	return fp
+}
+
+// compressPoint encodes a curve point as a 33-byte compressed public key
+func compressPoint(x, y *big.Int) []byte {
+	b := make([]byte, 33)
+	b[0] = 0x02 | byte(y.Bit(0))
+	x.FillBytes(b[1:])
+	return b
+}
+
+// PublicKey returns the public key in the uncompressed format used by
+// transactions and addresses
+func (k *ExtendedKey) PublicKey() []byte {
+	pub, _ := btcec.ParsePubKey(k.pubKeyBytes())
+	return pub.SerializeUncompressed()
+}
+
+// Address returns the address of the key's public key
+func (k *ExtendedKey) Address() string {
+	return crypto.GetAddressFromPubKey(k.PublicKey())
+}
+
+// PrivateKey returns the signing key of an extended private key
+func (k *ExtendedKey) PrivateKey() (*ecdsa.PrivateKey, error) {
+	if !k.private {
+		return nil, fmt.Errorf("extended key is public")
+	}
+	return crypto.PrivateKeyFromBytes(k.key), nil
+}
+
+// Wallet returns a wallet that signs with the key
+func (k *ExtendedKey) Wallet() (*crypto.Wallet, error) {
+	if !k.private {
+		return nil, fmt.Errorf("extended key is public")
+	}
+	return crypto.WalletFromPrivateKey(k.key), nil
+}
+
+// String encodes the key in base58check, as xprv/xpub on mainnet and
+// tprv/tpub on the test networks
+func (k *ExtendedKey) String() string {
+	version := k.params.HDPublicKeyID
+	if k.private {
+		version = k.params.HDPrivateKeyID
+	}
+
+	b := make([]byte, 0, serializedKeyLen+crypto.ChecksumLength)
+	b = append(b, version[:]...)
+	b = append(b, k.depth)
+	b = append(b, k.parentFP[:]...)
+	b = binary.BigEndian.AppendUint32(b, k.childNumber)
+	b = append(b, k.chainCode...)
+	if k.private {
+		b = append(b, 0x00)
+	}
+	b = append(b, k.key...)
+	b = append(b, crypto.Checksum(b)...)
+	return base58.Encode(b)
+}
+
+// ParseExtendedKey decodes an extended key of the given network
+func ParseExtendedKey(s string, params *chaincfg.Params) (*ExtendedKey, error) {
+	decoded, err := base58.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode extended key: %v", err)
+	}
+	if len(decoded) != serializedKeyLen+crypto.ChecksumLength {
+		return nil, fmt.Errorf("invalid extended key length")
+	}
+
+	payload := decoded[:serializedKeyLen]
+	if string(crypto.Checksum(payload)) != string(decoded[serializedKeyLen:]) {
+		return nil, fmt.Errorf("invalid extended key checksum")
+	}
+
+	var version [4]byte
+	copy(version[:], payload[:4])
+	k := &ExtendedKey{
+		params:      params,
+		depth:       payload[4],
+		childNumber: binary.BigEndian.Uint32(payload[9:13]),
+		chainCode:   append([]byte(nil), payload[13:45]...),
+	}
+	copy(k.parentFP[:], payload[5:9])
+
+	switch version {
+	case params.HDPrivateKeyID:
+		if payload[45] != 0x00 {
+			return nil, fmt.Errorf("invalid private key prefix")
+		}
+		k.private = true
+		k.key = append([]byte(nil), payload[46:]...)
+		d := new(big.Int).SetBytes(k.key)
+		if d.Sign() == 0 || d.Cmp(btcec.S256().N) >= 0 {
+			return nil, fmt.Errorf("invalid private key")
+		}
+	case params.HDPublicKeyID:
+		k.key = append([]byte(nil), payload[45:]...)
+		if _, err := btcec.ParsePubKey(k.key); err != nil {
+			return nil, fmt.Errorf("invalid public key: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("extended key belongs to another network (version %x)", version)
+	}
+
+	if k.depth == 0 && (k.parentFP != [4]byte{} || k.childNumber != 0) {
+		return nil, fmt.Errorf("invalid master key")
+	}
+	return k, nil
+}
@@ -0,0 +1,126 @@
+package hdwallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+)
+
+// BIP-32 test vector 1
+var extKeyVectors = []struct {
+	path string
+	xprv string
+	xpub string
+}{
+	{"m",
+		"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+		"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"},
+	{"m/0'",
+		"xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+		"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"},
+	{"m/0'/1",
+		"xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+		"xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ"},
+	{"m/0'/1/2'/2/1000000000",
+		"xprvA41z7zogVVwxVSgdKUHDy1SKmdb533PjDz7J6N6mV6uS3ze1ai8FHa8kmHScGpWmj4WggLyQjgPie1rFSruoUihUZREPSL39UNdE3BBDu76",
+		"xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy"},
+}
+
+func TestExtendedKeyVectors(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewMaster failed: %v", err)
+	}
+
+	for _, v := range extKeyVectors {
+		path, err := ParsePath(v.path)
+		if err != nil {
+			t.Fatalf("ParsePath(%s) failed: %v", v.path, err)
+		}
+		key, err := master.Derive(path)
+		if err != nil {
+			t.Fatalf("Derive(%s) failed: %v", v.path, err)
+		}
+		if got := key.String(); got != v.xprv {
+			t.Errorf("%s private key = %s, want %s", v.path, got, v.xprv)
+		}
+		if got := key.Neuter().String(); got != v.xpub {
+			t.Errorf("%s public key = %s, want %s", v.path, got, v.xpub)
+		}
+
+		parsed, err := ParseExtendedKey(v.xprv, &chaincfg.MainNetParams)
+		if err != nil || parsed.String() != v.xprv {
+			t.Errorf("ParseExtendedKey(%s) = %v, %v", v.xprv, parsed, err)
+		}
+		parsed, err = ParseExtendedKey(v.xpub, &chaincfg.MainNetParams)
+		if err != nil || parsed.String() != v.xpub || parsed.IsPrivate() {
+			t.Errorf("ParseExtendedKey(%s) = %v, %v", v.xpub, parsed, err)
+		}
+	}
+}
+
+func TestPublicDerivation(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, _ := NewMaster(seed, &chaincfg.TestNetParams)
+	account, err := master.Derive(AccountPath(chaincfg.TestNetParams.HDCoinType, 0))
+	if err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	xpub := account.Neuter()
+
+	// Non-hardened children of the public key match those of the private key
+	private, _ := account.Derive(Path{ExternalChain, 7})
+	public, err := xpub.Derive(Path{ExternalChain, 7})
+	if err != nil {
+		t.Fatalf("Public derivation failed: %v", err)
+	}
+	if private.Address() != public.Address() || !bytes.Equal(private.PublicKey(), public.PublicKey()) {
+		t.Error("Public and private derivation disagree")
+	}
+
+	if _, err := xpub.Child(HardenedKeyStart); err != ErrHardenedFromPublic {
+		t.Errorf("Hardened child of a public key: got %v, want ErrHardenedFromPublic", err)
+	}
+	if _, err := public.Wallet(); err == nil {
+		t.Error("Public key returned a wallet")
+	}
+
+	// The derived wallet signs for the derived address
+	wallet, err := private.Wallet()
+	if err != nil {
+		t.Fatalf("Wallet failed: %v", err)
+	}
+	if !bytes.Equal(wallet.PublicKey, private.PublicKey()) {
+		t.Error("Wallet public key differs from the extended key's")
+	}
+	signature, _ := wallet.Sign([]byte("message"))
+	if !crypto.VerifySignature(public.PublicKey(), []byte("message"), signature) {
+		t.Error("Signature of the derived wallet does not verify")
+	}
+
+	// Test network keys are rejected on mainnet
+	if _, err := ParseExtendedKey(xpub.String(), &chaincfg.MainNetParams); err == nil {
+		t.Error("Parsed a testnet key as mainnet")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := ParsePath("m/44'/1h/0'/1/5")
+	if err != nil {
+		t.Fatalf("ParsePath failed: %v", err)
+	}
+	want := AddressPath(1, 0, InternalChain, 5)
+	if path.String() != want.String() || path.String() != "m/44'/1'/0'/1/5" {
+		t.Errorf("ParsePath = %s, want %s", path, want)
+	}
+
+	for _, s := range []string{"", "44'/0'", "m/x", "m/2147483648", "m//1"} {
+		if _, err := ParsePath(s); err == nil {
+			t.Errorf("ParsePath(%q) succeeded", s)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package hdwallet
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// BIP-39 entropy sizes in bits; 128 bits give 12 words and 256 bits 24
+const (
+	MinEntropyBits     = 128
+	MaxEntropyBits     = 256
+	DefaultEntropyBits = 256
+)
+
+// seedIterations is the PBKDF2 iteration count of BIP-39 seeds
+const seedIterations = 2048
+
+//go:embed english.txt
+var englishWords string
+
+var (
+	wordList  = strings.Fields(englishWords)
+	wordIndex = indexWords(wordList)
+)
+
+// indexWords maps each word of a list to its position
+func indexWords(words []string) map[string]int {
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		index[word] = i
+	}
+	return index
+}
+
+// NewEntropy returns random entropy for a mnemonic of the given size in bits
+func NewEntropy(bits int) ([]byte, error) {
+	if err := checkEntropyBits(bits); err != nil {
+		return nil, err
+	}
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, fmt.Errorf("failed to generate entropy: %v", err)
+	}
+	return entropy, nil
+}
+
+// checkEntropyBits validates a BIP-39 entropy size
+func checkEntropyBits(bits int) error {
+	if bits < MinEntropyBits || bits > MaxEntropyBits || bits%32 != 0 {
+		return fmt.Errorf("invalid entropy size %d bits (want a multiple of 32 from %d to %d)", bits, MinEntropyBits, MaxEntropyBits)
+	}
+	return nil
+}
+
+// NewMnemonic encodes entropy as a BIP-39 English mnemonic: the entropy is
+// followed by the first bits of its SHA-256 as a checksum and split into
+// 11-bit word indexes
+func NewMnemonic(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if err := checkEntropyBits(bits); err != nil {
+		return "", err
+	}
+	checksumBits := bits / 32
+	hash := sha256.Sum256(entropy)
+
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, uint(checksumBits))
+	data.Or(data, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	wordCount := (bits + checksumBits) / 11
+	words := make([]string, wordCount)
+	mask := big.NewInt(2047)
+	index := new(big.Int)
+	for i := wordCount - 1; i >= 0; i-- {
+		index.And(data, mask)
+		words[i] = wordList[index.Int64()]
+		data.Rsh(data, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy decodes a mnemonic, verifying its words and checksum
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	wordCount := len(words)
+	if wordCount < 12 || wordCount > 24 || wordCount%3 != 0 {
+		return nil, fmt.Errorf("invalid mnemonic length %d words (want 12, 15, 18, 21 or 24)", wordCount)
+	}
+
+	data := new(big.Int)
+	for _, word := range words {
+		index, ok := wordIndex[strings.ToLower(word)]
+		if !ok {
+			return nil, fmt.Errorf("unknown mnemonic word %q", word)
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(index)))
+	}
+
+	checksumBits := wordCount * 11 / 33
+	entropyBytes := (wordCount*11 - checksumBits) / 8
+	checksum := new(big.Int).And(data, big.NewInt(1<<checksumBits-1))
+	data.Rsh(data, uint(checksumBits))
+
+	entropy := make([]byte, entropyBytes)
+	data.FillBytes(entropy)
+
+	hash := sha256.Sum256(entropy)
+	if checksum.Int64() != int64(hash[0]>>(8-checksumBits)) {
+		return nil, fmt.Errorf("invalid mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic checks that a mnemonic has known words and a valid checksum
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// NewSeed derives the 64-byte BIP-32 seed of a mnemonic and optional
+// passphrase with PBKDF2-HMAC-SHA512. Any passphrase is valid; a different
+// passphrase gives a different wallet.
+func NewSeed(mnemonic, passphrase string) []byte {
+	password := norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(password), []byte(salt), seedIterations, 64, sha512.New)
+}
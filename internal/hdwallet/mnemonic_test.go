@@ -0,0 +1,102 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// BIP-39 test vectors, all with the passphrase "TREZOR"
+var mnemonicVectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"808080808080808080808080808080808080808080808080",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always",
+		"107d7c02a5aa6f38c58083ff74f04c607c2d2c0ecc55501dadd72d025b751bc27fe913ffb796f841c49b1d33b610cf0e91d3aa239027f5e99fe4ce9e5088cd65",
+	},
+	{
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+		"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+	},
+}
+
+func TestMnemonicVectors(t *testing.T) {
+	if len(wordList) != 2048 {
+		t.Fatalf("Word list has %d words, want 2048", len(wordList))
+	}
+
+	for _, v := range mnemonicVectors {
+		entropy, _ := hex.DecodeString(v.entropy)
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("NewMnemonic(%s) failed: %v", v.entropy, err)
+		}
+		if mnemonic != v.mnemonic {
+			t.Errorf("NewMnemonic(%s) = %q, want %q", v.entropy, mnemonic, v.mnemonic)
+		}
+
+		decoded, err := MnemonicToEntropy(v.mnemonic)
+		if err != nil || hex.EncodeToString(decoded) != v.entropy {
+			t.Errorf("MnemonicToEntropy(%q) = %x, %v", v.mnemonic, decoded, err)
+		}
+
+		if seed := hex.EncodeToString(NewSeed(v.mnemonic, "TREZOR")); seed != v.seed {
+			t.Errorf("NewSeed(%q) = %s, want %s", v.mnemonic, seed, v.seed)
+		}
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	valid := mnemonicVectors[0].mnemonic
+	if err := ValidateMnemonic(strings.ToUpper(valid)); err != nil {
+		t.Errorf("Rejected an upper-case mnemonic: %v", err)
+	}
+
+	invalid := []string{
+		"",
+		"abandon abandon abandon",
+		strings.Replace(valid, "about", "abandon", 1),  // bad checksum
+		strings.Replace(valid, "about", "bitcoins", 1), // unknown word
+	}
+	for _, mnemonic := range invalid {
+		if err := ValidateMnemonic(mnemonic); err == nil {
+			t.Errorf("Accepted invalid mnemonic %q", mnemonic)
+		}
+	}
+}
+
+func TestNewEntropy(t *testing.T) {
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		entropy, err := NewEntropy(bits)
+		if err != nil || len(entropy) != bits/8 {
+			t.Fatalf("NewEntropy(%d) = %d bytes, %v", bits, len(entropy), err)
+		}
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("NewMnemonic failed: %v", err)
+		}
+		if words := len(strings.Fields(mnemonic)); words != bits*3/32 {
+			t.Errorf("%d bits of entropy gave %d words", bits, words)
+		}
+	}
+
+	for _, bits := range []int{0, 96, 130, 288} {
+		if _, err := NewEntropy(bits); err == nil {
+			t.Errorf("NewEntropy(%d) succeeded", bits)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package hdwallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Purpose is the BIP-44 purpose level of every derivation path
+const Purpose uint32 = 44
+
+// Chains of a BIP-44 account: receiving addresses are handed out on the
+// external chain, change goes to the internal chain
+const (
+	ExternalChain uint32 = 0
+	InternalChain uint32 = 1
+)
+
+// Path is a sequence of child indexes from the master key; hardened
+// indexes include HardenedKeyStart
+type Path []uint32
+
+// AccountPath returns m/44'/coinType'/account'
+func AccountPath(coinType, account uint32) Path {
+	return Path{Purpose + HardenedKeyStart, coinType + HardenedKeyStart, account + HardenedKeyStart}
+}
+
+// AddressPath returns m/44'/coinType'/account'/chain/index
+func AddressPath(coinType, account, chain, index uint32) Path {
+	return append(AccountPath(coinType, account), chain, index)
+}
+
+// ParsePath parses a path such as m/44'/0'/0'/0/1; hardened indexes are
+// marked with ' or h
+func ParsePath(s string) (Path, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with m", s)
+	}
+
+	path := make(Path, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid derivation path %q: bad index %q", s, part)
+		}
+		if hardened {
+			index += uint64(HardenedKeyStart)
+		}
+		path = append(path, uint32(index))
+	}
+	return path, nil
+}
+
+// String formats the path with ' marking hardened indexes
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range p {
+		b.WriteString("/")
+		if index >= HardenedKeyStart {
+			b.WriteString(strconv.FormatUint(uint64(index-HardenedKeyStart), 10))
+			b.WriteString("'")
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return b.String()
+}
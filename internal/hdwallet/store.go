@@ -0,0 +1,114 @@
+package hdwallet
+
+import (
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/storage"
+)
+
+// SaveKey derives the key at a path and stores it in the wallet with its
+// path. It returns ErrInvalidChild if the path has no valid key.
+func SaveKey(ws *storage.WalletStorage, master *ExtendedKey, path Path) (*ExtendedKey, error) {
+	key, err := master.Derive(path)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := key.Wallet()
+	if err != nil {
+		return nil, err
+	}
+	if err := ws.SaveHDWallet(key.Address(), wallet.PrivateKey.D.Bytes(), wallet.PublicKey, path.String()); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// DeriveNext stores the next unused key of a chain of an account of the
+// wallet's HD seed and advances the chain. The wallet must be unlocked.
+func DeriveNext(ws *storage.WalletStorage, params *chaincfg.Params, account, chain uint32) (*ExtendedKey, Path, error) {
+	seed, err := ws.GetHDSeed()
+	if err != nil {
+		return nil, nil, err
+	}
+	master, err := NewMaster(seed, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accounts, err := ws.GetHDAccounts()
+	if err != nil {
+		return nil, nil, err
+	}
+	state := storage.HDAccount{Account: account}
+	for _, a := range accounts {
+		if a.Account == account {
+			state = a
+		}
+	}
+	next := &state.NextExternal
+	if chain == InternalChain {
+		next = &state.NextInternal
+	}
+
+	// Skip the rare indexes without a valid key
+	for {
+		path := AddressPath(params.HDCoinType, account, chain, *next)
+		*next++
+		key, err := SaveKey(ws, master, path)
+		if err == ErrInvalidChild {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := ws.SetHDAccount(state); err != nil {
+			return nil, nil, err
+		}
+		return key, path, nil
+	}
+}
+
+// WatchAccount stores the addresses of both chains of an account's public
+// key as watched, up to gapLimit past the last used address of each chain,
+// and returns the addresses it added. Keys at depth 3 are assumed to be
+// BIP-44 accounts, so their addresses get full paths.
+func WatchAccount(ws *storage.WalletStorage, account *ExtendedKey, params *chaincfg.Params, gapLimit int, used UsedFunc) ([]string, error) {
+	var accountPath Path
+	if account.Depth() == 3 && account.ChildNumber() >= HardenedKeyStart {
+		accountPath = AccountPath(params.HDCoinType, account.ChildNumber()-HardenedKeyStart)
+	}
+
+	var added []string
+	for _, chain := range []uint32{ExternalChain, InternalChain} {
+		next, err := ScanChain(account, chain, gapLimit, used)
+		if err != nil {
+			return nil, err
+		}
+		chainKey, err := account.Child(chain)
+		if err != nil {
+			return nil, err
+		}
+
+		for index := uint32(0); index < next+uint32(gapLimit); index++ {
+			key, err := chainKey.Child(index)
+			if err == ErrInvalidChild {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			address := key.Address()
+			if ws.WalletExists(address) {
+				continue
+			}
+			path := ""
+			if accountPath != nil {
+				path = append(accountPath[:3:3], chain, index).String()
+			}
+			if err := ws.SaveWatchAddress(address, key.PublicKey(), path); err != nil {
+				return nil, err
+			}
+			added = append(added, address)
+		}
+	}
+	return added, nil
+}
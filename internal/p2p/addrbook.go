@@ -0,0 +1,283 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// maxAddrBookSize bounds the number of addresses kept on disk
+	maxAddrBookSize = 2000
+
+	// maxAddrFailures is the number of consecutive failed dials after which
+	// an address that never worked is forgotten
+	maxAddrFailures = 5
+
+	// retryInterval is the minimum wait before redialing a failed address;
+	// it doubles with every consecutive failure
+	retryInterval = time.Minute
+)
+
+// Address sources
+const (
+	SourceSeed   = "seed"
+	SourceManual = "manual"
+	SourceMDNS   = "mdns"
+	SourceDHT    = "dht"
+	SourceGossip = "addr"
+)
+
+// KnownAddress is an address book entry with its dial history
+type KnownAddress struct {
+	Addr        string    `json:"addr"` // Full multiaddr including /p2p/<id>
+	Source      string    `json:"source"`
+	Attempts    int       `json:"attempts"`
+	Successes   int       `json:"successes"`
+	Failures    int       `json:"failures"` // Consecutive failed dials
+	LastSeen    time.Time `json:"last_seen"`
+	LastAttempt time.Time `json:"last_attempt"`
+	LastSuccess time.Time `json:"last_success"`
+}
+
+// Score rates how likely the address is to accept a connection, between 0 and 1.
+// Untried addresses start at 0.5; every success raises and every failure
+// lowers the score, and recent successes count extra.
+func (ka *KnownAddress) Score() float64 {
+	score := float64(ka.Successes+1) / float64(ka.Attempts+2)
+	if !ka.LastSuccess.IsZero() && time.Since(ka.LastSuccess) < 24*time.Hour {
+		score = (score + 1) / 2
+	}
+	for i := 0; i < ka.Failures; i++ {
+		score /= 2
+	}
+	return score
+}
+
+// retryAt returns the earliest time the address should be dialed again
+func (ka *KnownAddress) retryAt() time.Time {
+	if ka.Failures == 0 {
+		return ka.LastAttempt
+	}
+	return ka.LastAttempt.Add(retryInterval << uint(ka.Failures-1))
+}
+
+// AddrBook is the on-disk set of peer addresses a node has learned, so it
+// can reconnect after a restart without a seed or --connect
+type AddrBook struct {
+	path  string
+	mu    sync.Mutex
+	addrs map[string]*KnownAddress
+}
+
+// NewAddrBook loads the address book at path, starting empty if the file
+// does not exist. An empty path keeps the book in memory only.
+func NewAddrBook(path string) (*AddrBook, error) {
+	book := &AddrBook{
+		path:  path,
+		addrs: make(map[string]*KnownAddress),
+	}
+
+	if path == "" {
+		return book, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return book, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %v", err)
+	}
+
+	var entries []*KnownAddress
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse address book %s: %v", path, err)
+	}
+	for _, ka := range entries {
+		book.addrs[ka.Addr] = ka
+	}
+	return book, nil
+}
+
+// Save writes the address book to disk
+func (b *AddrBook) Save() error {
+	if b.path == "" {
+		return nil
+	}
+
+	b.mu.Lock()
+	data, err := json.MarshalIndent(b.sorted(), "", "  ")
+	b.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode address book: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0700); err != nil {
+		return fmt.Errorf("failed to create address book directory: %v", err)
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write address book: %v", err)
+	}
+	return os.Rename(tmp, b.path)
+}
+
+// Add records an address, reporting whether it was new. Addresses must
+// include the peer ID.
+func (b *AddrBook) Add(addr, source string) bool {
+	if _, err := peer.AddrInfoFromString(addr); err != nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ka, ok := b.addrs[addr]; ok {
+		ka.LastSeen = time.Now()
+		return false
+	}
+
+	if len(b.addrs) >= maxAddrBookSize {
+		b.evict()
+	}
+	b.addrs[addr] = &KnownAddress{Addr: addr, Source: source, LastSeen: time.Now()}
+	return true
+}
+
+// Attempt records a dial attempt
+func (b *AddrBook) Attempt(addr string) {
+	b.update(addr, func(ka *KnownAddress) {
+		ka.Attempts++
+		ka.LastAttempt = time.Now()
+	})
+}
+
+// Good records a successful connection
+func (b *AddrBook) Good(addr string) {
+	b.update(addr, func(ka *KnownAddress) {
+		ka.Successes++
+		ka.Failures = 0
+		ka.LastSuccess = time.Now()
+		ka.LastSeen = ka.LastSuccess
+	})
+}
+
+// Failed records a failed connection; addresses that never worked are
+// forgotten after maxAddrFailures consecutive failures
+func (b *AddrBook) Failed(addr string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ka, ok := b.addrs[addr]
+	if !ok {
+		return
+	}
+	ka.Failures++
+	if ka.Successes == 0 && ka.Failures >= maxAddrFailures && ka.Source != SourceSeed {
+		delete(b.addrs, addr)
+	}
+}
+
+// Get returns a copy of an entry
+func (b *AddrBook) Get(addr string) (KnownAddress, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ka, ok := b.addrs[addr]
+	if !ok {
+		return KnownAddress{}, false
+	}
+	return *ka, true
+}
+
+// Len returns the number of known addresses
+func (b *AddrBook) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.addrs)
+}
+
+// Candidates returns up to n addresses worth dialing now, best first,
+// skipping peers for which skip returns true
+func (b *AddrBook) Candidates(n int, skip func(peer.ID) bool) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	seen := make(map[peer.ID]bool)
+	candidates := make([]string, 0, n)
+	for _, ka := range b.sorted() {
+		if len(candidates) == n {
+			break
+		}
+		if ka.Attempts > 0 && now.Before(ka.retryAt()) {
+			continue
+		}
+		info, err := peer.AddrInfoFromString(ka.Addr)
+		if err != nil || seen[info.ID] || skip(info.ID) {
+			continue
+		}
+		seen[info.ID] = true
+		candidates = append(candidates, ka.Addr)
+	}
+	return candidates
+}
+
+// Sample returns up to n addresses to share with peers, best first.
+// Addresses that keep failing are not passed on.
+func (b *AddrBook) Sample(n int) []KnownAddress {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sample := make([]KnownAddress, 0, n)
+	for _, ka := range b.sorted() {
+		if len(sample) == n {
+			break
+		}
+		if ka.Failures > 0 {
+			continue
+		}
+		sample = append(sample, *ka)
+	}
+	return sample
+}
+
+// sorted returns all entries by descending score; callers hold b.mu
+func (b *AddrBook) sorted() []*KnownAddress {
+	entries := make([]*KnownAddress, 0, len(b.addrs))
+	for _, ka := range b.addrs {
+		entries = append(entries, ka)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		si, sj := entries[i].Score(), entries[j].Score()
+		if si != sj {
+			return si > sj
+		}
+		return entries[i].LastSeen.After(entries[j].LastSeen)
+	})
+	return entries
+}
+
+// evict drops the worst address to make room; callers hold b.mu
+func (b *AddrBook) evict() {
+	entries := b.sorted()
+	if len(entries) > 0 {
+		delete(b.addrs, entries[len(entries)-1].Addr)
+	}
+}
+
+// update applies fn to an entry if it exists
+func (b *AddrBook) update(addr string, fn func(*KnownAddress)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ka, ok := b.addrs[addr]; ok {
+		fn(ka)
+	}
+}
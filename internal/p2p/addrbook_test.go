@@ -0,0 +1,87 @@
+package p2p
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	testAddrA = "/ip4/10.0.0.1/tcp/9000/p2p/12D3KooWKHyutAuhviTbp2rqyqjD9LjgAWe8Ny6fWh2bBqa8d3JJ"
+	testAddrB = "/ip4/10.0.0.2/tcp/9000/p2p/12D3KooWAPiUoK3gL7jMe9peQCYr2UArb6xoxdb95A455NjSd84B"
+)
+
+func noSkip(peer.ID) bool { return false }
+
+func TestAddrBookPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peers.json")
+
+	book, err := NewAddrBook(path)
+	if err != nil {
+		t.Fatalf("Failed to create address book: %v", err)
+	}
+	if !book.Add(testAddrA, SourceSeed) {
+		t.Error("New address not reported as new")
+	}
+	if book.Add(testAddrA, SourceGossip) {
+		t.Error("Known address reported as new")
+	}
+	if book.Add("/ip4/10.0.0.3/tcp/9000", SourceGossip) {
+		t.Error("Accepted an address without a peer ID")
+	}
+	book.Attempt(testAddrA)
+	book.Good(testAddrA)
+
+	if err := book.Save(); err != nil {
+		t.Fatalf("Failed to save address book: %v", err)
+	}
+
+	reloaded, err := NewAddrBook(path)
+	if err != nil {
+		t.Fatalf("Failed to reload address book: %v", err)
+	}
+	ka, ok := reloaded.Get(testAddrA)
+	if !ok {
+		t.Fatal("Address lost across restart")
+	}
+	if ka.Source != SourceSeed || ka.Successes != 1 || ka.Attempts != 1 {
+		t.Errorf("Unexpected entry after reload: %+v", ka)
+	}
+}
+
+func TestAddrBookScoresReliability(t *testing.T) {
+	book, _ := NewAddrBook("")
+	book.Add(testAddrA, SourceGossip)
+	book.Add(testAddrB, SourceGossip)
+
+	// A works, B keeps failing
+	book.Attempt(testAddrA)
+	book.Good(testAddrA)
+	book.Attempt(testAddrB)
+	book.Failed(testAddrB)
+
+	a, _ := book.Get(testAddrA)
+	b, _ := book.Get(testAddrB)
+	if a.Score() <= b.Score() {
+		t.Errorf("Reliable address scored %.3f, failing one %.3f", a.Score(), b.Score())
+	}
+
+	// The failed address backs off before being retried
+	candidates := book.Candidates(2, noSkip)
+	if len(candidates) != 1 || candidates[0] != testAddrA {
+		t.Errorf("Expected only the reliable address, got %v", candidates)
+	}
+	if sample := book.Sample(10); len(sample) != 1 {
+		t.Errorf("Failing address shared with peers: %+v", sample)
+	}
+
+	// Addresses that never worked are forgotten
+	for i := 1; i < maxAddrFailures; i++ {
+		book.Attempt(testAddrB)
+		book.Failed(testAddrB)
+	}
+	if _, ok := book.Get(testAddrB); ok {
+		t.Error("Address that never worked was not forgotten")
+	}
+}
@@ -0,0 +1,215 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// DefaultBanDuration is how long a misbehaving peer stays banned
+const DefaultBanDuration = 24 * time.Hour
+
+// BanEntry is a banned peer. Peer IDs are free to generate, so the ban
+// also covers the IP address the peer was connected from, if known.
+type BanEntry struct {
+	PeerID    peer.ID   `json:"peer_id"`
+	IP        string    `json:"ip,omitempty"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	Until     time.Time `json:"until"` // Zero for a permanent ban
+}
+
+// Permanent reports whether the ban never expires
+func (e *BanEntry) Permanent() bool {
+	return e.Until.IsZero()
+}
+
+// expired reports whether a temporary ban has run out
+func (e *BanEntry) expired(now time.Time) bool {
+	return !e.Permanent() && !now.Before(e.Until)
+}
+
+// BanList is the set of banned peers, kept on disk so bans survive restarts
+type BanList struct {
+	path string
+	mu   sync.Mutex
+	bans map[peer.ID]*BanEntry
+}
+
+// NewBanList loads the ban list at path, starting empty if the file does not
+// exist. An empty path keeps the list in memory only.
+func NewBanList(path string) (*BanList, error) {
+	list := &BanList{bans: make(map[peer.ID]*BanEntry)}
+	if err := list.load(path); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// load switches the list to the file at path and merges in its entries
+func (l *BanList) load(path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.path = path
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read ban list: %v", err)
+	}
+
+	var entries []*BanEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("failed to parse ban list %s: %v", path, err)
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		if !entry.expired(now) {
+			l.bans[entry.PeerID] = entry
+		}
+	}
+	return nil
+}
+
+// Save writes the ban list to disk
+func (l *BanList) Save() error {
+	l.mu.Lock()
+	path := l.path
+	data, err := json.MarshalIndent(l.list(), "", "  ")
+	l.mu.Unlock()
+	if path == "" {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode ban list: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create ban list directory: %v", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write ban list: %v", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Ban bans a peer for duration, or permanently if duration is not positive,
+// and saves the list
+func (l *BanList) Ban(peerID peer.ID, duration time.Duration, reason string) error {
+	return l.BanAddr(peerID, "", duration, reason)
+}
+
+// BanAddr bans a peer like Ban, and with it the IP address it connected
+// from unless ip is empty
+func (l *BanList) BanAddr(peerID peer.ID, ip string, duration time.Duration, reason string) error {
+	entry := &BanEntry{PeerID: peerID, IP: ip, Reason: reason, CreatedAt: time.Now()}
+	if duration > 0 {
+		entry.Until = entry.CreatedAt.Add(duration)
+	}
+
+	l.mu.Lock()
+	l.bans[peerID] = entry
+	l.mu.Unlock()
+	return l.Save()
+}
+
+// Unban lifts a ban, reporting whether the peer was banned
+func (l *BanList) Unban(peerID peer.ID) (bool, error) {
+	l.mu.Lock()
+	_, ok := l.bans[peerID]
+	delete(l.bans, peerID)
+	l.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+	return true, l.Save()
+}
+
+// IsBanned reports whether a peer is currently banned
+func (l *BanList) IsBanned(peerID peer.ID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.bans[peerID]
+	if !ok {
+		return false
+	}
+	if entry.expired(time.Now()) {
+		delete(l.bans, peerID)
+		return false
+	}
+	return true
+}
+
+// IsBannedAddr reports whether an address is on a banned IP
+func (l *BanList) IsBannedAddr(addr multiaddr.Multiaddr) bool {
+	ip := addrIP(addr)
+	if ip == "" {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, entry := range l.list() {
+		if entry.IP == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// addrIP returns the IP address of a multiaddr, or "" if it has none
+func addrIP(addr multiaddr.Multiaddr) string {
+	if addr == nil {
+		return ""
+	}
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// List returns the current bans, oldest first
+func (l *BanList) List() []BanEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]BanEntry, 0, len(l.bans))
+	for _, entry := range l.list() {
+		entries = append(entries, *entry)
+	}
+	return entries
+}
+
+// list drops expired bans and returns the rest oldest first; callers hold l.mu
+func (l *BanList) list() []*BanEntry {
+	now := time.Now()
+	entries := make([]*BanEntry, 0, len(l.bans))
+	for id, entry := range l.bans {
+		if entry.expired(now) {
+			delete(l.bans, id)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries
+}
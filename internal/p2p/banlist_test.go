@@ -0,0 +1,100 @@
+package p2p
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+func TestBanListPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banlist.json")
+	infoA, _ := peer.AddrInfoFromString(testAddrA)
+	infoB, _ := peer.AddrInfoFromString(testAddrB)
+
+	list, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("Failed to create ban list: %v", err)
+	}
+	if err := list.Ban(infoA.ID, time.Hour, "invalid block"); err != nil {
+		t.Fatalf("Failed to ban peer: %v", err)
+	}
+	if err := list.Ban(infoB.ID, 0, "operator"); err != nil {
+		t.Fatalf("Failed to ban peer: %v", err)
+	}
+
+	reloaded, err := NewBanList(path)
+	if err != nil {
+		t.Fatalf("Failed to reload ban list: %v", err)
+	}
+	if !reloaded.IsBanned(infoA.ID) || !reloaded.IsBanned(infoB.ID) {
+		t.Fatal("Bans lost across reload")
+	}
+
+	entries := reloaded.List()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 bans, got %d", len(entries))
+	}
+	if entries[0].PeerID != infoA.ID || entries[0].Reason != "invalid block" || entries[0].Permanent() {
+		t.Errorf("Unexpected temporary ban %+v", entries[0])
+	}
+	if !entries[1].Permanent() {
+		t.Error("Ban without duration is not permanent")
+	}
+
+	removed, err := reloaded.Unban(infoA.ID)
+	if err != nil || !removed {
+		t.Fatalf("Failed to unban peer: %v", err)
+	}
+	if removed, _ := reloaded.Unban(infoA.ID); removed {
+		t.Error("Unbanning twice reported a ban")
+	}
+
+	again, _ := NewBanList(path)
+	if again.IsBanned(infoA.ID) {
+		t.Error("Lifted ban came back after reload")
+	}
+}
+
+func TestBanListExpires(t *testing.T) {
+	infoA, _ := peer.AddrInfoFromString(testAddrA)
+
+	list, _ := NewBanList("")
+	list.Ban(infoA.ID, time.Millisecond, "flooding")
+	time.Sleep(5 * time.Millisecond)
+
+	if list.IsBanned(infoA.ID) {
+		t.Error("Expired ban still in force")
+	}
+	if len(list.List()) != 0 {
+		t.Error("Expired ban still listed")
+	}
+}
+
+func TestBanListBansAddresses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banlist.json")
+	infoA, _ := peer.AddrInfoFromString(testAddrA)
+	infoB, _ := peer.AddrInfoFromString(testAddrB)
+
+	list, _ := NewBanList(path)
+	if err := list.BanAddr(infoA.ID, addrIP(infoA.Addrs[0]), time.Hour, "invalid block"); err != nil {
+		t.Fatalf("Failed to ban peer: %v", err)
+	}
+
+	// Any port and peer ID on the banned IP is refused
+	sameIP, _ := multiaddr.NewMultiaddr("/ip4/10.0.0.1/tcp/9999")
+	reloaded, _ := NewBanList(path)
+	if !reloaded.IsBannedAddr(sameIP) {
+		t.Error("Banned IP address not refused after reload")
+	}
+	if reloaded.IsBannedAddr(infoB.Addrs[0]) {
+		t.Error("Other IP address refused")
+	}
+
+	reloaded.Unban(infoA.ID)
+	if reloaded.IsBannedAddr(sameIP) {
+		t.Error("IP address still refused after the ban was lifted")
+	}
+}
@@ -0,0 +1,256 @@
+package p2p
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/yourusername/bt/internal/merkle"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+// shortIDSize is the number of bytes of a short transaction ID on the wire
+const shortIDSize = 6
+
+// CompactBlock announces a block by its header and short IDs of its
+// transactions, so peers rebuild it from their pending pool. Transactions a
+// peer cannot have yet, such as the coinbase, are prefilled in full.
+type CompactBlock struct {
+	Header    types.BlockHeader
+	Hash      []byte
+	Nonce     uint64   // Salts the short IDs so collisions differ per peer and block
+	ShortIDs  []uint64 // Short IDs of the transactions not prefilled, in block order
+	Prefilled []PrefilledTx
+}
+
+// PrefilledTx is a transaction sent in full at its index in the block
+type PrefilledTx struct {
+	Index int
+	Tx    *tx.Transaction
+}
+
+// shortIDKey derives the short ID key from a block hash and nonce
+func shortIDKey(blockHash []byte, nonce uint64) []byte {
+	h := sha256.New()
+	h.Write(blockHash)
+	binary.Write(h, binary.LittleEndian, nonce)
+	return h.Sum(nil)
+}
+
+// shortTxID returns the first 6 bytes of SHA-256(key || txID)
+func shortTxID(key, txID []byte) uint64 {
+	h := sha256.New()
+	h.Write(key)
+	h.Write(txID)
+
+	var id [8]byte
+	copy(id[:shortIDSize], h.Sum(nil))
+	return binary.LittleEndian.Uint64(id[:])
+}
+
+// newCompactBlock builds the compact form of a block, prefilling the coinbase
+func newCompactBlock(block *types.Block, nonce uint64) *CompactBlock {
+	cb := &CompactBlock{Header: block.Header, Hash: block.Hash, Nonce: nonce}
+
+	key := shortIDKey(block.Hash, nonce)
+	for i, transaction := range block.Transactions.([]*tx.Transaction) {
+		if i == 0 {
+			cb.Prefilled = append(cb.Prefilled, PrefilledTx{Index: 0, Tx: transaction})
+			continue
+		}
+		cb.ShortIDs = append(cb.ShortIDs, shortTxID(key, transaction.ID))
+	}
+	return cb
+}
+
+// reconstruct fills a compact block's transactions from the prefilled ones
+// and pool. It returns the transactions in block order and the indexes of
+// those still missing, which are left nil. Pool transactions whose short
+// IDs collide are treated as missing.
+func (cb *CompactBlock) reconstruct(pool []*tx.Transaction) ([]*tx.Transaction, []int, error) {
+	txs := make([]*tx.Transaction, len(cb.ShortIDs)+len(cb.Prefilled))
+	for _, p := range cb.Prefilled {
+		if p.Index < 0 || p.Index >= len(txs) {
+			return nil, nil, fmt.Errorf("prefilled index %d out of range", p.Index)
+		}
+		if txs[p.Index] != nil {
+			return nil, nil, fmt.Errorf("duplicate prefilled index %d", p.Index)
+		}
+		txs[p.Index] = p.Tx
+	}
+
+	key := shortIDKey(cb.Hash, cb.Nonce)
+	byShortID := make(map[uint64]*tx.Transaction, len(pool))
+	for _, transaction := range pool {
+		id := shortTxID(key, transaction.ID)
+		if _, ok := byShortID[id]; ok {
+			byShortID[id] = nil
+			continue
+		}
+		byShortID[id] = transaction
+	}
+
+	var missing []int
+	next := 0
+	for i := range txs {
+		if txs[i] != nil {
+			continue
+		}
+		if txs[i] = byShortID[cb.ShortIDs[next]]; txs[i] == nil {
+			missing = append(missing, i)
+		}
+		next++
+	}
+	return txs, missing, nil
+}
+
+// announceBlock relays a new block to every peer not yet known to have it:
+// as a compact block to peers that support them and by inv to the others
+func (n *Network) announceBlock(block *types.Block) {
+	var compact, inv *Message
+	for _, c := range n.announceTargets(block.Hash) {
+		if c.info.ProtocolVersion >= CompactBlocksVersion {
+			if compact == nil {
+				var w wireWriter
+				w.compactBlock(newCompactBlock(block, rand.Uint64()))
+				compact = &Message{Type: MsgTypeCmpctBlock, Data: w.Bytes()}
+			}
+			go c.send(compact)
+			continue
+		}
+
+		if inv == nil {
+			var w wireWriter
+			w.invs([]InvVect{{Type: InvTypeBlock, Hash: block.Hash}})
+			inv = &Message{Type: MsgTypeInv, Data: w.Bytes()}
+		}
+		go c.send(inv)
+	}
+}
+
+// handleCompactBlock rebuilds a relayed compact block from the pending pool,
+// fetches the transactions we lack from the sender and processes the block.
+// If the rebuilt block does not match its merkle root the full block is
+// fetched instead.
+func (n *Network) handleCompactBlock(c *peerConn, data []byte) {
+	r := newWireReader(data)
+	cb := r.compactBlock()
+	if err := r.done(); err != nil {
+		n.misbehaving(c, PenaltyMalformed, fmt.Sprintf("invalid compact block: %v", err))
+		return
+	}
+
+	// Never announce it back
+	if c.known.Add(cb.Hash) {
+		c.bestHeight.Add(1)
+	}
+	if n.haveInventory(InvVect{Type: InvTypeBlock, Hash: cb.Hash}) || !n.startRequest(cb.Hash) {
+		return
+	}
+	defer n.finishRequest(cb.Hash)
+
+	// Check the work before spending a round trip on the block
+	if penalty, err := n.checkBlockWork(&cb.Header, cb.Hash); err != nil {
+		n.misbehaving(c, penalty, fmt.Sprintf("compact block %x: %v", cb.Hash, err))
+		return
+	}
+
+	txs, missing, err := cb.reconstruct(n.pendingTxs())
+	if err != nil {
+		n.misbehaving(c, PenaltyMalformed, fmt.Sprintf("invalid compact block %x: %v", cb.Hash, err))
+		return
+	}
+	if len(missing) > 0 && !n.fetchBlockTxns(c, cb.Hash, txs, missing) {
+		return
+	}
+
+	ids := make([][]byte, len(txs))
+	for i, transaction := range txs {
+		ids[i] = transaction.ID
+	}
+	if !bytes.Equal(merkle.BuildMerkleRoot(ids), cb.Header.MerkleRoot) {
+		fmt.Printf("Compact block %x did not rebuild, fetching it in full\n", cb.Hash[:8])
+		n.fetchData(c, []InvVect{{Type: InvTypeBlock, Hash: cb.Hash}})
+		return
+	}
+
+	fmt.Printf("📦 Rebuilt compact block %x (%d of %d transactions from pending pool)\n",
+		cb.Hash[:8], len(txs)-len(cb.Prefilled)-len(missing), len(txs))
+	n.processReceivedBlock(&types.Block{Header: cb.Header, Hash: cb.Hash, Transactions: txs}, c.info.ID)
+}
+
+// fetchBlockTxns requests the missing transactions of a compact block by
+// index and fills them into txs, reporting whether all of them arrived
+func (n *Network) fetchBlockTxns(c *peerConn, hash []byte, txs []*tx.Transaction, missing []int) bool {
+	var w wireWriter
+	w.bytes(hash)
+	w.count(len(missing))
+	for _, index := range missing {
+		w.varint(int64(index))
+	}
+
+	response, err := c.request(MsgTypeGetBlockTxn, w.Bytes(), syncTimeout)
+	if err != nil {
+		fmt.Printf("Failed to fetch block transactions from %s: %v\n", c.info.ID, err)
+		return false
+	}
+	if response.Type != MsgTypeBlockTxn {
+		return false
+	}
+
+	r := newWireReader(response.Data)
+	received := make([]*tx.Transaction, r.count())
+	for i := range received {
+		received[i] = r.transaction()
+	}
+	if err := r.done(); err != nil {
+		n.misbehaving(c, PenaltyMalformed, fmt.Sprintf("invalid blocktxn: %v", err))
+		return false
+	}
+	if len(received) == 0 {
+		return false // The peer no longer has the block
+	}
+	if len(received) != len(missing) {
+		n.misbehaving(c, PenaltyMalformed, fmt.Sprintf("blocktxn has %d transactions, requested %d", len(received), len(missing)))
+		return false
+	}
+
+	for i, index := range missing {
+		txs[index] = received[i]
+	}
+	return true
+}
+
+// serveBlockTxn answers a getblocktxn request with the requested
+// transactions of a block; a block we do not have yields an empty reply
+func (n *Network) serveBlockTxn(data []byte) ([]byte, error) {
+	r := newWireReader(data)
+	hash := r.bytes()
+	indexes := make([]int, r.count())
+	for i := range indexes {
+		indexes[i] = int(r.varint())
+	}
+	if err := r.done(); err != nil {
+		return nil, fmt.Errorf("invalid getblocktxn: %v", err)
+	}
+
+	var w wireWriter
+	block, err := n.blockchain.GetBlockByHash(hash)
+	if err != nil {
+		w.count(0)
+		return w.Bytes(), nil
+	}
+
+	txs := block.Transactions.([]*tx.Transaction)
+	w.count(len(indexes))
+	for _, index := range indexes {
+		if index < 0 || index >= len(txs) {
+			return nil, fmt.Errorf("transaction index %d out of range", index)
+		}
+		w.transaction(txs[index])
+	}
+	return w.Bytes(), nil
+}
@@ -0,0 +1,145 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+// newTestBlock returns an unmined block holding a coinbase and n other
+// transactions with distinct IDs
+func newTestBlock(n int) *types.Block {
+	txs := make([]*tx.Transaction, n+1)
+	for i := range txs {
+		txs[i] = tx.NewTransaction(nil, []tx.TxOutput{{Value: int64(i + 1), PubKeyHash: []byte("recipient")}})
+	}
+	return &types.Block{
+		Header:       types.BlockHeader{Version: 1, Timestamp: time.Unix(1700000000, 0)},
+		Hash:         crypto.DoubleHashBytes([]byte("block")),
+		Transactions: txs,
+	}
+}
+
+func TestCompactBlockEncodingRoundTrip(t *testing.T) {
+	sent := newCompactBlock(newTestBlock(3), 42)
+	if len(sent.ShortIDs) != 3 || len(sent.Prefilled) != 1 || sent.Prefilled[0].Index != 0 {
+		t.Fatalf("Unexpected compact block layout: %d short IDs, %d prefilled", len(sent.ShortIDs), len(sent.Prefilled))
+	}
+
+	var w wireWriter
+	w.compactBlock(sent)
+	r := newWireReader(w.Bytes())
+	got := r.compactBlock()
+	if err := r.done(); err != nil {
+		t.Fatalf("Failed to decode compact block: %v", err)
+	}
+
+	if !bytes.Equal(got.Hash, sent.Hash) || got.Nonce != sent.Nonce {
+		t.Error("Hash or nonce changed in round trip")
+	}
+	for i, id := range sent.ShortIDs {
+		if got.ShortIDs[i] != id || id >= 1<<(8*shortIDSize) {
+			t.Errorf("Short ID %d: sent %x, got %x", i, id, got.ShortIDs[i])
+		}
+	}
+	if !bytes.Equal(got.Prefilled[0].Tx.ID, sent.Prefilled[0].Tx.ID) {
+		t.Error("Prefilled coinbase changed in round trip")
+	}
+}
+
+func TestReconstructCompactBlock(t *testing.T) {
+	block := newTestBlock(3)
+	txs := block.Transactions.([]*tx.Transaction)
+	cb := newCompactBlock(block, 7)
+
+	// The pool lacks the second transaction and holds an unrelated one
+	unrelated := &tx.Transaction{ID: crypto.DoubleHashBytes([]byte("unrelated"))}
+	rebuilt, missing, err := cb.reconstruct([]*tx.Transaction{txs[3], unrelated, txs[1]})
+	if err != nil {
+		t.Fatalf("Failed to reconstruct: %v", err)
+	}
+	if len(missing) != 1 || missing[0] != 2 {
+		t.Fatalf("Expected index 2 missing, got %v", missing)
+	}
+	for _, i := range []int{0, 1, 3} {
+		if rebuilt[i] != txs[i] {
+			t.Errorf("Transaction %d not filled in", i)
+		}
+	}
+	if rebuilt[2] != nil {
+		t.Error("Missing transaction was filled in")
+	}
+
+	// Another nonce yields other short IDs, so the pool no longer matches
+	salted := newCompactBlock(block, 8)
+	if salted.ShortIDs[0] == cb.ShortIDs[0] {
+		t.Error("Short IDs do not depend on the nonce")
+	}
+
+	cb.Prefilled[0].Index = 4
+	if _, _, err := cb.reconstruct(nil); err == nil {
+		t.Error("Accepted a prefilled index beyond the block")
+	}
+}
+
+func TestCompactBlockRelay(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping relay test in short mode")
+	}
+
+	sender, _ := newRegTestNode(t, 0)
+	receiver, _ := newRegTestNode(t, 0)
+	if err := receiver.ConnectToPeer(sender.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	// Fund two wallets so two independent transactions can be mined
+	alice, _ := crypto.NewWallet()
+	bob, _ := crypto.NewWallet()
+	for _, miner := range []*crypto.Wallet{alice, bob} {
+		block, err := sender.blockchain.AddBlock(nil, miner.GetAddress())
+		if err != nil {
+			t.Fatalf("Failed to mine block: %v", err)
+		}
+		sender.BroadcastBlock(block)
+		waitForTip(t, receiver.blockchain, block.Hash)
+	}
+
+	recipient, _ := crypto.NewWallet()
+	relayed, err := sender.blockchain.CreateTransaction(alice.GetAddress(), recipient.GetAddress(), 10, alice)
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+	sender.BroadcastTransaction(relayed)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for receiver.findPendingTx(relayed.ID) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Transaction was not relayed")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// The receiver never hears of this one before the block
+	unseen, err := sender.blockchain.CreateTransaction(bob.GetAddress(), recipient.GetAddress(), 10, bob)
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	block, err := sender.blockchain.AddBlock([]*tx.Transaction{relayed, unseen}, alice.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+	sender.BroadcastBlock(block)
+
+	// The receiver rebuilds the block and fetches only the unseen transaction
+	waitForTip(t, receiver.blockchain, block.Hash)
+	got := receiver.blockchain.GetLatestBlock().Transactions.([]*tx.Transaction)
+	if len(got) != 3 || !bytes.Equal(got[2].ID, unseen.ID) {
+		t.Errorf("Rebuilt block has unexpected transactions")
+	}
+}
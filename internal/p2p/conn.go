@@ -0,0 +1,216 @@
+package p2p
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+)
+
+const (
+	// WireProtocol carries all messages exchanged with a peer over one
+	// long-lived stream, starting with the version handshake
+	WireProtocol = "/wire/1.0.0"
+
+	// writeTimeout bounds writing a single message
+	writeTimeout = 30 * time.Second
+
+	// handlerWorkers bounds the messages of one peer handled at once
+	handlerWorkers = 4
+
+	// inboxSize bounds the messages of one peer waiting for a worker; a
+	// peer that gets further ahead is flooding us
+	inboxSize = 64
+)
+
+var (
+	// errConnClosed is returned for requests on a closed connection
+	errConnClosed = errors.New("connection closed")
+
+	// errRateLimited and errInboxFull are why readLoop drops a message
+	errRateLimited = errors.New("rate limit exceeded")
+	errInboxFull   = errors.New("too many messages waiting")
+)
+
+// peerConn is the long-lived message stream to a peer. Requests carry an id
+// so any number of them can be in flight and answered out of order.
+type peerConn struct {
+	stream network.Stream
+	reader *bufio.Reader
+	magic  uint32
+
+	// Set once the handshake has completed
+	info  *PeerInfo
+	known *inventoryFilter
+
+	// Misbehavior score and per-type limits on the peer's messages
+	score   atomic.Int32
+	limiter *rateLimiter
+
+	// Height the peer advertised in its version, plus the blocks it
+	// announced since; it bounds the headers we take from the peer
+	bestHeight atomic.Int64
+
+	stats peerStats
+
+	writeMutex sync.Mutex
+
+	pendingMutex sync.Mutex
+	nextID       uint32
+	pending      map[uint32]chan *Message
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// newPeerConn wraps a stream to a peer
+func newPeerConn(stream network.Stream, magic uint32) *peerConn {
+	return &peerConn{
+		stream:  stream,
+		reader:  bufio.NewReader(stream),
+		magic:   magic,
+		known:   newInventoryFilter(maxKnownInventory),
+		limiter: newRateLimiter(),
+		pending: make(map[uint32]chan *Message),
+		closed:  make(chan struct{}),
+	}
+}
+
+// send writes a message; concurrent senders are serialized
+func (c *peerConn) send(msg *Message) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	c.stream.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := writeMessage(c.stream, c.magic, msg); err != nil {
+		return fmt.Errorf("failed to send %s: %v", msg.Type, err)
+	}
+	c.stats.sent(msg)
+	return nil
+}
+
+// read reads the next message; only the handshake and readLoop call it
+func (c *peerConn) read() (*Message, error) {
+	msg, err := readMessage(c.reader, c.magic)
+	if err != nil {
+		return nil, err
+	}
+	c.stats.received(msg)
+	return msg, nil
+}
+
+// request sends a message and waits for the reply with the same id
+func (c *peerConn) request(msgType MessageType, data []byte, timeout time.Duration) (*Message, error) {
+	reply := make(chan *Message, 1)
+
+	c.pendingMutex.Lock()
+	c.nextID++
+	if c.nextID == 0 {
+		c.nextID++ // 0 marks unsolicited messages
+	}
+	id := c.nextID
+	c.pending[id] = reply
+	c.pendingMutex.Unlock()
+
+	defer func() {
+		c.pendingMutex.Lock()
+		delete(c.pending, id)
+		c.pendingMutex.Unlock()
+	}()
+
+	if err := c.send(&Message{Type: msgType, ID: id, Data: data}); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case response := <-reply:
+		if response.Type == MsgTypeReject {
+			return nil, fmt.Errorf("peer rejected %s: %s", msgType, newWireReader(response.Data).string())
+		}
+		return response, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("%s request timed out", msgType)
+	case <-c.closed:
+		return nil, errConnClosed
+	}
+}
+
+// reply answers the request with the given id
+func (c *peerConn) reply(id uint32, msgType MessageType, data []byte) error {
+	return c.send(&Message{Type: msgType, ID: id, Response: true, Data: data})
+}
+
+// reject sends a reject message carrying a reason; id is 0 outside a request
+func (c *peerConn) reject(id uint32, reason error) error {
+	var w wireWriter
+	w.string(reason.Error())
+	return c.send(&Message{Type: MsgTypeReject, ID: id, Response: id != 0, Data: w.Bytes()})
+}
+
+// readLoop delivers replies to waiting requests and queues every other
+// message for handle until the stream fails. A fixed set of workers runs
+// handle, so handlers may make requests of their own while replies keep
+// arriving. Messages over the peer's rate limit or arriving while the queue
+// is full are passed to drop instead.
+func (c *peerConn) readLoop(handle func(*Message), drop func(*Message, error)) error {
+	defer c.close()
+
+	inbox := make(chan *Message, inboxSize)
+	defer close(inbox)
+	for i := 0; i < handlerWorkers; i++ {
+		go func() {
+			for msg := range inbox {
+				handle(msg)
+			}
+		}()
+	}
+
+	for {
+		msg, err := c.read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if !msg.Response {
+			if !c.limiter.allow(msg.Type) {
+				drop(msg, errRateLimited)
+				continue
+			}
+			select {
+			case inbox <- msg:
+			default:
+				drop(msg, errInboxFull)
+			}
+			continue
+		}
+
+		c.pendingMutex.Lock()
+		reply, ok := c.pending[msg.ID]
+		c.pendingMutex.Unlock()
+		if ok {
+			select {
+			case reply <- msg:
+			default: // Duplicate reply
+			}
+		}
+	}
+}
+
+// close shuts the stream down and fails all pending requests
+func (c *peerConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.stream.Reset()
+	})
+}
@@ -0,0 +1,165 @@
+package p2p
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+)
+
+const (
+	// DefaultMaxInbound is the default number of peers that may dial us
+	DefaultMaxInbound = 32
+
+	// DefaultMaxOutbound is the default number of peers we may dial,
+	// including manual connections
+	DefaultMaxOutbound = 16
+
+	// pingInterval is how often every peer is pinged to measure latency
+	// and detect dead connections
+	pingInterval = 2 * time.Minute
+)
+
+// peerStats counts a peer's traffic; all fields are updated atomically
+type peerStats struct {
+	bytesSent     atomic.Uint64
+	bytesReceived atomic.Uint64
+	msgsSent      atomic.Uint64
+	msgsReceived  atomic.Uint64
+	lastSend      atomic.Int64 // Unix nanoseconds
+	lastReceive   atomic.Int64 // Unix nanoseconds
+	pingTime      atomic.Int64 // Last round trip in nanoseconds
+	minPingTime   atomic.Int64 // Best round trip in nanoseconds
+}
+
+// sent records a message written to the peer
+func (s *peerStats) sent(msg *Message) {
+	s.bytesSent.Add(uint64(frameHeaderSize + len(msg.Data)))
+	s.msgsSent.Add(1)
+	s.lastSend.Store(time.Now().UnixNano())
+}
+
+// received records a message read from the peer
+func (s *peerStats) received(msg *Message) {
+	s.bytesReceived.Add(uint64(frameHeaderSize + len(msg.Data)))
+	s.msgsReceived.Add(1)
+	s.lastReceive.Store(time.Now().UnixNano())
+}
+
+// pinged records a ping round trip
+func (s *peerStats) pinged(rtt time.Duration) {
+	s.pingTime.Store(int64(rtt))
+	for {
+		best := s.minPingTime.Load()
+		if best != 0 && best <= int64(rtt) {
+			return
+		}
+		if s.minPingTime.CompareAndSwap(best, int64(rtt)) {
+			return
+		}
+	}
+}
+
+// fill copies the counters into a peer's info
+func (s *peerStats) fill(info *PeerInfo) {
+	info.BytesSent = s.bytesSent.Load()
+	info.BytesReceived = s.bytesReceived.Load()
+	info.MessagesSent = s.msgsSent.Load()
+	info.MessagesReceived = s.msgsReceived.Load()
+	info.LastSend = unixNanoTime(s.lastSend.Load())
+	info.LastReceive = unixNanoTime(s.lastReceive.Load())
+	info.PingTime = time.Duration(s.pingTime.Load())
+	info.MinPingTime = time.Duration(s.minPingTime.Load())
+}
+
+// unixNanoTime converts a stored timestamp, keeping 0 as the zero time
+func unixNanoTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// SetConnLimits sets the number of inbound and outbound peer slots.
+// Inbound peers over the limit are refused after the version exchange, and
+// dials beyond the outbound limit fail.
+func (n *Network) SetConnLimits(maxInbound, maxOutbound int) {
+	n.peerMutex.Lock()
+	defer n.peerMutex.Unlock()
+	n.maxInbound = maxInbound
+	n.maxOutbound = maxOutbound
+}
+
+// countPeers returns the number of inbound or outbound peers; callers hold
+// n.peerMutex
+func (n *Network) countPeers(inbound bool) int {
+	count := 0
+	for _, c := range n.peers {
+		if c.info.Inbound == inbound {
+			count++
+		}
+	}
+	return count
+}
+
+// inboundSlotFree reports whether another peer may dial us
+func (n *Network) inboundSlotFree() bool {
+	n.peerMutex.RLock()
+	defer n.peerMutex.RUnlock()
+	return n.countPeers(true) < n.maxInbound
+}
+
+// outboundSlotFree reports whether we may dial another peer
+func (n *Network) outboundSlotFree() bool {
+	n.peerMutex.RLock()
+	defer n.peerMutex.RUnlock()
+	return n.countPeers(false) < n.maxOutbound
+}
+
+// onConnected is called by libp2p for every new connection
+func (n *Network) onConnected(_ network.Network, conn network.Conn) {
+	if conn.Stat().Direction == network.DirInbound {
+		go n.expireHandshake(conn.RemotePeer())
+	}
+}
+
+// onDisconnected is called by libp2p when a connection closes; the peer is
+// forgotten once its last connection is gone
+func (n *Network) onDisconnected(net network.Network, conn network.Conn) {
+	if net.Connectedness(conn.RemotePeer()) != network.Connected {
+		n.removePeer(conn.RemotePeer())
+	}
+}
+
+// peerGone logs a peer's departure and replaces dropped outbound peers from
+// the address book
+func (n *Network) peerGone(c *peerConn) {
+	fmt.Printf("👋 Peer %s disconnected after %s (sent %d B, received %d B)\n",
+		c.info.ID, time.Since(c.info.ConnectedAt).Round(time.Second),
+		c.stats.bytesSent.Load(), c.stats.bytesReceived.Load())
+
+	if !c.info.Inbound && n.ctx.Err() == nil {
+		go n.fillOutbound()
+	}
+}
+
+// pingLoop pings a peer every pingInterval to keep its latency current;
+// a peer that does not answer is disconnected
+func (n *Network) pingLoop(c *peerConn) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.closed:
+			return
+		}
+
+		if _, err := n.pingConn(c); err != nil && err != errConnClosed {
+			n.dropPeer(c.info.ID, fmt.Errorf("ping failed: %v", err))
+			return
+		}
+	}
+}
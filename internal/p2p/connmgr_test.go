@@ -0,0 +1,104 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeerStatsAreTracked(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping connection test in short mode")
+	}
+
+	server, _ := newRegTestNode(t, 0)
+	client, _ := newRegTestNode(t, 0)
+	if err := client.ConnectToPeer(server.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	if _, err := client.Ping(server.host.ID()); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+
+	infos := client.GetPeerInfo()
+	if len(infos) != 1 {
+		t.Fatalf("Expected 1 peer, got %d", len(infos))
+	}
+	info := infos[0]
+	if info.BytesSent == 0 || info.BytesReceived == 0 {
+		t.Errorf("Traffic not counted: sent %d, received %d", info.BytesSent, info.BytesReceived)
+	}
+	// Version, verack and ping went out; version and pong came back
+	if info.MessagesSent < 3 || info.MessagesReceived < 2 {
+		t.Errorf("Messages not counted: sent %d, received %d", info.MessagesSent, info.MessagesReceived)
+	}
+	if info.LastSend.IsZero() || info.LastReceive.IsZero() {
+		t.Error("Last message times not recorded")
+	}
+	if info.PingTime <= 0 || info.MinPingTime <= 0 || info.MinPingTime > info.PingTime {
+		t.Errorf("Unexpected ping times %v (min %v)", info.PingTime, info.MinPingTime)
+	}
+}
+
+func TestConnectionSlotLimits(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping connection test in short mode")
+	}
+
+	server, _ := newRegTestNode(t, 0)
+	server.SetConnLimits(1, DefaultMaxOutbound)
+
+	first, _ := newRegTestNode(t, 0)
+	if err := first.ConnectToPeer(server.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	second, _ := newRegTestNode(t, 0)
+	if err := second.ConnectToPeer(server.Addrs()[0]); err == nil {
+		t.Error("Connected beyond the inbound limit")
+	}
+	if count := server.GetPeerCount(); count != 1 {
+		t.Errorf("Expected 1 inbound peer, got %d", count)
+	}
+
+	// The outbound limit applies to the dialing side
+	second.SetConnLimits(DefaultMaxInbound, 1)
+	other, _ := newRegTestNode(t, 0)
+	if err := second.ConnectToPeer(other.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	third, _ := newRegTestNode(t, 0)
+	if err := second.ConnectToPeer(third.Addrs()[0]); err == nil {
+		t.Error("Connected beyond the outbound limit")
+	}
+}
+
+func TestDroppedOutboundPeerIsReplaced(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping connection test in short mode")
+	}
+
+	a, _ := newRegTestNode(t, 0)
+	b, _ := newRegTestNode(t, 0)
+
+	client, _ := newRegTestNode(t, 0)
+	cfg := DiscoveryConfig{Seeds: append(a.Addrs(), b.Addrs()...), TargetOutbound: 1}
+	if err := client.StartDiscovery(cfg); err != nil {
+		t.Fatalf("Failed to start discovery: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for client.GetPeerCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Client never connected to a seed")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// Stopping the connected seed makes the client dial the other one
+	// right away instead of waiting for the next discovery round
+	connected, spare := a, b
+	if client.isPeer(b.host.ID()) {
+		connected, spare = b, a
+	}
+	connected.Stop()
+	waitForPeer(t, client, spare)
+}
@@ -0,0 +1,34 @@
+//go:build dht
+
+package p2p
+
+import (
+	"context"
+	"io"
+
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	drouting "github.com/libp2p/go-libp2p/p2p/discovery/routing"
+)
+
+// The DHT pulls in a large dependency tree, so it is only built with -tags dht
+func init() {
+	newDHTDiscovery = func(ctx context.Context, h host.Host, prefix protocol.ID, bootstrap []peer.AddrInfo) (discovery.Discovery, io.Closer, error) {
+		kdht, err := dht.New(ctx, h,
+			dht.Mode(dht.ModeAutoServer),
+			dht.ProtocolPrefix(prefix),
+			dht.BootstrapPeers(bootstrap...),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := kdht.Bootstrap(ctx); err != nil {
+			kdht.Close()
+			return nil, nil, err
+		}
+		return drouting.NewRoutingDiscovery(kdht), kdht, nil
+	}
+}
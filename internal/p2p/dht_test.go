@@ -0,0 +1,57 @@
+//go:build dht
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func TestDHTDiscovery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping DHT test in short mode")
+	}
+
+	seed, _ := newRegTestNode(t, 0)
+	first, _ := newRegTestNode(t, 0)
+	last, _ := newRegTestNode(t, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	start := func(n *Network, bootstrap []peer.AddrInfo) discovery.Discovery {
+		d, closer, err := newDHTDiscovery(ctx, n.host, n.protocolID(""), bootstrap)
+		if err != nil {
+			t.Fatalf("Failed to start DHT: %v", err)
+		}
+		t.Cleanup(func() { closer.Close() })
+		return d
+	}
+
+	// Both nodes bootstrap from the seed, which knows neither
+	start(seed, nil)
+	bootstrap := []peer.AddrInfo{{ID: seed.host.ID(), Addrs: seed.host.Addrs()}}
+	advertiser := start(first, bootstrap)
+	finder := start(last, bootstrap)
+
+	// The last node finds the first under the network's rendezvous
+	for {
+		if _, err := advertiser.Advertise(ctx, first.rendezvous()); err == nil {
+			if found, err := finder.FindPeers(ctx, last.rendezvous()); err == nil {
+				for info := range found {
+					if info.ID == first.host.ID() {
+						return
+					}
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("Node was not found through the DHT")
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
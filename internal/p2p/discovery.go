@@ -0,0 +1,294 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+)
+
+const (
+	// DefaultTargetOutbound is the number of outbound peers discovery keeps
+	DefaultTargetOutbound = 8
+
+	// maxAddrPerMsg bounds the addresses of an addr message
+	maxAddrPerMsg = 1000
+
+	// discoveryInterval is how often discovery tops up outbound peers,
+	// queries the DHT and saves the address book
+	discoveryInterval = 30 * time.Second
+)
+
+// DiscoveryConfig selects how a node finds peers
+type DiscoveryConfig struct {
+	// AddrBookPath is the file learned addresses are kept in across restarts
+	AddrBookPath string
+
+	// Seeds are full multiaddrs (including /p2p/<id>) dialed at startup;
+	// they also bootstrap the DHT
+	Seeds []string
+
+	// MDNS finds peers on the local network
+	MDNS bool
+
+	// DHT advertises and finds peers through a Kademlia DHT rendezvous.
+	// It requires building with the dht tag.
+	DHT bool
+
+	// TargetOutbound is the number of outbound peers to keep (default DefaultTargetOutbound)
+	TargetOutbound int
+}
+
+// newDHTDiscovery starts a Kademlia DHT using the given protocol prefix. It is
+// set by dht.go when the node is built with the dht tag.
+var newDHTDiscovery func(ctx context.Context, h host.Host, prefix protocol.ID, bootstrap []peer.AddrInfo) (discovery.Discovery, io.Closer, error)
+
+// StartDiscovery loads the address book and starts finding peers through
+// seeds, mDNS, the DHT and addr gossip. Outbound connections are topped up
+// to the target from the best addresses in the book.
+func (n *Network) StartDiscovery(cfg DiscoveryConfig) error {
+	book, err := NewAddrBook(cfg.AddrBookPath)
+	if err != nil {
+		return err
+	}
+
+	var bootstrap []peer.AddrInfo
+	for _, seed := range cfg.Seeds {
+		info, err := peer.AddrInfoFromString(seed)
+		if err != nil {
+			return fmt.Errorf("invalid seed %q: %v", seed, err)
+		}
+		book.Add(seed, SourceSeed)
+		bootstrap = append(bootstrap, *info)
+	}
+
+	n.addrBook = book
+	n.targetOutbound = cfg.TargetOutbound
+	if n.targetOutbound <= 0 {
+		n.targetOutbound = DefaultTargetOutbound
+	}
+
+	if cfg.MDNS {
+		service := mdns.NewMdnsService(n.host, n.rendezvous(), &mdnsNotifee{network: n})
+		if err := service.Start(); err != nil {
+			return fmt.Errorf("failed to start mDNS: %v", err)
+		}
+		n.discoveryClosers = append(n.discoveryClosers, service)
+	}
+
+	var dht discovery.Discovery
+	if cfg.DHT {
+		if newDHTDiscovery == nil {
+			return fmt.Errorf("DHT discovery is not available in this build (build with -tags dht)")
+		}
+		d, closer, err := newDHTDiscovery(n.ctx, n.host, n.protocolID(""), bootstrap)
+		if err != nil {
+			return fmt.Errorf("failed to start DHT: %v", err)
+		}
+		n.discoveryClosers = append(n.discoveryClosers, closer)
+		dht = d
+		go n.advertise(dht)
+	}
+
+	fmt.Printf("🔭 Peer discovery started (%d known addresses, mDNS %v, DHT %v)\n", book.Len(), cfg.MDNS, cfg.DHT)
+	go n.discoveryLoop(dht)
+	return nil
+}
+
+// rendezvous is the mDNS service name and DHT namespace of our network
+func (n *Network) rendezvous() string {
+	return fmt.Sprintf("_btc-%08x._udp", n.blockchain.Params.Net)
+}
+
+// discoveryLoop periodically finds peers, keeps outbound connections at the
+// target and saves the address book
+func (n *Network) discoveryLoop(dht discovery.Discovery) {
+	ticker := time.NewTicker(discoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		if dht != nil {
+			n.findDHTPeers(dht)
+		}
+		n.fillOutbound()
+		if err := n.addrBook.Save(); err != nil {
+			fmt.Printf("Failed to save address book: %v\n", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-n.ctx.Done():
+			return
+		}
+	}
+}
+
+// fillOutbound dials the best known addresses until the outbound target is met
+func (n *Network) fillOutbound() {
+	if !n.dialMutex.TryLock() {
+		return // Already dialing
+	}
+	defer n.dialMutex.Unlock()
+
+	if n.addrBook == nil {
+		return // Discovery not started
+	}
+	skip := func(id peer.ID) bool {
+		return id == n.host.ID() || n.isPeer(id) || n.bans.IsBanned(id) || !n.gater.isAllowed(id)
+	}
+
+	// Work down the whole list so failed dials are made up for
+	for _, addr := range n.addrBook.Candidates(n.addrBook.Len(), skip) {
+		if n.outboundCount() >= n.targetOutbound {
+			return
+		}
+		if err := n.ConnectToPeer(addr); err != nil {
+			fmt.Printf("Failed to connect to %s: %v\n", addr, err)
+		}
+	}
+}
+
+// outboundCount returns the number of peers we dialed
+func (n *Network) outboundCount() int {
+	n.peerMutex.RLock()
+	defer n.peerMutex.RUnlock()
+	return n.countPeers(false)
+}
+
+// learnAddrs adds a peer's addresses to the address book
+func (n *Network) learnAddrs(info peer.AddrInfo, source string) int {
+	if info.ID == n.host.ID() {
+		return 0
+	}
+
+	addrs, err := peer.AddrInfoToP2pAddrs(&info)
+	if err != nil {
+		return 0
+	}
+
+	added := 0
+	for _, addr := range addrs {
+		if n.addrBook.Add(addr.String(), source) {
+			added++
+		}
+	}
+	return added
+}
+
+// requestAddrs asks a peer for the addresses it knows
+func (n *Network) requestAddrs(peerID peer.ID) {
+	c := n.getPeer(peerID)
+	if c == nil {
+		return
+	}
+	response, err := c.request(MsgTypeGetAddr, nil, syncTimeout)
+	if err != nil {
+		fmt.Printf("Failed to request addresses from %s: %v\n", peerID, err)
+		return
+	}
+	if response.Type != MsgTypeAddr {
+		return
+	}
+	n.handleAddrs(c, response.Data)
+}
+
+// handleAddrs adds the addresses of an addr message to the address book
+func (n *Network) handleAddrs(c *peerConn, data []byte) {
+	if n.addrBook == nil {
+		return
+	}
+
+	r := newWireReader(data)
+	addrs := r.addrs()
+	if err := r.done(); err != nil {
+		n.misbehaving(c, PenaltyMalformed, fmt.Sprintf("invalid addr: %v", err))
+		return
+	}
+	if len(addrs) > maxAddrPerMsg {
+		addrs = addrs[:maxAddrPerMsg]
+	}
+
+	added := 0
+	for _, ka := range addrs {
+		info, err := peer.AddrInfoFromString(ka.Addr)
+		if err != nil || info.ID == n.host.ID() {
+			continue
+		}
+		if n.addrBook.Add(ka.Addr, SourceGossip) {
+			added++
+		}
+	}
+	if added > 0 {
+		fmt.Printf("📇 Learned %d addresses from %s\n", added, c.info.ID)
+		go n.fillOutbound()
+	}
+}
+
+// serveAddrs answers a getaddr request with our own addresses followed by
+// the best entries of the address book
+func (n *Network) serveAddrs() []byte {
+	addrs := make([]KnownAddress, 0, maxAddrPerMsg)
+	for _, addr := range n.Addrs() {
+		addrs = append(addrs, KnownAddress{Addr: addr, LastSeen: time.Now()})
+	}
+	if n.addrBook != nil {
+		addrs = append(addrs, n.addrBook.Sample(maxAddrPerMsg-len(addrs))...)
+	}
+
+	var w wireWriter
+	w.addrs(addrs)
+	return w.Bytes()
+}
+
+// advertise keeps our rendezvous record in the DHT fresh
+func (n *Network) advertise(dht discovery.Discovery) {
+	for {
+		wait := time.Minute
+		ttl, err := dht.Advertise(n.ctx, n.rendezvous())
+		if err != nil {
+			fmt.Printf("Failed to advertise on the DHT: %v\n", err)
+		} else if ttl > 0 {
+			wait = ttl * 7 / 8
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-n.ctx.Done():
+			return
+		}
+	}
+}
+
+// findDHTPeers adds the peers advertising our rendezvous to the address book
+func (n *Network) findDHTPeers(dht discovery.Discovery) {
+	ctx, cancel := context.WithTimeout(n.ctx, discoveryInterval)
+	defer cancel()
+
+	found, err := dht.FindPeers(ctx, n.rendezvous(), discovery.Limit(maxAddrPerMsg))
+	if err != nil {
+		fmt.Printf("Failed to query the DHT: %v\n", err)
+		return
+	}
+	for info := range found {
+		n.learnAddrs(info, SourceDHT)
+	}
+}
+
+// mdnsNotifee receives peers found on the local network
+type mdnsNotifee struct {
+	network *Network
+}
+
+// HandlePeerFound records a LAN peer and dials it if we need more peers
+func (m *mdnsNotifee) HandlePeerFound(info peer.AddrInfo) {
+	if m.network.learnAddrs(info, SourceMDNS) > 0 {
+		fmt.Printf("📡 Found peer %s via mDNS\n", info.ID)
+		go m.network.fillOutbound()
+	}
+}
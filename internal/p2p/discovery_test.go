@@ -0,0 +1,76 @@
+package p2p
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// waitForPeer waits until n has completed the handshake with other
+func waitForPeer(t *testing.T, n, other *Network) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !n.isPeer(other.host.ID()) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Node %s never connected to %s", n.host.ID(), other.host.ID())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestAddrGossipFindsPeers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping discovery test in short mode")
+	}
+
+	first, _ := newRegTestNode(t, 0)
+	hub, _ := newRegTestNode(t, 0)
+	if err := hub.StartDiscovery(DiscoveryConfig{}); err != nil {
+		t.Fatalf("Failed to start discovery: %v", err)
+	}
+	if err := first.ConnectToPeer(hub.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	// The hub learns the first node's listen address from its addr reply
+	deadline := time.Now().Add(10 * time.Second)
+	for hub.addrBook.Len() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Hub did not learn the first node's address")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// A new node seeded with only the hub finds the first node through it
+	last, _ := newRegTestNode(t, 0)
+	if err := last.StartDiscovery(DiscoveryConfig{Seeds: hub.Addrs()}); err != nil {
+		t.Fatalf("Failed to start discovery: %v", err)
+	}
+	waitForPeer(t, last, hub)
+	waitForPeer(t, last, first)
+}
+
+func TestAddrBookReconnectsAfterRestart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping discovery test in short mode")
+	}
+
+	path := filepath.Join(t.TempDir(), "peers.json")
+	server, _ := newRegTestNode(t, 0)
+
+	client, _ := newRegTestNode(t, 0)
+	seeds := DiscoveryConfig{AddrBookPath: path, Seeds: server.Addrs()}
+	if err := client.StartDiscovery(seeds); err != nil {
+		t.Fatalf("Failed to start discovery: %v", err)
+	}
+	waitForPeer(t, client, server)
+	client.Stop()
+
+	// Without seeds or --connect the restarted node dials its saved peer
+	restarted, _ := newRegTestNode(t, 0)
+	if err := restarted.StartDiscovery(DiscoveryConfig{AddrBookPath: path}); err != nil {
+		t.Fatalf("Failed to start discovery: %v", err)
+	}
+	waitForPeer(t, restarted, server)
+}
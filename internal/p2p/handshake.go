@@ -0,0 +1,375 @@
+package p2p
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// ProtocolVersion is the P2P protocol version this node speaks
+	ProtocolVersion uint32 = 3
+
+	// MinProtocolVersion is the oldest peer protocol version we accept.
+	// Version 3 added input sequences to the transaction encoding, which
+	// changed every transaction ID, so older peers are on another chain.
+	MinProtocolVersion uint32 = 3
+
+	// CompactBlocksVersion is the first protocol version that accepts new
+	// blocks as compact blocks; older peers are sent an inv instead
+	CompactBlocksVersion uint32 = 2
+
+	// UserAgent identifies this software to peers
+	UserAgent = "/bt:0.1.0/"
+
+	// handshakeTimeout bounds the handshake; inbound peers that do not
+	// complete it in time are disconnected
+	handshakeTimeout = 10 * time.Second
+)
+
+// ServiceFlag advertises the services a node offers to its peers
+type ServiceFlag uint64
+
+const (
+	// ServiceNodeNetwork means the node stores and serves the full chain
+	ServiceNodeNetwork ServiceFlag = 1 << iota
+)
+
+// HasService reports whether all flags in s are set
+func (f ServiceFlag) HasService(s ServiceFlag) bool {
+	return f&s == s
+}
+
+// VersionMessage is the first message exchanged with a new peer
+type VersionMessage struct {
+	ProtocolVersion uint32
+	Network         string
+	Net             uint32
+	GenesisHash     []byte
+	BestHeight      int
+	Services        ServiceFlag
+	UserAgent       string
+	Timestamp       time.Time
+}
+
+// PeerInfo is the metadata recorded for a peer after a successful handshake
+type PeerInfo struct {
+	ID              peer.ID
+	Addr            string
+	Inbound         bool
+	ProtocolVersion uint32
+	Services        ServiceFlag
+	UserAgent       string
+	StartHeight     int
+	GenesisHash     []byte
+	ConnectedAt     time.Time
+	BanScore        int // Misbehavior score at the time of the query
+
+	// Traffic and latency at the time of the query
+	BytesSent        uint64
+	BytesReceived    uint64
+	MessagesSent     uint64
+	MessagesReceived uint64
+	LastSend         time.Time
+	LastReceive      time.Time
+	PingTime         time.Duration // Zero until the first ping is answered
+	MinPingTime      time.Duration
+}
+
+// localVersion builds our version message
+func (n *Network) localVersion() *VersionMessage {
+	return &VersionMessage{
+		ProtocolVersion: ProtocolVersion,
+		Network:         n.blockchain.Params.Name,
+		Net:             n.blockchain.Params.Net,
+		GenesisHash:     n.blockchain.GenesisHash(),
+		BestHeight:      n.blockchain.Height(),
+		Services:        n.services,
+		UserAgent:       UserAgent,
+		Timestamp:       time.Now(),
+	}
+}
+
+// checkVersion rejects peers that cannot follow our chain
+func (n *Network) checkVersion(v *VersionMessage) error {
+	if v.ProtocolVersion < MinProtocolVersion {
+		return fmt.Errorf("protocol version %d is older than %d", v.ProtocolVersion, MinProtocolVersion)
+	}
+	if v.Net != n.blockchain.Params.Net {
+		return fmt.Errorf("peer is on network %s (%08x), we are on %s", v.Network, v.Net, n.blockchain.Params.Name)
+	}
+	if !bytes.Equal(v.GenesisHash, n.blockchain.GenesisHash()) {
+		return fmt.Errorf("genesis mismatch (peer %x, ours %x)", v.GenesisHash, n.blockchain.GenesisHash())
+	}
+	return nil
+}
+
+// handshake opens the wire stream to a peer and performs the outbound side
+// of the handshake: send version, receive version, send verack
+func (n *Network) handshake(peerID peer.ID) (*PeerInfo, error) {
+	stream, err := n.host.NewStream(n.ctx, peerID, n.protocolID(WireProtocol))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wire stream: %v", err)
+	}
+	c := newPeerConn(stream, n.blockchain.Params.Net)
+	stream.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	if err := n.writeVersion(c); err != nil {
+		c.close()
+		return nil, err
+	}
+
+	response, err := c.read()
+	if err != nil {
+		c.close()
+		return nil, fmt.Errorf("failed to read version: %v", err)
+	}
+	if response.Type == MsgTypeReject {
+		c.close()
+		return nil, fmt.Errorf("peer rejected us: %s", newWireReader(response.Data).string())
+	}
+	if response.Type != MsgTypeVersion {
+		c.close()
+		return nil, fmt.Errorf("expected version, got %s", response.Type)
+	}
+
+	r := newWireReader(response.Data)
+	version := r.version()
+	if err := r.done(); err != nil {
+		c.close()
+		return nil, fmt.Errorf("failed to decode version: %v", err)
+	}
+	if err := n.checkVersion(version); err != nil {
+		c.reject(0, err)
+		c.close()
+		return nil, err
+	}
+
+	if err := c.send(&Message{Type: MsgTypeVerack}); err != nil {
+		c.close()
+		return nil, fmt.Errorf("failed to send verack: %v", err)
+	}
+
+	stream.SetDeadline(time.Time{})
+	info := n.addPeer(c, version)
+	go n.runPeer(c)
+
+	return info, nil
+}
+
+// handleWireStream performs the inbound side of the handshake: receive
+// version, send version, receive verack. The peer is recorded before we
+// answer so it is listed as soon as the dialer has our version. The stream
+// then stays open for all further messages.
+func (n *Network) handleWireStream(stream network.Stream) {
+	peerID := stream.Conn().RemotePeer()
+	c := newPeerConn(stream, n.blockchain.Params.Net)
+	stream.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	msg, err := c.read()
+	if err != nil || msg.Type != MsgTypeVersion {
+		fmt.Printf("⛔ Disconnecting peer %s: no version message\n", peerID)
+		c.close()
+		n.host.Network().ClosePeer(peerID)
+		return
+	}
+
+	r := newWireReader(msg.Data)
+	version := r.version()
+	if err := r.done(); err != nil {
+		fmt.Printf("⛔ Disconnecting peer %s: invalid version: %v\n", peerID, err)
+		c.close()
+		n.host.Network().ClosePeer(peerID)
+		return
+	}
+	if err := n.checkVersion(version); err != nil {
+		fmt.Printf("⛔ Disconnecting peer %s: %v\n", peerID, err)
+		c.reject(0, err)
+		c.close()
+		n.host.Network().ClosePeer(peerID)
+		return
+	}
+	if !n.isPeer(peerID) && !n.inboundSlotFree() {
+		fmt.Printf("⛔ Disconnecting peer %s: all %d inbound slots are in use\n", peerID, n.maxInbound)
+		c.reject(0, fmt.Errorf("too many connections"))
+		c.close()
+		n.host.Network().ClosePeer(peerID)
+		return
+	}
+
+	// Hold the write lock so nothing is sent to the peer before our version
+	c.writeMutex.Lock()
+	n.addPeer(c, version)
+	err = writeMessage(stream, c.magic, &Message{Type: MsgTypeVersion, Data: n.encodeVersion()})
+	c.writeMutex.Unlock()
+	if err != nil {
+		n.dropPeer(peerID, fmt.Errorf("failed to send version: %v", err))
+		return
+	}
+
+	verack, err := c.read()
+	if err != nil || verack.Type != MsgTypeVerack {
+		n.dropPeer(peerID, fmt.Errorf("no verack"))
+		return
+	}
+	stream.SetDeadline(time.Time{})
+
+	fmt.Printf("🤝 Accepted peer %s (%s, height %d)\n", peerID, version.UserAgent, version.BestHeight)
+
+	if version.BestHeight > n.blockchain.Height() {
+		go n.syncWithPeer(peerID)
+	}
+	if n.addrBook != nil {
+		go n.requestAddrs(peerID)
+	}
+
+	n.runPeer(c)
+}
+
+// encodeVersion encodes our version message
+func (n *Network) encodeVersion() []byte {
+	var w wireWriter
+	w.version(n.localVersion())
+	return w.Bytes()
+}
+
+// writeVersion sends our version message
+func (n *Network) writeVersion(c *peerConn) error {
+	return c.send(&Message{Type: MsgTypeVersion, Data: n.encodeVersion()})
+}
+
+// runPeer serves a peer's messages until its stream fails, then forgets it
+func (n *Network) runPeer(c *peerConn) {
+	go n.pingLoop(c)
+
+	err := c.readLoop(func(msg *Message) {
+		n.handleMessage(c, msg)
+	}, func(msg *Message, reason error) {
+		n.misbehaving(c, PenaltyRateLimit, fmt.Sprintf("%s dropped: %v", msg.Type, reason))
+		if msg.ID != 0 {
+			c.reject(msg.ID, reason)
+		}
+	})
+
+	if !n.removeConn(c) || n.ctx.Err() != nil {
+		return // Replaced by a newer stream or shutting down
+	}
+	if err != nil {
+		fmt.Printf("⛔ Disconnecting peer %s: %v\n", c.info.ID, err)
+		if errors.Is(err, ErrBadChecksum) || errors.Is(err, ErrMessageTooLarge) {
+			n.misbehaving(c, PenaltyMalformed, err.Error())
+		}
+	}
+	n.host.Network().ClosePeer(c.info.ID)
+	n.peerGone(c)
+}
+
+// addPeer records a peer that sent an acceptable version, replacing any
+// older stream to the same peer
+func (n *Network) addPeer(c *peerConn, v *VersionMessage) *PeerInfo {
+	conn := c.stream.Conn()
+	c.info = &PeerInfo{
+		ID:              conn.RemotePeer(),
+		Addr:            conn.RemoteMultiaddr().String(),
+		Inbound:         conn.Stat().Direction == network.DirInbound,
+		ProtocolVersion: v.ProtocolVersion,
+		Services:        v.Services,
+		UserAgent:       v.UserAgent,
+		StartHeight:     v.BestHeight,
+		GenesisHash:     v.GenesisHash,
+		ConnectedAt:     time.Now(),
+	}
+
+	c.bestHeight.Store(int64(v.BestHeight))
+
+	n.peerMutex.Lock()
+	old := n.peers[c.info.ID]
+	n.peers[c.info.ID] = c
+	n.peerMutex.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+	return c.info
+}
+
+// removePeer forgets a peer and closes its stream
+func (n *Network) removePeer(peerID peer.ID) {
+	n.peerMutex.Lock()
+	c := n.peers[peerID]
+	delete(n.peers, peerID)
+	n.peerMutex.Unlock()
+
+	if c != nil {
+		c.close()
+		n.peerGone(c)
+	}
+}
+
+// removeConn forgets a peer if c is still its current stream
+func (n *Network) removeConn(c *peerConn) bool {
+	n.peerMutex.Lock()
+	defer n.peerMutex.Unlock()
+
+	if n.peers[c.info.ID] != c {
+		return false
+	}
+	delete(n.peers, c.info.ID)
+	return true
+}
+
+// dropPeer forgets and disconnects a peer
+func (n *Network) dropPeer(peerID peer.ID, reason error) {
+	fmt.Printf("⛔ Disconnecting peer %s: %v\n", peerID, reason)
+	n.removePeer(peerID)
+	n.host.Network().ClosePeer(peerID)
+}
+
+// getPeer returns the stream of a peer that completed the handshake
+func (n *Network) getPeer(peerID peer.ID) *peerConn {
+	n.peerMutex.RLock()
+	defer n.peerMutex.RUnlock()
+	return n.peers[peerID]
+}
+
+// isPeer reports whether a peer has completed the handshake
+func (n *Network) isPeer(peerID peer.ID) bool {
+	n.peerMutex.RLock()
+	defer n.peerMutex.RUnlock()
+	_, ok := n.peers[peerID]
+	return ok
+}
+
+// expireHandshake disconnects an inbound peer that has not completed the
+// handshake within handshakeTimeout
+func (n *Network) expireHandshake(peerID peer.ID) {
+	select {
+	case <-time.After(handshakeTimeout):
+	case <-n.ctx.Done():
+		return
+	}
+
+	if !n.isPeer(peerID) && n.host.Network().Connectedness(peerID) == network.Connected {
+		fmt.Printf("⛔ Disconnecting peer %s: handshake timed out\n", peerID)
+		n.host.Network().ClosePeer(peerID)
+	}
+}
+
+// GetPeerInfo returns the handshake metadata of all connected peers
+func (n *Network) GetPeerInfo() []PeerInfo {
+	n.peerMutex.RLock()
+	defer n.peerMutex.RUnlock()
+
+	infos := make([]PeerInfo, 0, len(n.peers))
+	for _, c := range n.peers {
+		info := *c.info
+		info.BanScore = int(c.score.Load())
+		c.stats.fill(&info)
+		infos = append(infos, info)
+	}
+	return infos
+}
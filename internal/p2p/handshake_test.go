@@ -0,0 +1,110 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+)
+
+func TestCheckVersion(t *testing.T) {
+	bc, _ := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	defer bc.Close()
+
+	n := &Network{blockchain: bc, services: ServiceNodeNetwork}
+
+	if err := n.checkVersion(n.localVersion()); err != nil {
+		t.Fatalf("Own version rejected: %v", err)
+	}
+
+	old := n.localVersion()
+	old.ProtocolVersion = MinProtocolVersion - 1
+	if err := n.checkVersion(old); err == nil {
+		t.Error("Accepted an outdated protocol version")
+	}
+
+	foreign := n.localVersion()
+	foreign.Network = chaincfg.MainNetParams.Name
+	foreign.Net = chaincfg.MainNetParams.Net
+	if err := n.checkVersion(foreign); err == nil {
+		t.Error("Accepted a peer on another network")
+	}
+
+	fork := n.localVersion()
+	fork.GenesisHash = make([]byte, 32)
+	if err := n.checkVersion(fork); err == nil {
+		t.Error("Accepted a peer with another genesis")
+	}
+}
+
+func TestHandshakeRecordsPeerInfo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping handshake test in short mode")
+	}
+
+	bc1, _ := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	defer bc1.Close()
+	bc2, _ := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	defer bc2.Close()
+
+	// Node 1 is one block ahead so its start height is distinguishable
+	miner, _ := crypto.NewWallet()
+	if _, err := bc1.AddBlock(nil, miner.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+
+	ctx := context.Background()
+
+	network1, err := NewNetwork(ctx, bc1, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create network1: %v", err)
+	}
+	defer network1.Stop()
+
+	network2, err := NewNetwork(ctx, bc2, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create network2: %v", err)
+	}
+	defer network2.Stop()
+
+	node1Addr := fmt.Sprintf("%s/p2p/%s", network1.host.Addrs()[0], network1.host.ID())
+	if err := network2.ConnectToPeer(node1Addr); err != nil {
+		t.Fatalf("Failed to connect peers: %v", err)
+	}
+
+	outbound := network2.GetPeerInfo()
+	if len(outbound) != 1 {
+		t.Fatalf("Expected 1 peer on node 2, got %d", len(outbound))
+	}
+	info := outbound[0]
+	if info.ID != network1.host.ID() || info.Inbound {
+		t.Errorf("Unexpected outbound peer info: %+v", info)
+	}
+	if info.ProtocolVersion != ProtocolVersion || info.UserAgent != UserAgent {
+		t.Errorf("Unexpected version metadata: %+v", info)
+	}
+	if !info.Services.HasService(ServiceNodeNetwork) {
+		t.Error("Peer does not advertise ServiceNodeNetwork")
+	}
+	if info.StartHeight != bc1.Height() {
+		t.Errorf("Expected start height %d, got %d", bc1.Height(), info.StartHeight)
+	}
+
+	// The listener records the dialer as an inbound peer
+	inbound := network1.GetPeerInfo()
+	if len(inbound) != 1 || !inbound[0].Inbound || inbound[0].ID != network2.host.ID() {
+		t.Errorf("Unexpected inbound peers on node 1: %+v", inbound)
+	}
+
+	// Peers are forgotten once disconnected
+	network2.host.Network().ClosePeer(network1.host.ID())
+	time.Sleep(200 * time.Millisecond)
+	if network1.GetPeerCount() != 0 || network2.GetPeerCount() != 0 {
+		t.Errorf("Peers not removed after disconnect: %d, %d", network1.GetPeerCount(), network2.GetPeerCount())
+	}
+}
@@ -0,0 +1,178 @@
+package p2p
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// BanThreshold is the misbehavior score at which a peer is banned
+const BanThreshold = 100
+
+// Misbehavior penalties added to a peer's score
+const (
+	// PenaltyInvalidBlock is charged for a block that fails validation
+	PenaltyInvalidBlock = 100
+
+	// PenaltyInvalidHeaders is charged for headers that do not link up or
+	// lack proof-of-work
+	PenaltyInvalidHeaders = 50
+
+	// PenaltyInvalidTx is charged for a transaction that can never be valid,
+	// such as a relayed coinbase or a bad signature
+	PenaltyInvalidTx = 20
+
+	// PenaltyMalformed is charged for messages that cannot be decoded and
+	// replies that do not match the request
+	PenaltyMalformed = 20
+
+	// PenaltyRateLimit is charged for every message over its rate limit
+	PenaltyRateLimit = 1
+)
+
+// rateLimit is the sustained rate (messages per second) and burst allowed
+// for a message type
+type rateLimit struct {
+	rate  float64
+	burst float64
+}
+
+// messageLimits are the per-peer limits on unsolicited messages and
+// requests; replies to our own requests are not limited
+var messageLimits = map[MessageType]rateLimit{
+	MsgTypeInv:         {rate: 50, burst: 500},
+	MsgTypeGetData:     {rate: 20, burst: 200},
+	MsgTypeGetHeaders:  {rate: 2, burst: 20},
+	MsgTypeGetBlocks:   {rate: 20, burst: 200},
+	MsgTypeGetAddr:     {rate: 0.1, burst: 5},
+	MsgTypeAddr:        {rate: 0.5, burst: 10},
+	MsgTypePing:        {rate: 1, burst: 30},
+	MsgTypeCmpctBlock:  {rate: 5, burst: 100},
+	MsgTypeGetBlockTxn: {rate: 5, burst: 100},
+}
+
+// tokenBucket refills at rate tokens per second up to burst; every message
+// takes one token
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a full bucket
+func newTokenBucket(limit rateLimit, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: limit.rate, burst: limit.burst, tokens: limit.burst, last: now}
+}
+
+// take refills the bucket for the time passed and takes a token if one is left
+func (b *tokenBucket) take(now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimiter holds a peer's token buckets, one per limited message type
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[MessageType]*tokenBucket
+}
+
+// newRateLimiter creates a limiter enforcing messageLimits
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: make(map[MessageType]*tokenBucket)}
+}
+
+// allow reports whether another message of the given type may be handled
+func (l *rateLimiter) allow(msgType MessageType) bool {
+	limit, ok := messageLimits[msgType]
+	if !ok {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	bucket, ok := l.buckets[msgType]
+	if !ok {
+		bucket = newTokenBucket(limit, now)
+		l.buckets[msgType] = bucket
+	}
+	return bucket.take(now)
+}
+
+// misbehaving adds a penalty to a peer's score and bans and disconnects the
+// peer once the score reaches BanThreshold
+func (n *Network) misbehaving(c *peerConn, penalty int, reason string) {
+	score := int(c.score.Add(int32(penalty)))
+	fmt.Printf("⚠️  Peer %s misbehaved (+%d, score %d): %s\n", c.info.ID, penalty, score, reason)
+
+	if score >= BanThreshold && score-penalty < BanThreshold {
+		if err := n.Ban(c.info.ID, DefaultBanDuration, reason); err != nil {
+			fmt.Printf("Failed to ban peer %s: %v\n", c.info.ID, err)
+		}
+	}
+}
+
+// misbehavingPeer penalizes a peer by ID if it is still connected
+func (n *Network) misbehavingPeer(peerID peer.ID, penalty int, reason string) {
+	if c := n.getPeer(peerID); c != nil {
+		n.misbehaving(c, penalty, reason)
+	}
+}
+
+// Ban bans a peer for duration, or permanently if duration is not positive,
+// and disconnects it. If the peer is connected, the IP address it connects
+// from is banned as well, so it cannot return under a new peer ID.
+func (n *Network) Ban(peerID peer.ID, duration time.Duration, reason string) error {
+	var ip string
+	for _, conn := range n.host.Network().ConnsToPeer(peerID) {
+		if ip = addrIP(conn.RemoteMultiaddr()); ip != "" {
+			break
+		}
+	}
+	err := n.bans.BanAddr(peerID, ip, duration, reason)
+
+	fmt.Printf("🚫 Banned peer %s: %s\n", peerID, reason)
+	if n.isPeer(peerID) {
+		n.dropPeer(peerID, fmt.Errorf("banned: %s", reason))
+	} else {
+		n.host.Network().ClosePeer(peerID)
+	}
+	return err
+}
+
+// Unban lifts a peer's ban, reporting whether it was banned
+func (n *Network) Unban(peerID peer.ID) (bool, error) {
+	return n.bans.Unban(peerID)
+}
+
+// IsBanned reports whether a peer is banned
+func (n *Network) IsBanned(peerID peer.ID) bool {
+	return n.bans.IsBanned(peerID)
+}
+
+// ListBanned returns the current bans
+func (n *Network) ListBanned() []BanEntry {
+	return n.bans.List()
+}
+
+// LoadBanList switches the ban list to the file at path, keeping bans made
+// so far, so bans persist across restarts
+func (n *Network) LoadBanList(path string) error {
+	if err := n.bans.load(path); err != nil {
+		return err
+	}
+	return n.bans.Save()
+}
@@ -0,0 +1,211 @@
+package p2p
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Now()
+	bucket := newTokenBucket(rateLimit{rate: 2, burst: 3}, start)
+
+	for i := 0; i < 3; i++ {
+		if !bucket.take(start) {
+			t.Fatalf("Message %d within the burst was refused", i)
+		}
+	}
+	if bucket.take(start) {
+		t.Error("Message over the burst was allowed")
+	}
+
+	// Two tokens per second refill one token in half a second
+	if !bucket.take(start.Add(500 * time.Millisecond)) {
+		t.Error("Bucket did not refill")
+	}
+	if bucket.take(start.Add(500 * time.Millisecond)) {
+		t.Error("Bucket refilled too much")
+	}
+
+	// Refills are capped at the burst
+	later := start.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		bucket.take(later)
+	}
+	if bucket.take(later) {
+		t.Error("Bucket grew beyond its burst")
+	}
+}
+
+// readerStream is a stream that only reads from a buffer
+type readerStream struct {
+	network.Stream
+	io.Reader
+}
+
+func (s *readerStream) Read(p []byte) (int, error) { return s.Reader.Read(p) }
+func (s *readerStream) Reset() error               { return nil }
+
+func TestReadLoopBoundsHandlers(t *testing.T) {
+	magic := chaincfg.RegTestParams.Net
+	var frames bytes.Buffer
+	const flood = 200
+	for i := 0; i < flood; i++ {
+		writeMessage(&frames, magic, &Message{Type: MsgTypeBlocks})
+	}
+	for i := 0; i < 30; i++ {
+		writeMessage(&frames, magic, &Message{Type: MsgTypeGetHeaders})
+	}
+	c := newPeerConn(&readerStream{Reader: &frames}, magic)
+
+	// Handlers block until released, as if the peer kept us busy
+	release := make(chan struct{})
+	var mu sync.Mutex
+	running, peak := 0, 0
+	dropped := make(map[error]int)
+	err := c.readLoop(func(msg *Message) {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		running--
+		mu.Unlock()
+	}, func(msg *Message, reason error) {
+		mu.Lock()
+		dropped[reason]++
+		mu.Unlock()
+	})
+	close(release)
+	if err != nil {
+		t.Fatalf("readLoop failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if peak > handlerWorkers {
+		t.Errorf("%d messages handled at once, limit is %d", peak, handlerWorkers)
+	}
+	if queued := flood + 30 - dropped[errInboxFull] - dropped[errRateLimited]; queued > handlerWorkers+inboxSize {
+		t.Errorf("%d messages queued, limit is %d", queued, handlerWorkers+inboxSize)
+	}
+	if burst := int(messageLimits[MsgTypeGetHeaders].burst); dropped[errRateLimited] != 30-burst {
+		t.Errorf("%d messages over the rate limit dropped, want %d", dropped[errRateLimited], 30-burst)
+	}
+}
+
+func TestCheckTransaction(t *testing.T) {
+	n, bc := newRegTestNode(t, 0)
+
+	miner, _ := crypto.NewWallet()
+	if _, err := bc.AddBlock(nil, miner.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+	recipient, _ := crypto.NewWallet()
+	valid, err := bc.CreateTransaction(miner.GetAddress(), recipient.GetAddress(), 10, miner)
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+	if err := n.checkTransaction(valid); err != nil {
+		t.Errorf("Valid transaction rejected: %v", err)
+	}
+
+	forged := *valid
+	forged.Inputs = append([]tx.TxInput{}, valid.Inputs...)
+	forged.Inputs[0].Signature = append([]byte{}, valid.Inputs[0].Signature...)
+	forged.Inputs[0].Signature[0] ^= 0xFF
+	if err := n.checkTransaction(&forged); err == nil {
+		t.Error("Transaction with a bad signature accepted")
+	}
+
+	coinbase := tx.NewCoinbaseTxToPubKeyHash([]byte("miner"), "", 50)
+	if err := n.checkTransaction(coinbase); err == nil {
+		t.Error("Relayed coinbase accepted")
+	}
+
+	// Parents we do not know yet may still arrive
+	orphan := tx.NewTransaction(
+		[]tx.TxInput{{TxID: []byte("prev"), OutIndex: 0}},
+		[]tx.TxOutput{{Value: 100, PubKeyHash: []byte("recipient")}},
+	)
+	if err := n.checkTransaction(orphan); err != nil {
+		t.Errorf("Transaction with unknown parent rejected: %v", err)
+	}
+
+	orphan.Outputs[0].Value = 0
+	if err := n.checkTransaction(orphan); err == nil {
+		t.Error("Zero-value output accepted")
+	}
+}
+
+func TestMisbehavingPeerIsBanned(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping ban test in short mode")
+	}
+
+	honest, _ := newRegTestNode(t, 0)
+	rogue, _ := newRegTestNode(t, 0)
+	if err := rogue.ConnectToPeer(honest.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	waitForPeer(t, honest, rogue)
+
+	c := honest.getPeer(rogue.host.ID())
+	honest.misbehaving(c, PenaltyInvalidHeaders, "invalid headers")
+	if !honest.isPeer(rogue.host.ID()) {
+		t.Fatal("Peer disconnected below the ban threshold")
+	}
+	if info := honest.GetPeerInfo(); len(info) != 1 || info[0].BanScore != PenaltyInvalidHeaders {
+		t.Errorf("Ban score not reported: %+v", info)
+	}
+
+	honest.misbehaving(c, PenaltyInvalidHeaders, "invalid headers")
+	if honest.isPeer(rogue.host.ID()) {
+		t.Error("Peer still connected after reaching the ban threshold")
+	}
+	if !honest.IsBanned(rogue.host.ID()) {
+		t.Fatal("Peer not banned after reaching the ban threshold")
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for rogue.isPeer(honest.host.ID()) {
+		if time.Now().After(deadline) {
+			t.Fatal("Banned peer never noticed the disconnect")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// Neither side can reconnect while the ban lasts
+	if err := rogue.ConnectToPeer(honest.Addrs()[0]); err == nil {
+		t.Error("Banned peer reconnected")
+	}
+	if err := honest.ConnectToPeer(rogue.Addrs()[0]); err == nil {
+		t.Error("Connected to a banned peer")
+	}
+
+	// A fresh peer ID from the banned IP address is refused as well
+	sybil, _ := newRegTestNode(t, 0)
+	if err := sybil.ConnectToPeer(honest.Addrs()[0]); err == nil {
+		t.Error("Banned IP address reconnected under a new peer ID")
+	}
+
+	if removed, _ := honest.Unban(rogue.host.ID()); !removed {
+		t.Fatal("Failed to lift ban")
+	}
+	// Connections refused on accept leave the address in dial backoff
+	rogue.host.Network().(*swarm.Swarm).Backoff().Clear(honest.host.ID())
+	if err := rogue.ConnectToPeer(honest.Addrs()[0]); err != nil {
+		t.Errorf("Failed to reconnect after the ban was lifted: %v", err)
+	}
+}
@@ -1,9 +1,10 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -19,36 +20,6 @@ import (
 	"github.com/yourusername/bt/pkg/types"
 )
 
-const (
-	// Protocol IDs
-	BlockProtocol = "/btc/block/1.0.0"
-	TxProtocol    = "/btc/tx/1.0.0"
-	SyncProtocol  = "/btc/sync/1.0.0"
-	PingProtocol  = "/btc/ping/1.0.0"
-)
-
-// MessageType represents the type of P2P message
-type MessageType string
-
-const (
-	MsgTypeNewBlock     MessageType = "new_block"
-	MsgTypeNewTx        MessageType = "new_tx"
-	MsgTypeGetBlocks    MessageType = "get_blocks"
-	MsgTypeBlocks       MessageType = "blocks"
-	MsgTypeGetHeight    MessageType = "get_height"
-	MsgTypeHeight       MessageType = "height"
-	MsgTypePing         MessageType = "ping"
-	MsgTypePong         MessageType = "pong"
-)
-
-// Message represents a P2P network message
-type Message struct {
-	Type      MessageType `json:"type"`
-	Data      []byte      `json:"data"`
-	Timestamp time.Time   `json:"timestamp"`
-	From      string      `json:"from"`
-}
-
 // Network manages P2P networking for the blockchain
 type Network struct {
 	host       host.Host
@@ -56,9 +27,30 @@ type Network struct {
 	ctx        context.Context
 	cancel     context.CancelFunc
 
-	// Peer management
-	peers     map[peer.ID]bool
+	// Peer management; only peers that completed the handshake are listed
+	peers     map[peer.ID]*peerConn
+	dialing   map[peer.ID]struct{}
 	peerMutex sync.RWMutex
+	services  ServiceFlag
+	bans      *BanList
+	gater     *peerGater
+
+	// Connection slots
+	maxInbound  int
+	maxOutbound int
+
+	// Relay; items being fetched from some peer are not requested twice
+	inFlight     map[string]struct{}
+	requestMutex sync.Mutex
+
+	// Guards the blockchain's PendingTxs, which the handlers of every peer
+	// read and add to, and their wire size in pendingBytes
+	pendingMutex sync.RWMutex
+	pendingBytes int
+
+	// Blocks and transactions waiting for their parents
+	orphans   *orphanBlockPool
+	orphanTxs *orphanTxPool
 
 	// Message handlers
 	blockHandler func(*types.Block)
@@ -67,21 +59,56 @@ type Network struct {
 	// Synchronization
 	syncMutex sync.Mutex
 	syncing   bool
+
+	// Discovery; addrBook is nil until StartDiscovery
+	addrBook         *AddrBook
+	targetOutbound   int
+	dialMutex        sync.Mutex
+	discoveryClosers []io.Closer
 }
 
-// NewNetwork creates a new P2P network
+// NewNetwork creates a new P2P network with a new identity on every start
 func NewNetwork(ctx context.Context, bc *blockchain.Blockchain, listenAddr string) (*Network, error) {
+	return NewNetworkWithConfig(ctx, bc, NetworkConfig{ListenAddr: listenAddr})
+}
+
+// NewNetworkWithConfig creates a new P2P network with the given identity,
+// security transports and allowlist
+func NewNetworkWithConfig(ctx context.Context, bc *blockchain.Blockchain, cfg NetworkConfig) (*Network, error) {
 	// Parse listen address
-	addr, err := multiaddr.NewMultiaddr(listenAddr)
+	addr, err := multiaddr.NewMultiaddr(cfg.ListenAddr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid listen address: %v", err)
 	}
 
-	// Create libp2p host
-	h, err := libp2p.New(
+	security, err := securityOptions(cfg.Security)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bans are kept in memory until LoadBanList
+	bans, err := NewBanList("")
+	if err != nil {
+		return nil, err
+	}
+	gater := newPeerGater(bans, cfg.AllowedPeers)
+
+	opts := []libp2p.Option{
 		libp2p.ListenAddrs(addr),
 		libp2p.NATPortMap(), // Enable NAT traversal
-	)
+		libp2p.ConnectionGater(gater),
+		security,
+	}
+	if cfg.KeyPath != "" {
+		key, err := LoadOrCreateIdentity(cfg.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, libp2p.Identity(key))
+	}
+
+	// Create libp2p host
+	h, err := libp2p.New(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p host: %v", err)
 	}
@@ -89,22 +116,41 @@ func NewNetwork(ctx context.Context, bc *blockchain.Blockchain, listenAddr strin
 	netCtx, cancel := context.WithCancel(ctx)
 
 	n := &Network{
-		host:       h,
-		blockchain: bc,
-		ctx:        netCtx,
-		cancel:     cancel,
-		peers:      make(map[peer.ID]bool),
-	}
-
-	// Set up stream handlers
-	h.SetStreamHandler(protocol.ID(BlockProtocol), n.handleBlockStream)
-	h.SetStreamHandler(protocol.ID(TxProtocol), n.handleTxStream)
-	h.SetStreamHandler(protocol.ID(SyncProtocol), n.handleSyncStream)
-	h.SetStreamHandler(protocol.ID(PingProtocol), n.handlePingStream)
+		host:        h,
+		blockchain:  bc,
+		ctx:         netCtx,
+		cancel:      cancel,
+		peers:       make(map[peer.ID]*peerConn),
+		dialing:     make(map[peer.ID]struct{}),
+		inFlight:    make(map[string]struct{}),
+		orphans:     newOrphanBlockPool(maxOrphanBlocks, orphanBlockTTL),
+		orphanTxs:   newOrphanTxPool(maxOrphanTxs, maxOrphanTxsPerPeer, orphanTxTTL),
+		services:    ServiceNodeNetwork,
+		bans:        bans,
+		gater:       gater,
+		maxInbound:  DefaultMaxInbound,
+		maxOutbound: DefaultMaxOutbound,
+	}
+
+	// Each peer speaks to us over a single long-lived stream
+	h.SetStreamHandler(n.protocolID(WireProtocol), n.handleWireStream)
+
+	// Peers that dial us must complete the handshake; forget peers once
+	// their last connection closes
+	h.Network().Notify(&network.NotifyBundle{
+		ConnectedF:    n.onConnected,
+		DisconnectedF: n.onDisconnected,
+	})
 
 	return n, nil
 }
 
+// protocolID tags a protocol with the network magic so nodes of different
+// networks never negotiate a common protocol
+func (n *Network) protocolID(proto string) protocol.ID {
+	return protocol.ID(n.blockchain.Params.ProtocolPrefix() + proto)
+}
+
 // Start begins network operations
 func (n *Network) Start() error {
 	fmt.Printf("🌐 P2P Network started\n")
@@ -116,9 +162,26 @@ func (n *Network) Start() error {
 	return nil
 }
 
+// Addrs returns the full multiaddrs (including /p2p/<id>) peers can dial
+func (n *Network) Addrs() []string {
+	addrs := make([]string, 0, len(n.host.Addrs()))
+	for _, addr := range n.host.Addrs() {
+		addrs = append(addrs, fmt.Sprintf("%s/p2p/%s", addr, n.host.ID()))
+	}
+	return addrs
+}
+
 // Stop gracefully shuts down the network
 func (n *Network) Stop() error {
 	n.cancel()
+	for _, closer := range n.discoveryClosers {
+		closer.Close()
+	}
+	if n.addrBook != nil {
+		if err := n.addrBook.Save(); err != nil {
+			fmt.Printf("Failed to save address book: %v\n", err)
+		}
+	}
 	return n.host.Close()
 }
 
@@ -134,359 +197,310 @@ func (n *Network) ConnectToPeer(peerAddr string) error {
 		return fmt.Errorf("failed to parse peer info: %v", err)
 	}
 
-	if err := n.host.Connect(n.ctx, *peerInfo); err != nil {
-		return fmt.Errorf("failed to connect to peer: %v", err)
+	if n.bans.IsBanned(peerInfo.ID) {
+		return fmt.Errorf("peer %s is banned", peerInfo.ID)
 	}
-
-	n.peerMutex.Lock()
-	n.peers[peerInfo.ID] = true
-	n.peerMutex.Unlock()
-
-	fmt.Printf("✓ Connected to peer: %s\n", peerInfo.ID.String())
-
-	// Start synchronization with the new peer
-	go n.syncWithPeer(peerInfo.ID)
-
-	return nil
-}
-
-// BroadcastBlock broadcasts a new block to all peers
-func (n *Network) BroadcastBlock(block *types.Block) {
-	data, err := json.Marshal(block)
-	if err != nil {
-		fmt.Printf("Failed to marshal block: %v\n", err)
-		return
+	if !n.gater.isAllowed(peerInfo.ID) {
+		return fmt.Errorf("peer %s is not on the allowlist", peerInfo.ID)
 	}
 
-	msg := Message{
-		Type:      MsgTypeNewBlock,
-		Data:      data,
-		Timestamp: time.Now(),
-		From:      n.host.ID().String(),
+	// Dial each peer once at a time and keep existing connections
+	if !n.startDial(peerInfo.ID) {
+		return fmt.Errorf("already connecting to %s", peerInfo.ID)
 	}
-
-	n.broadcast(BlockProtocol, msg)
-}
-
-// BroadcastTransaction broadcasts a new transaction to all peers
-func (n *Network) BroadcastTransaction(transaction *tx.Transaction) {
-	data, err := json.Marshal(transaction)
-	if err != nil {
-		fmt.Printf("Failed to marshal transaction: %v\n", err)
-		return
+	defer n.finishDial(peerInfo.ID)
+	if n.isPeer(peerInfo.ID) {
+		return nil
 	}
-
-	msg := Message{
-		Type:      MsgTypeNewTx,
-		Data:      data,
-		Timestamp: time.Now(),
-		From:      n.host.ID().String(),
+	if !n.outboundSlotFree() {
+		return fmt.Errorf("all %d outbound slots are in use", n.maxOutbound)
 	}
 
-	n.broadcast(TxProtocol, msg)
-}
-
-// broadcast sends a message to all connected peers
-func (n *Network) broadcast(proto string, msg Message) {
-	n.peerMutex.RLock()
-	peers := make([]peer.ID, 0, len(n.peers))
-	for p := range n.peers {
-		peers = append(peers, p)
+	if n.addrBook != nil {
+		n.addrBook.Add(peerAddr, SourceManual)
+		n.addrBook.Attempt(peerAddr)
 	}
-	n.peerMutex.RUnlock()
 
-	for _, peerID := range peers {
-		go n.sendMessage(peerID, proto, msg)
+	if err := n.host.Connect(n.ctx, *peerInfo); err != nil {
+		n.markFailed(peerAddr)
+		return fmt.Errorf("failed to connect to peer: %v", err)
 	}
-}
 
-// sendMessage sends a message to a specific peer
-func (n *Network) sendMessage(peerID peer.ID, proto string, msg Message) error {
-	stream, err := n.host.NewStream(n.ctx, peerID, protocol.ID(proto))
+	// Refuse peers that speak another protocol version or follow another chain
+	info, err := n.handshake(peerInfo.ID)
 	if err != nil {
-		return fmt.Errorf("failed to open stream: %v", err)
+		n.markFailed(peerAddr)
+		n.host.Network().ClosePeer(peerInfo.ID)
+		return fmt.Errorf("refusing peer %s: %v", peerInfo.ID, err)
 	}
-	defer stream.Close()
-
-	encoder := json.NewEncoder(stream)
-	if err := encoder.Encode(msg); err != nil {
-		return fmt.Errorf("failed to send message: %v", err)
-	}
-
-	return nil
-}
 
-// handleBlockStream handles incoming block messages
-func (n *Network) handleBlockStream(stream network.Stream) {
-	defer stream.Close()
+	fmt.Printf("✓ Connected to peer: %s (%s, height %d)\n", peerInfo.ID.String(), info.UserAgent, info.StartHeight)
 
-	var msg Message
-	decoder := json.NewDecoder(stream)
-	if err := decoder.Decode(&msg); err != nil {
-		fmt.Printf("Failed to decode block message: %v\n", err)
-		return
+	if n.addrBook != nil {
+		n.addrBook.Good(peerAddr)
+		go n.requestAddrs(peerInfo.ID)
 	}
 
-	if msg.Type == MsgTypeNewBlock {
-		var block types.Block
-		if err := json.Unmarshal(msg.Data, &block); err != nil {
-			fmt.Printf("Failed to unmarshal block: %v\n", err)
-			return
-		}
+	// Start synchronization with the new peer
+	go n.syncWithPeer(peerInfo.ID)
 
-		// Process the received block
-		n.processReceivedBlock(&block)
-	}
+	return nil
 }
 
-// handleTxStream handles incoming transaction messages
-func (n *Network) handleTxStream(stream network.Stream) {
-	defer stream.Close()
+// startDial marks a peer as being dialed; it returns false if a dial is
+// already in progress
+func (n *Network) startDial(peerID peer.ID) bool {
+	n.peerMutex.Lock()
+	defer n.peerMutex.Unlock()
 
-	var msg Message
-	decoder := json.NewDecoder(stream)
-	if err := decoder.Decode(&msg); err != nil {
-		fmt.Printf("Failed to decode tx message: %v\n", err)
-		return
+	if _, ok := n.dialing[peerID]; ok {
+		return false
 	}
+	n.dialing[peerID] = struct{}{}
+	return true
+}
 
-	if msg.Type == MsgTypeNewTx {
-		var transaction tx.Transaction
-		if err := json.Unmarshal(msg.Data, &transaction); err != nil {
-			fmt.Printf("Failed to unmarshal transaction: %v\n", err)
-			return
-		}
+// finishDial clears a dial in progress
+func (n *Network) finishDial(peerID peer.ID) {
+	n.peerMutex.Lock()
+	delete(n.dialing, peerID)
+	n.peerMutex.Unlock()
+}
 
-		// Process the received transaction
-		n.processReceivedTransaction(&transaction)
+// markFailed records a failed dial in the address book
+func (n *Network) markFailed(addr string) {
+	if n.addrBook != nil {
+		n.addrBook.Failed(addr)
 	}
 }
 
-// handleSyncStream handles blockchain synchronization requests
-func (n *Network) handleSyncStream(stream network.Stream) {
-	defer stream.Close()
+// BroadcastBlock announces a new block to all peers that do not have it,
+// sending compact blocks where supported
+func (n *Network) BroadcastBlock(block *types.Block) {
+	n.removeConfirmedTxs(block)
+	n.announceBlock(block)
+}
 
-	var msg Message
-	decoder := json.NewDecoder(stream)
-	if err := decoder.Decode(&msg); err != nil {
-		fmt.Printf("Failed to decode sync message: %v\n", err)
-		return
-	}
+// BroadcastTransaction adds a transaction to the pending pool and announces
+// it to all peers that do not have it
+func (n *Network) BroadcastTransaction(transaction *tx.Transaction) {
+	n.addPendingTx(transaction)
+	n.announce(InvVect{Type: InvTypeTx, Hash: transaction.ID})
+}
 
-	encoder := json.NewEncoder(stream)
+// handleMessage dispatches a message received from a peer after the handshake
+func (n *Network) handleMessage(c *peerConn, msg *Message) {
+	var response []byte
+	var responseType MessageType
+	var err error
 
+	// readLoop has already charged the message against the peer's rate limit
 	switch msg.Type {
-	case MsgTypeGetHeight:
-		// Send our blockchain height
-		response := Message{
-			Type:      MsgTypeHeight,
-			Data:      []byte(fmt.Sprintf("%d", n.blockchain.Height())),
-			Timestamp: time.Now(),
-			From:      n.host.ID().String(),
-		}
-		encoder.Encode(response)
-
+	case MsgTypeInv:
+		n.handleInv(c, msg.Data)
+		return
+	case MsgTypeAddr:
+		n.handleAddrs(c, msg.Data)
+		return
+	case MsgTypeCmpctBlock:
+		n.handleCompactBlock(c, msg.Data)
+		return
+	case MsgTypeGetAddr:
+		response, responseType = n.serveAddrs(), MsgTypeAddr
+	case MsgTypePing:
+		response, responseType = msg.Data, MsgTypePong
+	case MsgTypeGetHeaders:
+		response, err = n.serveHeaders(msg.Data)
+		responseType = MsgTypeHeaders
 	case MsgTypeGetBlocks:
-		// Send requested blocks
-		var startHeight int
-		fmt.Sscanf(string(msg.Data), "%d", &startHeight)
-
-		blocks := make([]*types.Block, 0)
-		for i := startHeight; i < n.blockchain.Height(); i++ {
-			block, err := n.blockchain.GetBlock(i)
-			if err == nil {
-				blocks = append(blocks, block)
-			}
-		}
-
-		data, _ := json.Marshal(blocks)
-		response := Message{
-			Type:      MsgTypeBlocks,
-			Data:      data,
-			Timestamp: time.Now(),
-			From:      n.host.ID().String(),
+		response, err = n.serveBlocks(msg.Data)
+		responseType = MsgTypeBlocks
+	case MsgTypeGetData:
+		response, err = n.serveData(msg.Data)
+		responseType = MsgTypeData
+	case MsgTypeGetBlockTxn:
+		response, err = n.serveBlockTxn(msg.Data)
+		responseType = MsgTypeBlockTxn
+	default:
+		if msg.ID != 0 {
+			c.reject(msg.ID, fmt.Errorf("unsupported request %s", msg.Type))
 		}
-		encoder.Encode(response)
+		return
 	}
-}
-
-// handlePingStream handles ping/pong messages for peer liveness
-func (n *Network) handlePingStream(stream network.Stream) {
-	defer stream.Close()
 
-	var msg Message
-	decoder := json.NewDecoder(stream)
-	if err := decoder.Decode(&msg); err != nil {
+	if err != nil {
+		// Requests only fail to be answered when they cannot be decoded
+		n.misbehaving(c, PenaltyMalformed, fmt.Sprintf("invalid %s: %v", msg.Type, err))
+		c.reject(msg.ID, err)
 		return
 	}
-
-	if msg.Type == MsgTypePing {
-		encoder := json.NewEncoder(stream)
-		response := Message{
-			Type:      MsgTypePong,
-			Timestamp: time.Now(),
-			From:      n.host.ID().String(),
-		}
-		encoder.Encode(response)
+	if err := c.reply(msg.ID, responseType, response); err != nil {
+		fmt.Printf("Failed to answer %s: %v\n", msg.Type, err)
 	}
 }
 
-// syncWithPeer synchronizes blockchain with a peer
-func (n *Network) syncWithPeer(peerID peer.ID) {
-	n.syncMutex.Lock()
-	if n.syncing {
-		n.syncMutex.Unlock()
-		return
+// Ping measures the round trip time to a peer
+func (n *Network) Ping(peerID peer.ID) (time.Duration, error) {
+	c := n.getPeer(peerID)
+	if c == nil {
+		return 0, fmt.Errorf("not connected to %s", peerID)
 	}
-	n.syncing = true
-	n.syncMutex.Unlock()
+	return n.pingConn(c)
+}
 
-	defer func() {
-		n.syncMutex.Lock()
-		n.syncing = false
-		n.syncMutex.Unlock()
-	}()
+// pingConn pings a peer and records the round trip in its stats
+func (n *Network) pingConn(c *peerConn) (time.Duration, error) {
+	var w wireWriter
+	w.uint64(uint64(time.Now().UnixNano()))
 
-	// Get peer's blockchain height
-	stream, err := n.host.NewStream(n.ctx, peerID, protocol.ID(SyncProtocol))
+	start := time.Now()
+	response, err := c.request(MsgTypePing, w.Bytes(), syncTimeout)
 	if err != nil {
-		fmt.Printf("Failed to open sync stream: %v\n", err)
-		return
+		return 0, err
 	}
-	defer stream.Close()
-
-	// Request height
-	msg := Message{
-		Type:      MsgTypeGetHeight,
-		Timestamp: time.Now(),
-		From:      n.host.ID().String(),
+	if response.Type != MsgTypePong || !bytes.Equal(response.Data, w.Bytes()) {
+		return 0, fmt.Errorf("unexpected ping reply %s", response.Type)
 	}
+	rtt := time.Since(start)
+	c.stats.pinged(rtt)
+	return rtt, nil
+}
 
-	encoder := json.NewEncoder(stream)
-	if err := encoder.Encode(msg); err != nil {
-		fmt.Printf("Failed to request height: %v\n", err)
+// processReceivedBlock processes a block relayed by a peer
+func (n *Network) processReceivedBlock(block *types.Block, from peer.ID) {
+	// Check if we already have this block
+	if _, err := n.blockchain.GetBlockByHash(block.Hash); err == nil {
+		return // Already have it
+	}
+	if n.orphans.has(block.Hash) {
 		return
 	}
 
-	// Read response
-	var response Message
-	decoder := json.NewDecoder(stream)
-	if err := decoder.Decode(&response); err != nil {
-		fmt.Printf("Failed to read height response: %v\n", err)
+	if !bytes.Equal(block.Header.PrevBlockHash, n.blockchain.GetLatestBlock().Hash) {
+		// A block building on one of ours other than the tip belongs to a
+		// chain we have not seen yet; fetch it headers-first from the peer.
+		// Blocks whose parent we lack wait for it in the orphan pool.
+		if _, err := n.blockchain.GetBlockByHash(block.Header.PrevBlockHash); err == nil {
+			go n.syncWithPeer(from)
+		} else {
+			n.addOrphanBlock(block, from)
+		}
 		return
 	}
 
-	var peerHeight int
-	fmt.Sscanf(string(response.Data), "%d", &peerHeight)
-
-	ourHeight := n.blockchain.Height()
-
-	if peerHeight > ourHeight {
-		fmt.Printf("📥 Syncing blockchain (our: %d, peer: %d)\n", ourHeight, peerHeight)
-		n.downloadBlocks(peerID, ourHeight)
+	if n.acceptBlock(block, from) {
+		n.connectOrphans(block.Hash)
 	}
 }
 
-// downloadBlocks downloads missing blocks from a peer
-func (n *Network) downloadBlocks(peerID peer.ID, startHeight int) {
-	stream, err := n.host.NewStream(n.ctx, peerID, protocol.ID(SyncProtocol))
-	if err != nil {
-		fmt.Printf("Failed to open sync stream: %v\n", err)
-		return
-	}
-	defer stream.Close()
-
-	// Request blocks
-	msg := Message{
-		Type:      MsgTypeGetBlocks,
-		Data:      []byte(fmt.Sprintf("%d", startHeight)),
-		Timestamp: time.Now(),
-		From:      n.host.ID().String(),
+// acceptBlock connects a block extending our tip, announces it and reports
+// whether it was valid
+func (n *Network) acceptBlock(block *types.Block, from peer.ID) bool {
+	if err := n.blockchain.ConnectBlocks(n.blockchain.Height()-1, []*types.Block{block}); err != nil {
+		fmt.Printf("Received invalid block: %v\n", err)
+		// Blame the peer only if the block still extends our tip, so a block
+		// racing another one to the same height is not punished
+		if bytes.Equal(block.Header.PrevBlockHash, n.blockchain.GetLatestBlock().Hash) {
+			n.misbehavingPeer(from, PenaltyInvalidBlock, fmt.Sprintf("invalid block %x: %v", block.Hash, err))
+		}
+		return false
 	}
 
-	encoder := json.NewEncoder(stream)
-	if err := encoder.Encode(msg); err != nil {
-		fmt.Printf("Failed to request blocks: %v\n", err)
-		return
-	}
+	fmt.Printf("✓ Received and added block %x from network\n", block.Hash[:8])
+	n.removeConfirmedTxs(block)
+	n.announceBlock(block)
 
-	// Read response
-	var response Message
-	decoder := json.NewDecoder(stream)
-	if err := decoder.Decode(&response); err != nil {
-		fmt.Printf("Failed to read blocks response: %v\n", err)
-		return
+	// Orphans may have been waiting for transactions confirmed by the block
+	for _, transaction := range block.Transactions.([]*tx.Transaction) {
+		n.promoteOrphanTxs(transaction)
 	}
 
-	if response.Type == MsgTypeBlocks {
-		var blocks []*types.Block
-		if err := json.Unmarshal(response.Data, &blocks); err != nil {
-			fmt.Printf("Failed to unmarshal blocks: %v\n", err)
-			return
-		}
-
-		// Add blocks to our blockchain
-		for _, block := range blocks {
-			n.processReceivedBlock(block)
-		}
-
-		fmt.Printf("✓ Synced %d blocks\n", len(blocks))
+	// Call custom handler if set
+	if n.blockHandler != nil {
+		n.blockHandler(block)
 	}
+	return true
 }
 
-// processReceivedBlock processes a block received from the network
-func (n *Network) processReceivedBlock(block *types.Block) {
-	// Check if we already have this block
-	if _, err := n.blockchain.GetBlockByHash(block.Hash); err == nil {
+// processReceivedTransaction processes a transaction relayed by a peer
+func (n *Network) processReceivedTransaction(transaction *tx.Transaction, from peer.ID) {
+	if n.findPendingTx(transaction.ID) != nil || n.orphanTxs.has(transaction.ID) {
 		return // Already have it
 	}
 
-	// Validate the block
-	if err := n.blockchain.ValidateBlock(block); err != nil {
-		fmt.Printf("Received invalid block: %v\n", err)
+	if err := n.checkTransaction(transaction); err != nil {
+		fmt.Printf("Received invalid transaction %x: %v\n", transaction.ID, err)
+		n.misbehavingPeer(from, PenaltyInvalidTx, fmt.Sprintf("invalid transaction %x: %v", transaction.ID, err))
 		return
 	}
 
-	// Add to blockchain
-	n.blockchain.Blocks = append(n.blockchain.Blocks, block)
-
-	// Update UTXO set
-	if txs, ok := block.Transactions.([]*tx.Transaction); ok {
-		for _, transaction := range txs {
-			n.blockchain.UTXOSet.Update(transaction)
-		}
+	// Transactions spending outputs we have not seen wait for their parents
+	if missing := n.missingParents(transaction); len(missing) > 0 {
+		n.addOrphanTx(transaction, from, missing)
+		return
 	}
 
-	// Save to storage
-	if n.blockchain.Storage != nil {
-		if err := n.blockchain.Storage.SaveBlock(block); err != nil {
-			fmt.Printf("Failed to save received block: %v\n", err)
-		}
+	if !n.acceptTransaction(transaction) {
+		return // Already pending, conflicting, or the pool is full
 	}
+	fmt.Printf("✓ Received transaction %x from network\n", transaction.ID[:8])
+	n.promoteOrphanTxs(transaction)
+}
 
-	fmt.Printf("✓ Received and added block %x from network\n", block.Hash[:8])
+// acceptTransaction adds a transaction to the pending pool and announces it.
+// It returns false if the pool does not take it (see addPendingTx).
+func (n *Network) acceptTransaction(transaction *tx.Transaction) bool {
+	if !n.addPendingTx(transaction) {
+		return false
+	}
+	n.announce(InvVect{Type: InvTypeTx, Hash: transaction.ID})
 
 	// Call custom handler if set
-	if n.blockHandler != nil {
-		n.blockHandler(block)
+	if n.txHandler != nil {
+		n.txHandler(transaction)
 	}
+	return true
 }
 
-// processReceivedTransaction processes a transaction received from the network
-func (n *Network) processReceivedTransaction(transaction *tx.Transaction) {
-	// Note: Full verification requires previous transactions
-	// For now, we'll add to pending and let the blockchain validate
-	// TODO: Implement transaction pool with full verification
-
-	// Add to pending transactions
-	n.blockchain.PendingTxs = append(n.blockchain.PendingTxs, transaction)
+// findParent looks up a transaction in the chain or the pending pool
+func (n *Network) findParent(id []byte) *tx.Transaction {
+	if transaction, err := n.blockchain.FindTransaction(id); err == nil {
+		return transaction
+	}
+	return n.findPendingTx(id)
+}
 
-	fmt.Printf("✓ Received transaction %x from network\n", transaction.ID[:8])
+// checkTransaction rejects transactions that can never become valid. Inputs
+// spending transactions we do not know yet are not checked, since their
+// parents may still be on their way.
+func (n *Network) checkTransaction(transaction *tx.Transaction) error {
+	if transaction.IsCoinbase() {
+		return fmt.Errorf("coinbase transactions are not relayed")
+	}
+	if len(transaction.Inputs) == 0 || len(transaction.Outputs) == 0 {
+		return fmt.Errorf("transaction has no inputs or outputs")
+	}
+	for i, output := range transaction.Outputs {
+		if output.Value <= 0 {
+			return fmt.Errorf("output %d has non-positive value %d", i, output.Value)
+		}
+	}
 
-	// Call custom handler if set
-	if n.txHandler != nil {
-		n.txHandler(transaction)
+	prevTxs := make(map[string]*tx.Transaction)
+	for i, input := range transaction.Inputs {
+		prevTx := n.findParent(input.TxID)
+		if prevTx == nil {
+			return nil // Unknown parent
+		}
+		if input.OutIndex < 0 || input.OutIndex >= len(prevTx.Outputs) {
+			return fmt.Errorf("input %d spends missing output %d", i, input.OutIndex)
+		}
+		prevTxs[string(input.TxID)] = prevTx
 	}
+	if !transaction.Verify(prevTxs) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
 }
 
 // SetBlockHandler sets a custom handler for received blocks
@@ -3,20 +3,22 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
 	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
 	"github.com/yourusername/bt/pkg/types"
 )
 
 func TestNewNetwork(t *testing.T) {
 	// Create blockchain
-	wallet, _ := crypto.NewWallet()
-	bc, err := blockchain.NewBlockchain(wallet.GetAddress(), ":memory:")
+	bc, err := blockchain.NewBlockchain(&chaincfg.MainNetParams, ":memory:")
 	if err != nil {
-		bc, _ = blockchain.NewBlockchain(wallet.GetAddress(), "./test-p2p-new.db")
+		bc, _ = blockchain.NewBlockchain(&chaincfg.MainNetParams, "./test-p2p-new.db")
 		defer bc.Close()
 	}
 
@@ -43,8 +45,7 @@ func TestNewNetwork(t *testing.T) {
 }
 
 func TestNetworkStart(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), "./test-p2p-start.db")
+	bc, _ := blockchain.NewBlockchain(&chaincfg.MainNetParams, "./test-p2p-start.db")
 	defer bc.Close()
 
 	ctx := context.Background()
@@ -75,13 +76,10 @@ func TestPeerConnection(t *testing.T) {
 	}
 
 	// Create two nodes
-	wallet1, _ := crypto.NewWallet()
-	wallet2, _ := crypto.NewWallet()
-
-	bc1, _ := blockchain.NewBlockchain(wallet1.GetAddress(), "./test-p2p-peer1.db")
+	bc1, _ := blockchain.NewBlockchain(&chaincfg.MainNetParams, "./test-p2p-peer1.db")
 	defer bc1.Close()
 
-	bc2, _ := blockchain.NewBlockchain(wallet2.GetAddress(), "./test-p2p-peer2.db")
+	bc2, _ := blockchain.NewBlockchain(&chaincfg.MainNetParams, "./test-p2p-peer2.db")
 	defer bc2.Close()
 
 	ctx := context.Background()
@@ -123,18 +121,87 @@ func TestPeerConnection(t *testing.T) {
 	}
 }
 
+func TestCrossNetworkIsolation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping cross-network test in short mode")
+	}
+
+	mainChain, _ := blockchain.New(&chaincfg.MainNetParams, storage.NewMemoryBackend())
+	defer mainChain.Close()
+	regtestChain, _ := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	defer regtestChain.Close()
+
+	ctx := context.Background()
+
+	mainNode, err := NewNetwork(ctx, mainChain, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create mainnet node: %v", err)
+	}
+	defer mainNode.Stop()
+
+	regtestNode, err := NewNetwork(ctx, regtestChain, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create regtest node: %v", err)
+	}
+	defer regtestNode.Stop()
+
+	// The transport connects, but no protocol of one network is served by the other
+	mainAddr := fmt.Sprintf("%s/p2p/%s", mainNode.host.Addrs()[0], mainNode.host.ID())
+	if err := regtestNode.ConnectToPeer(mainAddr); err == nil {
+		t.Error("Regtest node accepted a mainnet peer")
+	}
+
+	if regtestNode.GetPeerCount() != 0 {
+		t.Errorf("Expected 0 peers, got %d", regtestNode.GetPeerCount())
+	}
+}
+
+func TestGenesisMismatchRefused(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping genesis mismatch test in short mode")
+	}
+
+	bc1, _ := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	defer bc1.Close()
+	bc2, _ := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	defer bc2.Close()
+
+	// Same network, but node 2 follows a different chain
+	bc2.Blocks[0].Hash = make([]byte, 32)
+
+	ctx := context.Background()
+
+	network1, err := NewNetwork(ctx, bc1, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create network1: %v", err)
+	}
+	defer network1.Stop()
+
+	network2, err := NewNetwork(ctx, bc2, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create network2: %v", err)
+	}
+	defer network2.Stop()
+
+	node1Addr := fmt.Sprintf("%s/p2p/%s", network1.host.Addrs()[0], network1.host.ID())
+	if err := network2.ConnectToPeer(node1Addr); err == nil {
+		t.Fatal("Connected to a peer with a different genesis block")
+	}
+
+	if network2.GetPeerCount() != 0 {
+		t.Errorf("Expected 0 peers, got %d", network2.GetPeerCount())
+	}
+}
+
 func TestMessageBroadcast(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping broadcast test in short mode")
 	}
 
-	wallet1, _ := crypto.NewWallet()
-	wallet2, _ := crypto.NewWallet()
-
-	bc1, _ := blockchain.NewBlockchain(wallet1.GetAddress(), "./test-p2p-broadcast1.db")
+	bc1, _ := blockchain.New(&chaincfg.MainNetParams, storage.NewMemoryBackend())
 	defer bc1.Close()
 
-	bc2, _ := blockchain.NewBlockchain(wallet2.GetAddress(), "./test-p2p-broadcast2.db")
+	bc2, _ := blockchain.New(&chaincfg.MainNetParams, storage.NewMemoryBackend())
 	defer bc2.Close()
 
 	ctx := context.Background()
@@ -153,26 +220,29 @@ func TestMessageBroadcast(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	// Set up receiver
-	received := false
+	var received atomic.Bool
 	network2.SetBlockHandler(func(block *types.Block) {
-		received = true
+		received.Store(true)
 	})
 
-	// Broadcast from node 1
-	block := bc1.GetLatestBlock()
+	// Broadcast a new block from node 1
+	miner, _ := crypto.NewWallet()
+	block, err := bc1.AddBlock(nil, miner.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
 	network1.BroadcastBlock(block)
 
 	// Wait for message
 	time.Sleep(2 * time.Second)
 
-	if !received {
+	if !received.Load() {
 		t.Error("Block was not received by peer")
 	}
 }
 
 func TestGetPeerCount(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), "./test-p2p-count.db")
+	bc, _ := blockchain.NewBlockchain(&chaincfg.MainNetParams, "./test-p2p-count.db")
 	defer bc.Close()
 
 	ctx := context.Background()
@@ -186,8 +256,7 @@ func TestGetPeerCount(t *testing.T) {
 }
 
 func TestGetPeers(t *testing.T) {
-	wallet, _ := crypto.NewWallet()
-	bc, _ := blockchain.NewBlockchain(wallet.GetAddress(), "./test-p2p-getpeers.db")
+	bc, _ := blockchain.NewBlockchain(&chaincfg.MainNetParams, "./test-p2p-getpeers.db")
 	defer bc.Close()
 
 	ctx := context.Background()
@@ -0,0 +1,503 @@
+package p2p
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/pow"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+const (
+	// maxOrphanBlocks bounds the blocks held while their parents are fetched
+	maxOrphanBlocks = 100
+
+	// orphanBlockTTL is how long an orphan block waits for its parent
+	orphanBlockTTL = 20 * time.Minute
+)
+
+// orphanBlock is a block whose parent we do not have yet
+type orphanBlock struct {
+	block *types.Block
+	from  peer.ID
+	added time.Time
+}
+
+// orphanBlockPool holds orphan blocks by hash and by parent hash, evicting
+// the oldest once full
+type orphanBlockPool struct {
+	mu     sync.Mutex
+	byHash map[string]*orphanBlock
+	byPrev map[string][]*orphanBlock
+	limit  int
+	ttl    time.Duration
+}
+
+// newOrphanBlockPool creates a pool holding up to limit blocks for ttl
+func newOrphanBlockPool(limit int, ttl time.Duration) *orphanBlockPool {
+	return &orphanBlockPool{
+		byHash: make(map[string]*orphanBlock),
+		byPrev: make(map[string][]*orphanBlock),
+		limit:  limit,
+		ttl:    ttl,
+	}
+}
+
+// add stores an orphan, reporting whether it was new
+func (p *orphanBlockPool) add(block *types.Block, from peer.ID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.byHash[string(block.Hash)]; ok {
+		return false
+	}
+
+	p.expire(time.Now())
+	p.insert(&orphanBlock{block: block, from: from, added: time.Now()})
+	return true
+}
+
+// restore puts back an orphan taken by children, keeping its age so it
+// still expires on time
+func (p *orphanBlockPool) restore(orphan *orphanBlock) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.byHash[string(orphan.block.Hash)]; ok {
+		return
+	}
+	p.expire(time.Now())
+	if time.Since(orphan.added) <= p.ttl {
+		p.insert(orphan)
+	}
+}
+
+// insert indexes an orphan, evicting the oldest if full; callers hold p.mu
+func (p *orphanBlockPool) insert(orphan *orphanBlock) {
+	for len(p.byHash) >= p.limit {
+		p.removeOldest()
+	}
+	p.byHash[string(orphan.block.Hash)] = orphan
+	prev := string(orphan.block.Header.PrevBlockHash)
+	p.byPrev[prev] = append(p.byPrev[prev], orphan)
+}
+
+// has reports whether a block is held as an orphan
+func (p *orphanBlockPool) has(hash []byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.byHash[string(hash)]
+	return ok
+}
+
+// root follows a block's ancestors through the pool and returns the hash
+// of the first one that is missing
+func (p *orphanBlockPool) root(hash []byte) []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for {
+		orphan, ok := p.byHash[string(hash)]
+		if !ok {
+			return hash
+		}
+		hash = orphan.block.Header.PrevBlockHash
+	}
+}
+
+// children removes and returns the orphans building on a block
+func (p *orphanBlockPool) children(hash []byte) []*orphanBlock {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Copy first, since remove shifts the indexed slice
+	children := append([]*orphanBlock(nil), p.byPrev[string(hash)]...)
+	for _, orphan := range children {
+		p.remove(orphan)
+	}
+	return children
+}
+
+// hasChildren reports whether any orphan builds on a block
+func (p *orphanBlockPool) hasChildren(hash []byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.byPrev[string(hash)]) > 0
+}
+
+// discard drops an orphan that was connected some other way, such as by a
+// headers-first sync
+func (p *orphanBlockPool) discard(hash []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if orphan, ok := p.byHash[string(hash)]; ok {
+		p.remove(orphan)
+	}
+}
+
+// len returns the number of orphans held
+func (p *orphanBlockPool) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.byHash)
+}
+
+// expire drops orphans older than the pool's ttl; callers hold p.mu
+func (p *orphanBlockPool) expire(now time.Time) {
+	for _, orphan := range p.byHash {
+		if now.Sub(orphan.added) > p.ttl {
+			p.remove(orphan)
+		}
+	}
+}
+
+// removeOldest drops the orphan that has waited longest; callers hold p.mu
+func (p *orphanBlockPool) removeOldest() {
+	var oldest *orphanBlock
+	for _, orphan := range p.byHash {
+		if oldest == nil || orphan.added.Before(oldest.added) {
+			oldest = orphan
+		}
+	}
+	if oldest != nil {
+		p.remove(oldest)
+	}
+}
+
+// remove drops an orphan from both indexes; callers hold p.mu
+func (p *orphanBlockPool) remove(orphan *orphanBlock) {
+	delete(p.byHash, string(orphan.block.Hash))
+
+	prev := string(orphan.block.Header.PrevBlockHash)
+	siblings := p.byPrev[prev]
+	for i, sibling := range siblings {
+		if sibling == orphan {
+			siblings = append(siblings[:i], siblings[i+1:]...)
+			break
+		}
+	}
+	if len(siblings) == 0 {
+		delete(p.byPrev, prev)
+	} else {
+		p.byPrev[prev] = siblings
+	}
+}
+
+// addOrphanBlock holds a block whose parent we do not have and asks the
+// peer that sent it for the missing parent, which usually is the one block
+// we missed. If that parent turns out to be an orphan as well, the gap is
+// longer and is synced headers-first from the peer instead of walking back
+// one block per round trip. Only blocks with valid proof-of-work are held,
+// so orphans are as costly to fake as real blocks.
+func (n *Network) addOrphanBlock(block *types.Block, from peer.ID) {
+	if penalty, err := n.checkBlockWork(&block.Header, block.Hash); err != nil {
+		n.misbehavingPeer(from, penalty, fmt.Sprintf("orphan block %x: %v", block.Hash, err))
+		return
+	}
+	hash := block.Hash
+
+	if !n.orphans.add(block, from) {
+		return
+	}
+	if n.orphans.hasChildren(hash) {
+		fmt.Printf("🧩 Holding orphan block %x, syncing the missing blocks from the peer (%d orphans)\n", hash[:8], n.orphans.len())
+		go n.syncWithPeer(from)
+		return
+	}
+	missing := n.orphans.root(hash)
+	fmt.Printf("🧩 Holding orphan block %x, fetching missing parent %x (%d orphans)\n", hash[:8], missing[:8], n.orphans.len())
+	n.requestBlock(from, missing)
+}
+
+// checkBlockWork checks that a block hash matches its header and meets a
+// target within the chain's limits, without needing the parent. On failure
+// it returns the penalty for the peer that sent the block.
+func (n *Network) checkBlockWork(header *types.BlockHeader, hash []byte) (int, error) {
+	if !bytes.Equal(crypto.HashBlockHeader(header), hash) {
+		return PenaltyMalformed, fmt.Errorf("hash does not match its header")
+	}
+	target := header.DifficultyTarget
+	if target < n.blockchain.Params.MinTargetBits || target > n.blockchain.Params.MaxTargetBits || !pow.IsValidHash(hash, target) {
+		return PenaltyInvalidBlock, fmt.Errorf("invalid proof-of-work")
+	}
+	return 0, nil
+}
+
+// requestBlock asks a peer for a single block unless it is already in flight
+func (n *Network) requestBlock(peerID peer.ID, hash []byte) {
+	c := n.getPeer(peerID)
+	if c == nil || !n.startRequest(hash) {
+		return
+	}
+	go n.fetchData(c, []InvVect{{Type: InvTypeBlock, Hash: hash}})
+}
+
+// connectOrphans connects the orphans waiting on a block we just accepted,
+// then the orphans waiting on those, and so on. Orphans whose parent is no
+// longer our tip may belong to a heavier branch, so they stay in the pool
+// until they expire and their sender is asked for its chain.
+func (n *Network) connectOrphans(hash []byte) {
+	queue := [][]byte{hash}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		for _, orphan := range n.orphans.children(parent) {
+			if !bytes.Equal(orphan.block.Header.PrevBlockHash, n.blockchain.GetLatestBlock().Hash) {
+				// A sibling was connected first
+				n.orphans.restore(orphan)
+				go n.syncWithPeer(orphan.from)
+				continue
+			}
+			if n.acceptBlock(orphan.block, orphan.from) {
+				queue = append(queue, orphan.block.Hash)
+			}
+		}
+	}
+}
+
+const (
+	// maxOrphanTxs bounds the transactions held while their parents are fetched
+	maxOrphanTxs = 100
+
+	// maxOrphanTxsPerPeer bounds the orphans a single peer can make us hold
+	maxOrphanTxsPerPeer = 20
+
+	// orphanTxTTL is how long an orphan transaction waits for its parents
+	orphanTxTTL = 20 * time.Minute
+)
+
+// outpoint identifies a transaction output
+type outpoint struct {
+	txID  string
+	index int
+}
+
+// orphanTx is a transaction spending outputs of transactions we have not seen
+type orphanTx struct {
+	tx      *tx.Transaction
+	from    peer.ID
+	added   time.Time
+	missing []outpoint
+}
+
+// orphanTxPool holds orphan transactions by ID and by the outpoints they
+// wait for. Each peer can only fill part of the pool.
+type orphanTxPool struct {
+	mu         sync.Mutex
+	byID       map[string]*orphanTx
+	byOutpoint map[outpoint][]*orphanTx
+	perPeer    map[peer.ID]int
+	limit      int
+	peerLimit  int
+	ttl        time.Duration
+}
+
+// newOrphanTxPool creates a pool holding up to limit transactions, at most
+// peerLimit from one peer, for ttl
+func newOrphanTxPool(limit, peerLimit int, ttl time.Duration) *orphanTxPool {
+	return &orphanTxPool{
+		byID:       make(map[string]*orphanTx),
+		byOutpoint: make(map[outpoint][]*orphanTx),
+		perPeer:    make(map[peer.ID]int),
+		limit:      limit,
+		peerLimit:  peerLimit,
+		ttl:        ttl,
+	}
+}
+
+// add stores an orphan waiting for the given outpoints, reporting whether
+// it was new. A peer over its share loses its own oldest orphan first.
+func (p *orphanTxPool) add(transaction *tx.Transaction, from peer.ID, missing []outpoint) bool {
+	return p.insert(&orphanTx{tx: transaction, from: from, added: time.Now(), missing: missing})
+}
+
+// restore puts back an orphan taken by waitingOn that still misses other
+// parents, keeping its age so a peer trickling parents cannot keep it
+// alive past its expiry
+func (p *orphanTxPool) restore(orphan *orphanTx, missing []outpoint) bool {
+	if time.Since(orphan.added) > p.ttl {
+		return false
+	}
+	return p.insert(&orphanTx{tx: orphan.tx, from: orphan.from, added: orphan.added, missing: missing})
+}
+
+// insert indexes an orphan, making room for it, and reports whether it was
+// new
+func (p *orphanTxPool) insert(orphan *orphanTx) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.byID[string(orphan.tx.ID)]; ok {
+		return false
+	}
+
+	p.expire(time.Now())
+	for p.perPeer[orphan.from] >= p.peerLimit {
+		p.removeOldest(func(other *orphanTx) bool { return other.from == orphan.from })
+	}
+	for len(p.byID) >= p.limit {
+		p.removeOldest(func(*orphanTx) bool { return true })
+	}
+
+	p.byID[string(orphan.tx.ID)] = orphan
+	for _, op := range orphan.missing {
+		p.byOutpoint[op] = append(p.byOutpoint[op], orphan)
+	}
+	p.perPeer[orphan.from]++
+	return true
+}
+
+// has reports whether a transaction is held as an orphan
+func (p *orphanTxPool) has(id []byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.byID[string(id)]
+	return ok
+}
+
+// waitingOn removes and returns the orphans spending outputs of a transaction
+func (p *orphanTxPool) waitingOn(parent *tx.Transaction) []*orphanTx {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var orphans []*orphanTx
+	for i := range parent.Outputs {
+		for _, orphan := range p.byOutpoint[outpoint{txID: string(parent.ID), index: i}] {
+			if _, ok := p.byID[string(orphan.tx.ID)]; ok {
+				p.remove(orphan)
+				orphans = append(orphans, orphan)
+			}
+		}
+	}
+	return orphans
+}
+
+// len returns the number of orphans held
+func (p *orphanTxPool) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.byID)
+}
+
+// expire drops orphans older than the pool's ttl; callers hold p.mu
+func (p *orphanTxPool) expire(now time.Time) {
+	for _, orphan := range p.byID {
+		if now.Sub(orphan.added) > p.ttl {
+			p.remove(orphan)
+		}
+	}
+}
+
+// removeOldest drops the oldest orphan matching fn; callers hold p.mu
+func (p *orphanTxPool) removeOldest(fn func(*orphanTx) bool) {
+	var oldest *orphanTx
+	for _, orphan := range p.byID {
+		if fn(orphan) && (oldest == nil || orphan.added.Before(oldest.added)) {
+			oldest = orphan
+		}
+	}
+	if oldest != nil {
+		p.remove(oldest)
+	}
+}
+
+// remove drops an orphan from all indexes; callers hold p.mu
+func (p *orphanTxPool) remove(orphan *orphanTx) {
+	delete(p.byID, string(orphan.tx.ID))
+
+	for _, op := range orphan.missing {
+		waiting := p.byOutpoint[op]
+		for i, other := range waiting {
+			if other == orphan {
+				waiting = append(waiting[:i], waiting[i+1:]...)
+				break
+			}
+		}
+		if len(waiting) == 0 {
+			delete(p.byOutpoint, op)
+		} else {
+			p.byOutpoint[op] = waiting
+		}
+	}
+
+	p.perPeer[orphan.from]--
+	if p.perPeer[orphan.from] <= 0 {
+		delete(p.perPeer, orphan.from)
+	}
+}
+
+// missingParents returns the outpoints a transaction spends from
+// transactions that are neither in our chain nor pending
+func (n *Network) missingParents(transaction *tx.Transaction) []outpoint {
+	var missing []outpoint
+	for _, input := range transaction.Inputs {
+		if n.findParent(input.TxID) == nil {
+			missing = append(missing, outpoint{txID: string(input.TxID), index: input.OutIndex})
+		}
+	}
+	return missing
+}
+
+// addOrphanTx holds a transaction until its parents arrive and asks the
+// peer that relayed it for them
+func (n *Network) addOrphanTx(transaction *tx.Transaction, from peer.ID, missing []outpoint) {
+	if !n.orphanTxs.add(transaction, from, missing) {
+		return
+	}
+	fmt.Printf("🧩 Holding orphan transaction %x, fetching %d missing parent(s) (%d orphans)\n",
+		transaction.ID[:8], len(missing), n.orphanTxs.len())
+
+	c := n.getPeer(from)
+	if c == nil {
+		return
+	}
+	wanted := make([]InvVect, 0, len(missing))
+	seen := make(map[string]bool)
+	for _, op := range missing {
+		if !seen[op.txID] && n.startRequest([]byte(op.txID)) {
+			wanted = append(wanted, InvVect{Type: InvTypeTx, Hash: []byte(op.txID)})
+		}
+		seen[op.txID] = true
+	}
+	for start := 0; start < len(wanted); start += maxGetDataItems {
+		end := start + maxGetDataItems
+		if end > len(wanted) {
+			end = len(wanted)
+		}
+		go n.fetchData(c, wanted[start:end])
+	}
+}
+
+// promoteOrphanTxs moves the orphans spending a newly accepted transaction
+// into the pending pool, then the orphans spending those, and so on
+func (n *Network) promoteOrphanTxs(parent *tx.Transaction) {
+	queue := []*tx.Transaction{parent}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+
+		for _, orphan := range n.orphanTxs.waitingOn(next) {
+			if n.findPendingTx(orphan.tx.ID) != nil {
+				continue
+			}
+			if err := n.checkTransaction(orphan.tx); err != nil {
+				n.misbehavingPeer(orphan.from, PenaltyInvalidTx, fmt.Sprintf("invalid transaction %x: %v", orphan.tx.ID, err))
+				continue
+			}
+			if missing := n.missingParents(orphan.tx); len(missing) > 0 {
+				n.orphanTxs.restore(orphan, missing)
+				continue
+			}
+			if n.acceptTransaction(orphan.tx) {
+				queue = append(queue, orphan.tx)
+			}
+		}
+	}
+}
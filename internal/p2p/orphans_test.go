@@ -0,0 +1,296 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+// testOrphan builds a block with the given hash and parent
+func testOrphan(hash, prev string) *types.Block {
+	return &types.Block{Hash: []byte(hash), Header: types.BlockHeader{PrevBlockHash: []byte(prev)}}
+}
+
+func TestOrphanBlockPool(t *testing.T) {
+	pool := newOrphanBlockPool(3, time.Hour)
+
+	if !pool.add(testOrphan("b2", "b1"), "") {
+		t.Error("New orphan not reported as new")
+	}
+	if pool.add(testOrphan("b2", "b1"), "") {
+		t.Error("Known orphan reported as new")
+	}
+	pool.add(testOrphan("b3", "b2"), "")
+	pool.add(testOrphan("c2", "b1"), "")
+
+	if root := pool.root([]byte("b3")); string(root) != "b1" {
+		t.Errorf("Expected missing ancestor b1, got %s", root)
+	}
+
+	children := pool.children([]byte("b1"))
+	if len(children) != 2 {
+		t.Fatalf("Expected 2 children of b1, got %d", len(children))
+	}
+	if pool.has([]byte("b2")) || pool.has([]byte("c2")) || !pool.has([]byte("b3")) {
+		t.Error("Children not removed from the pool")
+	}
+
+	// A full pool evicts its oldest orphan
+	pool.add(testOrphan("d1", "d0"), "")
+	pool.add(testOrphan("e1", "e0"), "")
+	pool.add(testOrphan("f1", "f0"), "")
+	if pool.len() != 3 || pool.has([]byte("b3")) {
+		t.Errorf("Oldest orphan not evicted (%d orphans)", pool.len())
+	}
+}
+
+func TestOrphanBlockChildren(t *testing.T) {
+	pool := newOrphanBlockPool(10, time.Hour)
+	pool.add(testOrphan("b3", "b2"), "")
+	if !pool.hasChildren([]byte("b2")) || pool.hasChildren([]byte("b3")) {
+		t.Error("Children of orphan blocks not tracked")
+	}
+
+	pool.discard([]byte("b3"))
+	if pool.has([]byte("b3")) || pool.hasChildren([]byte("b2")) {
+		t.Error("Discarded orphan still held")
+	}
+}
+
+func TestOrphanBlocksExpire(t *testing.T) {
+	pool := newOrphanBlockPool(10, time.Millisecond)
+	pool.add(testOrphan("b2", "b1"), "")
+	time.Sleep(5 * time.Millisecond)
+
+	pool.add(testOrphan("c2", "c1"), "")
+	if pool.has([]byte("b2")) {
+		t.Error("Expired orphan still held")
+	}
+}
+
+func TestRestoredOrphanKeepsItsAge(t *testing.T) {
+	pool := newOrphanBlockPool(10, 50*time.Millisecond)
+	pool.add(testOrphan("b2", "b1"), "")
+	children := pool.children([]byte("b1"))
+
+	pool.restore(children[0])
+	if !pool.has([]byte("b2")) || len(pool.children([]byte("b1"))) != 1 {
+		t.Fatal("Restored orphan not held")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	pool.restore(children[0])
+	if pool.has([]byte("b2")) {
+		t.Error("Orphan restored past its expiry")
+	}
+}
+
+func TestOrphanBlocksConnectOnceParentArrives(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping orphan test in short mode")
+	}
+
+	// The miner dials, so the node never starts a headers-first sync
+	miner, _ := newRegTestNode(t, 0)
+	node, _ := newRegTestNode(t, 0)
+	if err := miner.ConnectToPeer(node.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	waitForPeer(t, node, miner)
+
+	// Mine without announcing, so the node misses every block
+	wallet, _ := crypto.NewWallet()
+	var blocks []*types.Block
+	for i := 0; i < 3; i++ {
+		block, err := miner.blockchain.AddBlock(nil, wallet.GetAddress())
+		if err != nil {
+			t.Fatalf("Failed to mine block: %v", err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	// Only the newest block reaches the node. Its parent is fetched from
+	// the sender, and since that is an orphan too, the rest of the gap is
+	// synced headers-first and everything connects in order
+	node.processReceivedBlock(blocks[2], miner.host.ID())
+	waitForTip(t, node.blockchain, blocks[2].Hash)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for node.orphans.len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected an empty orphan pool, got %d orphans", node.orphans.len())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if node.blockchain.Height() != 4 {
+		t.Errorf("Expected height 4, got %d", node.blockchain.Height())
+	}
+}
+
+func TestOrphanOffTipIsKept(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping orphan test in short mode")
+	}
+
+	node, nodeChain := newRegTestNode(t, 0)
+
+	// Two miners build competing children on the same parent
+	first, err := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	defer first.Close()
+	second, err := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	defer second.Close()
+
+	alice, _ := crypto.NewWallet()
+	bob, _ := crypto.NewWallet()
+	parent, err := first.AddBlock(nil, alice.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+	child, err := first.AddBlock(nil, alice.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+	if err := second.ConnectBlocks(0, []*types.Block{parent}); err != nil {
+		t.Fatalf("Failed to connect block: %v", err)
+	}
+	sibling, err := second.AddBlock(nil, bob.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+
+	// Both children wait for the parent; one connects, the other stays
+	// held in case its branch turns out heavier
+	node.orphans.add(child, "")
+	node.orphans.add(sibling, "")
+	node.processReceivedBlock(parent, "")
+
+	if tip := nodeChain.GetLatestBlock().Hash; string(tip) != string(child.Hash) {
+		t.Fatalf("Expected tip %x, got %x", child.Hash, tip)
+	}
+	if !node.orphans.has(sibling.Hash) {
+		t.Error("Orphan off the tip was dropped")
+	}
+}
+
+// testOrphanTx builds a transaction spending output 0 of parent
+func testOrphanTx(id, parent string) *tx.Transaction {
+	return &tx.Transaction{
+		ID:      []byte(id),
+		Inputs:  []tx.TxInput{{TxID: []byte(parent), OutIndex: 0}},
+		Outputs: []tx.TxOutput{{Value: 1}},
+	}
+}
+
+func TestOrphanTxPool(t *testing.T) {
+	pool := newOrphanTxPool(4, 2, time.Hour)
+	missing := func(parent string) []outpoint { return []outpoint{{txID: parent, index: 0}} }
+
+	if !pool.add(testOrphanTx("a", "p"), "peer1", missing("p")) {
+		t.Error("New orphan not reported as new")
+	}
+	if pool.add(testOrphanTx("a", "p"), "peer1", missing("p")) {
+		t.Error("Known orphan reported as new")
+	}
+	pool.add(testOrphanTx("b", "p"), "peer2", missing("p"))
+
+	// One peer cannot push out another peer's orphans
+	pool.add(testOrphanTx("c", "q"), "peer1", missing("q"))
+	pool.add(testOrphanTx("d", "q"), "peer1", missing("q"))
+	if pool.has([]byte("a")) || !pool.has([]byte("b")) || pool.len() != 3 {
+		t.Errorf("Per-peer limit not enforced (%d orphans)", pool.len())
+	}
+
+	parent := &tx.Transaction{ID: []byte("p"), Outputs: []tx.TxOutput{{Value: 2}}}
+	waiting := pool.waitingOn(parent)
+	if len(waiting) != 1 || string(waiting[0].tx.ID) != "b" {
+		t.Fatalf("Expected orphan b to wait on p, got %d orphans", len(waiting))
+	}
+	if pool.has([]byte("b")) {
+		t.Error("Promoted orphan still held")
+	}
+	if pool.perPeer["peer2"] != 0 || pool.perPeer["peer1"] != 2 {
+		t.Errorf("Unexpected per-peer counts %v", pool.perPeer)
+	}
+}
+
+func TestRestoredOrphanTxKeepsItsAge(t *testing.T) {
+	pool := newOrphanTxPool(4, 2, 50*time.Millisecond)
+	waitsOn := []outpoint{{txID: "p", index: 0}, {txID: "q", index: 0}}
+	pool.add(&tx.Transaction{ID: []byte("a"), Inputs: []tx.TxInput{{TxID: []byte("p")}, {TxID: []byte("q")}}}, "peer1", waitsOn)
+
+	// Each parent that arrives puts the orphan back with its original age
+	parent := &tx.Transaction{ID: []byte("p"), Outputs: []tx.TxOutput{{Value: 1}}}
+	orphan := pool.waitingOn(parent)[0]
+	if !pool.restore(orphan, waitsOn[1:]) || !pool.has([]byte("a")) {
+		t.Fatal("Restored orphan not held")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	orphan = pool.waitingOn(&tx.Transaction{ID: []byte("q"), Outputs: []tx.TxOutput{{Value: 1}}})[0]
+	if pool.restore(orphan, []outpoint{{txID: "r", index: 0}}) || pool.has([]byte("a")) {
+		t.Error("Orphan restored past its expiry")
+	}
+}
+
+func TestOrphanTransactionWaitsForParent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping orphan test in short mode")
+	}
+
+	sender, _ := newRegTestNode(t, 0)
+	miner, _ := crypto.NewWallet()
+	if _, err := sender.blockchain.AddBlock(nil, miner.GetAddress()); err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+
+	node, _ := newRegTestNode(t, 0)
+	if err := node.ConnectToPeer(sender.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	waitForTip(t, node.blockchain, sender.blockchain.GetLatestBlock().Hash)
+
+	// The sender has an unconfirmed parent the node has never seen
+	recipient, _ := crypto.NewWallet()
+	parent, err := sender.blockchain.CreateTransaction(miner.GetAddress(), recipient.GetAddress(), 10, miner)
+	if err != nil {
+		t.Fatalf("Failed to create parent: %v", err)
+	}
+	sender.addPendingTx(parent)
+
+	child := tx.NewTransaction(
+		[]tx.TxInput{{TxID: parent.ID, OutIndex: 0, PubKey: recipient.PublicKey}},
+		[]tx.TxOutput{{Value: 10, PubKeyHash: []byte("someone")}},
+	)
+	if err := child.Sign(recipient, map[string]*tx.Transaction{string(parent.ID): parent}); err != nil {
+		t.Fatalf("Failed to sign child: %v", err)
+	}
+
+	// The child waits until its parent is fetched from the sender
+	node.processReceivedTransaction(child, sender.host.ID())
+	deadline := time.Now().Add(10 * time.Second)
+	for node.findPendingTx(child.ID) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Orphan transaction was never promoted")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if node.findPendingTx(parent.ID) == nil {
+		t.Error("Parent transaction not fetched")
+	}
+	if node.orphanTxs.len() != 0 {
+		t.Errorf("Expected an empty orphan pool, got %d orphans", node.orphanTxs.len())
+	}
+}
@@ -0,0 +1,408 @@
+package p2p
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+const (
+	// maxInvPerMsg bounds the entries of an inv message
+	maxInvPerMsg = 1000
+
+	// maxGetDataItems bounds the items requested in one getdata
+	maxGetDataItems = 16
+
+	// maxKnownInventory bounds each peer's known-inventory filter
+	maxKnownInventory = 5000
+
+	// maxPendingTxs and maxPendingTxBytes bound the pending pool; no more
+	// transactions are relayed while it is full
+	maxPendingTxs     = 5000
+	maxPendingTxBytes = 5 << 20
+)
+
+// InvType identifies the kind of an inventory item
+type InvType uint8
+
+const (
+	InvTypeBlock InvType = iota + 1
+	InvTypeTx
+)
+
+// String returns the inventory type name
+func (t InvType) String() string {
+	switch t {
+	case InvTypeBlock:
+		return "block"
+	case InvTypeTx:
+		return "tx"
+	default:
+		return fmt.Sprintf("inv(%d)", uint8(t))
+	}
+}
+
+// InvVect announces or requests a single block or transaction by hash
+type InvVect struct {
+	Type InvType
+	Hash []byte
+}
+
+// inventoryFilter remembers the most recent hashes a peer is known to have,
+// evicting the oldest once full
+type inventoryFilter struct {
+	mu    sync.Mutex
+	set   map[string]struct{}
+	order []string
+	limit int
+}
+
+// newInventoryFilter creates a filter holding up to limit hashes
+func newInventoryFilter(limit int) *inventoryFilter {
+	return &inventoryFilter{
+		set:   make(map[string]struct{}),
+		limit: limit,
+	}
+}
+
+// Add records a hash, reporting whether it was new
+func (f *inventoryFilter) Add(hash []byte) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := string(hash)
+	if _, ok := f.set[key]; ok {
+		return false
+	}
+
+	if len(f.order) >= f.limit {
+		delete(f.set, f.order[0])
+		f.order = f.order[1:]
+	}
+	f.set[key] = struct{}{}
+	f.order = append(f.order, key)
+	return true
+}
+
+// Has reports whether a hash is known
+func (f *inventoryFilter) Has(hash []byte) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.set[string(hash)]
+	return ok
+}
+
+// announceTargets returns the peers not yet known to have an item and marks
+// the item as known to them
+func (n *Network) announceTargets(hash []byte) []*peerConn {
+	n.peerMutex.RLock()
+	defer n.peerMutex.RUnlock()
+
+	targets := make([]*peerConn, 0, len(n.peers))
+	for _, c := range n.peers {
+		if c.known.Add(hash) {
+			targets = append(targets, c)
+		}
+	}
+	return targets
+}
+
+// announce sends an inv for an item to every peer not yet known to have it
+func (n *Network) announce(inv InvVect) {
+	targets := n.announceTargets(inv.Hash)
+
+	var w wireWriter
+	w.invs([]InvVect{inv})
+	msg := &Message{Type: MsgTypeInv, Data: w.Bytes()}
+
+	for _, c := range targets {
+		go c.send(msg)
+	}
+}
+
+// handleInv handles inventory announcements by fetching unknown items
+func (n *Network) handleInv(c *peerConn, data []byte) {
+	r := newWireReader(data)
+	invs := r.invs()
+	if err := r.done(); err != nil {
+		n.misbehaving(c, PenaltyMalformed, fmt.Sprintf("invalid inv: %v", err))
+		return
+	}
+	if len(invs) > maxInvPerMsg {
+		invs = invs[:maxInvPerMsg]
+	}
+
+	wanted := make([]InvVect, 0, len(invs))
+	for _, inv := range invs {
+		// Never announce it back
+		if c.known.Add(inv.Hash) && inv.Type == InvTypeBlock {
+			c.bestHeight.Add(1)
+		}
+		if !n.haveInventory(inv) && n.startRequest(inv.Hash) {
+			wanted = append(wanted, inv)
+		}
+	}
+
+	for start := 0; start < len(wanted); start += maxGetDataItems {
+		end := start + maxGetDataItems
+		if end > len(wanted) {
+			end = len(wanted)
+		}
+		n.fetchData(c, wanted[start:end])
+	}
+}
+
+// haveInventory reports whether we already have an item
+func (n *Network) haveInventory(inv InvVect) bool {
+	switch inv.Type {
+	case InvTypeBlock:
+		_, err := n.blockchain.GetBlockByHash(inv.Hash)
+		return err == nil || n.orphans.has(inv.Hash)
+	case InvTypeTx:
+		return n.findPendingTx(inv.Hash) != nil || n.orphanTxs.has(inv.Hash)
+	default:
+		return true // Unknown types are never requested
+	}
+}
+
+// findPendingTx looks up a transaction in the pending pool
+func (n *Network) findPendingTx(id []byte) *tx.Transaction {
+	n.pendingMutex.RLock()
+	defer n.pendingMutex.RUnlock()
+
+	for _, pending := range n.blockchain.PendingTxs {
+		if bytes.Equal(pending.ID, id) {
+			return pending
+		}
+	}
+	return nil
+}
+
+// addPendingTx adds a transaction to the pending pool; it returns false if
+// the pool already holds it or a transaction spending the same outputs, or
+// if the pool is full
+func (n *Network) addPendingTx(transaction *tx.Transaction) bool {
+	size := wireSize(transaction)
+
+	n.pendingMutex.Lock()
+	defer n.pendingMutex.Unlock()
+
+	if len(n.blockchain.PendingTxs) >= maxPendingTxs || n.pendingBytes+size > maxPendingTxBytes {
+		return false
+	}
+	spends := spentOutpoints([]*tx.Transaction{transaction})
+	for _, pending := range n.blockchain.PendingTxs {
+		if bytes.Equal(pending.ID, transaction.ID) || spendsAny(pending, spends) {
+			return false
+		}
+	}
+	n.blockchain.PendingTxs = append(n.blockchain.PendingTxs, transaction)
+	n.pendingBytes += size
+	return true
+}
+
+// removeConfirmedTxs drops the transactions of a connected block from the
+// pending pool, along with those spending the same outputs as the block's
+// and their descendants, which can no longer confirm
+func (n *Network) removeConfirmedTxs(block *types.Block) {
+	transactions, ok := block.Transactions.([]*tx.Transaction)
+	if !ok {
+		return
+	}
+	confirmed := make(map[string]bool, len(transactions))
+	for _, transaction := range transactions {
+		confirmed[string(transaction.ID)] = true
+	}
+	spent := spentOutpoints(transactions)
+
+	n.pendingMutex.Lock()
+	defer n.pendingMutex.Unlock()
+
+	conflicted := make(map[string]bool)
+	var kept []*tx.Transaction
+	for _, pending := range n.blockchain.PendingTxs {
+		if confirmed[string(pending.ID)] {
+			n.pendingBytes -= wireSize(pending)
+			continue
+		}
+		if spendsAny(pending, spent) || spendsFrom(pending, conflicted) {
+			conflicted[string(pending.ID)] = true
+			n.pendingBytes -= wireSize(pending)
+			continue
+		}
+		kept = append(kept, pending)
+	}
+	n.blockchain.PendingTxs = kept
+}
+
+// spentOutpoints returns the outputs spent by non-coinbase transactions
+func spentOutpoints(transactions []*tx.Transaction) map[outpoint]bool {
+	spent := make(map[outpoint]bool)
+	for _, transaction := range transactions {
+		if transaction.IsCoinbase() {
+			continue
+		}
+		for _, input := range transaction.Inputs {
+			spent[outpoint{txID: string(input.TxID), index: input.OutIndex}] = true
+		}
+	}
+	return spent
+}
+
+// spendsAny reports whether a transaction spends one of the given outputs
+func spendsAny(transaction *tx.Transaction, outpoints map[outpoint]bool) bool {
+	for _, input := range transaction.Inputs {
+		if outpoints[outpoint{txID: string(input.TxID), index: input.OutIndex}] {
+			return true
+		}
+	}
+	return false
+}
+
+// spendsFrom reports whether a transaction spends outputs of one of the
+// given transactions
+func spendsFrom(transaction *tx.Transaction, ids map[string]bool) bool {
+	for _, input := range transaction.Inputs {
+		if ids[string(input.TxID)] {
+			return true
+		}
+	}
+	return false
+}
+
+// wireSize is the size of a transaction in relay messages
+func wireSize(transaction *tx.Transaction) int {
+	var w wireWriter
+	w.transaction(transaction)
+	return len(w.Bytes())
+}
+
+// pendingTxs returns a copy of the pending pool
+func (n *Network) pendingTxs() []*tx.Transaction {
+	n.pendingMutex.RLock()
+	defer n.pendingMutex.RUnlock()
+	return append([]*tx.Transaction(nil), n.blockchain.PendingTxs...)
+}
+
+// startRequest marks an item as in flight; it returns false if another
+// peer is already being asked for it
+func (n *Network) startRequest(hash []byte) bool {
+	n.requestMutex.Lock()
+	defer n.requestMutex.Unlock()
+
+	if _, ok := n.inFlight[string(hash)]; ok {
+		return false
+	}
+	n.inFlight[string(hash)] = struct{}{}
+	return true
+}
+
+// finishRequest clears an in-flight item
+func (n *Network) finishRequest(hash []byte) {
+	n.requestMutex.Lock()
+	delete(n.inFlight, string(hash))
+	n.requestMutex.Unlock()
+}
+
+// fetchData requests announced items with getdata and processes the replies
+func (n *Network) fetchData(c *peerConn, invs []InvVect) {
+	defer func() {
+		for _, inv := range invs {
+			n.finishRequest(inv.Hash)
+		}
+	}()
+
+	var w wireWriter
+	w.invs(invs)
+
+	response, err := c.request(MsgTypeGetData, w.Bytes(), syncTimeout)
+	if err != nil {
+		fmt.Printf("Failed to fetch data from %s: %v\n", c.info.ID, err)
+		return
+	}
+	if response.Type != MsgTypeData {
+		return
+	}
+
+	// Only accept the items we asked for
+	requested := make(map[string]bool, len(invs))
+	for _, inv := range invs {
+		requested[string(inv.Hash)] = true
+	}
+
+	r := newWireReader(response.Data)
+	var blocks []*types.Block
+	var txs []*tx.Transaction
+	for i, count := 0, r.count(); i < count; i++ {
+		switch InvType(r.uint8()) {
+		case InvTypeBlock:
+			block := r.block()
+			if requested[string(crypto.HashBlockHeader(&block.Header))] {
+				blocks = append(blocks, block)
+			}
+		case InvTypeTx:
+			transaction := r.transaction()
+			if requested[string(transaction.ID)] {
+				txs = append(txs, transaction)
+			}
+		default:
+			r.fail(fmt.Errorf("unknown inventory type"))
+		}
+	}
+	if err := r.done(); err != nil {
+		n.misbehaving(c, PenaltyMalformed, fmt.Sprintf("invalid data: %v", err))
+		return
+	}
+
+	for _, block := range blocks {
+		n.processReceivedBlock(block, c.info.ID)
+	}
+	for _, transaction := range txs {
+		n.processReceivedTransaction(transaction, c.info.ID)
+	}
+}
+
+// serveData answers a getdata request; unknown items are left out
+func (n *Network) serveData(data []byte) ([]byte, error) {
+	r := newWireReader(data)
+	invs := r.invs()
+	if err := r.done(); err != nil {
+		return nil, fmt.Errorf("invalid getdata: %v", err)
+	}
+	if len(invs) > maxGetDataItems {
+		invs = invs[:maxGetDataItems]
+	}
+
+	var items wireWriter
+	count := 0
+	for _, inv := range invs {
+		switch inv.Type {
+		case InvTypeBlock:
+			block, err := n.blockchain.GetBlockByHash(inv.Hash)
+			if err != nil {
+				continue
+			}
+			items.uint8(uint8(inv.Type))
+			items.block(block)
+		case InvTypeTx:
+			transaction := n.findPendingTx(inv.Hash)
+			if transaction == nil {
+				continue
+			}
+			items.uint8(uint8(inv.Type))
+			items.transaction(transaction)
+		default:
+			continue
+		}
+		count++
+	}
+
+	var w wireWriter
+	w.count(count)
+	w.buf.Write(items.Bytes())
+	return w.Bytes(), nil
+}
@@ -0,0 +1,183 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+func TestInventoryFilter(t *testing.T) {
+	filter := newInventoryFilter(2)
+
+	if !filter.Add([]byte("a")) {
+		t.Error("First add of a hash should report it as new")
+	}
+	if filter.Add([]byte("a")) {
+		t.Error("Second add of a hash should report it as known")
+	}
+
+	filter.Add([]byte("b"))
+	filter.Add([]byte("c"))
+
+	if filter.Has([]byte("a")) {
+		t.Error("Oldest hash was not evicted")
+	}
+	if !filter.Has([]byte("b")) || !filter.Has([]byte("c")) {
+		t.Error("Recent hashes missing from filter")
+	}
+}
+
+func TestPendingPool(t *testing.T) {
+	bc, err := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	defer bc.Close()
+	n := &Network{blockchain: bc}
+
+	spend := testOrphanTx("spend", "parent")
+	child := testOrphanTx("child", "spend")
+	other := testOrphanTx("other", "elsewhere")
+	for _, transaction := range []*tx.Transaction{spend, child, other} {
+		if !n.addPendingTx(transaction) {
+			t.Fatalf("Pool refused %s", transaction.ID)
+		}
+	}
+	if n.addPendingTx(spend) {
+		t.Error("Pool took a transaction twice")
+	}
+	if n.addPendingTx(testOrphanTx("double", "parent")) {
+		t.Error("Pool took a conflicting spend")
+	}
+
+	// A block spending the same output evicts the pending spend and its child
+	n.removeConfirmedTxs(&types.Block{Transactions: []*tx.Transaction{testOrphanTx("mined", "parent")}})
+	if pending := n.pendingTxs(); len(pending) != 1 || string(pending[0].ID) != "other" {
+		t.Fatalf("Pool holds %d transactions after a conflicting block", len(pending))
+	}
+
+	// A block confirming a pending transaction removes it
+	n.removeConfirmedTxs(&types.Block{Transactions: []*tx.Transaction{other}})
+	if len(n.pendingTxs()) != 0 || n.pendingBytes != 0 {
+		t.Errorf("Pool holds %d transactions of %d bytes after they were mined", len(n.pendingTxs()), n.pendingBytes)
+	}
+
+	// A full pool takes nothing more
+	n.pendingBytes = maxPendingTxBytes
+	if n.addPendingTx(other) {
+		t.Error("Pool took a transaction beyond its byte limit")
+	}
+}
+
+// newRelayLine connects three regtest nodes in a line: first <- middle <- last
+func newRelayLine(t *testing.T) (first, middle, last *Network) {
+	first, _ = newRegTestNode(t, 0)
+	middle, _ = newRegTestNode(t, 0)
+	last, _ = newRegTestNode(t, 0)
+
+	if err := middle.ConnectToPeer(first.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	if err := last.ConnectToPeer(middle.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	return first, middle, last
+}
+
+func TestBlockRelay(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping relay test in short mode")
+	}
+
+	first, middle, last := newRelayLine(t)
+
+	miner, _ := crypto.NewWallet()
+	block, err := first.blockchain.AddBlock(nil, miner.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+	first.BroadcastBlock(block)
+
+	// The block travels two hops as a compact block
+	waitForTip(t, last.blockchain, block.Hash)
+
+	// The middle node knows the first node has the block and never echoes it back
+	c := middle.getPeer(first.host.ID())
+	if c == nil || !c.known.Has(block.Hash) {
+		t.Error("Sender not recorded as knowing the block")
+	}
+}
+
+func TestTransactionRelay(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping relay test in short mode")
+	}
+
+	first, middle, last := newRelayLine(t)
+
+	// Spend a mined output every node knows, so no node holds the
+	// transaction back as an orphan
+	miner, _ := crypto.NewWallet()
+	block, err := first.blockchain.AddBlock(nil, miner.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+	first.BroadcastBlock(block)
+	waitForTip(t, last.blockchain, block.Hash)
+
+	recipient, _ := crypto.NewWallet()
+	transaction, err := first.blockchain.CreateTransaction(miner.GetAddress(), recipient.GetAddress(), 10, miner)
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+	first.BroadcastTransaction(transaction)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for last.findPendingTx(transaction.ID) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Transaction was not relayed to the last node")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// Every node holds the transaction exactly once
+	for _, n := range []*Network{first, middle, last} {
+		if pending := n.pendingTxs(); len(pending) != 1 {
+			t.Errorf("Expected 1 pending transaction, got %d", len(pending))
+		}
+	}
+}
+
+func TestSpoofedTransactionIDIsRejected(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping relay test in short mode")
+	}
+
+	honest, _ := newRegTestNode(t, 0)
+	rogue, _ := newRegTestNode(t, 0)
+	if err := rogue.ConnectToPeer(honest.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	waitForPeer(t, honest, rogue)
+
+	// The rogue node serves a different transaction under a requested ID
+	wanted := tx.NewTransaction([]tx.TxInput{{TxID: []byte("parent"), OutIndex: 0}}, []tx.TxOutput{{Value: 1}})
+	other := tx.NewTransaction([]tx.TxInput{{TxID: []byte("parent"), OutIndex: 0}}, []tx.TxOutput{{Value: 2}})
+	other.ID = wanted.ID
+	rogue.addPendingTx(other)
+
+	c := honest.getPeer(rogue.host.ID())
+	honest.fetchData(c, []InvVect{{Type: InvTypeTx, Hash: wanted.ID}})
+	if honest.findPendingTx(wanted.ID) != nil || honest.orphanTxs.has(wanted.ID) {
+		t.Error("Accepted a transaction under a spoofed ID")
+	}
+	if score := c.score.Load(); score != PenaltyMalformed {
+		t.Errorf("Ban score = %d, want %d", score, PenaltyMalformed)
+	}
+}
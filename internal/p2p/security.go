@@ -0,0 +1,183 @@
+package p2p
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/control"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2ptls "github.com/libp2p/go-libp2p/p2p/security/tls"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Security transports that encrypt and authenticate connections
+const (
+	SecurityNoise = "noise"
+	SecurityTLS   = "tls"
+)
+
+// DefaultSecurity is offered when no transports are selected; Noise is
+// preferred
+var DefaultSecurity = []string{SecurityNoise, SecurityTLS}
+
+// NetworkConfig configures the libp2p host of a node
+type NetworkConfig struct {
+	// ListenAddr is the multiaddr to listen on
+	ListenAddr string
+
+	// KeyPath is the file the node's private key is kept in, so its peer ID
+	// survives restarts. A key is generated and saved if the file does not
+	// exist. Empty uses a new identity on every start.
+	KeyPath string
+
+	// Security lists the security transports to offer in order of
+	// preference (default DefaultSecurity)
+	Security []string
+
+	// AllowedPeers, if not empty, are the only peers we connect to or
+	// accept connections from
+	AllowedPeers []peer.ID
+}
+
+// ParsePeerIDs decodes peer IDs such as those of an allowlist
+func ParsePeerIDs(ids []string) ([]peer.ID, error) {
+	peers := make([]peer.ID, 0, len(ids))
+	for _, s := range ids {
+		id, err := peer.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer ID %q: %v", s, err)
+		}
+		peers = append(peers, id)
+	}
+	return peers, nil
+}
+
+// LoadOrCreateIdentity reads a libp2p private key from path, generating an
+// Ed25519 key and saving it with owner-only permissions if the file does
+// not exist
+func LoadOrCreateIdentity(path string) (libp2pcrypto.PrivKey, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		key, err := libp2pcrypto.UnmarshalPrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node key %s: %v", path, err)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read node key: %v", err)
+	}
+
+	key, _, err := libp2pcrypto.GenerateEd25519Key(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate node key: %v", err)
+	}
+	data, err = libp2pcrypto.MarshalPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode node key: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create node key directory: %v", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write node key: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, fmt.Errorf("failed to write node key: %v", err)
+	}
+	return key, nil
+}
+
+// securityOptions returns the libp2p options offering the named transports
+func securityOptions(names []string) (libp2p.Option, error) {
+	if len(names) == 0 {
+		names = DefaultSecurity
+	}
+
+	opts := make([]libp2p.Option, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		switch name {
+		case SecurityNoise:
+			opts = append(opts, libp2p.Security(noise.ID, noise.New))
+		case SecurityTLS:
+			opts = append(opts, libp2p.Security(libp2ptls.ID, libp2ptls.New))
+		default:
+			return nil, fmt.Errorf("unknown security transport %q (want %s or %s)", name, SecurityNoise, SecurityTLS)
+		}
+	}
+	return libp2p.ChainOptions(opts...), nil
+}
+
+// peerGater refuses connections to and from banned peers and their IP
+// addresses and, with an allowlist, from every peer not on it
+type peerGater struct {
+	bans    *BanList
+	allowed map[peer.ID]struct{}
+}
+
+// newPeerGater creates a gater; an empty allowlist allows every peer
+func newPeerGater(bans *BanList, allowed []peer.ID) *peerGater {
+	g := &peerGater{bans: bans}
+	if len(allowed) > 0 {
+		g.allowed = make(map[peer.ID]struct{}, len(allowed))
+		for _, id := range allowed {
+			g.allowed[id] = struct{}{}
+		}
+	}
+	return g
+}
+
+// isAllowed reports whether the allowlist admits a peer
+func (g *peerGater) isAllowed(p peer.ID) bool {
+	if g.allowed == nil {
+		return true
+	}
+	_, ok := g.allowed[p]
+	return ok
+}
+
+// InterceptPeerDial refuses to dial banned peers and peers not on the allowlist
+func (g *peerGater) InterceptPeerDial(p peer.ID) bool {
+	return g.isAllowed(p) && !g.bans.IsBanned(p)
+}
+
+// InterceptAddrDial refuses to dial banned IP addresses
+func (g *peerGater) InterceptAddrDial(_ peer.ID, addr multiaddr.Multiaddr) bool {
+	return !g.bans.IsBannedAddr(addr)
+}
+
+// InterceptAccept drops inbound connections from banned IP addresses before
+// the peer identifies itself
+func (g *peerGater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	return !g.bans.IsBannedAddr(addrs.RemoteMultiaddr())
+}
+
+// InterceptSecured drops connections from banned peers and peers not on the
+// allowlist once their identity is authenticated
+func (g *peerGater) InterceptSecured(_ network.Direction, p peer.ID, _ network.ConnMultiaddrs) bool {
+	return g.isAllowed(p) && !g.bans.IsBanned(p)
+}
+
+// InterceptUpgraded allows all fully set up connections
+func (g *peerGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// IsAllowed reports whether a peer may connect; every peer may unless the
+// node was configured with an allowlist
+func (n *Network) IsAllowed(peerID peer.ID) bool {
+	return n.gater.isAllowed(peerID)
+}
@@ -0,0 +1,135 @@
+package p2p
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/storage"
+)
+
+// newConfiguredNode starts a regtest node listening on a random local port
+func newConfiguredNode(t *testing.T, cfg NetworkConfig) *Network {
+	bc, err := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	t.Cleanup(func() { bc.Close() })
+
+	cfg.ListenAddr = "/ip4/127.0.0.1/tcp/0"
+	network, err := NewNetworkWithConfig(context.Background(), bc, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create network: %v", err)
+	}
+	t.Cleanup(func() { network.Stop() })
+	return network
+}
+
+func TestLoadOrCreateIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "regtest", "nodekey")
+
+	key, err := LoadOrCreateIdentity(path)
+	if err != nil {
+		t.Fatalf("Failed to create identity: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Key not saved: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Key file has mode %v, want 0600", info.Mode().Perm())
+	}
+
+	loaded, err := LoadOrCreateIdentity(path)
+	if err != nil {
+		t.Fatalf("Failed to load identity: %v", err)
+	}
+	if !key.Equals(loaded) {
+		t.Error("Loaded key differs from the saved one")
+	}
+
+	os.WriteFile(path, []byte("not a key"), 0600)
+	if _, err := LoadOrCreateIdentity(path); err == nil {
+		t.Error("Accepted a corrupt key file")
+	}
+}
+
+func TestSecurityOptions(t *testing.T) {
+	if _, err := securityOptions([]string{SecurityTLS, SecurityNoise}); err != nil {
+		t.Errorf("Rejected known transports: %v", err)
+	}
+	if _, err := securityOptions([]string{"plaintext"}); err == nil {
+		t.Error("Accepted an unknown transport")
+	}
+}
+
+func TestIdentitySurvivesRestart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping network test in short mode")
+	}
+
+	path := filepath.Join(t.TempDir(), "nodekey")
+	first := newConfiguredNode(t, NetworkConfig{KeyPath: path})
+	id := first.host.ID()
+	first.Stop()
+
+	second := newConfiguredNode(t, NetworkConfig{KeyPath: path})
+	if second.host.ID() != id {
+		t.Errorf("Peer ID changed across restarts: %s, then %s", id, second.host.ID())
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping network test in short mode")
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "nodekey")
+	key, err := LoadOrCreateIdentity(keyPath)
+	if err != nil {
+		t.Fatalf("Failed to create identity: %v", err)
+	}
+	allowedID, _ := peer.IDFromPrivateKey(key)
+
+	server := newConfiguredNode(t, NetworkConfig{AllowedPeers: []peer.ID{allowedID}})
+	allowed := newConfiguredNode(t, NetworkConfig{KeyPath: keyPath})
+	if err := allowed.ConnectToPeer(server.Addrs()[0]); err != nil {
+		t.Fatalf("Allowed peer failed to connect: %v", err)
+	}
+
+	stranger := newConfiguredNode(t, NetworkConfig{})
+	if err := stranger.ConnectToPeer(server.Addrs()[0]); err == nil {
+		t.Error("Peer outside the allowlist connected")
+	}
+	if server.IsAllowed(stranger.host.ID()) || !server.IsAllowed(allowedID) {
+		t.Error("IsAllowed does not follow the allowlist")
+	}
+
+	// The allowlist also applies to dialing out
+	if err := server.ConnectToPeer(stranger.Addrs()[0]); err == nil {
+		t.Error("Dialed a peer outside the allowlist")
+	}
+}
+
+func TestSecurityTransportSelection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping network test in short mode")
+	}
+
+	server := newConfiguredNode(t, NetworkConfig{Security: []string{SecurityTLS}})
+
+	noiseOnly := newConfiguredNode(t, NetworkConfig{Security: []string{SecurityNoise}})
+	if err := noiseOnly.ConnectToPeer(server.Addrs()[0]); err == nil {
+		t.Error("Connected without a common security transport")
+	}
+
+	tlsOnly := newConfiguredNode(t, NetworkConfig{Security: []string{SecurityTLS}})
+	if err := tlsOnly.ConnectToPeer(server.Addrs()[0]); err != nil {
+		t.Errorf("Failed to connect over TLS: %v", err)
+	}
+}
@@ -0,0 +1,354 @@
+package p2p
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+const (
+	// maxHeadersPerMsg bounds a headers response; a full response means
+	// the peer has more headers to send
+	maxHeadersPerMsg = 2000
+
+	// blockBatchSize is the number of bodies requested in one get_blocks
+	blockBatchSize = 16
+
+	// maxBatchesInFlight bounds parallel body requests across peers
+	maxBatchesInFlight = 8
+
+	// syncTimeout bounds a single sync request
+	syncTimeout = 30 * time.Second
+)
+
+// syncWithPeer synchronizes headers-first: the peer's header chain is
+// downloaded and validated, then the bodies are fetched in batches from all
+// peers that serve blocks
+func (n *Network) syncWithPeer(peerID peer.ID) {
+	n.syncMutex.Lock()
+	if n.syncing {
+		n.syncMutex.Unlock()
+		return
+	}
+	n.syncing = true
+	n.syncMutex.Unlock()
+
+	defer func() {
+		n.syncMutex.Lock()
+		n.syncing = false
+		n.syncMutex.Unlock()
+	}()
+
+	headers, err := n.fetchHeaders(peerID)
+	if err != nil {
+		fmt.Printf("Failed to fetch headers: %v\n", err)
+		return
+	}
+	if len(headers) == 0 {
+		return
+	}
+
+	forkHeight, hashes, err := n.blockchain.CheckHeaders(headers)
+	if err == blockchain.ErrNotBestChain {
+		return
+	}
+	if err != nil {
+		fmt.Printf("Received invalid headers from %s: %v\n", peerID, err)
+		n.misbehavingPeer(peerID, PenaltyInvalidHeaders, fmt.Sprintf("invalid headers: %v", err))
+		return
+	}
+
+	fmt.Printf("📥 Syncing %d blocks (our height: %d, fork at %d)\n", len(hashes), n.blockchain.Height(), forkHeight)
+	if err := n.downloadBlocks(peerID, forkHeight, hashes); err != nil {
+		fmt.Printf("Failed to sync blocks: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✓ Synced %d blocks\n", len(hashes))
+	n.connectOrphans(n.blockchain.GetLatestBlock().Hash)
+
+	// Let peers that are behind know about our new tip
+	n.announce(InvVect{Type: InvTypeBlock, Hash: n.blockchain.GetLatestBlock().Hash})
+}
+
+// fetchHeaders downloads the peer's headers following our best chain,
+// validating each batch as it arrives. The peer may send as many headers as
+// its advertised height allows, plus one batch for blocks it found without
+// announcing them, so it cannot stream headers without end.
+func (n *Network) fetchHeaders(peerID peer.ID) ([]types.BlockHeader, error) {
+	c := n.getPeer(peerID)
+	if c == nil {
+		return nil, fmt.Errorf("peer %s is not connected", peerID)
+	}
+	limit := int(c.bestHeight.Load()) + maxHeadersPerMsg
+
+	locator := n.blockchain.BlockLocator()
+	headers := make([]types.BlockHeader, 0)
+	var prevHash []byte
+
+	for {
+		batch, err := n.requestHeaders(peerID, locator)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return headers, nil
+		}
+		if len(headers)+len(batch) > limit {
+			err := fmt.Errorf("peer sent more than %d headers, beyond its advertised height", limit)
+			n.misbehavingPeer(peerID, PenaltyInvalidHeaders, err.Error())
+			return nil, err
+		}
+
+		// The first batch must build on one of our blocks, each later one
+		// on the batch before it
+		if prevHash == nil {
+			prevHash = batch[0].PrevBlockHash
+			if _, err := n.blockchain.GetBlockByHash(prevHash); err != nil {
+				err := fmt.Errorf("headers do not connect to our chain")
+				n.misbehavingPeer(peerID, PenaltyInvalidHeaders, err.Error())
+				return nil, err
+			}
+		}
+		hashes, err := n.blockchain.ValidateHeaders(prevHash, batch)
+		if err != nil {
+			n.misbehavingPeer(peerID, PenaltyInvalidHeaders, fmt.Sprintf("invalid headers: %v", err))
+			return nil, err
+		}
+		headers = append(headers, batch...)
+
+		if len(batch) < maxHeadersPerMsg {
+			return headers, nil
+		}
+
+		// Continue after the last header received
+		prevHash = hashes[len(hashes)-1]
+		locator = [][]byte{prevHash}
+	}
+}
+
+// requestHeaders sends one get_headers request
+func (n *Network) requestHeaders(peerID peer.ID, locator [][]byte) ([]types.BlockHeader, error) {
+	var w wireWriter
+	w.hashes(locator)
+
+	response, err := n.request(peerID, MsgTypeGetHeaders, w.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if response.Type != MsgTypeHeaders {
+		return nil, fmt.Errorf("expected headers, got %s", response.Type)
+	}
+
+	r := newWireReader(response.Data)
+	headers := make([]types.BlockHeader, r.count())
+	if len(headers) > maxHeadersPerMsg {
+		err := fmt.Errorf("peer sent %d headers, limit is %d", len(headers), maxHeadersPerMsg)
+		n.misbehavingPeer(peerID, PenaltyMalformed, err.Error())
+		return nil, err
+	}
+	for i := range headers {
+		headers[i] = r.header()
+	}
+	if err := r.done(); err != nil {
+		n.misbehavingPeer(peerID, PenaltyMalformed, fmt.Sprintf("invalid headers: %v", err))
+		return nil, fmt.Errorf("failed to decode headers: %v", err)
+	}
+	return headers, nil
+}
+
+// downloadBlocks fetches the bodies of validated headers window by window
+// and connects them on top of forkHeight. A competing branch is kept in
+// memory until it outweighs our chain.
+func (n *Network) downloadBlocks(syncPeer peer.ID, forkHeight int, hashes [][]byte) error {
+	peers := n.downloadPeers(syncPeer)
+	window := blockBatchSize * maxBatchesInFlight
+
+	var pending []*types.Block
+	for start := 0; start < len(hashes); start += window {
+		end := start + window
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+
+		blocks, err := n.fetchBlocks(peers, hashes[start:end])
+		if err != nil {
+			return err
+		}
+		pending = append(pending, blocks...)
+
+		err = n.blockchain.ConnectBlocks(forkHeight, pending)
+		if err == blockchain.ErrNotBestChain {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, block := range pending {
+			n.orphans.discard(block.Hash)
+			n.removeConfirmedTxs(block)
+			if n.blockHandler != nil {
+				n.blockHandler(block)
+			}
+		}
+		forkHeight += len(pending)
+		pending = nil
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("downloaded branch does not have more work than ours")
+	}
+	return nil
+}
+
+// downloadPeers lists the peers to fetch bodies from, sync peer first
+func (n *Network) downloadPeers(syncPeer peer.ID) []peer.ID {
+	peers := []peer.ID{syncPeer}
+	for _, info := range n.GetPeerInfo() {
+		if info.ID != syncPeer && info.Services.HasService(ServiceNodeNetwork) {
+			peers = append(peers, info.ID)
+		}
+	}
+	return peers
+}
+
+// fetchBlocks downloads bodies in parallel batches spread over peers;
+// a failed batch is retried with the next peer
+func (n *Network) fetchBlocks(peers []peer.ID, hashes [][]byte) ([]*types.Block, error) {
+	var batches [][][]byte
+	for start := 0; start < len(hashes); start += blockBatchSize {
+		end := start + blockBatchSize
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		batches = append(batches, hashes[start:end])
+	}
+
+	results := make([][]*types.Block, len(batches))
+	errs := make([]error, len(batches))
+
+	var wg sync.WaitGroup
+	for i := range batches {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for attempt := 0; attempt < len(peers); attempt++ {
+				blocks, err := n.requestBlocks(peers[(i+attempt)%len(peers)], batches[i])
+				if err == nil {
+					results[i] = blocks
+					return
+				}
+				errs[i] = err
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	blocks := make([]*types.Block, 0, len(hashes))
+	for i, batch := range results {
+		if batch == nil {
+			return nil, fmt.Errorf("failed to download blocks: %v", errs[i])
+		}
+		blocks = append(blocks, batch...)
+	}
+	return blocks, nil
+}
+
+// requestBlocks fetches the bodies of the given hashes from one peer and
+// checks that each body matches its header
+func (n *Network) requestBlocks(peerID peer.ID, hashes [][]byte) ([]*types.Block, error) {
+	var w wireWriter
+	w.hashes(hashes)
+
+	response, err := n.request(peerID, MsgTypeGetBlocks, w.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if response.Type != MsgTypeBlocks {
+		return nil, fmt.Errorf("expected blocks, got %s", response.Type)
+	}
+
+	r := newWireReader(response.Data)
+	blocks := make([]*types.Block, r.count())
+	for i := range blocks {
+		blocks[i] = r.block()
+	}
+	if err := r.done(); err != nil {
+		n.misbehavingPeer(peerID, PenaltyMalformed, fmt.Sprintf("invalid blocks: %v", err))
+		return nil, fmt.Errorf("failed to decode blocks: %v", err)
+	}
+	if len(blocks) != len(hashes) {
+		return nil, fmt.Errorf("peer %s returned %d of %d blocks", peerID, len(blocks), len(hashes))
+	}
+
+	for i, block := range blocks {
+		if !bytes.Equal(crypto.HashBlockHeader(&block.Header), hashes[i]) {
+			n.misbehavingPeer(peerID, PenaltyMalformed, "returned an unexpected block")
+			return nil, fmt.Errorf("peer %s returned an unexpected block", peerID)
+		}
+	}
+	return blocks, nil
+}
+
+// request sends a request over the peer's stream and waits for the reply
+func (n *Network) request(peerID peer.ID, msgType MessageType, data []byte) (*Message, error) {
+	c := n.getPeer(peerID)
+	if c == nil {
+		return nil, fmt.Errorf("not connected to %s", peerID)
+	}
+	return c.request(msgType, data, syncTimeout)
+}
+
+// serveHeaders answers a get_headers request
+func (n *Network) serveHeaders(data []byte) ([]byte, error) {
+	r := newWireReader(data)
+	locator := r.hashes()
+	if err := r.done(); err != nil {
+		return nil, fmt.Errorf("invalid locator: %v", err)
+	}
+
+	headers := n.blockchain.LocateHeaders(locator, maxHeadersPerMsg)
+
+	var w wireWriter
+	w.count(len(headers))
+	for i := range headers {
+		w.header(&headers[i])
+	}
+	return w.Bytes(), nil
+}
+
+// serveBlocks answers a get_blocks request, stopping at the first unknown hash
+func (n *Network) serveBlocks(data []byte) ([]byte, error) {
+	r := newWireReader(data)
+	hashes := r.hashes()
+	if err := r.done(); err != nil {
+		return nil, fmt.Errorf("invalid block request: %v", err)
+	}
+	if len(hashes) > blockBatchSize {
+		hashes = hashes[:blockBatchSize]
+	}
+
+	blocks := make([]*types.Block, 0, len(hashes))
+	for _, hash := range hashes {
+		block, err := n.blockchain.GetBlockByHash(hash)
+		if err != nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+
+	var w wireWriter
+	w.count(len(blocks))
+	for _, block := range blocks {
+		w.block(block)
+	}
+	return w.Bytes(), nil
+}
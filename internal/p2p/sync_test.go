@@ -0,0 +1,186 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+)
+
+// newRegTestNode starts a regtest node with n blocks mined on top of genesis
+func newRegTestNode(t *testing.T, n int) (*Network, *blockchain.Blockchain) {
+	bc, err := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	if err != nil {
+		t.Fatalf("Failed to create blockchain: %v", err)
+	}
+	t.Cleanup(func() { bc.Close() })
+
+	miner, _ := crypto.NewWallet()
+	for i := 0; i < n; i++ {
+		if _, err := bc.AddBlock(nil, miner.GetAddress()); err != nil {
+			t.Fatalf("Failed to mine block: %v", err)
+		}
+	}
+
+	network, err := NewNetwork(context.Background(), bc, "/ip4/127.0.0.1/tcp/0")
+	if err != nil {
+		t.Fatalf("Failed to create network: %v", err)
+	}
+	t.Cleanup(func() { network.Stop() })
+
+	return network, bc
+}
+
+// waitForTip waits until bc's tip equals want
+func waitForTip(t *testing.T, bc *blockchain.Blockchain, want []byte) {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if bytes.Equal(bc.GetLatestBlock().Hash, want) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("Chain did not reach tip %x (height %d)", want, bc.Height())
+}
+
+func TestHeadersFirstSync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping sync test in short mode")
+	}
+
+	// More blocks than one download window so several batches are used
+	source, sourceChain := newRegTestNode(t, blockBatchSize*maxBatchesInFlight+20)
+	target, targetChain := newRegTestNode(t, 0)
+
+	if err := target.ConnectToPeer(source.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	waitForTip(t, targetChain, sourceChain.GetLatestBlock().Hash)
+
+	if err := targetChain.ValidateChain(); err != nil {
+		t.Errorf("Synced chain is invalid: %v", err)
+	}
+}
+
+func TestSyncFromMultiplePeers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping sync test in short mode")
+	}
+
+	first, firstChain := newRegTestNode(t, 50)
+	second, secondChain := newRegTestNode(t, 0)
+
+	if err := second.ConnectToPeer(first.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	waitForTip(t, secondChain, firstChain.GetLatestBlock().Hash)
+
+	// The new node downloads bodies from both peers
+	target, targetChain := newRegTestNode(t, 0)
+	if err := target.ConnectToPeer(first.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	if err := target.ConnectToPeer(second.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	waitForTip(t, targetChain, firstChain.GetLatestBlock().Hash)
+}
+
+func TestSyncSwitchesToHeavierFork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping sync test in short mode")
+	}
+
+	heavy, heavyChain := newRegTestNode(t, 6)
+	light, lightChain := newRegTestNode(t, 3)
+
+	// The light node is on another fork at every height; height-based
+	// sync would ask for blocks that do not connect to its chain
+	if err := light.ConnectToPeer(heavy.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	waitForTip(t, lightChain, heavyChain.GetLatestBlock().Hash)
+
+	if err := lightChain.ValidateChain(); err != nil {
+		t.Errorf("Reorganized chain is invalid: %v", err)
+	}
+	if lightChain.Height() != heavyChain.Height() {
+		t.Errorf("Expected height %d, got %d", heavyChain.Height(), lightChain.Height())
+	}
+}
+
+func TestRelayedBlockOnUnknownParentTriggersSync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping sync test in short mode")
+	}
+
+	source, sourceChain := newRegTestNode(t, 0)
+	target, targetChain := newRegTestNode(t, 0)
+
+	if err := target.ConnectToPeer(source.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	// Mine several blocks without relaying, then relay only the tip
+	miner, _ := crypto.NewWallet()
+	for i := 0; i < 5; i++ {
+		if _, err := sourceChain.AddBlock(nil, miner.GetAddress()); err != nil {
+			t.Fatalf("Failed to mine block: %v", err)
+		}
+	}
+	source.BroadcastBlock(sourceChain.GetLatestBlock())
+
+	waitForTip(t, targetChain, sourceChain.GetLatestBlock().Hash)
+}
+
+func TestHeadersBeyondAdvertisedHeightAreRejected(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping sync test in short mode")
+	}
+
+	// The source dials, so the target never starts a sync of its own
+	source, sourceChain := newRegTestNode(t, 0)
+	target, targetChain := newRegTestNode(t, 0)
+	if err := source.ConnectToPeer(target.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	waitForPeer(t, target, source)
+
+	miner, _ := crypto.NewWallet()
+	for i := 0; i < 5; i++ {
+		if _, err := sourceChain.AddBlock(nil, miner.GetAddress()); err != nil {
+			t.Fatalf("Failed to mine block: %v", err)
+		}
+	}
+
+	// Shrink the allowance so the unannounced blocks exceed it
+	c := target.getPeer(source.host.ID())
+	c.bestHeight.Store(int64(3 - maxHeadersPerMsg))
+	if _, err := target.fetchHeaders(source.host.ID()); err == nil {
+		t.Fatal("Accepted more headers than the peer advertised")
+	}
+	if score := c.score.Load(); score != PenaltyInvalidHeaders {
+		t.Errorf("Ban score = %d, want %d", score, PenaltyInvalidHeaders)
+	}
+	if targetChain.Height() != 1 {
+		t.Errorf("Expected height 1, got %d", targetChain.Height())
+	}
+
+	// Within the allowance the same headers are taken
+	c.bestHeight.Store(1)
+	headers, err := target.fetchHeaders(source.host.ID())
+	if err != nil {
+		t.Fatalf("Failed to fetch headers: %v", err)
+	}
+	if len(headers) != 5 {
+		t.Errorf("Expected 5 headers, got %d", len(headers))
+	}
+}
@@ -0,0 +1,505 @@
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+// Frame layout (little-endian):
+//
+//	magic    uint32  network magic
+//	type     uint8   message type
+//	flags    uint8   flagResponse marks a reply
+//	id       uint32  request id echoed by the reply, 0 for unsolicited messages
+//	length   uint32  payload length
+//	checksum [4]byte first 4 bytes of double SHA-256 of the payload
+//	payload  [length]byte
+const (
+	frameHeaderSize = 18
+
+	// MaxMessageSize bounds the payload of a single message
+	MaxMessageSize = 8 * 1024 * 1024
+
+	flagResponse uint8 = 1 << 0
+)
+
+var (
+	// ErrMessageTooLarge is returned for frames above MaxMessageSize
+	ErrMessageTooLarge = errors.New("message exceeds maximum size")
+
+	// ErrBadChecksum is returned when a payload does not match its checksum
+	ErrBadChecksum = errors.New("payload checksum mismatch")
+
+	// ErrBadMagic is returned for frames of another network
+	ErrBadMagic = errors.New("unexpected network magic")
+)
+
+// MessageType represents the type of P2P message
+type MessageType uint8
+
+const (
+	MsgTypeVersion MessageType = iota + 1
+	MsgTypeVerack
+	MsgTypeReject
+	MsgTypePing
+	MsgTypePong
+	MsgTypeInv
+	MsgTypeGetData
+	MsgTypeData
+	MsgTypeGetHeaders
+	MsgTypeHeaders
+	MsgTypeGetBlocks
+	MsgTypeBlocks
+	MsgTypeGetAddr
+	MsgTypeAddr
+	MsgTypeCmpctBlock
+	MsgTypeGetBlockTxn
+	MsgTypeBlockTxn
+)
+
+var messageTypeNames = map[MessageType]string{
+	MsgTypeVersion:     "version",
+	MsgTypeVerack:      "verack",
+	MsgTypeReject:      "reject",
+	MsgTypePing:        "ping",
+	MsgTypePong:        "pong",
+	MsgTypeInv:         "inv",
+	MsgTypeGetData:     "getdata",
+	MsgTypeData:        "data",
+	MsgTypeGetHeaders:  "get_headers",
+	MsgTypeHeaders:     "headers",
+	MsgTypeGetBlocks:   "get_blocks",
+	MsgTypeBlocks:      "blocks",
+	MsgTypeGetAddr:     "getaddr",
+	MsgTypeAddr:        "addr",
+	MsgTypeCmpctBlock:  "cmpctblock",
+	MsgTypeGetBlockTxn: "getblocktxn",
+	MsgTypeBlockTxn:    "blocktxn",
+}
+
+// String returns the message type name
+func (t MessageType) String() string {
+	if name, ok := messageTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("msg(%d)", uint8(t))
+}
+
+// Message is a single framed P2P message
+type Message struct {
+	Type     MessageType
+	ID       uint32 // Request id; replies carry the id of their request
+	Response bool
+	Data     []byte
+}
+
+// checksum returns the first 4 bytes of the payload's double SHA-256
+func checksum(payload []byte) []byte {
+	return crypto.DoubleHashBytes(payload)[:4]
+}
+
+// writeMessage frames a message and writes it in a single call
+func writeMessage(w io.Writer, magic uint32, msg *Message) error {
+	if len(msg.Data) > MaxMessageSize {
+		return ErrMessageTooLarge
+	}
+
+	frame := make([]byte, frameHeaderSize+len(msg.Data))
+	binary.LittleEndian.PutUint32(frame[0:4], magic)
+	frame[4] = uint8(msg.Type)
+	if msg.Response {
+		frame[5] = flagResponse
+	}
+	binary.LittleEndian.PutUint32(frame[6:10], msg.ID)
+	binary.LittleEndian.PutUint32(frame[10:14], uint32(len(msg.Data)))
+	copy(frame[14:18], checksum(msg.Data))
+	copy(frame[frameHeaderSize:], msg.Data)
+
+	_, err := w.Write(frame)
+	return err
+}
+
+// readMessage reads and verifies one frame
+func readMessage(r *bufio.Reader, magic uint32) (*Message, error) {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint32(header[0:4]) != magic {
+		return nil, ErrBadMagic
+	}
+	length := binary.LittleEndian.Uint32(header[10:14])
+	if length > MaxMessageSize {
+		return nil, ErrMessageTooLarge
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(checksum(payload), header[14:18]) {
+		return nil, ErrBadChecksum
+	}
+
+	return &Message{
+		Type:     MessageType(header[4]),
+		Response: header[5]&flagResponse != 0,
+		ID:       binary.LittleEndian.Uint32(header[6:10]),
+		Data:     payload,
+	}, nil
+}
+
+// wireWriter builds a binary payload
+type wireWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *wireWriter) uint8(v uint8) {
+	w.buf.WriteByte(v)
+}
+
+func (w *wireWriter) uint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	w.buf.Write(b[:])
+}
+
+func (w *wireWriter) uint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	w.buf.Write(b[:])
+}
+
+func (w *wireWriter) varint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func (w *wireWriter) count(n int) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutUvarint(b[:], uint64(n))])
+}
+
+func (w *wireWriter) bytes(v []byte) {
+	w.count(len(v))
+	w.buf.Write(v)
+}
+
+func (w *wireWriter) string(v string) {
+	w.bytes([]byte(v))
+}
+
+// Bytes returns the payload written so far
+func (w *wireWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// wireReader decodes a binary payload. The first error sticks and all
+// further reads return zero values.
+type wireReader struct {
+	data []byte
+	err  error
+}
+
+func newWireReader(data []byte) *wireReader {
+	return &wireReader{data: data}
+}
+
+func (r *wireReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+	r.data = nil
+}
+
+func (r *wireReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.data) {
+		r.fail(io.ErrUnexpectedEOF)
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *wireReader) uint8() uint8 {
+	if b := r.take(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *wireReader) uint32() uint32 {
+	if b := r.take(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *wireReader) uint64() uint64 {
+	if b := r.take(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *wireReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.fail(fmt.Errorf("invalid varint"))
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// count reads a length or element count. Every element takes at least one
+// byte, so counts above the remaining payload are rejected before allocating.
+func (r *wireReader) count() int {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail(fmt.Errorf("invalid count"))
+		return 0
+	}
+	r.data = r.data[n:]
+	if v > uint64(len(r.data)) {
+		r.fail(fmt.Errorf("count %d exceeds payload", v))
+		return 0
+	}
+	return int(v)
+}
+
+func (r *wireReader) bytes() []byte {
+	n := r.count()
+	b := r.take(n)
+	if b == nil || n == 0 {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+func (r *wireReader) string() string {
+	return string(r.bytes())
+}
+
+// done returns the first error, or an error if bytes are left over
+func (r *wireReader) done() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) > 0 {
+		return fmt.Errorf("%d trailing bytes", len(r.data))
+	}
+	return nil
+}
+
+func (w *wireWriter) header(h *types.BlockHeader) {
+	w.uint32(h.Version)
+	w.bytes(h.PrevBlockHash)
+	w.bytes(h.MerkleRoot)
+	w.varint(h.Timestamp.UnixNano())
+	w.uint32(h.DifficultyTarget)
+	w.uint32(h.Nonce)
+}
+
+func (r *wireReader) header() types.BlockHeader {
+	return types.BlockHeader{
+		Version:          r.uint32(),
+		PrevBlockHash:    r.bytes(),
+		MerkleRoot:       r.bytes(),
+		Timestamp:        time.Unix(0, r.varint()),
+		DifficultyTarget: r.uint32(),
+		Nonce:            r.uint32(),
+	}
+}
+
+func (w *wireWriter) transaction(t *tx.Transaction) {
+	w.bytes(t.ID)
+	w.count(len(t.Inputs))
+	for _, in := range t.Inputs {
+		w.bytes(in.TxID)
+		w.varint(int64(in.OutIndex))
+		w.uint32(in.Sequence)
+		w.bytes(in.Signature)
+		w.bytes(in.PubKey)
+	}
+	w.count(len(t.Outputs))
+	for _, out := range t.Outputs {
+		w.varint(out.Value)
+		w.bytes(out.PubKeyHash)
+	}
+}
+
+func (r *wireReader) transaction() *tx.Transaction {
+	t := &tx.Transaction{ID: r.bytes()}
+
+	t.Inputs = make([]tx.TxInput, r.count())
+	for i := range t.Inputs {
+		t.Inputs[i] = tx.TxInput{
+			TxID:      r.bytes(),
+			OutIndex:  int(r.varint()),
+			Sequence:  r.uint32(),
+			Signature: r.bytes(),
+			PubKey:    r.bytes(),
+		}
+	}
+
+	t.Outputs = make([]tx.TxOutput, r.count())
+	for i := range t.Outputs {
+		t.Outputs[i] = tx.TxOutput{
+			Value:      r.varint(),
+			PubKeyHash: r.bytes(),
+		}
+	}
+
+	// A peer could send any ID; it must be the one the contents give
+	if r.err == nil && !bytes.Equal(t.ComputeID(), t.ID) {
+		r.fail(fmt.Errorf("transaction %x does not match its ID", t.ID))
+	}
+	return t
+}
+
+// block writes a block. types.Block keeps its transactions in an
+// interface{}, so they are encoded explicitly.
+func (w *wireWriter) block(b *types.Block) {
+	w.header(&b.Header)
+	txs, _ := b.Transactions.([]*tx.Transaction)
+	w.count(len(txs))
+	for _, t := range txs {
+		w.transaction(t)
+	}
+	w.bytes(b.Hash)
+}
+
+func (r *wireReader) block() *types.Block {
+	b := &types.Block{Header: r.header()}
+	txs := make([]*tx.Transaction, r.count())
+	for i := range txs {
+		txs[i] = r.transaction()
+	}
+	b.Transactions = txs
+	b.Hash = r.bytes()
+	return b
+}
+
+func (w *wireWriter) compactBlock(cb *CompactBlock) {
+	w.header(&cb.Header)
+	w.bytes(cb.Hash)
+	w.uint64(cb.Nonce)
+	w.count(len(cb.ShortIDs))
+	for _, id := range cb.ShortIDs {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], id)
+		w.buf.Write(b[:shortIDSize])
+	}
+	w.count(len(cb.Prefilled))
+	for _, p := range cb.Prefilled {
+		w.varint(int64(p.Index))
+		w.transaction(p.Tx)
+	}
+}
+
+func (r *wireReader) compactBlock() *CompactBlock {
+	cb := &CompactBlock{Header: r.header(), Hash: r.bytes(), Nonce: r.uint64()}
+	cb.ShortIDs = make([]uint64, r.count())
+	for i := range cb.ShortIDs {
+		var b [8]byte
+		copy(b[:], r.take(shortIDSize))
+		cb.ShortIDs[i] = binary.LittleEndian.Uint64(b[:])
+	}
+	cb.Prefilled = make([]PrefilledTx, r.count())
+	for i := range cb.Prefilled {
+		cb.Prefilled[i] = PrefilledTx{Index: int(r.varint()), Tx: r.transaction()}
+	}
+	return cb
+}
+
+func (w *wireWriter) hashes(hashes [][]byte) {
+	w.count(len(hashes))
+	for _, hash := range hashes {
+		w.bytes(hash)
+	}
+}
+
+func (r *wireReader) hashes() [][]byte {
+	hashes := make([][]byte, r.count())
+	for i := range hashes {
+		hashes[i] = r.bytes()
+	}
+	return hashes
+}
+
+func (w *wireWriter) invs(invs []InvVect) {
+	w.count(len(invs))
+	for _, inv := range invs {
+		w.uint8(uint8(inv.Type))
+		w.bytes(inv.Hash)
+	}
+}
+
+func (r *wireReader) invs() []InvVect {
+	invs := make([]InvVect, r.count())
+	for i := range invs {
+		invs[i] = InvVect{Type: InvType(r.uint8()), Hash: r.bytes()}
+	}
+	return invs
+}
+
+func (w *wireWriter) addrs(addrs []KnownAddress) {
+	w.count(len(addrs))
+	for _, ka := range addrs {
+		w.string(ka.Addr)
+		w.varint(ka.LastSeen.Unix())
+	}
+}
+
+func (r *wireReader) addrs() []KnownAddress {
+	addrs := make([]KnownAddress, r.count())
+	for i := range addrs {
+		addrs[i] = KnownAddress{Addr: r.string(), LastSeen: time.Unix(r.varint(), 0)}
+	}
+	return addrs
+}
+
+func (w *wireWriter) version(v *VersionMessage) {
+	w.uint32(v.ProtocolVersion)
+	w.string(v.Network)
+	w.uint32(v.Net)
+	w.bytes(v.GenesisHash)
+	w.varint(int64(v.BestHeight))
+	w.uint64(uint64(v.Services))
+	w.string(v.UserAgent)
+	w.varint(v.Timestamp.Unix())
+}
+
+func (r *wireReader) version() *VersionMessage {
+	return &VersionMessage{
+		ProtocolVersion: r.uint32(),
+		Network:         r.string(),
+		Net:             r.uint32(),
+		GenesisHash:     r.bytes(),
+		BestHeight:      int(r.varint()),
+		Services:        ServiceFlag(r.uint64()),
+		UserAgent:       r.string(),
+		Timestamp:       time.Unix(r.varint(), 0),
+	}
+}
@@ -0,0 +1,186 @@
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/yourusername/bt/internal/blockchain"
+	"github.com/yourusername/bt/internal/chaincfg"
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/storage"
+	"github.com/yourusername/bt/internal/tx"
+	"github.com/yourusername/bt/pkg/types"
+)
+
+func TestMessageFraming(t *testing.T) {
+	magic := chaincfg.RegTestParams.Net
+	sent := &Message{Type: MsgTypeGetHeaders, ID: 7, Response: true, Data: []byte("payload")}
+
+	var buf bytes.Buffer
+	if err := writeMessage(&buf, magic, sent); err != nil {
+		t.Fatalf("writeMessage failed: %v", err)
+	}
+	if buf.Len() != frameHeaderSize+len(sent.Data) {
+		t.Errorf("Unexpected frame size %d", buf.Len())
+	}
+	frame := buf.Bytes()
+
+	got, err := readMessage(bufio.NewReader(bytes.NewReader(frame)), magic)
+	if err != nil {
+		t.Fatalf("readMessage failed: %v", err)
+	}
+	if got.Type != sent.Type || got.ID != sent.ID || !got.Response || !bytes.Equal(got.Data, sent.Data) {
+		t.Errorf("Round trip mismatch: %+v", got)
+	}
+
+	if _, err := readMessage(bufio.NewReader(bytes.NewReader(frame)), chaincfg.MainNetParams.Net); err != ErrBadMagic {
+		t.Errorf("Expected ErrBadMagic, got %v", err)
+	}
+
+	corrupt := append([]byte(nil), frame...)
+	corrupt[len(corrupt)-1] ^= 0xff
+	if _, err := readMessage(bufio.NewReader(bytes.NewReader(corrupt)), magic); err != ErrBadChecksum {
+		t.Errorf("Expected ErrBadChecksum, got %v", err)
+	}
+
+	// The length is checked before the payload is read or allocated
+	oversized := append([]byte(nil), frame[:frameHeaderSize]...)
+	oversized[10], oversized[11], oversized[12], oversized[13] = 0xff, 0xff, 0xff, 0xff
+	if _, err := readMessage(bufio.NewReader(bytes.NewReader(oversized)), magic); err != ErrMessageTooLarge {
+		t.Errorf("Expected ErrMessageTooLarge, got %v", err)
+	}
+	if err := writeMessage(&buf, magic, &Message{Type: MsgTypeBlocks, Data: make([]byte, MaxMessageSize+1)}); err != ErrMessageTooLarge {
+		t.Errorf("Expected ErrMessageTooLarge on write, got %v", err)
+	}
+}
+
+func TestBlockEncodingRoundTrip(t *testing.T) {
+	source, _ := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	defer source.Close()
+	miner, _ := crypto.NewWallet()
+	original, err := source.AddBlock(nil, miner.GetAddress())
+	if err != nil {
+		t.Fatalf("Failed to mine block: %v", err)
+	}
+
+	var w wireWriter
+	w.block(original)
+	r := newWireReader(w.Bytes())
+	block := r.block()
+	if err := r.done(); err != nil {
+		t.Fatalf("Failed to decode block: %v", err)
+	}
+
+	if !bytes.Equal(crypto.HashBlockHeader(&block.Header), original.Hash) {
+		t.Error("Decoded header hashes differently")
+	}
+
+	// A decoded block must connect like the original
+	target, _ := blockchain.New(&chaincfg.RegTestParams, storage.NewMemoryBackend())
+	defer target.Close()
+	if err := target.ConnectBlocks(0, []*types.Block{block}); err != nil {
+		t.Fatalf("Decoded block rejected: %v", err)
+	}
+
+	// Truncated payloads are rejected rather than partially decoded
+	r = newWireReader(w.Bytes()[:len(w.Bytes())-1])
+	r.block()
+	if r.done() == nil {
+		t.Error("Accepted a truncated block")
+	}
+
+	// So are transactions sent under an ID their contents do not give
+	coinbase := original.Transactions.([]*tx.Transaction)[0]
+	spoofed := *coinbase
+	spoofed.ID = crypto.DoubleHashBytes([]byte("other"))
+	w = wireWriter{}
+	w.transaction(&spoofed)
+	r = newWireReader(w.Bytes())
+	r.transaction()
+	if r.done() == nil {
+		t.Error("Accepted a transaction with a spoofed ID")
+	}
+
+	// Replaceability is part of the transaction and survives the trip
+	replaceable := tx.NewTransaction(
+		[]tx.TxInput{{TxID: coinbase.ID, OutIndex: 0, Sequence: tx.SequenceReplaceable}},
+		[]tx.TxOutput{{Value: 1, PubKeyHash: []byte("recipient")}},
+	)
+	w = wireWriter{}
+	w.transaction(replaceable)
+	r = newWireReader(w.Bytes())
+	decoded := r.transaction()
+	if err := r.done(); err != nil {
+		t.Fatalf("Failed to decode transaction: %v", err)
+	}
+	if !decoded.SignalsReplacement() {
+		t.Error("Decoded transaction lost its replace-by-fee signal")
+	}
+}
+
+func TestVersionEncodingRoundTrip(t *testing.T) {
+	sent := &VersionMessage{
+		ProtocolVersion: ProtocolVersion,
+		Network:         chaincfg.RegTestParams.Name,
+		Net:             chaincfg.RegTestParams.Net,
+		GenesisHash:     []byte{1, 2, 3},
+		BestHeight:      42,
+		Services:        ServiceNodeNetwork,
+		UserAgent:       UserAgent,
+		Timestamp:       time.Unix(1700000000, 0),
+	}
+
+	var w wireWriter
+	w.version(sent)
+	r := newWireReader(w.Bytes())
+	got := r.version()
+	if err := r.done(); err != nil {
+		t.Fatalf("Failed to decode version: %v", err)
+	}
+	if got.Net != sent.Net || got.BestHeight != sent.BestHeight || got.UserAgent != sent.UserAgent ||
+		!bytes.Equal(got.GenesisHash, sent.GenesisHash) || !got.Timestamp.Equal(sent.Timestamp) {
+		t.Errorf("Round trip mismatch: %+v", got)
+	}
+}
+
+func TestWireReaderRejectsHugeCounts(t *testing.T) {
+	var w wireWriter
+	w.count(1 << 40)
+
+	r := newWireReader(w.Bytes())
+	if hashes := r.hashes(); len(hashes) != 0 || r.done() == nil {
+		t.Error("Accepted a count larger than the payload")
+	}
+}
+
+func TestRequestsAreMultiplexed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping multiplexing test in short mode")
+	}
+
+	server, _ := newRegTestNode(t, 3)
+	client, _ := newRegTestNode(t, 0)
+	if err := client.ConnectToPeer(server.Addrs()[0]); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	// Concurrent requests share the single stream and each gets its own reply
+	errs := make(chan error, 20)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			_, err := client.Ping(server.host.ID())
+			errs <- err
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("Ping failed: %v", err)
+		}
+	}
+
+	if streams := len(client.getPeer(server.host.ID()).stream.Conn().GetStreams()); streams != 1 {
+		t.Errorf("Expected 1 stream to the peer, got %d", streams)
+	}
+}
@@ -13,12 +13,6 @@ import (
 const (
 	// MaxNonce is the maximum value for nonce
 	MaxNonce = math.MaxUint32
-
-	// TargetBits is the default difficulty (will be adjusted dynamically)
-	// Lower bits = harder difficulty
-	// 16 bits = very easy (for testing)
-	// 24 bits = Bitcoin's original difficulty
-	DefaultTargetBits = 16
 )
 
 // ProofOfWork represents a proof-of-work algorithm
@@ -97,3 +91,9 @@ func IsValidHash(hash []byte, difficultyTarget uint32) bool {
 func CompareHashes(hash1, hash2 []byte) int {
 	return bytes.Compare(hash1, hash2)
 }
+
+// Work returns the expected number of hashes needed to find a block at the
+// given difficulty; chains are compared by the sum of their blocks' work
+func Work(difficultyTarget uint32) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(difficultyTarget))
+}
@@ -0,0 +1,313 @@
+// Package psbt is a container for transactions signed in several steps or
+// places, modeled on Bitcoin's partially signed transactions (BIP-174). A
+// packet holds the unsigned transaction with what each signer needs per
+// input: the transaction whose output it spends, the signing key and its
+// derivation path. It collects signatures until every input is signed and
+// the final transaction can be extracted.
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"fmt"
+
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+// magic starts every serialized packet
+var magic = []byte("btpsbt\xff")
+
+// ErrIncomplete is returned when a packet still has unsigned inputs
+var ErrIncomplete = errors.New("not every input is signed")
+
+// Packet is a partially signed transaction
+type Packet struct {
+	Tx     *tx.Transaction // Without public keys, signatures or ID
+	Inputs []Input         // One per input of Tx
+}
+
+// Input is what signers and finalizers know about an input. Signatures do
+// not commit to input values, so the whole previous transaction is carried:
+// a signer checks it against the input's outpoint rather than trusting a
+// value it is told.
+type Input struct {
+	PrevTx      *tx.Transaction // Transaction whose output the input spends
+	PubKey      []byte          // Key expected to sign, if known
+	Path        string          // Derivation path of PubKey, if known
+	PartialSigs []PartialSig
+	FinalPubKey []byte // Set by Finalize
+	FinalSig    []byte
+}
+
+// PartialSig is a signature of an input by one key
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// New starts a packet from a transaction; public keys its inputs carry are
+// kept as the expected signers and signatures are dropped
+func New(transaction *tx.Transaction) (*Packet, error) {
+	if transaction.IsCoinbase() {
+		return nil, fmt.Errorf("coinbase transactions are not signed")
+	}
+	if len(transaction.Inputs) == 0 {
+		return nil, fmt.Errorf("transaction has no inputs")
+	}
+
+	p := &Packet{
+		Tx:     &tx.Transaction{Inputs: make([]tx.TxInput, len(transaction.Inputs))},
+		Inputs: make([]Input, len(transaction.Inputs)),
+	}
+	for i, input := range transaction.Inputs {
+		p.Tx.Inputs[i] = tx.TxInput{TxID: input.TxID, OutIndex: input.OutIndex, Sequence: input.Sequence}
+		p.Inputs[i].PubKey = input.PubKey
+	}
+	p.Tx.Outputs = append(p.Tx.Outputs, transaction.Outputs...)
+	return p, nil
+}
+
+// Update fills in the previous transactions of inputs that lack them
+func (p *Packet) Update(find func(txID []byte) (*tx.Transaction, error)) error {
+	for i, input := range p.Tx.Inputs {
+		if p.Inputs[i].PrevTx != nil {
+			continue
+		}
+		prevTx, err := find(input.TxID)
+		if err != nil {
+			return fmt.Errorf("input %d: %v", i, err)
+		}
+		p.Inputs[i].PrevTx = prevTx
+		if _, err := p.SpentOutput(i); err != nil {
+			p.Inputs[i].PrevTx = nil
+			return err
+		}
+	}
+	return nil
+}
+
+// SpentOutput returns the output input i spends, once its previous
+// transaction is known and proven to be the one the input refers to
+func (p *Packet) SpentOutput(i int) (*tx.TxOutput, error) {
+	input, prevTx := p.Tx.Inputs[i], p.Inputs[i].PrevTx
+	if prevTx == nil {
+		return nil, fmt.Errorf("output spent by input %d is unknown", i)
+	}
+	if !bytes.Equal(prevTx.ComputeID(), input.TxID) {
+		return nil, fmt.Errorf("input %d: previous transaction does not match %x", i, input.TxID)
+	}
+	if input.OutIndex < 0 || input.OutIndex >= len(prevTx.Outputs) {
+		return nil, fmt.Errorf("input %d spends missing output %d of %x", i, input.OutIndex, input.TxID)
+	}
+	return &prevTx.Outputs[input.OutIndex], nil
+}
+
+// Sign adds the wallet's signature to every input spending an output
+// locked to its key, and returns how many it signed. It refuses a packet
+// whose previous transactions do not match its inputs.
+func (p *Packet) Sign(wallet *crypto.Wallet) (int, error) {
+	pubKeyHash := crypto.PublicKeyHash(wallet.PublicKey)
+	signed := 0
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		if in.PrevTx == nil || in.FinalSig != nil {
+			continue
+		}
+		utxo, err := p.SpentOutput(i)
+		if err != nil {
+			return signed, err
+		}
+		if !bytes.Equal(utxo.PubKeyHash, pubKeyHash) {
+			continue
+		}
+		signature, err := wallet.Sign(p.Tx.SigHash(i, utxo.PubKeyHash))
+		if err != nil {
+			return signed, fmt.Errorf("failed to sign input %d: %v", i, err)
+		}
+		in.addSig(PartialSig{PubKey: wallet.PublicKey, Signature: signature})
+		if in.PubKey == nil {
+			in.PubKey = wallet.PublicKey
+		}
+		signed++
+	}
+	return signed, nil
+}
+
+// addSig adds a signature unless the key already signed
+func (in *Input) addSig(sig PartialSig) {
+	for _, existing := range in.PartialSigs {
+		if bytes.Equal(existing.PubKey, sig.PubKey) {
+			return
+		}
+	}
+	in.PartialSigs = append(in.PartialSigs, sig)
+}
+
+// Combine merges packets of the same transaction into one holding
+// everything any of them knows. Final signatures are only taken if they
+// verify.
+func Combine(packets ...*Packet) (*Packet, error) {
+	if len(packets) == 0 {
+		return nil, fmt.Errorf("no packets to combine")
+	}
+	encoded, err := packets[0].Encode()
+	if err != nil {
+		return nil, err
+	}
+	combined, err := Decode(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	id := combined.Tx.TrimmedCopy().Hash()
+	for _, p := range packets[1:] {
+		if !bytes.Equal(p.Tx.TrimmedCopy().Hash(), id) || len(p.Inputs) != len(combined.Inputs) {
+			return nil, fmt.Errorf("packets are for different transactions")
+		}
+		for i, in := range p.Inputs {
+			target := &combined.Inputs[i]
+			if target.PrevTx == nil {
+				target.PrevTx = in.PrevTx
+			}
+			if target.PubKey == nil {
+				target.PubKey = in.PubKey
+			}
+			if target.Path == "" {
+				target.Path = in.Path
+			}
+			if target.FinalSig == nil && in.FinalSig != nil {
+				if !combined.validSig(i, in.FinalPubKey, in.FinalSig) {
+					return nil, fmt.Errorf("input %d has an invalid final signature", i)
+				}
+				target.FinalPubKey, target.FinalSig = in.FinalPubKey, in.FinalSig
+			}
+			for _, sig := range in.PartialSigs {
+				target.addSig(sig)
+			}
+		}
+	}
+	return combined, nil
+}
+
+// Finalize picks each input's valid signature by the key its output is
+// locked to. It returns ErrIncomplete, after finalizing what it can, if
+// some inputs lack one.
+func (p *Packet) Finalize() error {
+	complete := true
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		if in.FinalSig != nil {
+			continue
+		}
+		for _, sig := range in.PartialSigs {
+			if p.validSig(i, sig.PubKey, sig.Signature) {
+				in.FinalPubKey, in.FinalSig = sig.PubKey, sig.Signature
+				break
+			}
+		}
+		if in.FinalSig == nil {
+			complete = false
+			continue
+		}
+		// Partial data is no longer needed once the input is final
+		in.PartialSigs, in.Path = nil, ""
+	}
+	if !complete {
+		return ErrIncomplete
+	}
+	return nil
+}
+
+// validSig reports whether a signature of input i is valid and by the key
+// the spent output is locked to
+func (p *Packet) validSig(i int, pubKey, signature []byte) bool {
+	utxo, err := p.SpentOutput(i)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(crypto.PublicKeyHash(pubKey), utxo.PubKeyHash) &&
+		crypto.VerifySignature(pubKey, p.Tx.SigHash(i, utxo.PubKeyHash), signature)
+}
+
+// IsComplete reports whether every input is finalized
+func (p *Packet) IsComplete() bool {
+	for _, in := range p.Inputs {
+		if in.FinalSig == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Extract returns the signed transaction of a finalized packet. Its ID
+// covers the public keys but not the signatures, as for transactions the
+// wallet signs itself.
+func (p *Packet) Extract() (*tx.Transaction, error) {
+	if !p.IsComplete() {
+		return nil, ErrIncomplete
+	}
+
+	inputs := make([]tx.TxInput, len(p.Tx.Inputs))
+	for i, input := range p.Tx.Inputs {
+		inputs[i] = tx.TxInput{TxID: input.TxID, OutIndex: input.OutIndex, Sequence: input.Sequence, PubKey: p.Inputs[i].FinalPubKey}
+	}
+	transaction := tx.NewTransaction(inputs, append([]tx.TxOutput(nil), p.Tx.Outputs...))
+	for i := range transaction.Inputs {
+		transaction.Inputs[i].Signature = p.Inputs[i].FinalSig
+	}
+	return transaction, nil
+}
+
+// Fee returns what the inputs hold beyond the outputs, once every input's
+// previous transaction is known and verified
+func (p *Packet) Fee() (int64, error) {
+	var in, out int64
+	for i := range p.Inputs {
+		utxo, err := p.SpentOutput(i)
+		if err != nil {
+			return 0, err
+		}
+		in += utxo.Value
+	}
+	for _, output := range p.Tx.Outputs {
+		out += output.Value
+	}
+	return in - out, nil
+}
+
+// Encode serializes the packet as base64
+func (p *Packet) Encode() (string, error) {
+	var buf bytes.Buffer
+	buf.Write(magic)
+	if err := gob.NewEncoder(&buf).Encode(p); err != nil {
+		return "", fmt.Errorf("failed to encode packet: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Decode parses a packet made by Encode
+func Decode(s string) (*Packet, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packet encoding: %v", err)
+	}
+	if !bytes.HasPrefix(data, magic) {
+		return nil, fmt.Errorf("not a partially signed transaction")
+	}
+
+	var p Packet
+	if err := gob.NewDecoder(bytes.NewReader(data[len(magic):])).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to decode packet: %v", err)
+	}
+	if p.Tx == nil || len(p.Tx.Inputs) == 0 || len(p.Inputs) != len(p.Tx.Inputs) {
+		return nil, fmt.Errorf("malformed packet")
+	}
+	p.Tx.ID = nil
+	for i := range p.Tx.Inputs {
+		p.Tx.Inputs[i].PubKey, p.Tx.Inputs[i].Signature = nil, nil
+	}
+	return &p, nil
+}
@@ -0,0 +1,206 @@
+package psbt
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/yourusername/bt/internal/crypto"
+	"github.com/yourusername/bt/internal/tx"
+)
+
+// twoPartyTx spends an output of each wallet to a third address
+func twoPartyTx(t *testing.T, alice, bob *crypto.Wallet) (*tx.Transaction, map[string]*tx.Transaction) {
+	t.Helper()
+	funding := tx.NewTransaction(
+		[]tx.TxInput{{TxID: []byte("earlier"), OutIndex: 0}},
+		[]tx.TxOutput{
+			{Value: 5000, PubKeyHash: crypto.PublicKeyHash(alice.PublicKey)},
+			{Value: 3000, PubKeyHash: crypto.PublicKeyHash(bob.PublicKey)},
+		},
+	)
+	spend := tx.NewTransaction(
+		[]tx.TxInput{{TxID: funding.ID, OutIndex: 0}, {TxID: funding.ID, OutIndex: 1}},
+		[]tx.TxOutput{{Value: 7500, PubKeyHash: []byte("recipient-hash")}},
+	)
+	return spend, map[string]*tx.Transaction{string(funding.ID): funding}
+}
+
+func TestTwoPartySigning(t *testing.T) {
+	alice, _ := crypto.NewWallet()
+	bob, _ := crypto.NewWallet()
+	spend, prevTxs := twoPartyTx(t, alice, bob)
+	find := func(txID []byte) (*tx.Transaction, error) {
+		if prevTx, ok := prevTxs[string(txID)]; ok {
+			return prevTx, nil
+		}
+		return nil, fmt.Errorf("transaction not found")
+	}
+
+	p, err := New(spend)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := p.Update(find); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if fee, err := p.Fee(); err != nil || fee != 500 {
+		t.Errorf("Fee = %d, %v; want 500", fee, err)
+	}
+
+	// Each party signs its own copy, as on separate machines
+	encoded, err := p.Encode()
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	aliceCopy, _ := Decode(encoded)
+	bobCopy, _ := Decode(encoded)
+	if n, err := aliceCopy.Sign(alice); n != 1 || err != nil {
+		t.Fatalf("Alice signed %d inputs: %v", n, err)
+	}
+	if n, _ := bobCopy.Sign(bob); n != 1 {
+		t.Fatalf("Bob signed %d inputs", n)
+	}
+
+	if err := aliceCopy.Finalize(); err != ErrIncomplete {
+		t.Errorf("Finalize of a half-signed packet: got %v, want ErrIncomplete", err)
+	}
+	if _, err := aliceCopy.Extract(); err != ErrIncomplete {
+		t.Errorf("Extract of a half-signed packet: got %v, want ErrIncomplete", err)
+	}
+
+	combined, err := Combine(aliceCopy, bobCopy)
+	if err != nil {
+		t.Fatalf("Combine failed: %v", err)
+	}
+	if err := combined.Finalize(); err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	final, err := combined.Extract()
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !final.Verify(prevTxs) {
+		t.Error("Extracted transaction does not verify")
+	}
+
+	// The ID is the one a single signer would have given it
+	withKeys := tx.NewTransaction([]tx.TxInput{
+		{TxID: spend.Inputs[0].TxID, OutIndex: 0, PubKey: alice.PublicKey},
+		{TxID: spend.Inputs[1].TxID, OutIndex: 1, PubKey: bob.PublicKey},
+	}, spend.Outputs)
+	if !bytes.Equal(final.ID, withKeys.ID) {
+		t.Error("Extracted transaction has an unexpected ID")
+	}
+}
+
+func TestFinalizeRejectsWrongKey(t *testing.T) {
+	alice, _ := crypto.NewWallet()
+	bob, _ := crypto.NewWallet()
+	mallory, _ := crypto.NewWallet()
+	spend, prevTxs := twoPartyTx(t, alice, bob)
+
+	p, _ := New(spend)
+	p.Update(func(txID []byte) (*tx.Transaction, error) { return prevTxs[string(txID)], nil })
+	if n, _ := p.Sign(mallory); n != 0 {
+		t.Errorf("Signed %d inputs with an unrelated key", n)
+	}
+
+	// A signature over the wrong input is not accepted
+	aliceUTXO, _ := p.SpentOutput(0)
+	bobUTXO, _ := p.SpentOutput(1)
+	signature, _ := mallory.Sign(p.Tx.SigHash(0, aliceUTXO.PubKeyHash))
+	p.Inputs[0].PartialSigs = append(p.Inputs[0].PartialSigs, PartialSig{PubKey: mallory.PublicKey, Signature: signature})
+	p.Sign(alice)
+	bobSig, _ := bob.Sign(p.Tx.SigHash(0, bobUTXO.PubKeyHash))
+	p.Inputs[1].PartialSigs = append(p.Inputs[1].PartialSigs, PartialSig{PubKey: bob.PublicKey, Signature: bobSig})
+
+	if err := p.Finalize(); err != ErrIncomplete {
+		t.Fatalf("Finalize: got %v, want ErrIncomplete", err)
+	}
+	if !bytes.Equal(p.Inputs[0].FinalPubKey, alice.PublicKey) || p.Inputs[1].FinalSig != nil {
+		t.Errorf("Unexpected final signatures %+v", p.Inputs)
+	}
+}
+
+func TestForgedPrevTxIsRejected(t *testing.T) {
+	alice, _ := crypto.NewWallet()
+	bob, _ := crypto.NewWallet()
+	spend, prevTxs := twoPartyTx(t, alice, bob)
+
+	// A coordinator claims alice's input holds far more than it does,
+	// hiding a large fee
+	p, _ := New(spend)
+	p.Update(func(txID []byte) (*tx.Transaction, error) { return prevTxs[string(txID)], nil })
+	forged := *p.Inputs[0].PrevTx
+	forged.Outputs = append([]tx.TxOutput(nil), forged.Outputs...)
+	forged.Outputs[0].Value = 50000
+	p.Inputs[0].PrevTx = &forged
+
+	if _, err := p.Fee(); err == nil {
+		t.Error("Computed a fee from a forged previous transaction")
+	}
+	if n, err := p.Sign(alice); n != 0 || err == nil {
+		t.Errorf("Signed %d inputs of a forged packet (err %v)", n, err)
+	}
+
+	q, _ := New(spend)
+	if err := q.Update(func([]byte) (*tx.Transaction, error) { return &forged, nil }); err == nil {
+		t.Error("Update accepted a previous transaction that does not match")
+	}
+}
+
+func TestCombineRejectsInvalidFinalSig(t *testing.T) {
+	alice, _ := crypto.NewWallet()
+	bob, _ := crypto.NewWallet()
+	mallory, _ := crypto.NewWallet()
+	spend, prevTxs := twoPartyTx(t, alice, bob)
+
+	p, _ := New(spend)
+	p.Update(func(txID []byte) (*tx.Transaction, error) { return prevTxs[string(txID)], nil })
+	encoded, _ := p.Encode()
+	q, _ := Decode(encoded)
+
+	// A signature by a key the output is not locked to
+	utxo, _ := q.SpentOutput(0)
+	signature, _ := mallory.Sign(q.Tx.SigHash(0, utxo.PubKeyHash))
+	q.Inputs[0].FinalPubKey, q.Inputs[0].FinalSig = mallory.PublicKey, signature
+	if _, err := Combine(p, q); err == nil {
+		t.Error("Combined a packet with an invalid final signature")
+	}
+
+	q.Inputs[0].FinalPubKey, q.Inputs[0].FinalSig = nil, nil
+	q.Sign(alice)
+	q.Finalize()
+	combined, err := Combine(p, q)
+	if err != nil {
+		t.Fatalf("Combine failed: %v", err)
+	}
+	if !bytes.Equal(combined.Inputs[0].FinalPubKey, alice.PublicKey) {
+		t.Error("Valid final signature not combined")
+	}
+}
+
+func TestCombineAndDecodeErrors(t *testing.T) {
+	alice, _ := crypto.NewWallet()
+	bob, _ := crypto.NewWallet()
+	spend, _ := twoPartyTx(t, alice, bob)
+	other, _ := twoPartyTx(t, bob, alice)
+
+	p, _ := New(spend)
+	q, _ := New(other)
+	if _, err := Combine(p, q); err == nil {
+		t.Error("Combined packets of different transactions")
+	}
+
+	if _, err := Decode("not base64!"); err == nil {
+		t.Error("Decoded invalid base64")
+	}
+	if _, err := Decode("aGVsbG8="); err == nil {
+		t.Error("Decoded data without the packet prefix")
+	}
+	coinbase := tx.NewCoinbaseTxToPubKeyHash([]byte("hash"), "", 50)
+	if _, err := New(coinbase); err == nil {
+		t.Error("Made a packet of a coinbase")
+	}
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"errors"
+)
+
+// ErrNotFound is returned by a Backend when a key does not exist
+var ErrNotFound = errors.New("storage: key not found")
+
+// Backend is the key-value store that chain and wallet storage are built on.
+// Keys are ordered bytewise so that prefix iteration is cheap.
+type Backend interface {
+	// Get returns the value for key, or ErrNotFound
+	Get(key []byte) ([]byte, error)
+
+	// Put stores value under key, overwriting any existing value
+	Put(key, value []byte) error
+
+	// Delete removes key; deleting a missing key is not an error
+	Delete(key []byte) error
+
+	// Has reports whether key exists
+	Has(key []byte) (bool, error)
+
+	// NewIterator iterates over all keys starting with prefix, in key order.
+	// A nil prefix iterates the whole store.
+	NewIterator(prefix []byte) Iterator
+
+	// Write applies all operations in batch atomically
+	Write(batch *Batch) error
+
+	// Snapshot returns a consistent read-only view of the store
+	Snapshot() (Snapshot, error)
+
+	// Close releases the underlying resources
+	Close() error
+}
+
+// Iterator walks over a range of key/value pairs.
+// Key and Value are only valid until the next call to Next.
+type Iterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Release()
+	Error() error
+}
+
+// Snapshot is a frozen, read-only view of a Backend
+type Snapshot interface {
+	Get(key []byte) ([]byte, error)
+	Has(key []byte) (bool, error)
+	NewIterator(prefix []byte) Iterator
+	Release()
+}
+
+// batchOp is a single queued write
+type batchOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// Batch collects writes that are applied atomically by Backend.Write
+type Batch struct {
+	ops []batchOp
+}
+
+// NewBatch creates an empty batch
+func NewBatch() *Batch {
+	return &Batch{}
+}
+
+// Put queues a write of value under key
+func (b *Batch) Put(key, value []byte) {
+	b.ops = append(b.ops, batchOp{
+		key:   append([]byte(nil), key...),
+		value: append([]byte(nil), value...),
+	})
+}
+
+// Delete queues the removal of key
+func (b *Batch) Delete(key []byte) {
+	b.ops = append(b.ops, batchOp{
+		key:    append([]byte(nil), key...),
+		delete: true,
+	})
+}
+
+// Len returns the number of queued operations
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// Reset discards all queued operations
+func (b *Batch) Reset() {
+	b.ops = b.ops[:0]
+}
@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// backendFactories returns a constructor and cleanup for every Backend implementation
+func backendFactories(t *testing.T) map[string]func() (Backend, func()) {
+	return map[string]func() (Backend, func()){
+		"memory": func() (Backend, func()) {
+			b := NewMemoryBackend()
+			return b, func() { b.Close() }
+		},
+		"leveldb": func() (Backend, func()) {
+			path := fmt.Sprintf("./test_backend_%d.db", time.Now().UnixNano())
+			b, err := NewLevelDBBackend(path)
+			if err != nil {
+				t.Fatalf("Failed to open LevelDB backend: %v", err)
+			}
+			return b, func() {
+				b.Close()
+				os.RemoveAll(path)
+			}
+		},
+	}
+}
+
+func TestBackendGetPutDelete(t *testing.T) {
+	for name, factory := range backendFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			b, cleanup := factory()
+			defer cleanup()
+
+			if _, err := b.Get([]byte("missing")); err != ErrNotFound {
+				t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+			}
+
+			if err := b.Put([]byte("key"), []byte("value")); err != nil {
+				t.Fatalf("Put failed: %v", err)
+			}
+
+			value, err := b.Get([]byte("key"))
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if !bytes.Equal(value, []byte("value")) {
+				t.Errorf("Get = %q, want %q", value, "value")
+			}
+
+			exists, err := b.Has([]byte("key"))
+			if err != nil || !exists {
+				t.Errorf("Has(key) = %v, %v; want true, nil", exists, err)
+			}
+
+			if err := b.Delete([]byte("key")); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+
+			exists, _ = b.Has([]byte("key"))
+			if exists {
+				t.Error("Key still exists after Delete")
+			}
+		})
+	}
+}
+
+func TestBackendIteratorPrefix(t *testing.T) {
+	for name, factory := range backendFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			b, cleanup := factory()
+			defer cleanup()
+
+			b.Put([]byte("a_2"), []byte("2"))
+			b.Put([]byte("a_1"), []byte("1"))
+			b.Put([]byte("b_1"), []byte("x"))
+
+			iter := b.NewIterator([]byte("a_"))
+			defer iter.Release()
+
+			var keys []string
+			for iter.Next() {
+				keys = append(keys, string(iter.Key()))
+			}
+			if err := iter.Error(); err != nil {
+				t.Fatalf("Iterator error: %v", err)
+			}
+
+			if len(keys) != 2 || keys[0] != "a_1" || keys[1] != "a_2" {
+				t.Errorf("Iterated keys = %v, want [a_1 a_2]", keys)
+			}
+		})
+	}
+}
+
+func TestBackendBatch(t *testing.T) {
+	for name, factory := range backendFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			b, cleanup := factory()
+			defer cleanup()
+
+			b.Put([]byte("old"), []byte("1"))
+
+			batch := NewBatch()
+			batch.Put([]byte("new"), []byte("2"))
+			batch.Delete([]byte("old"))
+
+			if batch.Len() != 2 {
+				t.Errorf("Batch length = %d, want 2", batch.Len())
+			}
+
+			if err := b.Write(batch); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+
+			if exists, _ := b.Has([]byte("old")); exists {
+				t.Error("Batched delete was not applied")
+			}
+			if value, _ := b.Get([]byte("new")); !bytes.Equal(value, []byte("2")) {
+				t.Errorf("Batched put = %q, want %q", value, "2")
+			}
+		})
+	}
+}
+
+func TestBackendSnapshot(t *testing.T) {
+	for name, factory := range backendFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			b, cleanup := factory()
+			defer cleanup()
+
+			b.Put([]byte("key"), []byte("before"))
+
+			snap, err := b.Snapshot()
+			if err != nil {
+				t.Fatalf("Snapshot failed: %v", err)
+			}
+			defer snap.Release()
+
+			b.Put([]byte("key"), []byte("after"))
+			b.Put([]byte("other"), []byte("x"))
+
+			value, err := snap.Get([]byte("key"))
+			if err != nil {
+				t.Fatalf("Snapshot Get failed: %v", err)
+			}
+			if !bytes.Equal(value, []byte("before")) {
+				t.Errorf("Snapshot sees %q, want %q", value, "before")
+			}
+
+			if exists, _ := snap.Has([]byte("other")); exists {
+				t.Error("Snapshot sees a key written after it was taken")
+			}
+		})
+	}
+}
+
+func TestStorageWithMemoryBackend(t *testing.T) {
+	s := NewStorageWithBackend(NewMemoryBackend())
+	defer s.Close()
+
+	if err := s.SaveChainHeight(42); err != nil {
+		t.Fatalf("SaveChainHeight failed: %v", err)
+	}
+
+	height, err := s.GetChainHeight()
+	if err != nil {
+		t.Fatalf("GetChainHeight failed: %v", err)
+	}
+	if height != 42 {
+		t.Errorf("Height = %d, want 42", height)
+	}
+
+	if err := s.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if _, err := s.GetChainHeight(); err == nil {
+		t.Error("Height still present after Clear")
+	}
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+const hdSeedKey = "hd_seed"
+
+// ErrNoHDSeed is returned for HD operations on a wallet without a seed
+var ErrNoHDSeed = errors.New("wallet has no HD seed")
+
+// HDAccount records how far the chains of a BIP-44 account have been
+// handed out
+type HDAccount struct {
+	Account      uint32
+	NextExternal uint32
+	NextInternal uint32
+}
+
+// HDSeedData is the BIP-32 seed every HD key is derived from, encrypted
+// with the master key like the private keys
+type HDSeedData struct {
+	Seed      []byte // Ciphertext when Encrypted
+	Encrypted bool
+	Nonce     []byte
+	Accounts  []HDAccount
+}
+
+// HasHDSeed reports whether the wallet has an HD seed
+func (ws *WalletStorage) HasHDSeed() bool {
+	exists, _ := ws.db.Has([]byte(hdSeedKey))
+	return exists
+}
+
+// SaveHDSeed stores the HD seed with its accounts; a wallet has at most one
+// seed, which is encrypted if the wallets are
+func (ws *WalletStorage) SaveHDSeed(seed []byte, accounts []HDAccount) error {
+	if ws.IsWatchOnly() {
+		return ErrWatchOnly
+	}
+	if ws.HasHDSeed() {
+		return fmt.Errorf("wallet already has an HD seed")
+	}
+
+	seedData := &HDSeedData{Seed: append([]byte(nil), seed...), Accounts: accounts}
+	if ws.IsEncrypted() {
+		ws.mu.Lock()
+		masterKey := append([]byte(nil), ws.masterKey...)
+		ws.mu.Unlock()
+		defer wipe(masterKey)
+
+		if len(masterKey) == 0 {
+			return ErrWalletLocked
+		}
+		if err := seedData.encrypt(masterKey); err != nil {
+			return err
+		}
+	}
+	return ws.writeHDSeed(seedData)
+}
+
+// GetHDSeed returns the decrypted HD seed; encrypted wallets must be unlocked
+func (ws *WalletStorage) GetHDSeed() ([]byte, error) {
+	seedData, err := ws.readHDSeed()
+	if err != nil {
+		return nil, err
+	}
+	if seedData == nil {
+		return nil, ErrNoHDSeed
+	}
+	if !seedData.Encrypted {
+		return seedData.Seed, nil
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.masterKey == nil {
+		return nil, ErrWalletLocked
+	}
+	if err := seedData.decrypt(ws.masterKey); err != nil {
+		return nil, err
+	}
+	return seedData.Seed, nil
+}
+
+// GetHDAccounts returns the HD accounts, which are readable while the
+// wallets are locked
+func (ws *WalletStorage) GetHDAccounts() ([]HDAccount, error) {
+	seedData, err := ws.readHDSeed()
+	if err != nil {
+		return nil, err
+	}
+	if seedData == nil {
+		return nil, ErrNoHDSeed
+	}
+	return seedData.Accounts, nil
+}
+
+// SetHDAccount updates the next indexes of an HD account, adding it if new
+func (ws *WalletStorage) SetHDAccount(account HDAccount) error {
+	seedData, err := ws.readHDSeed()
+	if err != nil {
+		return err
+	}
+	if seedData == nil {
+		return ErrNoHDSeed
+	}
+
+	found := false
+	for i := range seedData.Accounts {
+		if seedData.Accounts[i].Account == account.Account {
+			seedData.Accounts[i] = account
+			found = true
+		}
+	}
+	if !found {
+		seedData.Accounts = append(seedData.Accounts, account)
+	}
+	return ws.writeHDSeed(seedData)
+}
+
+// readHDSeed reads the seed as stored; it returns nil if there is none
+func (ws *WalletStorage) readHDSeed() (*HDSeedData, error) {
+	data, err := ws.db.Get([]byte(hdSeedKey))
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var seedData HDSeedData
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&seedData); err != nil {
+		return nil, fmt.Errorf("failed to decode HD seed: %v", err)
+	}
+	return &seedData, nil
+}
+
+// writeHDSeed stores the seed record
+func (ws *WalletStorage) writeHDSeed(seedData *HDSeedData) error {
+	data, err := encodeHDSeed(seedData)
+	if err != nil {
+		return err
+	}
+	if err := ws.db.Put([]byte(hdSeedKey), data); err != nil {
+		return fmt.Errorf("failed to save HD seed: %v", err)
+	}
+	return nil
+}
+
+// encodeHDSeed serializes a seed record
+func encodeHDSeed(seedData *HDSeedData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(seedData); err != nil {
+		return nil, fmt.Errorf("failed to encode HD seed: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// encrypt replaces the plaintext seed with its ciphertext under the master key
+func (s *HDSeedData) encrypt(masterKey []byte) error {
+	if s.Encrypted {
+		return nil
+	}
+	nonce, ciphertext, err := seal(masterKey, s.Seed, []byte(hdSeedKey))
+	if err != nil {
+		return err
+	}
+	wipe(s.Seed)
+	s.Seed, s.Nonce, s.Encrypted = ciphertext, nonce, true
+	return nil
+}
+
+// decrypt replaces the encrypted seed with its plaintext
+func (s *HDSeedData) decrypt(masterKey []byte) error {
+	if !s.Encrypted {
+		return nil
+	}
+	plaintext, err := open(masterKey, s.Nonce, s.Seed, []byte(hdSeedKey))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt HD seed: %v", err)
+	}
+	s.Seed, s.Nonce, s.Encrypted = plaintext, nil, false
+	return nil
+}
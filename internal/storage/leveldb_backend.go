@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// LevelDBBackend is a Backend stored on disk with goleveldb
+type LevelDBBackend struct {
+	db *leveldb.DB
+}
+
+// NewLevelDBBackend opens (or creates) a LevelDB database at path
+func NewLevelDBBackend(path string) (*LevelDBBackend, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+
+	return &LevelDBBackend{db: db}, nil
+}
+
+// Get returns the value stored under key
+func (l *LevelDBBackend) Get(key []byte) ([]byte, error) {
+	value, err := l.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+// Put stores value under key
+func (l *LevelDBBackend) Put(key, value []byte) error {
+	return l.db.Put(key, value, nil)
+}
+
+// Delete removes key
+func (l *LevelDBBackend) Delete(key []byte) error {
+	return l.db.Delete(key, nil)
+}
+
+// Has reports whether key exists
+func (l *LevelDBBackend) Has(key []byte) (bool, error) {
+	return l.db.Has(key, nil)
+}
+
+// NewIterator iterates over keys with the given prefix
+func (l *LevelDBBackend) NewIterator(prefix []byte) Iterator {
+	return newLevelDBIterator(l.db.NewIterator(prefixRange(prefix), nil))
+}
+
+// Write applies a batch atomically
+func (l *LevelDBBackend) Write(batch *Batch) error {
+	lb := new(leveldb.Batch)
+	for _, op := range batch.ops {
+		if op.delete {
+			lb.Delete(op.key)
+		} else {
+			lb.Put(op.key, op.value)
+		}
+	}
+	return l.db.Write(lb, nil)
+}
+
+// Snapshot returns a consistent read-only view of the database
+func (l *LevelDBBackend) Snapshot() (Snapshot, error) {
+	snap, err := l.db.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %v", err)
+	}
+	return &levelDBSnapshot{snap: snap}, nil
+}
+
+// Close closes the database
+func (l *LevelDBBackend) Close() error {
+	return l.db.Close()
+}
+
+// levelDBSnapshot adapts a leveldb snapshot to the Snapshot interface
+type levelDBSnapshot struct {
+	snap *leveldb.Snapshot
+}
+
+func (s *levelDBSnapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.snap.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (s *levelDBSnapshot) Has(key []byte) (bool, error) {
+	return s.snap.Has(key, nil)
+}
+
+func (s *levelDBSnapshot) NewIterator(prefix []byte) Iterator {
+	return newLevelDBIterator(s.snap.NewIterator(prefixRange(prefix), nil))
+}
+
+func (s *levelDBSnapshot) Release() {
+	s.snap.Release()
+}
+
+// levelDBIterator adapts a goleveldb iterator to the Iterator interface
+type levelDBIterator struct {
+	iter iterator.Iterator
+}
+
+func newLevelDBIterator(iter iterator.Iterator) *levelDBIterator {
+	return &levelDBIterator{iter: iter}
+}
+
+func (it *levelDBIterator) Next() bool    { return it.iter.Next() }
+func (it *levelDBIterator) Key() []byte   { return it.iter.Key() }
+func (it *levelDBIterator) Value() []byte { return it.iter.Value() }
+func (it *levelDBIterator) Release()      { it.iter.Release() }
+func (it *levelDBIterator) Error() error  { return it.iter.Error() }
+
+// prefixRange converts a key prefix into a goleveldb range (nil means everything)
+func prefixRange(prefix []byte) *util.Range {
+	if len(prefix) == 0 {
+		return nil
+	}
+	return util.BytesPrefix(prefix)
+}
@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// MemoryBackend is a Backend held entirely in memory.
+// It is intended for tests and simulations that should not touch disk.
+type MemoryBackend struct {
+	mu     sync.RWMutex
+	data   map[string][]byte
+	closed bool
+}
+
+// NewMemoryBackend creates an empty in-memory store
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		data: make(map[string][]byte),
+	}
+}
+
+// Get returns a copy of the value stored under key
+func (m *MemoryBackend) Get(key []byte) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return nil, errClosed
+	}
+
+	value, ok := m.data[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+// Put stores a copy of value under key
+func (m *MemoryBackend) Put(key, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errClosed
+	}
+
+	m.data[string(key)] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete removes key
+func (m *MemoryBackend) Delete(key []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errClosed
+	}
+
+	delete(m.data, string(key))
+	return nil
+}
+
+// Has reports whether key exists
+func (m *MemoryBackend) Has(key []byte) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return false, errClosed
+	}
+
+	_, ok := m.data[string(key)]
+	return ok, nil
+}
+
+// NewIterator iterates over a point-in-time copy of the keys with prefix
+func (m *MemoryBackend) NewIterator(prefix []byte) Iterator {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return &memoryIterator{pos: -1, err: errClosed}
+	}
+	return newMemoryIterator(m.data, prefix)
+}
+
+// Write applies a batch atomically
+func (m *MemoryBackend) Write(batch *Batch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errClosed
+	}
+
+	for _, op := range batch.ops {
+		if op.delete {
+			delete(m.data, string(op.key))
+		} else {
+			m.data[string(op.key)] = append([]byte(nil), op.value...)
+		}
+	}
+	return nil
+}
+
+// Snapshot copies the current contents into a read-only view
+func (m *MemoryBackend) Snapshot() (Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return nil, errClosed
+	}
+
+	data := make(map[string][]byte, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	return &memorySnapshot{data: data}, nil
+}
+
+// Close marks the store closed; its contents are discarded
+func (m *MemoryBackend) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	m.data = nil
+	return nil
+}
+
+// errClosed is returned when a MemoryBackend is used after Close
+var errClosed = errors.New("storage: backend closed")
+
+// memorySnapshot is an immutable copy of a MemoryBackend
+type memorySnapshot struct {
+	data map[string][]byte
+}
+
+func (s *memorySnapshot) Get(key []byte) ([]byte, error) {
+	value, ok := s.data[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+func (s *memorySnapshot) Has(key []byte) (bool, error) {
+	_, ok := s.data[string(key)]
+	return ok, nil
+}
+
+func (s *memorySnapshot) NewIterator(prefix []byte) Iterator {
+	return newMemoryIterator(s.data, prefix)
+}
+
+func (s *memorySnapshot) Release() {
+	s.data = nil
+}
+
+// memoryIterator walks a sorted copy of matching entries
+type memoryIterator struct {
+	keys   [][]byte
+	values [][]byte
+	pos    int
+	err    error
+}
+
+func newMemoryIterator(data map[string][]byte, prefix []byte) *memoryIterator {
+	keys := make([]string, 0)
+	for k := range data {
+		if bytes.HasPrefix([]byte(k), prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	it := &memoryIterator{pos: -1}
+	for _, k := range keys {
+		it.keys = append(it.keys, []byte(k))
+		it.values = append(it.values, data[k])
+	}
+	return it
+}
+
+func (it *memoryIterator) Next() bool {
+	if it.err != nil || it.pos+1 >= len(it.keys) {
+		it.pos = len(it.keys)
+		return false
+	}
+	it.pos++
+	return true
+}
+
+func (it *memoryIterator) Key() []byte {
+	if it.pos < 0 || it.pos >= len(it.keys) {
+		return nil
+	}
+	return it.keys[it.pos]
+}
+
+func (it *memoryIterator) Value() []byte {
+	if it.pos < 0 || it.pos >= len(it.values) {
+		return nil
+	}
+	return it.values[it.pos]
+}
+
+func (it *memoryIterator) Release() {
+	it.keys = nil
+	it.values = nil
+}
+
+func (it *memoryIterator) Error() error {
+	return it.err
+}
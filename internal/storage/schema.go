@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// schemaVersionKey holds the on-disk layout version of the chain database
+	schemaVersionKey = "schema_version"
+
+	// CurrentSchemaVersion is the layout version written by this build
+	CurrentSchemaVersion uint32 = 2
+)
+
+// ErrFutureSchema is returned when a database was written by a newer build
+var ErrFutureSchema = errors.New("database schema is newer than this build supports")
+
+// ErrResyncRequired is returned when a database holds a chain that this build
+// no longer accepts and has to be deleted and synced again
+var ErrResyncRequired = errors.New("database must be deleted and resynced")
+
+// Migration upgrades a database from Version-1 to Version
+type Migration struct {
+	Version     uint32
+	Description string
+	Migrate     func(db Backend) error
+}
+
+// migrations must be ordered by Version and end at CurrentSchemaVersion
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "stamp schema version on legacy databases",
+		Migrate: func(db Backend) error {
+			// Version 0 databases already use the version 1 layout
+			// (block_, utxo_ and gob-encoded chain metadata); they only lack the marker.
+			return nil
+		},
+	},
+	{
+		Version:     2,
+		Description: "transactions commit to input sequences",
+		Migrate: func(db Backend) error {
+			// Input sequences are part of the transaction encoding, so every
+			// stored transaction ID, merkle root and the genesis block changed.
+			// The old chain cannot be converted in place.
+			return fmt.Errorf("%w: transaction IDs changed with input sequences", ErrResyncRequired)
+		},
+	},
+}
+
+// SchemaStatus describes the schema state of a database
+type SchemaStatus struct {
+	Version uint32      // Version found on disk (0 for unversioned legacy data)
+	Fresh   bool        // True if the database holds no data at all
+	Pending []Migration // Migrations that Migrate would apply
+}
+
+// NeedsMigration reports whether Migrate would change the database
+func (s *SchemaStatus) NeedsMigration() bool {
+	return len(s.Pending) > 0
+}
+
+// GetSchemaVersion reads the schema version marker.
+// ok is false if the database has no marker.
+func GetSchemaVersion(db Backend) (version uint32, ok bool, err error) {
+	data, err := db.Get([]byte(schemaVersionKey))
+	if err == ErrNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if len(data) != 4 {
+		return 0, false, fmt.Errorf("corrupt schema version marker (%d bytes)", len(data))
+	}
+	return binary.BigEndian.Uint32(data), true, nil
+}
+
+// putSchemaVersion writes the schema version marker
+func putSchemaVersion(db Backend, version uint32) error {
+	data := make([]byte, 4)
+	binary.BigEndian.PutUint32(data, version)
+	return db.Put([]byte(schemaVersionKey), data)
+}
+
+// CheckSchema inspects a database without modifying it
+func CheckSchema(db Backend) (*SchemaStatus, error) {
+	version, ok, err := GetSchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SchemaStatus{Version: version}
+
+	if !ok {
+		empty, err := isEmpty(db)
+		if err != nil {
+			return nil, err
+		}
+		if empty {
+			status.Fresh = true
+			return status, nil
+		}
+	}
+
+	if version > CurrentSchemaVersion {
+		return status, fmt.Errorf("%w: found version %d, supported up to %d", ErrFutureSchema, version, CurrentSchemaVersion)
+	}
+
+	for _, m := range migrations {
+		if m.Version > version {
+			status.Pending = append(status.Pending, m)
+		}
+	}
+
+	return status, nil
+}
+
+// Migrate brings a database up to CurrentSchemaVersion.
+// Fresh databases are stamped with the current version, legacy ones are upgraded
+// one migration at a time, and databases from newer builds are refused.
+func Migrate(db Backend) (*SchemaStatus, error) {
+	status, err := CheckSchema(db)
+	if err != nil {
+		return status, err
+	}
+
+	if status.Fresh {
+		return status, putSchemaVersion(db, CurrentSchemaVersion)
+	}
+
+	for _, m := range status.Pending {
+		if err := m.Migrate(db); err != nil {
+			return status, fmt.Errorf("migration to version %d (%s) failed: %w", m.Version, m.Description, err)
+		}
+		// Record progress after each step so an interrupted run resumes where it stopped
+		if err := putSchemaVersion(db, m.Version); err != nil {
+			return status, fmt.Errorf("failed to record schema version %d: %v", m.Version, err)
+		}
+	}
+
+	return status, nil
+}
+
+// isEmpty reports whether the database holds no keys at all
+func isEmpty(db Backend) (bool, error) {
+	iter := db.NewIterator(nil)
+	defer iter.Release()
+
+	empty := !iter.Next()
+	return empty, iter.Error()
+}
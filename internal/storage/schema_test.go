@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMigrateFreshDatabase(t *testing.T) {
+	db := NewMemoryBackend()
+	defer db.Close()
+
+	status, err := Migrate(db)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if !status.Fresh {
+		t.Error("Empty database not reported as fresh")
+	}
+
+	version, ok, err := GetSchemaVersion(db)
+	if err != nil || !ok {
+		t.Fatalf("Schema version not written: ok=%v err=%v", ok, err)
+	}
+	if version != CurrentSchemaVersion {
+		t.Errorf("Schema version = %d, want %d", version, CurrentSchemaVersion)
+	}
+}
+
+func TestMigrateLegacyDatabase(t *testing.T) {
+	db := NewMemoryBackend()
+	defer db.Close()
+
+	// Unversioned database as written by older builds
+	s := NewStorageWithBackend(db)
+	s.SaveChainTip([]byte("tip"))
+
+	status, err := CheckSchema(db)
+	if err != nil {
+		t.Fatalf("CheckSchema failed: %v", err)
+	}
+	if status.Fresh || status.Version != 0 || !status.NeedsMigration() {
+		t.Errorf("Legacy status = %+v, want version 0 with pending migrations", status)
+	}
+
+	// Legacy chains predate input sequences and cannot be upgraded in place
+	if _, err := Migrate(db); !errors.Is(err, ErrResyncRequired) {
+		t.Fatalf("Migrate error = %v, want ErrResyncRequired", err)
+	}
+
+	status, _ = CheckSchema(db)
+	if status.Version != 1 || !status.NeedsMigration() {
+		t.Errorf("Status after refused migration = %+v, want version 1 with pending migrations", status)
+	}
+}
+
+func TestMigrateAppliesStepsInOrder(t *testing.T) {
+	saved := migrations
+	defer func() { migrations = saved }()
+
+	var applied []uint32
+	step := func(v uint32) Migration {
+		return Migration{Version: v, Description: "test", Migrate: func(Backend) error {
+			applied = append(applied, v)
+			return nil
+		}}
+	}
+	migrations = []Migration{step(1), step(2), step(3)}
+
+	db := NewMemoryBackend()
+	defer db.Close()
+	putSchemaVersion(db, 1)
+
+	if _, err := Migrate(db); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	if len(applied) != 2 || applied[0] != 2 || applied[1] != 3 {
+		t.Errorf("Applied migrations = %v, want [2 3]", applied)
+	}
+}
+
+func TestMigrateStopsOnFailure(t *testing.T) {
+	saved := migrations
+	defer func() { migrations = saved }()
+
+	migrations = []Migration{
+		{Version: 1, Migrate: func(Backend) error { return nil }},
+		{Version: 2, Migrate: func(Backend) error { return errors.New("boom") }},
+	}
+
+	db := NewMemoryBackend()
+	defer db.Close()
+	db.Put([]byte("chain_tip"), []byte("tip"))
+
+	if _, err := Migrate(db); err == nil {
+		t.Fatal("Migrate succeeded despite a failing step")
+	}
+
+	version, _, _ := GetSchemaVersion(db)
+	if version != 1 {
+		t.Errorf("Schema version after failed step = %d, want 1", version)
+	}
+}
+
+func TestMigrateRefusesFutureSchema(t *testing.T) {
+	db := NewMemoryBackend()
+	defer db.Close()
+	putSchemaVersion(db, CurrentSchemaVersion+1)
+
+	if _, err := Migrate(db); !errors.Is(err, ErrFutureSchema) {
+		t.Errorf("Migrate error = %v, want ErrFutureSchema", err)
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"encoding/gob"
 	"fmt"
 
-	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/yourusername/bt/internal/tx"
 	"github.com/yourusername/bt/pkg/types"
 )
@@ -21,19 +19,29 @@ const (
 	difficultyKey   = "difficulty"
 )
 
-// Storage represents the LevelDB storage layer
+// Storage represents the blockchain storage layer
 type Storage struct {
-	db *leveldb.DB
+	db Backend
 }
 
-// NewStorage creates a new storage instance
+// NewStorage creates a new storage instance backed by LevelDB at path
 func NewStorage(path string) (*Storage, error) {
-	db, err := leveldb.OpenFile(path, nil)
+	db, err := NewLevelDBBackend(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %v", err)
+		return nil, err
 	}
 
-	return &Storage{db: db}, nil
+	return NewStorageWithBackend(db), nil
+}
+
+// NewStorageWithBackend creates a storage instance on top of an existing backend
+func NewStorageWithBackend(backend Backend) *Storage {
+	return &Storage{db: backend}
+}
+
+// Backend returns the underlying key-value backend
+func (s *Storage) Backend() Backend {
+	return s.db
 }
 
 // Close closes the database connection
@@ -51,7 +59,7 @@ func (s *Storage) SaveBlock(block *types.Block) error {
 
 	// Save block by hash
 	key := []byte(blockPrefix + string(block.Hash))
-	if err := s.db.Put(key, serialized, nil); err != nil {
+	if err := s.db.Put(key, serialized); err != nil {
 		return fmt.Errorf("failed to save block: %v", err)
 	}
 
@@ -61,7 +69,7 @@ func (s *Storage) SaveBlock(block *types.Block) error {
 // GetBlock retrieves a block by hash
 func (s *Storage) GetBlock(hash []byte) (*types.Block, error) {
 	key := []byte(blockPrefix + string(hash))
-	data, err := s.db.Get(key, nil)
+	data, err := s.db.Get(key)
 	if err != nil {
 		return nil, fmt.Errorf("block not found: %v", err)
 	}
@@ -76,12 +84,12 @@ func (s *Storage) GetBlock(hash []byte) (*types.Block, error) {
 
 // SaveChainTip saves the current chain tip (latest block hash)
 func (s *Storage) SaveChainTip(hash []byte) error {
-	return s.db.Put([]byte(tipKey), hash, nil)
+	return s.db.Put([]byte(tipKey), hash)
 }
 
 // GetChainTip retrieves the current chain tip
 func (s *Storage) GetChainTip() ([]byte, error) {
-	return s.db.Get([]byte(tipKey), nil)
+	return s.db.Get([]byte(tipKey))
 }
 
 // SaveChainHeight saves the current blockchain height
@@ -91,12 +99,12 @@ func (s *Storage) SaveChainHeight(height int) error {
 	if err := encoder.Encode(height); err != nil {
 		return err
 	}
-	return s.db.Put([]byte(heightKey), buf.Bytes(), nil)
+	return s.db.Put([]byte(heightKey), buf.Bytes())
 }
 
 // GetChainHeight retrieves the current blockchain height
 func (s *Storage) GetChainHeight() (int, error) {
-	data, err := s.db.Get([]byte(heightKey), nil)
+	data, err := s.db.Get([]byte(heightKey))
 	if err != nil {
 		return 0, err
 	}
@@ -117,12 +125,12 @@ func (s *Storage) SaveDifficulty(difficulty uint32) error {
 	if err := encoder.Encode(difficulty); err != nil {
 		return err
 	}
-	return s.db.Put([]byte(difficultyKey), buf.Bytes(), nil)
+	return s.db.Put([]byte(difficultyKey), buf.Bytes())
 }
 
 // GetDifficulty retrieves the current difficulty target
 func (s *Storage) GetDifficulty() (uint32, error) {
-	data, err := s.db.Get([]byte(difficultyKey), nil)
+	data, err := s.db.Get([]byte(difficultyKey))
 	if err != nil {
 		return 0, err
 	}
@@ -146,19 +154,19 @@ func (s *Storage) SaveUTXO(txID []byte, index int, output tx.TxOutput) error {
 		return fmt.Errorf("failed to encode UTXO: %v", err)
 	}
 
-	return s.db.Put(key, buf.Bytes(), nil)
+	return s.db.Put(key, buf.Bytes())
 }
 
 // DeleteUTXO removes a UTXO from the database
 func (s *Storage) DeleteUTXO(txID []byte, index int) error {
 	key := []byte(fmt.Sprintf("%s%x_%d", utxoPrefix, txID, index))
-	return s.db.Delete(key, nil)
+	return s.db.Delete(key)
 }
 
 // GetUTXO retrieves a UTXO from the database
 func (s *Storage) GetUTXO(txID []byte, index int) (*tx.TxOutput, error) {
 	key := []byte(fmt.Sprintf("%s%x_%d", utxoPrefix, txID, index))
-	data, err := s.db.Get(key, nil)
+	data, err := s.db.Get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +184,7 @@ func (s *Storage) GetUTXO(txID []byte, index int) (*tx.TxOutput, error) {
 func (s *Storage) GetAllUTXOs() (map[string][]tx.TxOutput, error) {
 	utxos := make(map[string][]tx.TxOutput)
 
-	iter := s.db.NewIterator(util.BytesPrefix([]byte(utxoPrefix)), nil)
+	iter := s.db.NewIterator([]byte(utxoPrefix))
 	defer iter.Release()
 
 	for iter.Next() {
@@ -200,21 +208,21 @@ func (s *Storage) GetAllUTXOs() (map[string][]tx.TxOutput, error) {
 // BlockExists checks if a block exists in the database
 func (s *Storage) BlockExists(hash []byte) bool {
 	key := []byte(blockPrefix + string(hash))
-	exists, _ := s.db.Has(key, nil)
+	exists, _ := s.db.Has(key)
 	return exists
 }
 
 // DeleteBlock removes a block from the database
 func (s *Storage) DeleteBlock(hash []byte) error {
 	key := []byte(blockPrefix + string(hash))
-	return s.db.Delete(key, nil)
+	return s.db.Delete(key)
 }
 
 // GetAllBlocks retrieves all blocks from the database
 func (s *Storage) GetAllBlocks() ([]*types.Block, error) {
 	var blocks []*types.Block
 
-	iter := s.db.NewIterator(util.BytesPrefix([]byte(blockPrefix)), nil)
+	iter := s.db.NewIterator([]byte(blockPrefix))
 	defer iter.Release()
 
 	for iter.Next() {
@@ -279,13 +287,17 @@ func deserializeBlock(data []byte) (*types.Block, error) {
 
 // Clear removes all data from the database
 func (s *Storage) Clear() error {
-	iter := s.db.NewIterator(nil, nil)
+	iter := s.db.NewIterator(nil)
 	defer iter.Release()
 
-	batch := new(leveldb.Batch)
+	batch := NewBatch()
 	for iter.Next() {
 		batch.Delete(iter.Key())
 	}
 
-	return s.db.Write(batch, nil)
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	return s.db.Write(batch)
 }
@@ -0,0 +1,340 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/gob"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	masterKeyKey  = "master_key"
+	masterKeySize = chacha20poly1305.KeySize
+	saltSize      = 16
+)
+
+// scrypt cost parameters for new passphrases; they are stored with the
+// encrypted master key so they can be raised later
+var (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var (
+	// ErrWalletLocked is returned when a private key is needed while the
+	// encrypted wallet is locked
+	ErrWalletLocked = errors.New("wallet is locked; unlock it with the passphrase first")
+
+	// ErrWrongPassphrase is returned when a passphrase does not decrypt the
+	// master key
+	ErrWrongPassphrase = errors.New("incorrect wallet passphrase")
+
+	// ErrNotEncrypted is returned for passphrase operations on a wallet
+	// that has no passphrase
+	ErrNotEncrypted = errors.New("wallet is not encrypted")
+
+	// ErrAlreadyEncrypted is returned when encrypting an encrypted wallet
+	ErrAlreadyEncrypted = errors.New("wallet is already encrypted")
+)
+
+// MasterKeyData is the random master key that encrypts every private key,
+// itself encrypted with a key derived from the passphrase. Changing the
+// passphrase only re-encrypts the master key.
+type MasterKeyData struct {
+	Salt       []byte
+	N, R, P    int // scrypt cost parameters
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+// deriveKey stretches a passphrase into an encryption key with scrypt
+func (m *MasterKeyData) deriveKey(passphrase string) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), m.Salt, m.N, m.R, m.P, masterKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %v", err)
+	}
+	return key, nil
+}
+
+// sealMasterKey encrypts a master key under a new salt and passphrase
+func sealMasterKey(masterKey []byte, passphrase string) (*MasterKeyData, error) {
+	m := &MasterKeyData{Salt: make([]byte, saltSize), N: scryptN, R: scryptR, P: scryptP}
+	if _, err := rand.Read(m.Salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %v", err)
+	}
+	key, err := m.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(key)
+
+	m.Nonce, m.Ciphertext, err = seal(key, masterKey, []byte(masterKeyKey))
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// open decrypts the master key with a passphrase
+func (m *MasterKeyData) open(passphrase string) ([]byte, error) {
+	key, err := m.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(key)
+
+	masterKey, err := open(key, m.Nonce, m.Ciphertext, []byte(masterKeyKey))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return masterKey, nil
+}
+
+// seal encrypts plaintext with XChaCha20-Poly1305 under a random nonce,
+// binding it to additionalData
+func seal(key, plaintext, additionalData []byte) (nonce, ciphertext []byte, err error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+	return nonce, aead.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts and authenticates a ciphertext produced by seal
+func open(key, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length %d", len(nonce))
+	}
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// wipe zeroes key material that is no longer needed
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// loadMasterKey reads the encrypted master key; it returns nil if the
+// wallet is not encrypted
+func (ws *WalletStorage) loadMasterKey() (*MasterKeyData, error) {
+	data, err := ws.db.Get([]byte(masterKeyKey))
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m MasterKeyData
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode master key: %v", err)
+	}
+	return &m, nil
+}
+
+// encodeMasterKey serializes an encrypted master key
+func encodeMasterKey(m *MasterKeyData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(m); err != nil {
+		return nil, fmt.Errorf("failed to encode master key: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// IsEncrypted reports whether the wallets are protected by a passphrase
+func (ws *WalletStorage) IsEncrypted() bool {
+	exists, _ := ws.db.Has([]byte(masterKeyKey))
+	return exists
+}
+
+// IsLocked reports whether the wallets are encrypted and not unlocked
+func (ws *WalletStorage) IsLocked() bool {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.masterKey == nil && ws.IsEncrypted()
+}
+
+// EncryptWallets protects all stored private keys with a passphrase. The
+// keys are encrypted with a new random master key, which is stored
+// encrypted with the passphrase. The wallets are locked afterwards.
+func (ws *WalletStorage) EncryptWallets(passphrase string) error {
+	if passphrase == "" {
+		return fmt.Errorf("passphrase must not be empty")
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	if ws.IsEncrypted() {
+		return ErrAlreadyEncrypted
+	}
+	if ws.IsWatchOnly() {
+		return ErrWatchOnly
+	}
+
+	masterKey := make([]byte, masterKeySize)
+	if _, err := rand.Read(masterKey); err != nil {
+		return fmt.Errorf("failed to generate master key: %v", err)
+	}
+	defer wipe(masterKey)
+
+	sealed, err := sealMasterKey(masterKey, passphrase)
+	if err != nil {
+		return err
+	}
+	encoded, err := encodeMasterKey(sealed)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := ws.GetAllAddresses()
+	if err != nil {
+		return err
+	}
+
+	// Rewrite every key and store the master key in one atomic batch
+	batch := NewBatch()
+	for _, address := range addresses {
+		walletData, err := ws.readWallet(address)
+		if err != nil {
+			return err
+		}
+		if err := walletData.encrypt(masterKey); err != nil {
+			return err
+		}
+		data, err := encodeWallet(walletData)
+		if err != nil {
+			return err
+		}
+		batch.Put([]byte(walletPrefix+address), data)
+	}
+
+	seed, err := ws.readHDSeed()
+	if err != nil {
+		return err
+	}
+	if seed != nil {
+		if err := seed.encrypt(masterKey); err != nil {
+			return err
+		}
+		data, err := encodeHDSeed(seed)
+		if err != nil {
+			return err
+		}
+		batch.Put([]byte(hdSeedKey), data)
+	}
+	batch.Put([]byte(masterKeyKey), encoded)
+
+	if err := ws.db.Write(batch); err != nil {
+		return fmt.Errorf("failed to save encrypted wallets: %v", err)
+	}
+	return nil
+}
+
+// Unlock decrypts the master key with the passphrase and keeps it in memory
+// until Lock, so private keys can be read and new wallets saved
+func (ws *WalletStorage) Unlock(passphrase string) error {
+	sealed, err := ws.loadMasterKey()
+	if err != nil {
+		return err
+	}
+	if sealed == nil {
+		return ErrNotEncrypted
+	}
+
+	masterKey, err := sealed.open(passphrase)
+	if err != nil {
+		return err
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	wipe(ws.masterKey)
+	ws.masterKey = masterKey
+	return nil
+}
+
+// Lock forgets the master key
+func (ws *WalletStorage) Lock() {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	wipe(ws.masterKey)
+	ws.masterKey = nil
+}
+
+// ChangePassphrase re-encrypts the master key with a new passphrase; the
+// private keys themselves are unchanged
+func (ws *WalletStorage) ChangePassphrase(oldPassphrase, newPassphrase string) error {
+	if newPassphrase == "" {
+		return fmt.Errorf("passphrase must not be empty")
+	}
+
+	sealed, err := ws.loadMasterKey()
+	if err != nil {
+		return err
+	}
+	if sealed == nil {
+		return ErrNotEncrypted
+	}
+
+	masterKey, err := sealed.open(oldPassphrase)
+	if err != nil {
+		return err
+	}
+	defer wipe(masterKey)
+
+	resealed, err := sealMasterKey(masterKey, newPassphrase)
+	if err != nil {
+		return err
+	}
+	encoded, err := encodeMasterKey(resealed)
+	if err != nil {
+		return err
+	}
+	if err := ws.db.Put([]byte(masterKeyKey), encoded); err != nil {
+		return fmt.Errorf("failed to save master key: %v", err)
+	}
+	return nil
+}
+
+// encrypt replaces the plaintext private key with its ciphertext under the
+// master key, bound to the wallet's address
+func (w *WalletData) encrypt(masterKey []byte) error {
+	if w.Encrypted || w.WatchOnly {
+		return nil
+	}
+	nonce, ciphertext, err := seal(masterKey, w.PrivateKey, []byte(w.Address))
+	if err != nil {
+		return err
+	}
+	wipe(w.PrivateKey)
+	w.PrivateKey, w.Nonce, w.Encrypted = ciphertext, nonce, true
+	return nil
+}
+
+// decrypt replaces the encrypted private key with its plaintext
+func (w *WalletData) decrypt(masterKey []byte) error {
+	if !w.Encrypted {
+		return nil
+	}
+	plaintext, err := open(masterKey, w.Nonce, w.PrivateKey, []byte(w.Address))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt private key of %s: %v", w.Address, err)
+	}
+	w.PrivateKey, w.Nonce, w.Encrypted = plaintext, nil, false
+	return nil
+}
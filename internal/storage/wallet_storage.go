@@ -5,8 +5,9 @@ import (
 	"encoding/gob"
 	"fmt"
 	"os"
-
-	"github.com/syndtr/goleveldb/leveldb"
+	"path/filepath"
+	"regexp"
+	"sync"
 )
 
 const (
@@ -16,24 +17,37 @@ const (
 
 // WalletStorage manages wallet persistence
 type WalletStorage struct {
-	db *leveldb.DB
+	db Backend
+
+	// Decrypted master key while an encrypted wallet is unlocked
+	mu        sync.Mutex
+	masterKey []byte
 }
 
 // WalletData represents serializable wallet information
 type WalletData struct {
 	Address    string
-	PrivateKey []byte
+	PrivateKey []byte // Ciphertext when Encrypted
 	PublicKey  []byte
+	Encrypted  bool
+	Nonce      []byte
+	Path       string // BIP-44 derivation path of HD keys, empty for imported keys
+	WatchOnly  bool   // Watched without a private key
 }
 
 // NewWalletStorage creates a new wallet storage instance
 func NewWalletStorage(path string) (*WalletStorage, error) {
-	db, err := leveldb.OpenFile(path, nil)
+	db, err := NewLevelDBBackend(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open wallet database: %v", err)
 	}
 
-	return &WalletStorage{db: db}, nil
+	return NewWalletStorageWithBackend(db), nil
+}
+
+// NewWalletStorageWithBackend creates wallet storage on top of an existing backend
+func NewWalletStorageWithBackend(backend Backend) *WalletStorage {
+	return &WalletStorage{db: backend}
 }
 
 // Close closes the wallet database
@@ -41,22 +55,54 @@ func (ws *WalletStorage) Close() error {
 	return ws.db.Close()
 }
 
-// SaveWallet saves a wallet to the database
+// SaveWallet saves a wallet to the database. Once the wallets are
+// encrypted the private key is encrypted too, which requires them to be
+// unlocked.
 func (ws *WalletStorage) SaveWallet(address string, privateKey, publicKey []byte) error {
-	walletData := WalletData{
+	return ws.saveWallet(&WalletData{
+		Address:    address,
+		PrivateKey: append([]byte(nil), privateKey...),
+		PublicKey:  publicKey,
+	})
+}
+
+// SaveHDWallet saves a key derived from the HD seed along with its path
+func (ws *WalletStorage) SaveHDWallet(address string, privateKey, publicKey []byte, path string) error {
+	return ws.saveWallet(&WalletData{
 		Address:    address,
-		PrivateKey: privateKey,
+		PrivateKey: append([]byte(nil), privateKey...),
 		PublicKey:  publicKey,
+		Path:       path,
+	})
+}
+
+// saveWallet encrypts a wallet if needed and stores it
+func (ws *WalletStorage) saveWallet(walletData *WalletData) error {
+	address := walletData.Address
+	if ws.IsWatchOnly() {
+		return ErrWatchOnly
 	}
+	if ws.IsEncrypted() {
+		ws.mu.Lock()
+		masterKey := append([]byte(nil), ws.masterKey...)
+		ws.mu.Unlock()
+		defer wipe(masterKey)
 
-	var buf bytes.Buffer
-	encoder := gob.NewEncoder(&buf)
-	if err := encoder.Encode(walletData); err != nil {
-		return fmt.Errorf("failed to encode wallet: %v", err)
+		if len(masterKey) == 0 {
+			return ErrWalletLocked
+		}
+		if err := walletData.encrypt(masterKey); err != nil {
+			return err
+		}
+	}
+
+	data, err := encodeWallet(walletData)
+	if err != nil {
+		return err
 	}
 
 	key := []byte(walletPrefix + address)
-	if err := ws.db.Put(key, buf.Bytes(), nil); err != nil {
+	if err := ws.db.Put(key, data); err != nil {
 		return fmt.Errorf("failed to save wallet: %v", err)
 	}
 
@@ -64,10 +110,52 @@ func (ws *WalletStorage) SaveWallet(address string, privateKey, publicKey []byte
 	return ws.addAddress(address)
 }
 
-// GetWallet retrieves a wallet by address
+// GetWallet retrieves a wallet by address with its private key decrypted;
+// encrypted wallets must be unlocked
 func (ws *WalletStorage) GetWallet(address string) (*WalletData, error) {
+	walletData, err := ws.readWallet(address)
+	if err != nil {
+		return nil, err
+	}
+	if !walletData.Encrypted {
+		return walletData, nil
+	}
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.masterKey == nil {
+		return nil, ErrWalletLocked
+	}
+	if err := walletData.decrypt(ws.masterKey); err != nil {
+		return nil, err
+	}
+	return walletData, nil
+}
+
+// GetPublicKey returns a wallet's public key, which is readable while the
+// wallets are locked
+func (ws *WalletStorage) GetPublicKey(address string) ([]byte, error) {
+	walletData, err := ws.readWallet(address)
+	if err != nil {
+		return nil, err
+	}
+	return walletData.PublicKey, nil
+}
+
+// GetKeyPath returns the derivation path of an HD key, or "" for imported
+// keys; it is readable while the wallets are locked
+func (ws *WalletStorage) GetKeyPath(address string) (string, error) {
+	walletData, err := ws.readWallet(address)
+	if err != nil {
+		return "", err
+	}
+	return walletData.Path, nil
+}
+
+// readWallet reads a wallet as stored, without decrypting it
+func (ws *WalletStorage) readWallet(address string) (*WalletData, error) {
 	key := []byte(walletPrefix + address)
-	data, err := ws.db.Get(key, nil)
+	data, err := ws.db.Get(key)
 	if err != nil {
 		return nil, fmt.Errorf("wallet not found: %v", err)
 	}
@@ -81,10 +169,20 @@ func (ws *WalletStorage) GetWallet(address string) (*WalletData, error) {
 	return &walletData, nil
 }
 
+// encodeWallet serializes a wallet record
+func encodeWallet(walletData *WalletData) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+	if err := encoder.Encode(walletData); err != nil {
+		return nil, fmt.Errorf("failed to encode wallet: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
 // GetAllAddresses returns all wallet addresses
 func (ws *WalletStorage) GetAllAddresses() ([]string, error) {
-	data, err := ws.db.Get([]byte(addressKey), nil)
-	if err == leveldb.ErrNotFound {
+	data, err := ws.db.Get([]byte(addressKey))
+	if err == ErrNotFound {
 		return []string{}, nil
 	}
 	if err != nil {
@@ -122,19 +220,19 @@ func (ws *WalletStorage) addAddress(address string) error {
 		return err
 	}
 
-	return ws.db.Put([]byte(addressKey), buf.Bytes(), nil)
+	return ws.db.Put([]byte(addressKey), buf.Bytes())
 }
 
 // DeleteWallet removes a wallet from the database
 func (ws *WalletStorage) DeleteWallet(address string) error {
 	key := []byte(walletPrefix + address)
-	return ws.db.Delete(key, nil)
+	return ws.db.Delete(key)
 }
 
 // WalletExists checks if a wallet exists
 func (ws *WalletStorage) WalletExists(address string) bool {
 	key := []byte(walletPrefix + address)
-	exists, _ := ws.db.Has(key, nil)
+	exists, _ := ws.db.Has(key)
 	return exists
 }
 
@@ -151,14 +249,96 @@ func (ws *WalletStorage) Clear() error {
 		}
 	}
 
-	return ws.db.Delete([]byte(addressKey), nil)
+	return ws.db.Delete([]byte(addressKey))
+}
+
+// GetWalletPath returns the wallet storage path inside a network's data directory
+func GetWalletPath(networkDir string) string {
+	return filepath.Join(networkDir, "wallets")
+}
+
+// walletNamePattern limits wallet names to safe directory names
+var walletNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// ValidateWalletName checks a wallet name; the empty name is the default wallet
+func ValidateWalletName(name string) error {
+	if name != "" && !walletNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid wallet name %q (use up to 64 letters, digits, _ or -)", name)
+	}
+	return nil
+}
+
+// GetNamedWalletPath returns the storage path of a named wallet. The
+// default wallet (empty name) is the wallets directory itself and named
+// wallets are subdirectories of it.
+func GetNamedWalletPath(networkDir, name string) string {
+	if name == "" {
+		return GetWalletPath(networkDir)
+	}
+	return filepath.Join(GetWalletPath(networkDir), name)
+}
+
+// WalletStoreExists reports whether a wallet database exists at path
+func WalletStoreExists(path string) bool {
+	_, err := os.Stat(filepath.Join(path, "CURRENT"))
+	return err == nil
 }
 
-// GetWalletPath returns the default wallet storage path
-func GetWalletPath() string {
-	homeDir, err := os.UserHomeDir()
+// ListWalletNames returns the names of the wallets stored in a network's
+// data directory, with "" for the default wallet
+func ListWalletNames(networkDir string) ([]string, error) {
+	dir := GetWalletPath(networkDir)
+	var names []string
+	if WalletStoreExists(dir) {
+		names = append(names, "")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return names, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet directory: %v", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() && ValidateWalletName(name) == nil && WalletStoreExists(filepath.Join(dir, name)) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// Backup copies the wallet database, as stored, to a new wallet database
+// at path. Encrypted keys stay encrypted under the same passphrase.
+func (ws *WalletStorage) Backup(path string) error {
+	if WalletStoreExists(path) {
+		return fmt.Errorf("a wallet already exists at %s", path)
+	}
+
+	snapshot, err := ws.db.Snapshot()
 	if err != nil {
-		return "./wallets.db"
+		return fmt.Errorf("failed to snapshot wallet: %v", err)
+	}
+	defer snapshot.Release()
+
+	batch := NewBatch()
+	iter := snapshot.NewIterator(nil)
+	for iter.Next() {
+		batch.Put(iter.Key(), iter.Value())
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("failed to read wallet: %v", err)
+	}
+
+	backup, err := NewLevelDBBackend(path)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %v", err)
+	}
+	defer backup.Close()
+	if err := backup.Write(batch); err != nil {
+		return fmt.Errorf("failed to write backup: %v", err)
 	}
-	return homeDir + "/.btc_wallets"
+	return nil
 }
@@ -0,0 +1,319 @@
+package storage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWalletStorageRoundTrip(t *testing.T) {
+	ws := NewWalletStorageWithBackend(NewMemoryBackend())
+	defer ws.Close()
+
+	if err := ws.SaveWallet("addr1", []byte("private"), []byte("public")); err != nil {
+		t.Fatalf("SaveWallet failed: %v", err)
+	}
+	walletData, err := ws.GetWallet("addr1")
+	if err != nil {
+		t.Fatalf("GetWallet failed: %v", err)
+	}
+	if !bytes.Equal(walletData.PrivateKey, []byte("private")) || walletData.Encrypted {
+		t.Errorf("Unexpected wallet %+v", walletData)
+	}
+	if ws.IsEncrypted() || ws.IsLocked() {
+		t.Error("New wallet storage should not be encrypted")
+	}
+	if err := ws.Unlock("secret"); err != ErrNotEncrypted {
+		t.Errorf("Unlock of unencrypted wallets: got %v, want ErrNotEncrypted", err)
+	}
+}
+
+func TestEncryptWallets(t *testing.T) {
+	backend := NewMemoryBackend()
+	ws := NewWalletStorageWithBackend(backend)
+	defer ws.Close()
+
+	ws.SaveWallet("addr1", []byte("private1"), []byte("public1"))
+	if err := ws.EncryptWallets("secret"); err != nil {
+		t.Fatalf("EncryptWallets failed: %v", err)
+	}
+	if err := ws.EncryptWallets("again"); err != ErrAlreadyEncrypted {
+		t.Errorf("Second EncryptWallets: got %v, want ErrAlreadyEncrypted", err)
+	}
+
+	// The stored record no longer contains the plaintext key
+	raw, _ := backend.Get([]byte(walletPrefix + "addr1"))
+	if bytes.Contains(raw, []byte("private1")) {
+		t.Error("Private key stored in plaintext after encryption")
+	}
+
+	if !ws.IsLocked() {
+		t.Fatal("Wallets not locked after encryption")
+	}
+	if _, err := ws.GetWallet("addr1"); err != ErrWalletLocked {
+		t.Errorf("GetWallet while locked: got %v, want ErrWalletLocked", err)
+	}
+	if err := ws.SaveWallet("addr2", []byte("private2"), []byte("public2")); err != ErrWalletLocked {
+		t.Errorf("SaveWallet while locked: got %v, want ErrWalletLocked", err)
+	}
+	if pub, err := ws.GetPublicKey("addr1"); err != nil || !bytes.Equal(pub, []byte("public1")) {
+		t.Errorf("GetPublicKey while locked: %q, %v", pub, err)
+	}
+
+	if err := ws.Unlock("wrong"); err != ErrWrongPassphrase {
+		t.Errorf("Unlock with wrong passphrase: got %v, want ErrWrongPassphrase", err)
+	}
+	if err := ws.Unlock("secret"); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	walletData, err := ws.GetWallet("addr1")
+	if err != nil || !bytes.Equal(walletData.PrivateKey, []byte("private1")) {
+		t.Fatalf("GetWallet after unlock: %+v, %v", walletData, err)
+	}
+
+	// New wallets are encrypted as they are saved
+	if err := ws.SaveWallet("addr2", []byte("private2"), []byte("public2")); err != nil {
+		t.Fatalf("SaveWallet while unlocked failed: %v", err)
+	}
+	raw, _ = backend.Get([]byte(walletPrefix + "addr2"))
+	if bytes.Contains(raw, []byte("private2")) {
+		t.Error("New private key stored in plaintext")
+	}
+
+	ws.Lock()
+	if _, err := ws.GetWallet("addr2"); err != ErrWalletLocked {
+		t.Errorf("GetWallet after Lock: got %v, want ErrWalletLocked", err)
+	}
+
+	// Reopening the store keeps the wallets encrypted
+	reopened := NewWalletStorageWithBackend(backend)
+	if !reopened.IsLocked() {
+		t.Error("Reopened wallet storage is not locked")
+	}
+}
+
+func TestChangePassphrase(t *testing.T) {
+	ws := NewWalletStorageWithBackend(NewMemoryBackend())
+	defer ws.Close()
+
+	ws.SaveWallet("addr1", []byte("private1"), []byte("public1"))
+	if err := ws.ChangePassphrase("", "new"); err != ErrNotEncrypted {
+		t.Errorf("ChangePassphrase without encryption: got %v, want ErrNotEncrypted", err)
+	}
+	ws.EncryptWallets("old")
+
+	if err := ws.ChangePassphrase("wrong", "new"); err != ErrWrongPassphrase {
+		t.Errorf("ChangePassphrase with wrong passphrase: got %v, want ErrWrongPassphrase", err)
+	}
+	if err := ws.ChangePassphrase("old", "new"); err != nil {
+		t.Fatalf("ChangePassphrase failed: %v", err)
+	}
+
+	if err := ws.Unlock("old"); err != ErrWrongPassphrase {
+		t.Errorf("Old passphrase still unlocks: %v", err)
+	}
+	if err := ws.Unlock("new"); err != nil {
+		t.Fatalf("New passphrase does not unlock: %v", err)
+	}
+	walletData, err := ws.GetWallet("addr1")
+	if err != nil || !bytes.Equal(walletData.PrivateKey, []byte("private1")) {
+		t.Errorf("GetWallet after passphrase change: %+v, %v", walletData, err)
+	}
+}
+
+func TestHDSeed(t *testing.T) {
+	backend := NewMemoryBackend()
+	ws := NewWalletStorageWithBackend(backend)
+	defer ws.Close()
+
+	if _, err := ws.GetHDSeed(); err != ErrNoHDSeed {
+		t.Errorf("GetHDSeed without a seed: got %v, want ErrNoHDSeed", err)
+	}
+	if err := ws.SaveHDSeed([]byte("seed"), []HDAccount{{Account: 0}}); err != nil {
+		t.Fatalf("SaveHDSeed failed: %v", err)
+	}
+	if err := ws.SaveHDSeed([]byte("other"), nil); err == nil {
+		t.Error("Replaced an existing HD seed")
+	}
+	if err := ws.SaveHDWallet("addr1", []byte("private1"), []byte("public1"), "m/44'/1'/0'/0/0"); err != nil {
+		t.Fatalf("SaveHDWallet failed: %v", err)
+	}
+
+	// Encryption covers the seed, and the accounts stay readable
+	ws.EncryptWallets("secret")
+	raw, _ := backend.Get([]byte(hdSeedKey))
+	if bytes.Contains(raw, []byte("seed")) {
+		t.Error("HD seed stored in plaintext after encryption")
+	}
+	if _, err := ws.GetHDSeed(); err != ErrWalletLocked {
+		t.Errorf("GetHDSeed while locked: got %v, want ErrWalletLocked", err)
+	}
+	if err := ws.SetHDAccount(HDAccount{Account: 0, NextExternal: 3}); err != nil {
+		t.Fatalf("SetHDAccount failed: %v", err)
+	}
+	accounts, err := ws.GetHDAccounts()
+	if err != nil || len(accounts) != 1 || accounts[0].NextExternal != 3 {
+		t.Errorf("GetHDAccounts = %+v, %v", accounts, err)
+	}
+
+	ws.Unlock("secret")
+	seed, err := ws.GetHDSeed()
+	if err != nil || !bytes.Equal(seed, []byte("seed")) {
+		t.Errorf("GetHDSeed after unlock = %q, %v", seed, err)
+	}
+	walletData, err := ws.GetWallet("addr1")
+	if err != nil || walletData.Path != "m/44'/1'/0'/0/0" {
+		t.Errorf("GetWallet of HD key = %+v, %v", walletData, err)
+	}
+}
+
+func TestListWalletNames(t *testing.T) {
+	networkDir := t.TempDir()
+	if names, err := ListWalletNames(networkDir); err != nil || len(names) != 0 {
+		t.Fatalf("ListWalletNames of an empty directory = %q, %v", names, err)
+	}
+
+	for _, name := range []string{"", "alice", "savings-2"} {
+		ws, err := NewWalletStorage(GetNamedWalletPath(networkDir, name))
+		if err != nil {
+			t.Fatalf("Failed to create wallet %q: %v", name, err)
+		}
+		ws.Close()
+	}
+
+	names, err := ListWalletNames(networkDir)
+	if err != nil || len(names) != 3 || names[0] != "" || names[1] != "alice" || names[2] != "savings-2" {
+		t.Errorf("ListWalletNames = %q, %v", names, err)
+	}
+
+	for _, name := range []string{"../escape", "a/b", ".", strings.Repeat("x", 65)} {
+		if err := ValidateWalletName(name); err == nil {
+			t.Errorf("Accepted wallet name %q", name)
+		}
+	}
+}
+
+func TestWalletBackup(t *testing.T) {
+	ws := NewWalletStorageWithBackend(NewMemoryBackend())
+	defer ws.Close()
+	ws.SaveWallet("addr1", []byte("private"), []byte("public"))
+	if err := ws.EncryptWallets("secret"); err != nil {
+		t.Fatalf("EncryptWallets failed: %v", err)
+	}
+
+	path := t.TempDir() + "/backup"
+	if err := ws.Backup(path); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	if err := ws.Backup(path); err == nil {
+		t.Error("Backup overwrote an existing wallet")
+	}
+
+	restored, err := NewWalletStorage(path)
+	if err != nil {
+		t.Fatalf("Failed to open backup: %v", err)
+	}
+	defer restored.Close()
+	if !restored.IsEncrypted() || !restored.WalletExists("addr1") {
+		t.Fatal("Backup lost the encrypted wallet")
+	}
+	if err := restored.Unlock("secret"); err != nil {
+		t.Fatalf("Unlock of backup failed: %v", err)
+	}
+	walletData, err := restored.GetWallet("addr1")
+	if err != nil || !bytes.Equal(walletData.PrivateKey, []byte("private")) {
+		t.Errorf("Backup key = %q, %v", walletData.PrivateKey, err)
+	}
+}
+
+func TestWatchOnlyWallet(t *testing.T) {
+	ws := NewWalletStorageWithBackend(NewMemoryBackend())
+	defer ws.Close()
+
+	if err := ws.SetWatchOnly(); err != nil {
+		t.Fatalf("SetWatchOnly failed: %v", err)
+	}
+	if !ws.IsWatchOnly() {
+		t.Fatal("Wallet should be watch-only")
+	}
+	if err := ws.SaveWallet("addr1", []byte("private"), []byte("public")); err != ErrWatchOnly {
+		t.Errorf("SaveWallet: got %v, want ErrWatchOnly", err)
+	}
+	if err := ws.SaveHDSeed([]byte("seed"), nil); err != ErrWatchOnly {
+		t.Errorf("SaveHDSeed: got %v, want ErrWatchOnly", err)
+	}
+	if err := ws.EncryptWallets("secret"); err != ErrWatchOnly {
+		t.Errorf("EncryptWallets: got %v, want ErrWatchOnly", err)
+	}
+
+	if err := ws.SaveWatchAddress("addr2", []byte("public"), ""); err != nil {
+		t.Fatalf("SaveWatchAddress failed: %v", err)
+	}
+	if err := ws.SaveWatchAddress("addr2", nil, ""); err == nil {
+		t.Error("Saved the same address twice")
+	}
+	walletData, err := ws.GetWallet("addr2")
+	if err != nil || !walletData.WatchOnly || walletData.PrivateKey != nil {
+		t.Errorf("Unexpected watched address %+v, %v", walletData, err)
+	}
+
+	if err := ws.SaveWatchXPub(WatchXPub{Key: "xpub1", GapLimit: 20}); err != nil {
+		t.Fatalf("SaveWatchXPub failed: %v", err)
+	}
+	if err := ws.SaveWatchXPub(WatchXPub{Key: "xpub1"}); err == nil {
+		t.Error("Watched the same key twice")
+	}
+	if xpubs, _ := ws.GetWatchXPubs(); len(xpubs) != 1 || xpubs[0].GapLimit != 20 {
+		t.Errorf("Unexpected watched keys %+v", xpubs)
+	}
+
+	// A wallet with keys cannot become watch-only
+	keyed := NewWalletStorageWithBackend(NewMemoryBackend())
+	defer keyed.Close()
+	keyed.SaveWallet("addr1", []byte("private"), []byte("public"))
+	if err := keyed.SetWatchOnly(); err == nil {
+		t.Error("Made a wallet with keys watch-only")
+	}
+}
+
+func TestWalletTxScan(t *testing.T) {
+	ws := NewWalletStorageWithBackend(NewMemoryBackend())
+	defer ws.Close()
+
+	if height, err := ws.ScanHeight(); err != nil || height != -1 {
+		t.Fatalf("Expected scan height -1, got %d, %v", height, err)
+	}
+	ws.SaveScannedBlock(0, []byte("block0"), []WalletTx{{TxID: []byte{2}, Height: 0, Received: 50}})
+	ws.SaveScannedBlock(1, []byte("block1"), nil)
+	ws.SaveScannedBlock(2, []byte("block2"), []WalletTx{
+		{TxID: []byte{1}, Height: 2, Index: 1, Sent: 20, Fee: 1, Addresses: []string{"recipient"}},
+		{TxID: []byte{3}, Height: 2, Index: 0, Received: 50, Coinbase: true},
+	})
+
+	txs, err := ws.GetWalletTxs()
+	if err != nil || len(txs) != 3 || txs[0].Height != 0 || !txs[1].Coinbase || txs[2].Amount() != -20 {
+		t.Fatalf("Unexpected wallet transactions %+v, %v", txs, err)
+	}
+	if txs[2].Fee != 1 || len(txs[2].Addresses) != 1 {
+		t.Errorf("Details were not kept: %+v", txs[2])
+	}
+	if height, _ := ws.ScanHeight(); height != 2 {
+		t.Errorf("Expected scan height 2, got %d", height)
+	}
+	if hash, _ := ws.ScannedBlockHash(1); string(hash) != "block1" {
+		t.Errorf("Expected hash of block 1, got %q", hash)
+	}
+
+	if err := ws.Rescan(1); err != nil {
+		t.Fatalf("Rescan failed: %v", err)
+	}
+	if txs, _ := ws.GetWalletTxs(); len(txs) != 1 || txs[0].Height != 0 {
+		t.Errorf("Expected only the first transaction, got %+v", txs)
+	}
+	if height, _ := ws.ScanHeight(); height != 0 {
+		t.Errorf("Expected scan height 0, got %d", height)
+	}
+	if hash, _ := ws.ScannedBlockHash(2); hash != nil {
+		t.Errorf("Disconnected block is still recorded: %q", hash)
+	}
+}
@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	watchOnlyKey    = "watch_only"
+	watchXPubsKey   = "watch_xpubs"
+	scanHeightKey   = "scan_height"
+	walletTxPrefix  = "wtx_"
+	scanBlockPrefix = "wblk_"
+)
+
+// ErrWatchOnly is returned for private key operations on a watch-only wallet
+var ErrWatchOnly = errors.New("wallet is watch-only")
+
+// WatchXPub is an extended public key whose addresses a wallet watches
+type WatchXPub struct {
+	Key      string
+	GapLimit int
+}
+
+// WalletTx records a transaction that paid to or spent from a wallet
+type WalletTx struct {
+	TxID      []byte
+	Height    int // -1 while unconfirmed
+	BlockHash []byte
+	Index     int   // Position in the block
+	Time      int64 // Block time, in Unix seconds
+	Received  int64 // Paid to the wallet's addresses
+	Sent      int64 // Spent from the wallet's addresses
+	Fee       int64 // Known when the wallet funded every input
+	Coinbase  bool
+
+	// Addresses are the counterparties: recipients outside the wallet for
+	// payments it sends, senders for payments it receives
+	Addresses []string
+}
+
+// Amount is what the transaction changed the wallet's balance by
+func (wtx WalletTx) Amount() int64 {
+	return wtx.Received - wtx.Sent
+}
+
+// SetWatchOnly makes a new, empty wallet watch-only, so it never holds
+// private keys
+func (ws *WalletStorage) SetWatchOnly() error {
+	addresses, err := ws.GetAllAddresses()
+	if err != nil {
+		return err
+	}
+	if len(addresses) > 0 || ws.HasHDSeed() {
+		return fmt.Errorf("only an empty wallet can be made watch-only")
+	}
+	if err := ws.db.Put([]byte(watchOnlyKey), []byte{1}); err != nil {
+		return fmt.Errorf("failed to mark wallet watch-only: %v", err)
+	}
+	return nil
+}
+
+// IsWatchOnly reports whether the wallet was created watch-only
+func (ws *WalletStorage) IsWatchOnly() bool {
+	exists, _ := ws.db.Has([]byte(watchOnlyKey))
+	return exists
+}
+
+// SaveWatchAddress adds an address the wallet watches without its private
+// key. The public key and derivation path are optional.
+func (ws *WalletStorage) SaveWatchAddress(address string, publicKey []byte, path string) error {
+	if ws.WalletExists(address) {
+		return fmt.Errorf("address %s is already in the wallet", address)
+	}
+	data, err := encodeWallet(&WalletData{
+		Address:   address,
+		PublicKey: publicKey,
+		Path:      path,
+		WatchOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+	if err := ws.db.Put([]byte(walletPrefix+address), data); err != nil {
+		return fmt.Errorf("failed to save watched address: %v", err)
+	}
+	return ws.addAddress(address)
+}
+
+// SaveWatchXPub adds an extended public key to the watched keys
+func (ws *WalletStorage) SaveWatchXPub(xpub WatchXPub) error {
+	xpubs, err := ws.GetWatchXPubs()
+	if err != nil {
+		return err
+	}
+	for _, existing := range xpubs {
+		if existing.Key == xpub.Key {
+			return fmt.Errorf("extended key is already watched")
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(append(xpubs, xpub)); err != nil {
+		return fmt.Errorf("failed to encode watched keys: %v", err)
+	}
+	if err := ws.db.Put([]byte(watchXPubsKey), buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to save watched keys: %v", err)
+	}
+	return nil
+}
+
+// GetWatchXPubs returns the watched extended public keys
+func (ws *WalletStorage) GetWatchXPubs() ([]WatchXPub, error) {
+	data, err := ws.db.Get([]byte(watchXPubsKey))
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var xpubs []WatchXPub
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&xpubs); err != nil {
+		return nil, fmt.Errorf("failed to decode watched keys: %v", err)
+	}
+	return xpubs, nil
+}
+
+// ScanHeight returns the last block height scanned for the wallet's
+// transactions, or -1 if none was
+func (ws *WalletStorage) ScanHeight() (int, error) {
+	data, err := ws.db.Get([]byte(scanHeightKey))
+	if err == ErrNotFound {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(data) != 8 {
+		return 0, fmt.Errorf("invalid scan height")
+	}
+	return int(int64(binary.BigEndian.Uint64(data))), nil
+}
+
+// SaveScannedBlock records the transactions found in a block and marks it
+// scanned in one write. The block hash lets later scans notice that the
+// block was disconnected.
+func (ws *WalletStorage) SaveScannedBlock(height int, hash []byte, txs []WalletTx) error {
+	batch := NewBatch()
+	for _, wtx := range txs {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(wtx); err != nil {
+			return fmt.Errorf("failed to encode wallet transaction: %v", err)
+		}
+		batch.Put(walletTxKey(wtx.TxID), buf.Bytes())
+	}
+	batch.Put(scanBlockKey(height), hash)
+	batch.Put([]byte(scanHeightKey), encodeHeight(height))
+
+	if err := ws.db.Write(batch); err != nil {
+		return fmt.Errorf("failed to save scanned block: %v", err)
+	}
+	return nil
+}
+
+// ScannedBlockHash returns the hash of the block scanned at a height, or
+// nil if none was recorded
+func (ws *WalletStorage) ScannedBlockHash(height int) ([]byte, error) {
+	hash, err := ws.db.Get(scanBlockKey(height))
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	return hash, err
+}
+
+// GetWalletTxs returns the recorded transactions in chain order
+func (ws *WalletStorage) GetWalletTxs() ([]WalletTx, error) {
+	var txs []WalletTx
+	iter := ws.db.NewIterator([]byte(walletTxPrefix))
+	for iter.Next() {
+		var wtx WalletTx
+		if err := gob.NewDecoder(bytes.NewReader(iter.Value())).Decode(&wtx); err != nil {
+			iter.Release()
+			return nil, fmt.Errorf("failed to decode wallet transaction: %v", err)
+		}
+		txs = append(txs, wtx)
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		if txs[i].Height != txs[j].Height {
+			return txs[i].Height < txs[j].Height
+		}
+		if txs[i].Index != txs[j].Index {
+			return txs[i].Index < txs[j].Index
+		}
+		return bytes.Compare(txs[i].TxID, txs[j].TxID) < 0
+	})
+	return txs, nil
+}
+
+// Rescan forgets the transactions and scanned blocks from a height on, so
+// the next scan finds them again. It also disconnects blocks that left the
+// chain.
+func (ws *WalletStorage) Rescan(fromHeight int) error {
+	if fromHeight < 0 {
+		fromHeight = 0
+	}
+	scanned, err := ws.ScanHeight()
+	if err != nil {
+		return err
+	}
+	txs, err := ws.GetWalletTxs()
+	if err != nil {
+		return err
+	}
+
+	batch := NewBatch()
+	for _, wtx := range txs {
+		if wtx.Height >= fromHeight {
+			batch.Delete(walletTxKey(wtx.TxID))
+		}
+	}
+	for height := fromHeight; height <= scanned; height++ {
+		batch.Delete(scanBlockKey(height))
+	}
+	if scanned >= fromHeight {
+		batch.Put([]byte(scanHeightKey), encodeHeight(fromHeight-1))
+	}
+	if err := ws.db.Write(batch); err != nil {
+		return fmt.Errorf("failed to reset wallet scan: %v", err)
+	}
+	return nil
+}
+
+// walletTxKey is the key of a recorded transaction
+func walletTxKey(txID []byte) []byte {
+	return []byte(fmt.Sprintf("%s%x", walletTxPrefix, txID))
+}
+
+// scanBlockKey is the key of the block hash scanned at a height
+func scanBlockKey(height int) []byte {
+	return append([]byte(scanBlockPrefix), encodeHeight(height)...)
+}
+
+// encodeHeight stores a height, which may be -1
+func encodeHeight(height int) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(int64(height)))
+	return data
+}
@@ -0,0 +1,67 @@
+package tx
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Sizes of the signature and public key of a signed input
+const (
+	SignatureSize = 64
+	PubKeySize    = 65
+)
+
+// SizeEstimate splits the serialized size of a signed transaction into a
+// fixed part and the parts each input and output add, for fee estimates
+type SizeEstimate struct {
+	Base   int
+	Input  int
+	Output int
+}
+
+// Size returns the estimated size of a signed transaction
+func (e SizeEstimate) Size(inputs, outputs int) int {
+	return e.Base + inputs*e.Input + outputs*e.Output
+}
+
+var (
+	sizeEstimate     SizeEstimate
+	sizeEstimateOnce sync.Once
+)
+
+// EstimateSizes measures the size of transactions with signed inputs and
+// large output values
+func EstimateSizes() SizeEstimate {
+	sizeEstimateOnce.Do(func() {
+		one := dummySize(1, 1)
+		input := dummySize(2, 1) - one
+		output := dummySize(1, 2) - one
+		sizeEstimate = SizeEstimate{Base: one - input - output, Input: input, Output: output}
+	})
+	return sizeEstimate
+}
+
+// dummySize serializes a transaction of the given shape
+func dummySize(inputs, outputs int) int {
+	t := &Transaction{ID: bytes.Repeat([]byte{0xff}, 32)}
+	for i := 0; i < inputs; i++ {
+		t.Inputs = append(t.Inputs, TxInput{
+			TxID:      bytes.Repeat([]byte{0xff}, 32),
+			OutIndex:  1 << 15,
+			Sequence:  SequenceReplaceable,
+			Signature: bytes.Repeat([]byte{0xff}, SignatureSize),
+			PubKey:    bytes.Repeat([]byte{0xff}, PubKeySize),
+		})
+	}
+	for i := 0; i < outputs; i++ {
+		t.Outputs = append(t.Outputs, TxOutput{
+			Value:      1 << 62,
+			PubKeyHash: bytes.Repeat([]byte{0xff}, 20),
+		})
+	}
+	data, err := t.Serialize()
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
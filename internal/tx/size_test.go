@@ -0,0 +1,31 @@
+package tx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEstimateSizes(t *testing.T) {
+	estimate := EstimateSizes()
+	if estimate.Input <= SignatureSize+PubKeySize || estimate.Output <= 20 || estimate.Base <= 0 {
+		t.Fatalf("Implausible estimate %+v", estimate)
+	}
+
+	// A typical signed payment is no bigger than its estimate
+	for inputs := 1; inputs <= 20; inputs++ {
+		payment := &Transaction{ID: make([]byte, 32)}
+		for i := 0; i < inputs; i++ {
+			payment.Inputs = append(payment.Inputs, TxInput{
+				TxID:      bytes.Repeat([]byte{1}, 32),
+				OutIndex:  i,
+				Signature: make([]byte, SignatureSize),
+				PubKey:    make([]byte, PubKeySize),
+			})
+		}
+		payment.Outputs = []TxOutput{{Value: 5000000000, PubKeyHash: make([]byte, 20)}, {Value: 1, PubKeyHash: make([]byte, 20)}}
+		data, _ := payment.Serialize()
+		if size := estimate.Size(inputs, 2); len(data) > size {
+			t.Errorf("%d inputs: %d bytes, estimated %d", inputs, len(data), size)
+		}
+	}
+}
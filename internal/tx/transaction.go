@@ -19,10 +19,19 @@ type Transaction struct {
 type TxInput struct {
 	TxID      []byte // Previous transaction ID
 	OutIndex  int    // Index of the output in previous transaction
+	Sequence  uint32 // SequenceReplaceable opts in to replace-by-fee
 	Signature []byte // Signature proving ownership
 	PubKey    []byte // Public key of the sender
 }
 
+// Input sequences only signal replace-by-fee: as in BIP-125, a transaction
+// with an input that is not final may be replaced while unconfirmed.
+// Unlike Bitcoin the final sequence is zero, so inputs opt in explicitly.
+const (
+	SequenceFinal       uint32 = 0
+	SequenceReplaceable uint32 = 1
+)
+
 // TxOutput represents a transaction output (new UTXO)
 type TxOutput struct {
 	Value      int64  // Amount in satoshis
@@ -46,6 +55,18 @@ func NewCoinbaseTx(to string, data string, reward int64) (*Transaction, error) {
 		data = fmt.Sprintf("Reward to %s", to)
 	}
 
+	// Decode recipient address to get pub key hash
+	pubKeyHash, err := crypto.DecodeAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %v", err)
+	}
+
+	return NewCoinbaseTxToPubKeyHash(pubKeyHash, data, reward), nil
+}
+
+// NewCoinbaseTxToPubKeyHash creates a coinbase paying a raw public key hash.
+// Unlike NewCoinbaseTx it does not depend on the selected network's address encoding.
+func NewCoinbaseTxToPubKeyHash(pubKeyHash []byte, data string, reward int64) *Transaction {
 	// Coinbase has no inputs (mined from nothing)
 	txin := TxInput{
 		TxID:      nil,
@@ -54,12 +75,6 @@ func NewCoinbaseTx(to string, data string, reward int64) (*Transaction, error) {
 		PubKey:    []byte(data),
 	}
 
-	// Decode recipient address to get pub key hash
-	pubKeyHash, err := crypto.DecodeAddress(to)
-	if err != nil {
-		return nil, fmt.Errorf("invalid address: %v", err)
-	}
-
 	txout := TxOutput{
 		Value:      reward,
 		PubKeyHash: pubKeyHash,
@@ -72,7 +87,18 @@ func NewCoinbaseTx(to string, data string, reward int64) (*Transaction, error) {
 
 	tx.ID = tx.Hash()
 
-	return tx, nil
+	return tx
+}
+
+// SignalsReplacement reports whether the transaction opted in to
+// replace-by-fee through the sequence of one of its inputs
+func (tx *Transaction) SignalsReplacement() bool {
+	for _, input := range tx.Inputs {
+		if input.Sequence != SequenceFinal {
+			return true
+		}
+	}
+	return false
 }
 
 // IsCoinbase checks if the transaction is a coinbase transaction
@@ -94,6 +120,19 @@ func (tx *Transaction) Hash() []byte {
 	return hash
 }
 
+// ComputeID returns the ID a transaction's contents give it: its hash
+// without signatures, since inputs are signed after the ID is set. An ID
+// received from elsewhere must match it.
+func (tx *Transaction) ComputeID() []byte {
+	txCopy := *tx
+	txCopy.Inputs = make([]TxInput, len(tx.Inputs))
+	for i, input := range tx.Inputs {
+		input.Signature = nil
+		txCopy.Inputs[i] = input
+	}
+	return txCopy.Hash()
+}
+
 // Serialize serializes the transaction to bytes
 func (tx *Transaction) Serialize() ([]byte, error) {
 	var buffer bytes.Buffer
@@ -134,29 +173,16 @@ func (tx *Transaction) Sign(wallet *crypto.Wallet, prevTxs map[string]*Transacti
 		}
 	}
 
-	// Create a trimmed copy for signing (without signatures and pubkeys)
-	txCopy := tx.TrimmedCopy()
-
 	// Sign each input
-	for i, input := range txCopy.Inputs {
+	for i, input := range tx.Inputs {
 		prevTx := prevTxs[string(input.TxID)]
-		txCopy.Inputs[i].Signature = nil
-		txCopy.Inputs[i].PubKey = prevTx.Outputs[input.OutIndex].PubKeyHash
+		dataToSign := tx.SigHash(i, prevTx.Outputs[input.OutIndex].PubKeyHash)
 
-		// Create data to sign (transaction hash)
-		dataToSign := txCopy.Hash()
-
-		// Sign the data
 		signature, err := wallet.Sign(dataToSign)
 		if err != nil {
 			return fmt.Errorf("failed to sign input %d: %v", i, err)
 		}
-
-		// Store signature in original transaction
 		tx.Inputs[i].Signature = signature
-
-		// Clear for next iteration
-		txCopy.Inputs[i].PubKey = nil
 	}
 
 	return nil
@@ -175,30 +201,29 @@ func (tx *Transaction) Verify(prevTxs map[string]*Transaction) bool {
 		}
 	}
 
-	// Create a trimmed copy for verification
-	txCopy := tx.TrimmedCopy()
-
 	// Verify each input
 	for i, input := range tx.Inputs {
 		prevTx := prevTxs[string(input.TxID)]
-		txCopy.Inputs[i].Signature = nil
-		txCopy.Inputs[i].PubKey = prevTx.Outputs[input.OutIndex].PubKeyHash
+		dataToVerify := tx.SigHash(i, prevTx.Outputs[input.OutIndex].PubKeyHash)
 
-		// Get the data that was signed
-		dataToVerify := txCopy.Hash()
-
-		// Verify signature
 		if !crypto.VerifySignature(input.PubKey, dataToVerify, input.Signature) {
 			return false
 		}
-
-		// Clear for next iteration
-		txCopy.Inputs[i].PubKey = nil
 	}
 
 	return true
 }
 
+// SigHash returns the data signed by input i: the transaction without
+// signatures or public keys, with the input's public key replaced by the
+// hash locking the output it spends. It does not depend on the other
+// inputs' keys or signatures, so inputs can be signed separately.
+func (tx *Transaction) SigHash(i int, prevPubKeyHash []byte) []byte {
+	txCopy := tx.TrimmedCopy()
+	txCopy.Inputs[i].PubKey = prevPubKeyHash
+	return txCopy.Hash()
+}
+
 // TrimmedCopy creates a copy of the transaction without signatures and pubkeys
 func (tx *Transaction) TrimmedCopy() *Transaction {
 	var inputs []TxInput
@@ -208,6 +233,7 @@ func (tx *Transaction) TrimmedCopy() *Transaction {
 		inputs = append(inputs, TxInput{
 			TxID:      input.TxID,
 			OutIndex:  input.OutIndex,
+			Sequence:  input.Sequence,
 			Signature: nil,
 			PubKey:    nil,
 		})
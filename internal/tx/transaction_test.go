@@ -1,6 +1,7 @@
 package tx
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/yourusername/bt/internal/crypto"
@@ -166,6 +167,66 @@ func TestSignAndVerify(t *testing.T) {
 	}
 }
 
+func TestComputeID(t *testing.T) {
+	wallet, _ := crypto.NewWallet()
+	prevTx, _ := NewCoinbaseTx(wallet.GetAddress(), "Prev TX", 100)
+	tx := NewTransaction(
+		[]TxInput{{TxID: prevTx.ID, OutIndex: 0, PubKey: wallet.PublicKey}},
+		[]TxOutput{{Value: 50, PubKeyHash: []byte("recipient")}},
+	)
+	if err := tx.Sign(wallet, map[string]*Transaction{string(prevTx.ID): prevTx}); err != nil {
+		t.Fatalf("Failed to sign transaction: %v", err)
+	}
+
+	// Signing does not change the ID
+	if !bytes.Equal(tx.ComputeID(), tx.ID) {
+		t.Error("Signed transaction does not match its ID")
+	}
+	if !bytes.Equal(prevTx.ComputeID(), prevTx.ID) {
+		t.Error("Coinbase does not match its ID")
+	}
+
+	// Any other change does
+	tx.Outputs[0].Value = 60
+	if bytes.Equal(tx.ComputeID(), tx.ID) {
+		t.Error("Changed transaction still matches its ID")
+	}
+}
+
+func TestSignalsReplacement(t *testing.T) {
+	wallet, _ := crypto.NewWallet()
+	prevTx, _ := NewCoinbaseTx(wallet.GetAddress(), "Prev TX", 100)
+	prevTxs := map[string]*Transaction{string(prevTx.ID): prevTx}
+
+	final := NewTransaction(
+		[]TxInput{{TxID: prevTx.ID, OutIndex: 0, PubKey: wallet.PublicKey}},
+		[]TxOutput{{Value: 50, PubKeyHash: []byte("recipient")}},
+	)
+	if final.SignalsReplacement() || prevTx.SignalsReplacement() {
+		t.Error("Transaction without sequences signals replacement")
+	}
+
+	replaceable := NewTransaction(
+		[]TxInput{{TxID: prevTx.ID, OutIndex: 0, Sequence: SequenceReplaceable, PubKey: wallet.PublicKey}},
+		[]TxOutput{{Value: 50, PubKeyHash: []byte("recipient")}},
+	)
+	if !replaceable.SignalsReplacement() {
+		t.Error("Replaceable transaction does not signal replacement")
+	}
+	if bytes.Equal(replaceable.ID, final.ID) {
+		t.Error("Sequence does not change the ID")
+	}
+
+	// The signature commits to the sequence, so nobody else can change it
+	if err := replaceable.Sign(wallet, prevTxs); err != nil {
+		t.Fatalf("Failed to sign transaction: %v", err)
+	}
+	replaceable.Inputs[0].Sequence = SequenceFinal
+	if replaceable.Verify(prevTxs) {
+		t.Error("Signature still valid after the sequence changed")
+	}
+}
+
 func TestVerifyInvalidSignature(t *testing.T) {
 	wallet1, _ := crypto.NewWallet()
 	wallet2, _ := crypto.NewWallet()
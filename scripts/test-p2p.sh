@@ -23,7 +23,7 @@ echo "✓ Build successful"
 # Start Node 1 (Bootstrap node)
 echo ""
 echo "🟢 Starting Node 1 (Bootstrap)..."
-./bin/node-p2p -db ./node1.db -listen "/ip4/0.0.0.0/tcp/9001" -fresh > node1.log 2>&1 &
+./bin/node-p2p -datadir ./node1 -db ./node1.db -listen "/ip4/0.0.0.0/tcp/9001" -fresh > node1.log 2>&1 &
 NODE1_PID=$!
 echo "   PID: $NODE1_PID"
 sleep 3
@@ -40,7 +40,7 @@ echo "   Address: $NODE1_ADDR"
 # Start Node 2 (connects to Node 1)
 echo ""
 echo "🟡 Starting Node 2..."
-./bin/node-p2p -db ./node2.db -listen "/ip4/0.0.0.0/tcp/9002" -connect "$NODE1_ADDR" -fresh > node2.log 2>&1 &
+./bin/node-p2p -datadir ./node2 -db ./node2.db -listen "/ip4/0.0.0.0/tcp/9002" -connect "$NODE1_ADDR" -fresh > node2.log 2>&1 &
 NODE2_PID=$!
 echo "   PID: $NODE2_PID"
 sleep 3
@@ -48,7 +48,7 @@ sleep 3
 # Start Node 3 (connects to Node 1)
 echo ""
 echo "🔵 Starting Node 3..."
-./bin/node-p2p -db ./node3.db -listen "/ip4/0.0.0.0/tcp/9003" -connect "$NODE1_ADDR" -fresh > node3.log 2>&1 &
+./bin/node-p2p -datadir ./node3 -db ./node3.db -listen "/ip4/0.0.0.0/tcp/9003" -connect "$NODE1_ADDR" -fresh > node3.log 2>&1 &
 NODE3_PID=$!
 echo "   PID: $NODE3_PID"
 sleep 2
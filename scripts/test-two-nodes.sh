@@ -6,7 +6,7 @@
 echo "🚀 Two-Node P2P Test"
 echo "===================="
 
-# Clean up
+# Clean up; ./node-a and ./node-b keep each node's peer ID across runs
 rm -rf ./node-a.db ./node-b.db
 
 # Build
@@ -19,7 +19,7 @@ fi
 
 echo ""
 echo "🟢 Starting Node A (creates blockchain)..."
-./bin/node-p2p -db node-a.db -listen "/ip4/127.0.0.1/tcp/9001" -fresh > node-a.log 2>&1 &
+./bin/node-p2p -datadir ./node-a -db node-a.db -listen "/ip4/127.0.0.1/tcp/9001" -fresh > node-a.log 2>&1 &
 NODE_A_PID=$!
 sleep 4
 
@@ -42,7 +42,7 @@ echo "  Blockchain height: $NODE_A_BLOCKS blocks"
 
 echo ""
 echo "🟡 Starting Node B (will sync from Node A)..."
-./bin/node-p2p -db node-b.db -listen "/ip4/127.0.0.1/tcp/9002" -connect "$NODE_A_ADDR" -fresh > node-b.log 2>&1 &
+./bin/node-p2p -datadir ./node-b -db node-b.db -listen "/ip4/127.0.0.1/tcp/9002" -connect "$NODE_A_ADDR" -fresh > node-b.log 2>&1 &
 NODE_B_PID=$!
 sleep 5
 